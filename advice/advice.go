@@ -0,0 +1,204 @@
+// Package advice is the catalog of remediation text xtop shows: RCA
+// actions, service diagnostic findings and doctor checks. Every entry has a
+// stable ID so the same wording reaches the TUI, CLI, JSON output and
+// alerts, and so a site can reword, translate or extend it without
+// touching code.
+//
+// Overrides are JSON arrays of entries read from /etc/xtop/advice.json and
+// then ~/.xtop/advice.json (or only $XTOP_ADVICE when set). A field left
+// empty in an override keeps the built-in value; unknown IDs are added.
+//
+//	[{"id": "rca.mem.swap", "refs": ["https://wiki.example.com/runbooks/swap"]},
+//	 {"id": "rca.io.latency", "lang": {"de": "Hohe Disk-Latenz: %s — Storage überlastet"}}]
+//
+// The language comes from $XTOP_LANG, falling back to LC_ALL, LC_MESSAGES
+// and LANG. Text templates use fmt verbs; translations may reorder their
+// arguments with explicit indexes (%[2]s).
+package advice
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// Entry is one piece of advice.
+type Entry struct {
+	ID       string            `json:"id"`
+	Severity string            `json:"severity,omitempty"` // "info", "warn", "crit"
+	Text     string            `json:"text,omitempty"`     // fmt template, English
+	Refs     []string          `json:"refs,omitempty"`     // docs, man pages, runbooks
+	Lang     map[string]string `json:"lang,omitempty"`     // translated templates by language code
+}
+
+var (
+	mu      sync.RWMutex
+	entries map[string]Entry
+	lang    string
+	once    sync.Once
+)
+
+func load() {
+	once.Do(func() {
+		mu.Lock()
+		entries = make(map[string]Entry, len(builtin))
+		for _, e := range builtin {
+			entries[e.ID] = e
+		}
+		lang = envLanguage()
+		mu.Unlock()
+
+		for _, p := range overridePaths() {
+			if err := Load(p); err != nil && !os.IsNotExist(err) {
+				log.Printf("xtop: advice overrides %s: %v", p, err)
+			}
+		}
+	})
+}
+
+func overridePaths() []string {
+	if p := os.Getenv("XTOP_ADVICE"); p != "" {
+		return []string{p}
+	}
+	paths := []string{"/etc/xtop/advice.json"}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".xtop", "advice.json"))
+	}
+	return paths
+}
+
+// envLanguage reduces a locale such as "de_DE.UTF-8" to "de". The C and
+// POSIX locales mean English.
+func envLanguage() string {
+	for _, k := range []string{"XTOP_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(k)
+		if v == "" {
+			continue
+		}
+		if i := strings.IndexAny(v, "_.@"); i >= 0 {
+			v = v[:i]
+		}
+		v = strings.ToLower(v)
+		if v == "c" || v == "posix" {
+			return "en"
+		}
+		return v
+	}
+	return "en"
+}
+
+// Load merges the override file at path into the catalog.
+func Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var over []Entry
+	if err := json.Unmarshal(data, &over); err != nil {
+		return err
+	}
+	load()
+	mu.Lock()
+	defer mu.Unlock()
+	for _, o := range over {
+		if o.ID == "" {
+			continue
+		}
+		e := entries[o.ID]
+		e.ID = o.ID
+		if o.Severity != "" {
+			e.Severity = o.Severity
+		}
+		if o.Text != "" {
+			e.Text = o.Text
+		}
+		if len(o.Refs) > 0 {
+			e.Refs = o.Refs
+		}
+		if len(o.Lang) > 0 {
+			merged := make(map[string]string, len(e.Lang)+len(o.Lang))
+			for k, v := range e.Lang {
+				merged[k] = v
+			}
+			for k, v := range o.Lang {
+				merged[k] = v
+			}
+			e.Lang = merged
+		}
+		entries[o.ID] = e
+	}
+	return nil
+}
+
+// SetLanguage overrides the language picked from the environment.
+func SetLanguage(code string) {
+	load()
+	mu.Lock()
+	lang = strings.ToLower(code)
+	mu.Unlock()
+}
+
+// Get returns the entry for id.
+func Get(id string) (Entry, bool) {
+	load()
+	mu.RLock()
+	defer mu.RUnlock()
+	e, ok := entries[id]
+	return e, ok
+}
+
+// All returns every entry sorted by ID.
+func All() []Entry {
+	load()
+	mu.RLock()
+	out := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, e)
+	}
+	mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// Text renders the advice for id in the active language. The template only
+// goes through fmt when args are given, so argument-less text may contain a
+// literal %. An empty id yields "", and an unknown one yields the id itself
+// so a typo shows up instead of vanishing.
+func Text(id string, args ...any) string {
+	if id == "" {
+		return ""
+	}
+	e, ok := Get(id)
+	if !ok {
+		return id
+	}
+	mu.RLock()
+	tmpl := e.Text
+	if t, ok := e.Lang[lang]; ok && t != "" {
+		tmpl = t
+	}
+	mu.RUnlock()
+	if len(args) == 0 {
+		return tmpl
+	}
+	return fmt.Sprintf(tmpl, args...)
+}
+
+// Action builds an RCA action from a catalog entry.
+func Action(id string, args ...any) model.Action {
+	return model.Action{Summary: Text(id, args...), AdviceID: id}
+}
+
+// CommandAction is Action with a runnable command attached.
+func CommandAction(command, id string, args ...any) model.Action {
+	a := Action(id, args...)
+	a.Command = command
+	return a
+}
@@ -0,0 +1,87 @@
+package advice
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinCatalog(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, e := range builtin {
+		if seen[e.ID] {
+			t.Errorf("duplicate ID %s", e.ID)
+		}
+		seen[e.ID] = true
+		if e.Text == "" {
+			t.Errorf("%s: empty text", e.ID)
+		}
+		switch e.Severity {
+		case "info", "warn", "crit":
+		default:
+			t.Errorf("%s: severity %q", e.ID, e.Severity)
+		}
+	}
+}
+
+func TestTextOverridesAndLanguage(t *testing.T) {
+	t.Setenv("XTOP_ADVICE", filepath.Join(t.TempDir(), "none.json"))
+	t.Setenv("XTOP_LANG", "")
+
+	if got := Text("rca.exhaust.swap", 12.0, 91.5); got != "Swap exhaustion in ~12m (91.5% used) — reduce memory pressure to stop swapping" {
+		t.Errorf("builtin = %q", got)
+	}
+	// No args: the template is not run through fmt, so a literal % survives.
+	if got := Text("doctor.disk.inodes"); !strings.Contains(got, "-printf '%h\\n'") {
+		t.Errorf("literal %% mangled: %q", got)
+	}
+	if Text("") != "" || Text("no.such.id") != "no.such.id" {
+		t.Error("empty and unknown IDs")
+	}
+
+	path := filepath.Join(t.TempDir(), "advice.json")
+	os.WriteFile(path, []byte(`[
+		{"id": "rca.mem.swap", "refs": ["https://wiki.example.com/swap"],
+		 "lang": {"de": "Swap aktiv (%[1]s) — RAM erweitern"}},
+		{"id": "site.runbook", "severity": "warn", "text": "See runbook %s"}
+	]`), 0o644)
+	if err := Load(path); err != nil {
+		t.Fatal(err)
+	}
+	e, ok := Get("rca.mem.swap")
+	if !ok || e.Severity != "crit" || !strings.HasPrefix(e.Text, "Active swapping") || len(e.Refs) != 1 {
+		t.Errorf("merged entry = %+v", e)
+	}
+	if got := Text("site.runbook", "R-12"); got != "See runbook R-12" {
+		t.Errorf("site entry = %q", got)
+	}
+
+	SetLanguage("de")
+	defer SetLanguage("en")
+	a := Action("rca.mem.swap", "120 pages/s")
+	if a.Summary != "Swap aktiv (120 pages/s) — RAM erweitern" || a.AdviceID != "rca.mem.swap" {
+		t.Errorf("translated action = %+v", a)
+	}
+	// Untranslated entries fall back to English.
+	if got := Text("rca.mem.oom", "3"); !strings.HasPrefix(got, "OOM kills occurred: 3") {
+		t.Errorf("fallback = %q", got)
+	}
+}
+
+func TestEnvLanguage(t *testing.T) {
+	for _, c := range []struct{ xtop, lang, want string }{
+		{"", "de_DE.UTF-8", "de"},
+		{"fr", "de_DE.UTF-8", "fr"},
+		{"", "C.UTF-8", "en"},
+		{"", "", "en"},
+	} {
+		t.Setenv("XTOP_LANG", c.xtop)
+		t.Setenv("LC_ALL", "")
+		t.Setenv("LC_MESSAGES", "")
+		t.Setenv("LANG", c.lang)
+		if got := envLanguage(); got != c.want {
+			t.Errorf("XTOP_LANG=%q LANG=%q → %q, want %q", c.xtop, c.lang, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package advice
+
+// Reference links shared by several entries.
+const (
+	refPSI       = "https://docs.kernel.org/accounting/psi.html"
+	refCgroupV2  = "https://docs.kernel.org/admin-guide/cgroup-v2.html"
+	refVMSysctl  = "https://docs.kernel.org/admin-guide/sysctl/vm.html"
+	refFSSysctl  = "https://docs.kernel.org/admin-guide/sysctl/fs.html"
+	refConntrack = "https://docs.kernel.org/networking/nf_conntrack-sysctl.html"
+	refIPSysctl  = "https://docs.kernel.org/networking/ip-sysctl.html"
+	refMySQLPool = "https://dev.mysql.com/doc/refman/8.0/en/innodb-buffer-pool.html"
+	refPGConfig  = "https://www.postgresql.org/docs/current/runtime-config-resource.html"
+	refPGVacuum  = "https://www.postgresql.org/docs/current/routine-vacuuming.html"
+	refBlockQ    = "https://docs.kernel.org/block/queue-sysfs.html"
+	refOOMAdjust = "https://www.freedesktop.org/software/systemd/man/latest/systemd.exec.html#OOMScoreAdjust="
+)
+
+// builtin is the shipped catalog. IDs are <source>.<area>.<condition> and
+// must never be renamed once released: site overrides and downstream
+// consumers of the JSON output key on them.
+var builtin = []Entry{
+	// ── RCA actions (engine.SuggestActions) ──
+	{ID: "rca.culprit.process", Severity: "info", Text: "Top culprit: %s (PID %d) — consuming most %s resources"},
+	{ID: "rca.culprit.cgroup", Severity: "info", Text: "Owning cgroup: %s"},
+	{ID: "rca.service.diag", Severity: "info", Text: "Press W for %s deep diagnostics (connections, queries, config) — press O for error rate logs"},
+	{ID: "rca.deploy.recent", Severity: "warn", Text: "Recent deploy detected: %s (PID %d, started %ds ago) — likely trigger"},
+
+	{ID: "rca.cpu.top", Severity: "info", Text: "Top CPU consumers: %s"},
+	{ID: "rca.cpu.throttle", Severity: "warn", Text: "Cgroup CPU throttling detected: %s — increase cpu.max quota or reduce load", Refs: []string{refCgroupV2}},
+	{ID: "rca.cpu.steal", Severity: "warn", Text: "CPU steal: %s — VM is overcommitted on hypervisor, migrate or resize"},
+	{ID: "rca.cpu.runqueue", Severity: "warn", Text: "Run queue saturated: %s — more threads than CPUs, reduce parallelism or scale out"},
+	{ID: "rca.cpu.softirq", Severity: "warn", Text: "High softirq CPU: %s — network interrupt storm or packet flood"},
+
+	{ID: "rca.mem.top", Severity: "info", Text: "Top memory consumers: %s"},
+	{ID: "rca.mem.swap", Severity: "crit", Text: "Active swapping: %s — system is thrashing, reduce memory usage or add RAM", Refs: []string{refVMSysctl}},
+	{ID: "rca.mem.reclaim", Severity: "warn", Text: "Direct reclaim active: %s — kernel stalling to free memory", Refs: []string{refVMSysctl}},
+	{ID: "rca.mem.oom", Severity: "crit", Text: "OOM kills occurred: %s — processes being killed by kernel"},
+	{ID: "rca.mem.available", Severity: "crit", Text: "Available memory critically low: %s — risk of OOM"},
+
+	{ID: "rca.io.top", Severity: "info", Text: "Top IO consumers: %s"},
+	{ID: "rca.io.fsfull.mount", Severity: "warn", Text: "Filesystem %s at %.1f%% used (%s) — %s"},
+	{ID: "rca.io.fsfull", Severity: "warn", Text: "Filesystem pressure: %s — check DiskGuard page (D)"},
+	{ID: "rca.io.writeback", Severity: "warn", Text: "Heavy dirty page writeback: %s — large write burst or flushing backlog", Refs: []string{refVMSysctl}},
+	{ID: "rca.io.latency", Severity: "warn", Text: "High disk latency: %s — storage overloaded, check IOPS limits"},
+	{ID: "rca.io.dstate", Severity: "warn", Text: "Processes stuck in D-state (uninterruptible IO): %s"},
+	{ID: "rca.io.backpressure", Severity: "warn", Text: "IO backpressure: %s (%s) gets %.0f%% of %s — %.1f MB/s vs %.1f MB/s from %s (%s)", Refs: []string{refCgroupV2}},
+	{ID: "rca.io.backpressure.enable_iocost", Severity: "info", Text: "Enable the io.cost controller on %s (%s) so io.weight is enforced", Refs: []string{refCgroupV2}},
+	{ID: "rca.io.backpressure.no_iocost", Severity: "info", Text: "Kernel has no io.cost and %s uses %s — io.weight has no effect; switch to bfq or use the io.max cap"},
+	{ID: "rca.io.backpressure.raise_weight", Severity: "info", Text: "Raise %s of %s from %d to %d — %s gets ~%.0f%% of %s under contention (idle bandwidth still flows to %s)"},
+	{ID: "rca.io.backpressure.lower_weight", Severity: "info", Text: "Or lower %s of %s from %d to %d for the same split"},
+	{ID: "rca.io.backpressure.cap", Severity: "info", Text: "Hard cap if weights cannot be used: io.max on %s %s/s on %s (not work-conserving)"},
+	{ID: "rca.io.db_writer.cancel", Severity: "info", Text: "If the %s can wait, cancel it (%s %d) and re-run it off-peak"},
+	{ID: "rca.io.db_writer.online_ddl", Severity: "info", Text: "Re-run the %s with a throttled online schema-change tool (gh-ost, pt-online-schema-change) so it backs off under load", Refs: []string{"https://github.com/github/gh-ost"}},
+
+	{ID: "rca.net.top", Severity: "info", Text: "Top network consumers: %s"},
+	{ID: "rca.net.drops", Severity: "warn", Text: "Packet drops detected: %s — NIC ring buffer overflow or backpressure"},
+	{ID: "rca.net.retrans", Severity: "warn", Text: "TCP retransmissions: %s — network congestion or remote host issues"},
+	{ID: "rca.net.conntrack", Severity: "warn", Text: "Conntrack table pressure: %s — nf_conntrack_max may need increase", Refs: []string{refConntrack}},
+	{ID: "rca.net.tcp_state", Severity: "warn", Text: "TCP state anomaly: %s — TIME_WAIT accumulation or SYN backlog", Refs: []string{refIPSysctl}},
+	{ID: "rca.net.closewait", Severity: "warn", Text: "CLOSE_WAIT leak: %s"},
+	{ID: "rca.net.closewait.leaker", Severity: "warn", Text: "CLOSE_WAIT leak: %s (PID %d) holding %d stale sockets, oldest %s — app not calling close()"},
+	{ID: "rca.net.errors", Severity: "warn", Text: "Interface errors: %s — check cable/NIC health"},
+	{ID: "rca.net.conntrack.drops", Severity: "crit", Text: "Conntrack drops: %s — increase nf_conntrack_max or investigate connection flood", Refs: []string{refConntrack}},
+	{ID: "rca.net.conntrack.insertfail", Severity: "crit", Text: "Conntrack insert failures: %s — table rejecting new flows, increase max or reduce churn", Refs: []string{refConntrack}},
+	{ID: "rca.net.conntrack.growth", Severity: "warn", Text: "Conntrack growth: %s — connections accumulating faster than closing; check keepalive/pooling"},
+	{ID: "rca.net.conntrack.invalid", Severity: "warn", Text: "Conntrack invalid: %s — malformed packets, check asymmetric routing or LB config"},
+	{ID: "rca.net.conntrack.hashcontention", Severity: "warn", Text: "Conntrack hash contention: %s — consider increasing buckets, check CPU/IRQ balance", Refs: []string{refConntrack}},
+
+	{ID: "rca.exhaust.memory", Severity: "crit", Text: "Memory exhaustion in ~%.0fm (%.1f%% used, growing %.2f%%/s) — identify growing process or add RAM"},
+	{ID: "rca.exhaust.swap", Severity: "crit", Text: "Swap exhaustion in ~%.0fm (%.1f%% used) — reduce memory pressure to stop swapping"},
+	{ID: "rca.exhaust.ports", Severity: "crit", Text: "Port exhaustion in ~%.0fm (%.1f%% used) — connection churn too high, check TIME_WAIT accumulation", Refs: []string{refIPSysctl}},
+	{ID: "rca.exhaust.fds", Severity: "crit", Text: "FD exhaustion in ~%.0fm (%.1f%% used) — file descriptor leak in progress", Refs: []string{refFSSysctl}},
+	{ID: "rca.exhaust.closewait", Severity: "warn", Text: "CLOSE_WAIT growing — %.0f sockets at +%.1f/s, will exhaust FDs. Check Network page (4)"},
+	{ID: "rca.exhaust.disk", Severity: "crit", Text: "Disk %s exhaustion in ~%.0fm (%.1f%% full) — see DiskGuard page (D) for top writers and big files"},
+	{ID: "rca.exhaust.other", Severity: "warn", Text: "%s exhaustion in ~%.0fm (%.1f%% used)"},
+
+	// ── Service diagnostics (collector diag findings) ──
+	{ID: "diag.nginx.config_error", Severity: "crit", Text: "Fix nginx configuration and reload", Refs: []string{"nginx -t"}},
+	{ID: "diag.nginx.single_worker", Severity: "warn", Text: "Set worker_processes auto or match CPU count"},
+	{ID: "diag.nginx.connections", Severity: "warn", Text: "Check for connection leak or increase capacity"},
+	{ID: "diag.nginx.errlog_crit", Severity: "crit", Text: "Check /var/log/nginx/error.log immediately"},
+	{ID: "diag.nginx.errlog", Severity: "warn", Text: "Check /var/log/nginx/error.log"},
+	{ID: "diag.nginx.5xx_crit", Severity: "crit", Text: "Check upstream servers and error.log"},
+	{ID: "diag.nginx.5xx", Severity: "warn", Text: "Monitor upstream health"},
+
+	{ID: "diag.apache.config_error", Severity: "crit", Text: "Fix Apache configuration", Refs: []string{"apachectl configtest"}},
+	{ID: "diag.apache.prefork", Severity: "warn", Text: "Consider switching to event or worker MPM"},
+	{ID: "diag.apache.errlog", Severity: "warn", Text: "Check %s"},
+	{ID: "diag.apache.max_workers", Severity: "warn", Text: "Consider increasing MaxRequestWorkers for production"},
+
+	{ID: "diag.mysql.connect", Severity: "warn", Text: "Check MySQL socket/permissions: mysql -N -e 'SELECT 1'"},
+	{ID: "diag.mysql.conn_limit", Severity: "crit", Text: "Increase max_connections or investigate connection leaks"},
+	{ID: "diag.mysql.conn_high", Severity: "warn", Text: "Monitor connection count; consider connection pooling"},
+	{ID: "diag.mysql.bufpool_hit_crit", Severity: "crit", Text: "Increase innodb_buffer_pool_size", Refs: []string{refMySQLPool}},
+	{ID: "diag.mysql.bufpool_hit", Severity: "warn", Text: "Consider increasing innodb_buffer_pool_size", Refs: []string{refMySQLPool}},
+	{ID: "diag.mysql.bufpool_small", Severity: "warn", Text: "Increase innodb_buffer_pool_size (typically 50-80% of RAM)", Refs: []string{refMySQLPool}},
+	{ID: "diag.mysql.slow_queries", Severity: "warn", Text: "Enable slow_query_log and optimize queries"},
+	{ID: "diag.mysql.long_query", Severity: "crit", Text: "Check SHOW PROCESSLIST and consider killing the query"},
+	{ID: "diag.mysql.long_queries", Severity: "warn", Text: "Check SHOW PROCESSLIST"},
+	{ID: "diag.mysql.repl_broken", Severity: "crit", Text: "Check SHOW SLAVE STATUS\\G for errors"},
+	{ID: "diag.mysql.repl_lag_crit", Severity: "crit", Text: "Investigate IO/SQL thread performance"},
+	{ID: "diag.mysql.repl_lag", Severity: "warn", Text: "Monitor replication lag trend"},
+
+	{ID: "diag.postgres.connect", Severity: "warn", Text: "Check PostgreSQL authentication: sudo -u postgres psql -c 'SELECT 1'"},
+	{ID: "diag.postgres.conn_limit", Severity: "crit", Text: "Increase max_connections or investigate query bottlenecks", Refs: []string{refPGConfig}},
+	{ID: "diag.postgres.idle_conns", Severity: "warn", Text: "Consider using a connection pooler (pgbouncer)"},
+	{ID: "diag.postgres.cache_hit_crit", Severity: "crit", Text: "Increase shared_buffers", Refs: []string{refPGConfig}},
+	{ID: "diag.postgres.cache_hit", Severity: "warn", Text: "Consider increasing shared_buffers", Refs: []string{refPGConfig}},
+	{ID: "diag.postgres.deadlocks", Severity: "warn", Text: "Investigate transaction ordering"},
+	{ID: "diag.postgres.temp_files", Severity: "warn", Text: "Increase work_mem to reduce temp file usage", Refs: []string{refPGConfig}},
+	{ID: "diag.postgres.dead_tuples", Severity: "warn", Text: "Run VACUUM ANALYZE %s", Refs: []string{refPGVacuum}},
+
+	{ID: "diag.haproxy.backends_down", Severity: "crit", Text: "Check backend server health"},
+	{ID: "diag.haproxy.5xx", Severity: "warn", Text: "Investigate backend errors"},
+	{ID: "diag.haproxy.queue", Severity: "warn", Text: "Backends may be overloaded"},
+	{ID: "diag.haproxy.maxconn", Severity: "warn", Text: "Increase maxconn or scale out"},
+
+	{ID: "diag.redis.connect", Severity: "warn", Text: "Check Redis is listening: redis-cli ping"},
+	{ID: "diag.redis.mem_crit", Severity: "crit", Text: "Increase maxmemory or review eviction policy"},
+	{ID: "diag.redis.mem_high", Severity: "warn", Text: "Monitor memory growth"},
+	{ID: "diag.redis.no_maxmemory", Severity: "warn", Text: "Set maxmemory in redis.conf"},
+	{ID: "diag.redis.fragmentation", Severity: "warn", Text: "Consider restarting Redis or enabling active defrag"},
+	{ID: "diag.redis.blocked_clients", Severity: "warn", Text: "Check BLPOP/BRPOP/WAIT blocking commands"},
+	{ID: "diag.redis.evictions", Severity: "warn", Text: "Increase maxmemory or review data expiry"},
+	{ID: "diag.redis.rdb_failed", Severity: "warn", Text: "Check disk space and Redis logs"},
+	{ID: "diag.redis.repl_link", Severity: "crit", Text: "Check master connectivity"},
+	{ID: "diag.redis.slowlog", Severity: "warn", Text: "Review slow commands: redis-cli slowlog get 10"},
+
+	{ID: "diag.docker.query", Severity: "warn", Text: "Check Docker socket permissions"},
+	{ID: "diag.docker.restarting", Severity: "crit", Text: "Check logs: docker logs %s"},
+	{ID: "diag.docker.stopped", Severity: "warn", Text: "Review stopped containers: docker ps -a --filter status=exited"},
+	{ID: "diag.docker.unhealthy", Severity: "crit", Text: "Check: docker inspect %s"},
+	{ID: "diag.docker.mem_crit", Severity: "crit", Text: "Increase memory limit or investigate leak"},
+	{ID: "diag.docker.mem_high", Severity: "warn", Text: "Monitor memory usage"},
+	{ID: "diag.docker.reclaimable", Severity: "warn", Text: "Run: docker system prune"},
+
+	// ── Doctor checks (xtop doctor) ──
+	{ID: "doctor.cpu.util_crit", Severity: "crit", Text: "Identify top CPU consumers: xtop -watch -section cpu"},
+	{ID: "doctor.cpu.util_warn", Severity: "warn", Text: "CPU load is elevated"},
+	{ID: "doctor.cpu.load_crit", Severity: "crit", Text: "System severely overloaded — tasks waiting far exceed CPU capacity"},
+	{ID: "doctor.cpu.load_warn", Severity: "warn", Text: "Load exceeds CPU count — tasks are queueing for CPU time"},
+	{ID: "doctor.cpu.load_iowait", Severity: "warn", Text: "Load driven by IO-waiting processes, not CPU contention"},
+	{ID: "doctor.cpu.runq_crit", Severity: "crit", Text: "Far more active tasks than CPUs — severe contention"},
+	{ID: "doctor.cpu.runq_warn", Severity: "warn", Text: "More active tasks than CPUs — some are queueing"},
+	{ID: "doctor.cpu.psi_crit", Severity: "crit", Text: "Severe CPU pressure", Refs: []string{refPSI}},
+	{ID: "doctor.cpu.psi_warn", Severity: "warn", Text: "CPU pressure detected", Refs: []string{refPSI}},
+
+	{ID: "doctor.mem.usage_crit", Severity: "crit", Text: "Memory critically low; risk of OOM"},
+	{ID: "doctor.mem.usage_warn", Severity: "warn", Text: "Memory usage elevated"},
+	{ID: "doctor.mem.swap_crit", Severity: "crit", Text: "Swap nearly full"},
+	{ID: "doctor.mem.swap_warn", Severity: "warn", Text: "Significant swap usage"},
+	{ID: "doctor.mem.psi_crit", Severity: "crit", Text: "Severe memory pressure", Refs: []string{refPSI}},
+	{ID: "doctor.mem.psi_warn", Severity: "warn", Text: "Memory pressure detected", Refs: []string{refPSI}},
+	{ID: "doctor.mem.available_low", Severity: "crit", Text: "System may OOM soon"},
+	{ID: "doctor.oom.adj_raised", Severity: "warn", Text: "%s has oom_score_adj=%d — the kernel is told to kill it first when memory runs out; set OOMScoreAdjust=%d", Refs: []string{refOOMAdjust}},
+	{ID: "doctor.oom.likely_victim_access", Severity: "warn", Text: "%s is OOM-kill candidate #%d (oom_score %d) — losing it locks you out of the host; set OOMScoreAdjust=%d", Refs: []string{refOOMAdjust}},
+	{ID: "doctor.oom.likely_victim_database", Severity: "warn", Text: "%s is OOM-kill candidate #%d (oom_score %d) — a kill aborts every open transaction and forces crash recovery; OOMScoreAdjust=%d makes the kernel prefer a cheaper victim", Refs: []string{refOOMAdjust}},
+	{ID: "doctor.oom.likely_victim_monitoring", Severity: "warn", Text: "%s is OOM-kill candidate #%d (oom_score %d) — losing it blinds you during the incident; set OOMScoreAdjust=%d", Refs: []string{refOOMAdjust}},
+	{ID: "doctor.oom.unprotected_access", Severity: "info", Text: "%s has no OOM protection (oom_score_adj=%d) — under memory exhaustion you can lose SSH to the host; OOMScoreAdjust=%d keeps it alive", Refs: []string{refOOMAdjust}},
+	{ID: "doctor.oom.unprotected_monitoring", Severity: "info", Text: "%s has no OOM protection (oom_score_adj=%d) — it can be killed mid-incident; OOMScoreAdjust=%d keeps it reporting", Refs: []string{refOOMAdjust}},
+
+	{ID: "doctor.disk.fs_crit", Severity: "crit", Text: "Filesystem %s critically full"},
+	{ID: "doctor.disk.fs_warn", Severity: "warn", Text: "Filesystem %s filling up"},
+	{ID: "doctor.disk.diskguard", Severity: "warn", Text: "Check: sudo xtop (DiskGuard page)"},
+	{ID: "doctor.disk.latency_crit", Severity: "crit", Text: "Disk latency severe"},
+	{ID: "doctor.disk.latency_warn", Severity: "warn", Text: "Disk latency elevated"},
+	{ID: "doctor.disk.psi_crit", Severity: "crit", Text: "Severe IO pressure", Refs: []string{refPSI}},
+	{ID: "doctor.disk.psi_warn", Severity: "warn", Text: "IO pressure detected", Refs: []string{refPSI}},
+	{ID: "doctor.blockq.sched_nvme", Severity: "warn", Text: "%s is NVMe but uses the %s scheduler — the drive queues in hardware, so per-IO scheduling only adds CPU cost and caps IOPS; use none", Refs: []string{refBlockQ}},
+	{ID: "doctor.blockq.sched_virt", Severity: "info", Text: "%s is a virtual disk using bfq — the hypervisor already schedules IO; none avoids doing it twice", Refs: []string{refBlockQ}},
+	{ID: "doctor.blockq.sched_hdd", Severity: "warn", Text: "%s is a spinning disk with no IO scheduler — unsorted requests turn into seeks; use mq-deadline", Refs: []string{refBlockQ}},
+	{ID: "doctor.blockq.sched_ssd", Severity: "info", Text: "%s is an SSD using bfq — per-process fairness costs throughput on flash; mq-deadline is cheaper unless you need bfq's isolation", Refs: []string{refBlockQ}},
+	{ID: "doctor.blockq.nr_raid", Severity: "warn", Text: "%s is a spinning disk (%s) with only %d queued requests — too shallow to keep every spindle busy; raise nr_requests to 256", Refs: []string{refBlockQ}},
+	{ID: "doctor.blockq.ra_hdd", Severity: "info", Text: "%s read-ahead is %d KB — too small for sequential reads on a spinning disk; use at least 128 KB", Refs: []string{refBlockQ}},
+	{ID: "doctor.blockq.ra_ssd", Severity: "info", Text: "%s read-ahead is %d KB — on flash that mostly evicts page cache with data random IO never reads; 128 KB is enough", Refs: []string{refBlockQ}},
+	{ID: "doctor.disk.inodes", Severity: "warn", Text: "Find dirs with many small files: find / -xdev -printf '%h\\n' | sort | uniq -c | sort -rn | head"},
+
+	{ID: "doctor.net.retrans_crit", Severity: "crit", Text: "Severe packet loss or congestion"},
+	{ID: "doctor.net.retrans_warn", Severity: "warn", Text: "TCP retransmissions elevated"},
+	{ID: "doctor.net.conntrack", Severity: "crit", Text: "Conntrack table nearly full; sysctl net.netfilter.nf_conntrack_max", Refs: []string{refConntrack}},
+	{ID: "doctor.net.closewait", Severity: "warn", Text: "Application not closing connections properly"},
+
+	{ID: "doctor.sys.fd_crit", Severity: "crit", Text: "FD exhaustion imminent; sysctl fs.file-max", Refs: []string{refFSSysctl}},
+	{ID: "doctor.sys.fd_warn", Severity: "warn", Text: "FD usage high", Refs: []string{refFSSysctl}},
+	{ID: "doctor.sys.failed_units", Severity: "warn", Text: "systemctl restart <unit>"},
+	{ID: "doctor.sys.security_updates", Severity: "warn", Text: "apt upgrade"},
+	{ID: "doctor.sys.ntp", Severity: "warn", Text: "systemctl enable --now systemd-timesyncd"},
+	{ID: "doctor.cert.expired", Severity: "crit", Text: "certbot renew --force-renewal"},
+	{ID: "doctor.cert.expiring", Severity: "warn", Text: "certbot renew"},
+
+	{ID: "doctor.svc.mysql_down", Severity: "crit", Text: "Check MySQL process and logs"},
+	{ID: "doctor.svc.postgres_down", Severity: "crit", Text: "Check PostgreSQL process and pg_hba.conf"},
+	{ID: "doctor.svc.redis_down", Severity: "crit", Text: "Check Redis process and configuration"},
+	{ID: "doctor.svc.docker_unhealthy", Severity: "warn", Text: "docker ps --filter health=unhealthy"},
+	{ID: "doctor.svc.docker_restarting", Severity: "crit", Text: "docker logs <container>"},
+}
@@ -54,6 +54,15 @@ func (c *Client) Status() (*StatusResponse, error) {
 	return &sr, nil
 }
 
+// Frame returns the daemon's latest full snapshot, rates and analysis.
+func (c *Client) Frame() (*FrameResponse, error) {
+	var fr FrameResponse
+	if err := c.getJSON("/v1/frame", &fr); err != nil {
+		return nil, err
+	}
+	return &fr, nil
+}
+
 // Top returns impact-scored process list.
 func (c *Client) Top(limit int) ([]model.ImpactScore, error) {
 	url := fmt.Sprintf("/v1/top?limit=%d", limit)
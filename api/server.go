@@ -60,6 +60,7 @@ func NewServer(sockPath string, provider SnapshotProvider, st *store.Store) (*Se
 	}
 
 	s.mux.HandleFunc("/v1/status", s.handleStatus)
+	s.mux.HandleFunc("/v1/frame", s.handleFrame)
 	s.mux.HandleFunc("/v1/top", s.handleTop)
 	s.mux.HandleFunc("/v1/proc/", s.handleProc)
 	s.mux.HandleFunc("/v1/incidents", s.handleIncidents)
@@ -109,6 +110,7 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 		resp.TopOffender = result.Blame[0].Comm
 		resp.TopOffenderPID = result.Blame[0].PID
 	}
+	resp.Blame = result.BlameDomains
 	resp.CPUBusy = 0
 	if rates != nil {
 		resp.CPUBusy = rates.CPUBusyPct
@@ -120,6 +122,17 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, resp)
 }
 
+// handleFrame returns the full latest tick — snapshot, rates and analysis,
+// the same shape as a recorded frame — so another TUI can render this host.
+func (s *Server) handleFrame(w http.ResponseWriter, r *http.Request) {
+	snap, rates, result := s.provider.Latest()
+	if snap == nil {
+		http.Error(w, "no data", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, FrameResponse{Snapshot: snap, Rates: rates, Result: result})
+}
+
 func (s *Server) handleTop(w http.ResponseWriter, r *http.Request) {
 	scores := s.provider.ImpactScores()
 	if len(scores) == 0 {
@@ -228,6 +241,16 @@ type StatusResponse struct {
 	TopOffenderPID int         `json:"top_offender_pid,omitempty"`
 	CPUBusy        float64     `json:"cpu_busy"`
 	MemPct         float64     `json:"mem_pct"`
+	// Blame ranks offenders per pressured domain, primary first: the
+	// first offender of the first domain is the one to restart first.
+	Blame []model.BlameDomain `json:"blame,omitempty"`
+}
+
+// FrameResponse is the /v1/frame endpoint response.
+type FrameResponse struct {
+	Snapshot *model.Snapshot       `json:"snapshot"`
+	Rates    *model.RateSnapshot   `json:"rates,omitempty"`
+	Result   *model.AnalysisResult `json:"result,omitempty"`
 }
 
 // DefaultSockPath returns the preferred socket path.
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ftahirops/xtop/advice"
+)
+
+// runAdvice implements `xtop advice` — browse the advice catalog that RCA
+// actions, diagnostics findings and doctor checks render from. Sites use
+// it to find the IDs to reword, translate or attach runbook links to in
+// /etc/xtop/advice.json; `--json` dumps the merged catalog as a starting
+// point for such a file.
+func runAdvice(args []string) error {
+	fs := flag.NewFlagSet("advice", flag.ExitOnError)
+	jsonOut := fs.Bool("json", false, "Print entries as JSON (same shape as an override file)")
+	lang := fs.String("lang", "", "Render text in this language (default from XTOP_LANG/LANG)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, `xtop advice — list the advice catalog
+
+  xtop advice                   all entries: ID, severity, text
+  xtop advice rca.mem           entries whose ID starts with a prefix
+  xtop advice rca.mem.swap      one entry with its references
+  xtop advice --json > /etc/xtop/advice.json   seed a site override file
+
+Overrides: /etc/xtop/advice.json, then ~/.xtop/advice.json ($XTOP_ADVICE
+replaces both). Empty fields keep the built-in value; new IDs are added.`)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if *lang != "" {
+		advice.SetLanguage(*lang)
+	}
+
+	prefix := fs.Arg(0)
+	var entries []advice.Entry
+	for _, e := range advice.All() {
+		if strings.HasPrefix(e.ID, prefix) {
+			entries = append(entries, e)
+		}
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no advice entries match %q", prefix)
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 1 && entries[0].ID == prefix {
+		e := entries[0]
+		fmt.Printf("%s%s%s  %s\n", B, e.ID, R, adviceSevCell(e.Severity))
+		fmt.Printf("  %s\n", advice.Text(e.ID))
+		for _, r := range e.Refs {
+			fmt.Printf("  %ssee:%s %s\n", D, R, r)
+		}
+		for code, t := range e.Lang {
+			fmt.Printf("  %s[%s]%s %s\n", D, code, R, t)
+		}
+		return nil
+	}
+
+	idW := 0
+	for _, e := range entries {
+		idW = max(idW, len(e.ID))
+	}
+	for _, e := range entries {
+		fmt.Printf("%-*s  %s  %s\n", idW, e.ID, adviceSevCell(e.Severity), advice.Text(e.ID))
+	}
+	return nil
+}
+
+func adviceSevCell(sev string) string {
+	switch sev {
+	case "crit":
+		return FBRed + "CRIT" + R
+	case "warn":
+		return FBYel + "WARN" + R
+	}
+	return D + "INFO" + R
+}
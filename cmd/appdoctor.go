@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/util"
 )
 
 // appDoctorFinding represents a single finding in the app doctor report.
@@ -683,7 +684,7 @@ func analyzeDeepFindings(r appReport) []appDoctorFinding {
 func printAppDoctorCLI(reports []appReport, hostname string, ts time.Time) {
 	fmt.Printf(" %s══════════════════════════════════════════════════════════%s\n", D, R)
 	fmt.Printf(" %s%s  APPLICATION HEALTH REPORT  %s  %s  %s\n",
-		B+BBlu+FBWht, " ", R, hostname, ts.Format("2006-01-02 15:04:05"))
+		B+BBlu+FBWht, " ", R, hostname, util.FmtTime(ts, "2006-01-02 15:04:05"))
 	fmt.Printf(" %s══════════════════════════════════════════════════════════%s\n\n", D, R)
 
 	totalCrit := 0
@@ -780,7 +781,7 @@ func generateAppDoctorReport(reports []appReport, hostname string, ts time.Time,
 
 	sb.WriteString("# xtop App Doctor Report\n\n")
 	sb.WriteString(fmt.Sprintf("**Host:** %s  \n", hostname))
-	sb.WriteString(fmt.Sprintf("**Date:** %s  \n", ts.Format("2006-01-02 15:04:05 MST")))
+	sb.WriteString(fmt.Sprintf("**Date:** %s  \n", util.FmtTime(ts, "2006-01-02 15:04:05 MST")))
 	sb.WriteString(fmt.Sprintf("**Version:** xtop v%s  \n", Version))
 	sb.WriteString(fmt.Sprintf("**Analysis:** %d samples over %s (interval %s)  \n\n",
 		cycles, time.Duration(cycles)*interval, interval))
@@ -963,7 +964,7 @@ func generateAppDoctorReport(reports []appReport, hostname string, ts time.Time,
 	// Footer
 	sb.WriteString("---\n\n")
 	sb.WriteString(fmt.Sprintf("*Generated by [xtop](https://github.com/ftahirops/xtop) v%s on %s*\n",
-		Version, ts.Format("2006-01-02 15:04:05")))
+		Version, util.FmtTime(ts, "2006-01-02 15:04:05")))
 
 	return sb.String()
 }
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/util"
 )
 
 // runBaseline implements `xtop baseline` — manage named "this is normal"
@@ -243,7 +244,7 @@ func baselineList(_ []string) error {
 	for _, d := range docs {
 		rows = append(rows, []string{
 			subcmdTrunc(d.Name, 22),
-			d.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			util.FmtTime(d.CreatedAt, "2006-01-02 15:04:05"),
 			fmt.Sprintf("%dd", d.SourceDays),
 			fmt.Sprintf("%.0f%% / %.0f%%", d.CPU.P95Max, d.CPU.MaxMax),
 			fmt.Sprintf("%.0f%% / %.0f%%", d.Memory.P95Max, d.Memory.MaxMax),
@@ -450,7 +451,7 @@ func baselineCompareANSI(c *baselineComparison) error {
 
 	fmt.Printf("  %sBASELINE%s    saved %s (%s of %dd data)\n",
 		B, R,
-		c.Baseline.CreatedAt.Local().Format("2006-01-02 15:04"),
+		util.FmtTime(c.Baseline.CreatedAt, "2006-01-02 15:04"),
 		fmtMinutes(c.Baseline.Minutes),
 		c.Baseline.SourceDays)
 	if c.Baseline.Note != "" {
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ftahirops/xtop/api"
+	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/ui"
+)
+
+// withCompare attaches the -compare source, if any, to the TUI model.
+func withCompare(m ui.Model, cfg Config) (ui.Model, error) {
+	if cfg.ComparePath == "" {
+		return m, nil
+	}
+	t, err := openCompareSource(cfg.ComparePath, cfg.HistorySize)
+	if err != nil {
+		return m, fmt.Errorf("-compare %s: %w", cfg.ComparePath, err)
+	}
+	return m.WithCompare(t, filepath.Base(cfg.ComparePath)), nil
+}
+
+// openCompareSource returns a ticker for the second host: a daemon API
+// socket when path is a socket (local, or forwarded from the other host
+// with ssh -L), otherwise a recording played one frame per tick.
+func openCompareSource(path string, historySize int) (engine.Ticker, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.Mode()&os.ModeSocket != 0 {
+		c := api.NewClient(path)
+		if err := c.Ping(); err != nil {
+			return nil, fmt.Errorf("daemon not reachable: %w", err)
+		}
+		return &socketTicker{client: c, eng: engine.NewEngine(historySize, 3)}, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	player, err := engine.NewPlayer(f, historySize)
+	if err != nil {
+		return nil, err
+	}
+	if player.Len() == 0 {
+		return nil, fmt.Errorf("no frames in recording")
+	}
+	return player, nil
+}
+
+// socketTicker polls another xtop daemon's /v1/frame. Its engine only
+// keeps history for the trend sparklines; nothing is collected locally.
+type socketTicker struct {
+	client *api.Client
+	eng    *engine.Engine
+	last   time.Time
+}
+
+func (t *socketTicker) Tick() (*model.Snapshot, *model.RateSnapshot, *model.AnalysisResult) {
+	fr, err := t.client.Frame()
+	if err != nil || fr.Snapshot == nil {
+		return nil, nil, nil
+	}
+	// The daemon may tick slower than we poll; push each frame once.
+	if !fr.Snapshot.Timestamp.Equal(t.last) {
+		t.last = fr.Snapshot.Timestamp
+		t.eng.History.Push(*fr.Snapshot)
+		if fr.Rates != nil {
+			t.eng.History.PushRate(*fr.Rates)
+		}
+	}
+	return fr.Snapshot, fr.Rates, fr.Result
+}
+
+func (t *socketTicker) Base() *engine.Engine {
+	return t.eng
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/util"
 )
 
 // runCost implements `xtop cost` — a VM right-sizing report.
@@ -361,8 +362,8 @@ func writeCostANSI(rep *costReport) error {
 	fmt.Printf("    %-16s %d minutes (%s of data)\n",
 		"Samples:", rep.Minutes, fmtMinutes(rep.Minutes))
 	fmt.Printf("    %-16s %s → %s\n",
-		"Range:", rep.StartedAt.Local().Format("2006-01-02 15:04"),
-		rep.EndedAt.Local().Format("2006-01-02 15:04"))
+		"Range:", util.FmtTime(rep.StartedAt, "2006-01-02 15:04"),
+		util.FmtTime(rep.EndedAt, "2006-01-02 15:04"))
 	fmt.Printf("    %-16s %.1f %% of expected minutes\n", "Coverage:", rep.Coverage*100)
 	if rep.NumCPUs > 0 {
 		fmt.Printf("    %-16s %d vCPU · %s RAM\n",
@@ -152,6 +152,7 @@ type diagJSONFinding struct {
 	Summary  string `json:"summary"`
 	Detail   string `json:"detail,omitempty"`
 	Advice   string `json:"advice,omitempty"`
+	AdviceID string `json:"advice_id,omitempty"`
 }
 
 func renderDiagnoseJSON(services []model.ServiceDiag, hostname string) error {
@@ -175,6 +176,7 @@ func renderDiagnoseJSON(services []model.ServiceDiag, hostname string) error {
 				Summary:  f.Summary,
 				Detail:   f.Detail,
 				Advice:   f.Advice,
+				AdviceID: f.AdviceID,
 			})
 		}
 		report.Services = append(report.Services, js)
@@ -0,0 +1,695 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
+)
+
+// runDiffRCA implements `xtop diff-rca a.json b.json` — a structured
+// before/after comparison of two saved RCA files (the TUI's `S` key, or
+// `xtop -json`). Shows what moved between the two moments:
+//
+//   - headline: health, primary bottleneck, culprit, confidence
+//   - metric deltas (CPU, memory, PSI, disk, network, RCA scores)
+//   - evidence that appeared, disappeared or persisted
+//   - process table changes: new heavy hitters, exits, big movers
+func runDiffRCA(args []string) error {
+	fs := flag.NewFlagSet("diff-rca", flag.ExitOnError)
+	var (
+		mdOut   = fs.Bool("md", false, "render as markdown")
+		jsonOut = fs.Bool("json", false, "render as a single JSON document")
+		top     = fs.Int("top", 10, "processes compared from each side's top list")
+		all     = fs.Bool("all", false, "show unchanged metrics too")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, `xtop diff-rca — compare two saved RCA JSON files
+
+  xtop diff-rca before.json after.json         colored terminal report
+  xtop diff-rca before.json after.json --md    markdown for tickets
+  xtop diff-rca before.json after.json --json  machine-readable diff
+
+Files come from the TUI's save key (S) or 'xtop -json > file.json'.
+
+Flags:`)
+		fs.PrintDefaults()
+	}
+
+	// Accept flags before, between or after the two file names.
+	var files []string
+	rest := args
+	for {
+		if err := fs.Parse(rest); err != nil {
+			return err
+		}
+		rest = fs.Args()
+		if len(rest) == 0 {
+			break
+		}
+		files = append(files, rest[0])
+		rest = rest[1:]
+	}
+	if len(files) != 2 {
+		fs.Usage()
+		return fmt.Errorf("diff-rca needs exactly two files, got %d", len(files))
+	}
+
+	a, err := loadSavedRCA(files[0])
+	if err != nil {
+		return err
+	}
+	b, err := loadSavedRCA(files[1])
+	if err != nil {
+		return err
+	}
+	d := diffSavedRCA(a, b, *top)
+
+	switch {
+	case *jsonOut:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	case *mdOut:
+		fmt.Print(renderRCADiffMD(d, *all))
+	default:
+		fmt.Print(renderRCADiffANSI(d, *all))
+	}
+	return nil
+}
+
+// savedRCA is the document written by saveRCA in the TUI and by -json.
+type savedRCA struct {
+	Timestamp string                `json:"timestamp"`
+	Snapshot  *model.Snapshot       `json:"snapshot"`
+	Rates     *model.RateSnapshot   `json:"rates"`
+	Analysis  *model.AnalysisResult `json:"analysis"`
+
+	path string
+}
+
+func loadSavedRCA(path string) (*savedRCA, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s savedRCA
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if s.Snapshot == nil && s.Analysis == nil {
+		return nil, fmt.Errorf("%s: not an xtop RCA save (no snapshot or analysis)", path)
+	}
+	s.path = path
+	return &s, nil
+}
+
+// when returns the save time, preferring the snapshot's own timestamp.
+func (s *savedRCA) when() time.Time {
+	if s.Snapshot != nil && !s.Snapshot.Timestamp.IsZero() {
+		return s.Snapshot.Timestamp
+	}
+	t, _ := time.Parse(time.RFC3339, s.Timestamp)
+	return t
+}
+
+// ── Diff model ──────────────────────────────────────────────────────────────
+
+// diffEvidenceMin mirrors the engine's evidenceStrengthMin: evidence below
+// it did not count toward the verdict.
+const diffEvidenceMin = 0.35
+
+type rcaDiff struct {
+	A        rcaDiffSide      `json:"a"`
+	B        rcaDiffSide      `json:"b"`
+	Elapsed  string           `json:"elapsed,omitempty"`
+	Metrics  []rcaMetricDelta `json:"metrics"`
+	Evidence rcaEvidenceDiff  `json:"evidence"`
+	Procs    rcaProcDiff      `json:"processes"`
+}
+
+type rcaDiffSide struct {
+	File       string    `json:"file"`
+	Time       time.Time `json:"time"`
+	Health     string    `json:"health"`
+	Bottleneck string    `json:"bottleneck,omitempty"`
+	Score      int       `json:"score"`
+	Confidence int       `json:"confidence"`
+	Culprit    string    `json:"culprit,omitempty"`
+	RootCause  string    `json:"root_cause,omitempty"`
+}
+
+type rcaMetricDelta struct {
+	Metric   string  `json:"metric"`
+	Unit     string  `json:"unit,omitempty"`
+	A        float64 `json:"a"`
+	B        float64 `json:"b"`
+	Delta    float64 `json:"delta"`
+	Material bool    `json:"material"`
+}
+
+type rcaEvidenceItem struct {
+	ID        string  `json:"id"`
+	Domain    string  `json:"domain,omitempty"`
+	Message   string  `json:"message"`
+	StrengthA float64 `json:"strength_a,omitempty"`
+	StrengthB float64 `json:"strength_b,omitempty"`
+	ValueA    float64 `json:"value_a,omitempty"`
+	ValueB    float64 `json:"value_b,omitempty"`
+}
+
+type rcaEvidenceDiff struct {
+	Appeared    []rcaEvidenceItem `json:"appeared,omitempty"`
+	Disappeared []rcaEvidenceItem `json:"disappeared,omitempty"`
+	Persisted   []rcaEvidenceItem `json:"persisted,omitempty"`
+}
+
+type rcaProcRow struct {
+	PID    int     `json:"pid"`
+	Comm   string  `json:"comm"`
+	CPUPct float64 `json:"cpu_pct"`
+	RSSMB  float64 `json:"rss_mb"`
+	IOMBs  float64 `json:"io_mbs"`
+	State  string  `json:"state,omitempty"`
+}
+
+type rcaProcChange struct {
+	rcaProcRow
+	CPUDelta float64 `json:"cpu_delta"`
+	RSSDelta float64 `json:"rss_delta_mb"`
+	IODelta  float64 `json:"io_delta_mbs"`
+}
+
+type rcaProcDiff struct {
+	Started []rcaProcRow    `json:"started,omitempty"` // heavy in B, absent in A
+	Exited  []rcaProcRow    `json:"exited,omitempty"`  // heavy in A, absent in B
+	Changed []rcaProcChange `json:"changed,omitempty"` // in both, moved materially
+}
+
+func diffSavedRCA(a, b *savedRCA, top int) *rcaDiff {
+	d := &rcaDiff{
+		A:        rcaSide(a),
+		B:        rcaSide(b),
+		Metrics:  diffRCAMetrics(a, b),
+		Evidence: diffRCAEvidence(a.Analysis, b.Analysis),
+		Procs:    diffRCAProcs(a.Rates, b.Rates, top),
+	}
+	if !d.A.Time.IsZero() && !d.B.Time.IsZero() {
+		d.Elapsed = d.B.Time.Sub(d.A.Time).Round(time.Second).String()
+	}
+	return d
+}
+
+func rcaSide(s *savedRCA) rcaDiffSide {
+	side := rcaDiffSide{File: filepath.Base(s.path), Time: s.when(), Health: "UNKNOWN"}
+	r := s.Analysis
+	if r == nil {
+		return side
+	}
+	side.Health = r.Health.String()
+	side.Bottleneck = r.PrimaryBottleneck
+	side.Score = r.PrimaryScore
+	side.Confidence = r.Confidence
+	side.Culprit = r.PrimaryAppName
+	if side.Culprit == "" {
+		side.Culprit = r.PrimaryProcess
+	}
+	if side.Culprit == "" {
+		side.Culprit = r.PrimaryCulprit
+	}
+	if r.Narrative != nil {
+		side.RootCause = r.Narrative.RootCause
+	}
+	return side
+}
+
+// rcaMetric is one comparable reading. ok is false when the save lacks the
+// source (e.g. no rates in a first-tick save).
+type rcaMetric struct {
+	name     string
+	unit     string
+	minDelta float64 // smallest change worth flagging
+	value    float64
+	ok       bool
+}
+
+// rcaMetrics extracts the compared readings in display order. Every metric
+// is "higher is worse", so a positive delta is a regression.
+func rcaMetrics(s *savedRCA) []rcaMetric {
+	var out []rcaMetric
+	add := func(name, unit string, minDelta, v float64, ok bool) {
+		out = append(out, rcaMetric{name, unit, minDelta, v, ok})
+	}
+	snap, rates := s.Snapshot, s.Rates
+	hasSnap, hasRates := snap != nil, rates != nil
+	if !hasSnap {
+		snap = &model.Snapshot{}
+	}
+	if !hasRates {
+		rates = &model.RateSnapshot{}
+	}
+
+	add("CPU busy", "%", 5, rates.CPUBusyPct, hasRates)
+	add("CPU iowait", "%", 2, rates.CPUIOWaitPct, hasRates)
+	add("CPU steal", "%", 2, rates.CPUStealPct, hasRates)
+	add("Load 1m", "", 1, snap.Global.CPU.LoadAvg.Load1, hasSnap)
+	memPct := 0.0
+	if mem := snap.Global.Memory; mem.Total > 0 {
+		memPct = float64(mem.Total-mem.Available) / float64(mem.Total) * 100
+	}
+	add("Memory used", "%", 5, memPct, hasSnap)
+	add("Swap used", "MB", 64, float64(snap.Global.Memory.SwapUsed)/(1024*1024), hasSnap)
+	add("PSI cpu some", "%", 2, snap.Global.PSI.CPU.Some.Avg10, hasSnap)
+	add("PSI mem some", "%", 2, snap.Global.PSI.Memory.Some.Avg10, hasSnap)
+	add("PSI mem full", "%", 1, snap.Global.PSI.Memory.Full.Avg10, hasSnap)
+	add("PSI io some", "%", 2, snap.Global.PSI.IO.Some.Avg10, hasSnap)
+	add("PSI io full", "%", 1, snap.Global.PSI.IO.Full.Avg10, hasSnap)
+
+	var util, await float64
+	for _, dr := range rates.DiskRates {
+		util = math.Max(util, dr.UtilPct)
+		await = math.Max(await, dr.AvgAwaitMs)
+	}
+	add("Disk util (max)", "%", 10, util, hasRates)
+	add("Disk await (max)", "ms", 5, await, hasRates)
+	add("TCP retransmits", "/s", 5, rates.RetransRate, hasRates)
+	add("TCP resets", "/s", 5, rates.TCPResetRate, hasRates)
+	add("Major faults", "/s", 50, rates.MajFaultRate, hasRates)
+
+	dstate := 0
+	for _, p := range snap.Processes {
+		if p.State == "D" {
+			dstate++
+		}
+	}
+	add("D-state tasks", "", 1, float64(dstate), hasSnap)
+	return out
+}
+
+func diffRCAMetrics(a, b *savedRCA) []rcaMetricDelta {
+	ma, mb := rcaMetrics(a), rcaMetrics(b)
+	var out []rcaMetricDelta
+	for i := range ma {
+		if !ma[i].ok || !mb[i].ok {
+			continue
+		}
+		out = append(out, newMetricDelta(ma[i].name, ma[i].unit, ma[i].minDelta, ma[i].value, mb[i].value))
+	}
+
+	// Per-bottleneck RCA scores, in A's order then anything new in B.
+	scores := func(r *model.AnalysisResult) map[string]int {
+		m := make(map[string]int)
+		if r != nil {
+			for _, e := range r.RCA {
+				m[e.Bottleneck] = e.Score
+			}
+		}
+		return m
+	}
+	sa, sb := scores(a.Analysis), scores(b.Analysis)
+	var names []string
+	for _, r := range []*model.AnalysisResult{a.Analysis, b.Analysis} {
+		if r == nil {
+			continue
+		}
+		for _, e := range r.RCA {
+			if !containsStr(names, e.Bottleneck) {
+				names = append(names, e.Bottleneck)
+			}
+		}
+	}
+	for _, n := range names {
+		out = append(out, newMetricDelta("RCA "+n, "", 10, float64(sa[n]), float64(sb[n])))
+	}
+	return out
+}
+
+func newMetricDelta(name, unit string, minDelta, a, b float64) rcaMetricDelta {
+	delta := b - a
+	return rcaMetricDelta{
+		Metric:   name,
+		Unit:     unit,
+		A:        a,
+		B:        b,
+		Delta:    delta,
+		Material: math.Abs(delta) >= minDelta,
+	}
+}
+
+// firedEvidence returns evidence that counted toward a verdict, keyed by ID.
+// Saves without v2 evidence fall back to the primary evidence strings.
+func firedEvidence(r *model.AnalysisResult) map[string]rcaEvidenceItem {
+	out := make(map[string]rcaEvidenceItem)
+	if r == nil {
+		return out
+	}
+	for _, rca := range r.RCA {
+		for _, ev := range rca.EvidenceV2 {
+			if ev.Strength < diffEvidenceMin {
+				continue
+			}
+			if cur, ok := out[ev.ID]; ok && cur.StrengthA >= ev.Strength {
+				continue
+			}
+			out[ev.ID] = rcaEvidenceItem{
+				ID:        ev.ID,
+				Domain:    string(ev.Domain),
+				Message:   ev.Message,
+				StrengthA: ev.Strength,
+				ValueA:    ev.Value,
+			}
+		}
+	}
+	if len(out) == 0 {
+		for _, s := range r.PrimaryEvidence {
+			out[s] = rcaEvidenceItem{ID: s, Message: s}
+		}
+	}
+	return out
+}
+
+func diffRCAEvidence(a, b *model.AnalysisResult) rcaEvidenceDiff {
+	ea, eb := firedEvidence(a), firedEvidence(b)
+	var d rcaEvidenceDiff
+	for id, ev := range eb {
+		if prev, ok := ea[id]; ok {
+			ev.ValueB, ev.StrengthB = ev.ValueA, ev.StrengthA
+			ev.ValueA, ev.StrengthA = prev.ValueA, prev.StrengthA
+			d.Persisted = append(d.Persisted, ev)
+			continue
+		}
+		ev.ValueB, ev.StrengthB = ev.ValueA, ev.StrengthA
+		ev.ValueA, ev.StrengthA = 0, 0
+		d.Appeared = append(d.Appeared, ev)
+	}
+	for id, ev := range ea {
+		if _, ok := eb[id]; !ok {
+			d.Disappeared = append(d.Disappeared, ev)
+		}
+	}
+	byStrength := func(list []rcaEvidenceItem) {
+		sort.Slice(list, func(i, j int) bool {
+			si := math.Max(list[i].StrengthA, list[i].StrengthB)
+			sj := math.Max(list[j].StrengthA, list[j].StrengthB)
+			if si != sj {
+				return si > sj
+			}
+			return list[i].ID < list[j].ID
+		})
+	}
+	byStrength(d.Appeared)
+	byStrength(d.Disappeared)
+	byStrength(d.Persisted)
+	return d
+}
+
+func procRow(p model.ProcessRate) rcaProcRow {
+	return rcaProcRow{
+		PID:    p.PID,
+		Comm:   p.Comm,
+		CPUPct: p.CPUPct,
+		RSSMB:  float64(p.RSS) / (1024 * 1024),
+		IOMBs:  p.ReadMBs + p.WriteMBs,
+		State:  p.State,
+	}
+}
+
+// topProcs returns the n heaviest processes by CPU, then RSS.
+func topProcs(r *model.RateSnapshot, n int) []model.ProcessRate {
+	if r == nil {
+		return nil
+	}
+	list := append([]model.ProcessRate(nil), r.ProcessRates...)
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].CPUPct != list[j].CPUPct {
+			return list[i].CPUPct > list[j].CPUPct
+		}
+		return list[i].RSS > list[j].RSS
+	})
+	if n > 0 && len(list) > n {
+		list = list[:n]
+	}
+	return list
+}
+
+// procKey identifies a process across saves; the comm guards against PID
+// reuse between the two moments.
+func procKey(p model.ProcessRate) string { return fmt.Sprintf("%d/%s", p.PID, p.Comm) }
+
+func diffRCAProcs(a, b *model.RateSnapshot, top int) rcaProcDiff {
+	var d rcaProcDiff
+	if a == nil || b == nil {
+		return d
+	}
+	index := func(r *model.RateSnapshot) map[string]model.ProcessRate {
+		m := make(map[string]model.ProcessRate, len(r.ProcessRates))
+		for _, p := range r.ProcessRates {
+			m[procKey(p)] = p
+		}
+		return m
+	}
+	ia, ib := index(a), index(b)
+
+	seen := make(map[string]bool)
+	for _, p := range topProcs(b, top) {
+		k := procKey(p)
+		seen[k] = true
+		prev, ok := ia[k]
+		if !ok {
+			if procHeavy(p) {
+				d.Started = append(d.Started, procRow(p))
+			}
+			continue
+		}
+		if c, ok := procChange(prev, p); ok {
+			d.Changed = append(d.Changed, c)
+		}
+	}
+	for _, p := range topProcs(a, top) {
+		k := procKey(p)
+		if seen[k] {
+			continue
+		}
+		cur, ok := ib[k]
+		if !ok {
+			if procHeavy(p) {
+				d.Exited = append(d.Exited, procRow(p))
+			}
+			continue
+		}
+		if c, ok := procChange(p, cur); ok {
+			d.Changed = append(d.Changed, c)
+		}
+	}
+	sort.Slice(d.Changed, func(i, j int) bool {
+		return math.Abs(d.Changed[i].CPUDelta) > math.Abs(d.Changed[j].CPUDelta)
+	})
+	return d
+}
+
+// procHeavy filters idle processes out of the started/exited lists.
+func procHeavy(p model.ProcessRate) bool {
+	return p.CPUPct >= 1 || p.ReadMBs+p.WriteMBs >= 1 || p.RSS >= 256<<20
+}
+
+// procChange reports a process whose CPU, memory or IO moved materially.
+func procChange(a, b model.ProcessRate) (rcaProcChange, bool) {
+	ra, rb := procRow(a), procRow(b)
+	c := rcaProcChange{
+		rcaProcRow: rb,
+		CPUDelta:   rb.CPUPct - ra.CPUPct,
+		RSSDelta:   rb.RSSMB - ra.RSSMB,
+		IODelta:    rb.IOMBs - ra.IOMBs,
+	}
+	material := math.Abs(c.CPUDelta) >= 5 || math.Abs(c.RSSDelta) >= 64 || math.Abs(c.IODelta) >= 5
+	return c, material
+}
+
+func containsStr(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ── Rendering ───────────────────────────────────────────────────────────────
+
+func fmtDiffVal(v float64, unit string) string {
+	switch unit {
+	case "%":
+		return fmt.Sprintf("%.1f%%", v)
+	case "":
+		if v == math.Trunc(v) {
+			return fmt.Sprintf("%.0f", v)
+		}
+		return fmt.Sprintf("%.2f", v)
+	}
+	return fmt.Sprintf("%.1f%s", v, unit)
+}
+
+func fmtDiffDelta(v float64, unit string) string {
+	s := fmtDiffVal(v, unit)
+	if v > 0 {
+		s = "+" + s
+	}
+	return s
+}
+
+func renderRCADiffANSI(d *rcaDiff, all bool) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\n  %sxtop diff-rca%s — %s → %s", B, R, d.A.File, d.B.File)
+	if d.Elapsed != "" {
+		fmt.Fprintf(&sb, "  (%s apart)", d.Elapsed)
+	}
+	sb.WriteString("\n\n")
+
+	side := func(label string, s rcaDiffSide) {
+		fmt.Fprintf(&sb, "  %s%-7s%s %s  %s", B, label, R, util.FmtTime(s.Time, "2006-01-02 15:04:05"), colorHealth(s.Health))
+		if s.Bottleneck != "" {
+			fmt.Fprintf(&sb, "  %s (score %d, %d%% confidence)", s.Bottleneck, s.Score, s.Confidence)
+		}
+		if s.Culprit != "" {
+			fmt.Fprintf(&sb, "  culprit %s%s%s", FBCyn, s.Culprit, R)
+		}
+		sb.WriteString("\n")
+		if s.RootCause != "" {
+			fmt.Fprintf(&sb, "          %s%s%s\n", FDim, s.RootCause, R)
+		}
+	}
+	side("BEFORE", d.A)
+	side("AFTER", d.B)
+	sb.WriteString("\n")
+
+	fmt.Fprintf(&sb, "  %sMETRICS%s\n", B, R)
+	shown := 0
+	for _, m := range d.Metrics {
+		if !all && !m.Material {
+			continue
+		}
+		shown++
+		color := FDim
+		switch {
+		case m.Material && m.Delta > 0:
+			color = B + FBRed
+		case m.Material && m.Delta < 0:
+			color = FBGrn
+		}
+		fmt.Fprintf(&sb, "    %-22s %10s → %-10s %s%s%s\n", m.Metric,
+			fmtDiffVal(m.A, m.Unit), fmtDiffVal(m.B, m.Unit), color, fmtDiffDelta(m.Delta, m.Unit), R)
+	}
+	if shown == 0 {
+		fmt.Fprintf(&sb, "    %sno material changes (--all to list every metric)%s\n", FDim, R)
+	}
+	sb.WriteString("\n")
+
+	ev := d.Evidence
+	if len(ev.Appeared)+len(ev.Disappeared)+len(ev.Persisted) > 0 {
+		fmt.Fprintf(&sb, "  %sEVIDENCE%s\n", B, R)
+		for _, e := range ev.Appeared {
+			fmt.Fprintf(&sb, "    %s+ %-28s%s %s\n", FBRed, e.ID, R, e.Message)
+		}
+		for _, e := range ev.Disappeared {
+			fmt.Fprintf(&sb, "    %s- %-28s%s %s\n", FBGrn, e.ID, R, e.Message)
+		}
+		for _, e := range ev.Persisted {
+			fmt.Fprintf(&sb, "    %s= %-28s%s strength %.2f → %.2f\n", FDim, e.ID, R, e.StrengthA, e.StrengthB)
+		}
+		sb.WriteString("\n")
+	}
+
+	p := d.Procs
+	if len(p.Started)+len(p.Exited)+len(p.Changed) > 0 {
+		fmt.Fprintf(&sb, "  %sPROCESSES%s\n", B, R)
+		for _, r := range p.Started {
+			fmt.Fprintf(&sb, "    %s+ %-7d %-16s%s CPU=%.1f%%  RSS=%.0fMB  IO=%.1fMB/s\n",
+				FBRed, r.PID, subcmdTrunc(r.Comm, 16), R, r.CPUPct, r.RSSMB, r.IOMBs)
+		}
+		for _, r := range p.Exited {
+			fmt.Fprintf(&sb, "    %s- %-7d %-16s%s CPU=%.1f%%  RSS=%.0fMB  IO=%.1fMB/s\n",
+				FBGrn, r.PID, subcmdTrunc(r.Comm, 16), R, r.CPUPct, r.RSSMB, r.IOMBs)
+		}
+		for _, c := range p.Changed {
+			fmt.Fprintf(&sb, "    %s~ %-7d %-16s%s CPU %s  RSS %s  IO %s\n",
+				FBYel, c.PID, subcmdTrunc(c.Comm, 16), R,
+				fmtDiffDelta(c.CPUDelta, "%"), fmtDiffDelta(c.RSSDelta, "MB"), fmtDiffDelta(c.IODelta, "MB/s"))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func renderRCADiffMD(d *rcaDiff, all bool) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# RCA diff — `%s` → `%s`\n\n", d.A.File, d.B.File)
+	sb.WriteString("| | before | after |\n|---|---|---|\n")
+	fmt.Fprintf(&sb, "| Time | %s | %s |\n", d.A.Time.Format(time.RFC3339), d.B.Time.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "| Health | %s | %s |\n", d.A.Health, d.B.Health)
+	fmt.Fprintf(&sb, "| Bottleneck | %s | %s |\n", orDash(d.A.Bottleneck), orDash(d.B.Bottleneck))
+	fmt.Fprintf(&sb, "| Score / confidence | %d / %d%% | %d / %d%% |\n", d.A.Score, d.A.Confidence, d.B.Score, d.B.Confidence)
+	fmt.Fprintf(&sb, "| Culprit | %s | %s |\n\n", orDash(d.A.Culprit), orDash(d.B.Culprit))
+
+	sb.WriteString("## Metrics\n\n| metric | before | after | delta |\n|--------|--------|-------|-------|\n")
+	for _, m := range d.Metrics {
+		if !all && !m.Material {
+			continue
+		}
+		delta := fmtDiffDelta(m.Delta, m.Unit)
+		if m.Material {
+			delta = "**" + delta + "**"
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n", m.Metric, fmtDiffVal(m.A, m.Unit), fmtDiffVal(m.B, m.Unit), delta)
+	}
+
+	ev := d.Evidence
+	if len(ev.Appeared)+len(ev.Disappeared)+len(ev.Persisted) > 0 {
+		sb.WriteString("\n## Evidence\n\n")
+		for _, e := range ev.Appeared {
+			fmt.Fprintf(&sb, "- **appeared** `%s` — %s\n", e.ID, e.Message)
+		}
+		for _, e := range ev.Disappeared {
+			fmt.Fprintf(&sb, "- **disappeared** `%s` — %s\n", e.ID, e.Message)
+		}
+		for _, e := range ev.Persisted {
+			fmt.Fprintf(&sb, "- persisted `%s` — strength %.2f → %.2f\n", e.ID, e.StrengthA, e.StrengthB)
+		}
+	}
+
+	p := d.Procs
+	if len(p.Started)+len(p.Exited)+len(p.Changed) > 0 {
+		sb.WriteString("\n## Processes\n\n| change | PID | process | CPU | RSS | IO |\n|--------|-----|---------|-----|-----|----|\n")
+		for _, r := range p.Started {
+			fmt.Fprintf(&sb, "| started | %d | %s | %.1f%% | %.0fMB | %.1fMB/s |\n", r.PID, r.Comm, r.CPUPct, r.RSSMB, r.IOMBs)
+		}
+		for _, r := range p.Exited {
+			fmt.Fprintf(&sb, "| exited | %d | %s | %.1f%% | %.0fMB | %.1fMB/s |\n", r.PID, r.Comm, r.CPUPct, r.RSSMB, r.IOMBs)
+		}
+		for _, c := range p.Changed {
+			fmt.Fprintf(&sb, "| changed | %d | %s | %s | %s | %s |\n", c.PID, c.Comm,
+				fmtDiffDelta(c.CPUDelta, "%"), fmtDiffDelta(c.RSSDelta, "MB"), fmtDiffDelta(c.IODelta, "MB/s"))
+		}
+	}
+	sb.WriteString("\n---\n*Generated by `xtop diff-rca`*\n")
+	return sb.String()
+}
+
+func colorHealth(h string) string {
+	switch h {
+	case "CRITICAL":
+		return fmt.Sprintf("%s%s CRITICAL %s", B, BRed, R)
+	case "DEGRADED":
+		return fmt.Sprintf("%s%sDEGRADED%s", B, FBYel, R)
+	case "OK":
+		return fmt.Sprintf("%sOK%s", FBGrn, R)
+	}
+	return h
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// writeSavedRCA writes a save in the same shape as the TUI's saveRCA.
+func writeSavedRCA(t *testing.T, dir, name string, snap *model.Snapshot, rates *model.RateSnapshot, res *model.AnalysisResult) string {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{
+		"timestamp": snap.Timestamp.Format(time.RFC3339),
+		"snapshot":  snap,
+		"rates":     rates,
+		"analysis":  res,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiffSavedRCA(t *testing.T) {
+	dir := t.TempDir()
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	before := &model.Snapshot{Timestamp: at}
+	before.Global.PSI.IO.Full.Avg10 = 1
+	beforeRates := &model.RateSnapshot{CPUBusyPct: 20, ProcessRates: []model.ProcessRate{
+		{PID: 10, Comm: "postgres", CPUPct: 15, RSS: 512 << 20},
+		{PID: 11, Comm: "cron", CPUPct: 8},
+	}}
+	beforeRes := &model.AnalysisResult{Health: model.HealthOK, RCA: []model.RCAEntry{
+		{Bottleneck: "IO Starvation", Score: 5, EvidenceV2: []model.Evidence{
+			{ID: "cpu.busy", Message: "CPU busy 60%", Strength: 0.5},
+		}},
+	}}
+
+	after := &model.Snapshot{Timestamp: at.Add(10 * time.Minute)}
+	after.Global.PSI.IO.Full.Avg10 = 30
+	afterRates := &model.RateSnapshot{CPUBusyPct: 22, ProcessRates: []model.ProcessRate{
+		{PID: 10, Comm: "postgres", CPUPct: 60, RSS: 520 << 20},
+		{PID: 42, Comm: "rsync", CPUPct: 12, ReadMBs: 80},
+	}}
+	afterRes := &model.AnalysisResult{Health: model.HealthCritical, PrimaryBottleneck: "IO Starvation",
+		PrimaryScore: 80, PrimaryProcess: "rsync", RCA: []model.RCAEntry{
+			{Bottleneck: "IO Starvation", Score: 80, EvidenceV2: []model.Evidence{
+				{ID: "io.psi.full", Message: "IO PSI full 30%", Strength: 0.9},
+				{ID: "cpu.busy", Message: "CPU busy 62%", Strength: 0.6},
+				{ID: "io.await", Message: "weak", Strength: 0.1},
+			}},
+		}}
+
+	a, err := loadSavedRCA(writeSavedRCA(t, dir, "a.json", before, beforeRates, beforeRes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := loadSavedRCA(writeSavedRCA(t, dir, "b.json", after, afterRates, afterRes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := diffSavedRCA(a, b, 10)
+
+	if d.A.Health != "OK" || d.B.Health != "CRITICAL" || d.B.Culprit != "rsync" || d.Elapsed != "10m0s" {
+		t.Errorf("headline = %+v / %+v (%s)", d.A, d.B, d.Elapsed)
+	}
+
+	material := map[string]float64{}
+	for _, m := range d.Metrics {
+		if m.Material {
+			material[m.Metric] = m.Delta
+		}
+	}
+	if material["PSI io full"] != 29 || material["RCA IO Starvation"] != 75 {
+		t.Errorf("material metrics = %v", material)
+	}
+	if _, ok := material["CPU busy"]; ok {
+		t.Error("a 2pp CPU change should not be material")
+	}
+
+	ev := d.Evidence
+	if len(ev.Appeared) != 1 || ev.Appeared[0].ID != "io.psi.full" {
+		t.Errorf("appeared = %+v", ev.Appeared)
+	}
+	if len(ev.Persisted) != 1 || ev.Persisted[0].StrengthA != 0.5 || ev.Persisted[0].StrengthB != 0.6 {
+		t.Errorf("persisted = %+v", ev.Persisted)
+	}
+	if len(ev.Disappeared) != 0 {
+		t.Errorf("disappeared = %+v", ev.Disappeared)
+	}
+
+	p := d.Procs
+	if len(p.Started) != 1 || p.Started[0].Comm != "rsync" {
+		t.Errorf("started = %+v", p.Started)
+	}
+	if len(p.Exited) != 1 || p.Exited[0].Comm != "cron" {
+		t.Errorf("exited = %+v", p.Exited)
+	}
+	if len(p.Changed) != 1 || p.Changed[0].PID != 10 || p.Changed[0].CPUDelta != 45 {
+		t.Errorf("changed = %+v", p.Changed)
+	}
+
+	md := renderRCADiffMD(d, false)
+	for _, want := range []string{"| Health | OK | CRITICAL |", "**appeared** `io.psi.full`", "| started | 42 | rsync |"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestLoadSavedRCARejectsOtherJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.json")
+	os.WriteFile(path, []byte(`{"hello":"world"}`), 0600)
+	if _, err := loadSavedRCA(path); err == nil {
+		t.Error("expected an error for a non-RCA JSON file")
+	}
+}
@@ -13,9 +13,11 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/ftahirops/xtop/advice"
 	xtopcfg "github.com/ftahirops/xtop/config"
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // CheckStatus represents the severity of a doctor check result.
@@ -49,6 +51,17 @@ type CheckResult struct {
 	Status   CheckStatus `json:"status"`
 	Detail   string      `json:"detail"`
 	Advice   string      `json:"advice,omitempty"`
+	AdviceID string      `json:"advice_id,omitempty"` // key into the advice catalog
+}
+
+// resolveAdvice fills Advice from the catalog for checks that only carry
+// an AdviceID, so every output format renders the same (overridable) text.
+func resolveAdvice(checks []CheckResult) {
+	for i := range checks {
+		if checks[i].Advice == "" {
+			checks[i].Advice = advice.Text(checks[i].AdviceID)
+		}
+	}
 }
 
 // DoctorReport holds the full health check output.
@@ -133,6 +146,8 @@ func runDoctor(cfg Config) error {
 		fmt.Fprintf(os.Stderr, "\r                                     \r")
 	}
 
+	resolveAdvice(report.Checks)
+
 	// Compute worst status
 	for _, c := range report.Checks {
 		if c.Status < CheckSkip && c.Status > report.WorstStatus {
@@ -237,6 +252,8 @@ func runDoctorWatch(cfg Config) error {
 			// Active service detection
 			report.Checks = append(report.Checks, checkActiveServices()...)
 
+			resolveAdvice(report.Checks)
+
 			// Compute worst status
 			for _, c := range report.Checks {
 				if c.Status < CheckSkip && c.Status > report.WorstStatus {
@@ -288,16 +305,16 @@ func checkCPU(snap *model.Snapshot, rates *model.RateSnapshot, result *model.Ana
 		loadPct := la.Load1 / float64(nCPU) * 100
 		detail := fmt.Sprintf("%.1f%% busy — load avg: %.2f/%.2f/%.2f (%d CPUs, %.0f%% capacity)",
 			rates.CPUBusyPct, la.Load1, la.Load5, la.Load15, nCPU, loadPct)
-		advice := ""
+		adviceID := ""
 		if rates.CPUBusyPct > 90 {
 			status = CheckCrit
-			advice = "Identify top CPU consumers: xtop -watch -section cpu"
+			adviceID = "doctor.cpu.util_crit"
 		} else if rates.CPUBusyPct > 70 {
 			status = CheckWarn
-			advice = "CPU load is elevated"
+			adviceID = "doctor.cpu.util_warn"
 		}
 		checks = append(checks, CheckResult{
-			Category: "CPU", Name: "Utilization", Status: status, Detail: detail, Advice: advice,
+			Category: "CPU", Name: "Utilization", Status: status, Detail: detail, AdviceID: adviceID,
 		})
 	}
 
@@ -328,13 +345,13 @@ func checkCPU(snap *model.Snapshot, rates *model.RateSnapshot, result *model.Ana
 	}
 	detail := fmt.Sprintf("1m=%.2f  5m=%.2f  15m=%.2f | %d CPUs → %.0f%% capacity (%s)",
 		load1, load5, load15, nCPU, loadPct, interpretation)
-	advice := ""
+	adviceID := ""
 	if loadPerCPU > 2 {
 		status = CheckCrit
-		advice = "System severely overloaded — tasks waiting far exceed CPU capacity"
+		adviceID = "doctor.cpu.load_crit"
 	} else if loadPerCPU > 1 {
 		status = CheckWarn
-		advice = "Load exceeds CPU count — tasks are queueing for CPU time"
+		adviceID = "doctor.cpu.load_warn"
 	}
 	// Trend: is load rising or falling?
 	if load1 > 0 {
@@ -360,13 +377,13 @@ func checkCPU(snap *model.Snapshot, rates *model.RateSnapshot, result *model.Ana
 			if status == CheckOK {
 				status = CheckWarn
 			}
-			advice = "Load driven by IO-waiting processes, not CPU contention"
+			adviceID = "doctor.cpu.load_iowait"
 		} else if runnable > nCPU {
 			detail += fmt.Sprintf("\n                           %d runnable threads vs %d cores → CPU saturated, tasks queueing", runnable, nCPU)
 		}
 	}
 	checks = append(checks, CheckResult{
-		Category: "CPU", Name: "Load average", Status: status, Detail: detail, Advice: advice,
+		Category: "CPU", Name: "Load average", Status: status, Detail: detail, AdviceID: adviceID,
 	})
 
 	// Run queue — which processes are actually running/runnable (R state)
@@ -386,7 +403,7 @@ func checkCPU(snap *model.Snapshot, rates *model.RateSnapshot, result *model.Ana
 	}
 	if len(rProcs) > 0 || len(dProcs) > 0 {
 		status = CheckOK
-		advice = ""
+		adviceID = ""
 		// Classify R-state processes
 		rDetail := fmt.Sprintf("%d running (R)", len(rProcs))
 		if len(dProcs) > 0 {
@@ -395,10 +412,10 @@ func checkCPU(snap *model.Snapshot, rates *model.RateSnapshot, result *model.Ana
 		totalActive := len(rProcs) + len(dProcs)
 		if totalActive > nCPU*2 {
 			status = CheckCrit
-			advice = "Far more active tasks than CPUs — severe contention"
+			adviceID = "doctor.cpu.runq_crit"
 		} else if totalActive > nCPU {
 			status = CheckWarn
-			advice = "More active tasks than CPUs — some are queueing"
+			adviceID = "doctor.cpu.runq_warn"
 		}
 		// Show top R-state processes (sorted by thread count already from proc collector)
 		if len(rProcs) > 0 {
@@ -488,7 +505,7 @@ func checkCPU(snap *model.Snapshot, rates *model.RateSnapshot, result *model.Ana
 			}
 		}
 		checks = append(checks, CheckResult{
-			Category: "CPU", Name: "Run queue", Status: status, Detail: rDetail, Advice: advice,
+			Category: "CPU", Name: "Run queue", Status: status, Detail: rDetail, AdviceID: adviceID,
 		})
 	}
 
@@ -496,16 +513,16 @@ func checkCPU(snap *model.Snapshot, rates *model.RateSnapshot, result *model.Ana
 	psi := snap.Global.PSI.CPU.Some.Avg10
 	status = CheckOK
 	detail = fmt.Sprintf("some avg10=%.1f%%", psi)
-	advice = ""
+	adviceID = ""
 	if psi > 25 {
 		status = CheckCrit
-		advice = "Severe CPU pressure"
+		adviceID = "doctor.cpu.psi_crit"
 	} else if psi > 10 {
 		status = CheckWarn
-		advice = "CPU pressure detected"
+		adviceID = "doctor.cpu.psi_warn"
 	}
 	checks = append(checks, CheckResult{
-		Category: "CPU", Name: "PSI pressure", Status: status, Detail: detail, Advice: advice,
+		Category: "CPU", Name: "PSI pressure", Status: status, Detail: detail, AdviceID: adviceID,
 	})
 
 	return checks
@@ -521,16 +538,16 @@ func checkMemory(snap *model.Snapshot, rates *model.RateSnapshot, result *model.
 		status := CheckOK
 		detail := fmt.Sprintf("%.1f%% used (%s available / %s total)",
 			usedPct, fmtBytesSimple(mem.Available), fmtBytesSimple(mem.Total))
-		advice := ""
+		adviceID := ""
 		if usedPct > 90 {
 			status = CheckCrit
-			advice = "Memory critically low; risk of OOM"
+			adviceID = "doctor.mem.usage_crit"
 		} else if usedPct > 80 {
 			status = CheckWarn
-			advice = "Memory usage elevated"
+			adviceID = "doctor.mem.usage_warn"
 		}
 		checks = append(checks, CheckResult{
-			Category: "Memory", Name: "Usage", Status: status, Detail: detail, Advice: advice,
+			Category: "Memory", Name: "Usage", Status: status, Detail: detail, AdviceID: adviceID,
 		})
 	}
 
@@ -540,16 +557,16 @@ func checkMemory(snap *model.Snapshot, rates *model.RateSnapshot, result *model.
 		status := CheckOK
 		detail := fmt.Sprintf("%.1f%% used (%s / %s)",
 			swapUsedPct, fmtBytesSimple(mem.SwapUsed), fmtBytesSimple(mem.SwapTotal))
-		advice := ""
+		adviceID := ""
 		if swapUsedPct > 80 {
 			status = CheckCrit
-			advice = "Swap nearly full"
+			adviceID = "doctor.mem.swap_crit"
 		} else if swapUsedPct > 50 {
 			status = CheckWarn
-			advice = "Significant swap usage"
+			adviceID = "doctor.mem.swap_warn"
 		}
 		checks = append(checks, CheckResult{
-			Category: "Memory", Name: "Swap", Status: status, Detail: detail, Advice: advice,
+			Category: "Memory", Name: "Swap", Status: status, Detail: detail, AdviceID: adviceID,
 		})
 	} else {
 		checks = append(checks, CheckResult{
@@ -561,24 +578,52 @@ func checkMemory(snap *model.Snapshot, rates *model.RateSnapshot, result *model.
 	psi := snap.Global.PSI.Memory.Full.Avg10
 	status := CheckOK
 	detail := fmt.Sprintf("full avg10=%.1f%%", psi)
-	advice := ""
+	adviceID := ""
 	if psi > 15 {
 		status = CheckCrit
-		advice = "Severe memory pressure"
+		adviceID = "doctor.mem.psi_crit"
 	} else if psi > 5 {
 		status = CheckWarn
-		advice = "Memory pressure detected"
+		adviceID = "doctor.mem.psi_warn"
 	}
 	checks = append(checks, CheckResult{
-		Category: "Memory", Name: "PSI pressure", Status: status, Detail: detail, Advice: advice,
+		Category: "Memory", Name: "PSI pressure", Status: status, Detail: detail, AdviceID: adviceID,
 	})
 
 	// Available memory absolute check
 	if mem.Available < 256*1024*1024 { // < 256 MB
 		checks = append(checks, CheckResult{
 			Category: "Memory", Name: "Available", Status: CheckCrit,
-			Detail: fmt.Sprintf("Only %s available", fmtBytesSimple(mem.Available)),
-			Advice: "System may OOM soon",
+			Detail:   fmt.Sprintf("Only %s available", fmtBytesSimple(mem.Available)),
+			AdviceID: "doctor.mem.available_low",
+		})
+	}
+
+	// OOM-killer standing of sshd, databases and monitoring agents
+	for _, s := range snap.Global.OOMScores {
+		name := s.Comm
+		if s.Unit != "" {
+			name = s.Unit
+		}
+		detail := fmt.Sprintf("oom_score_adj=%d oom_score=%d (victim #%d)", s.ScoreAdj, s.Score, s.Rank)
+		if s.Severity == "" {
+			checks = append(checks, CheckResult{
+				Category: "Memory", Name: "OOM " + name, Status: CheckOK, Detail: detail,
+			})
+			continue
+		}
+		fix := s.Fix
+		if s.Persist != "" {
+			fix = s.Persist
+		}
+		status := CheckOK
+		if s.Severity == "warn" {
+			status = CheckWarn
+		}
+		checks = append(checks, CheckResult{
+			Category: "Memory", Name: "OOM " + name, Status: status,
+			Detail:   fmt.Sprintf("%s → %d (%s)", detail, s.Suggested, fix),
+			AdviceID: s.AdviceID, Advice: s.Reason,
 		})
 	}
 
@@ -593,17 +638,17 @@ func checkDisk(snap *model.Snapshot, rates *model.RateSnapshot, result *model.An
 		for _, mr := range rates.MountRates {
 			status := CheckOK
 			detail := fmt.Sprintf("%.1f%% used (%.1f%% free)", mr.UsedPct, mr.FreePct)
-			advice := ""
+			adviceID := ""
 			if mr.FreePct < 5 {
 				status = CheckCrit
-				advice = fmt.Sprintf("Filesystem %s critically full", mr.MountPoint)
+				adviceID = "doctor.disk.fs_crit"
 			} else if mr.FreePct < 15 {
 				status = CheckWarn
-				advice = fmt.Sprintf("Filesystem %s filling up", mr.MountPoint)
+				adviceID = "doctor.disk.fs_warn"
 			}
 			checks = append(checks, CheckResult{
 				Category: "Disk", Name: fmt.Sprintf("FS %s", mr.MountPoint),
-				Status: status, Detail: detail, Advice: advice,
+				Status: status, Detail: detail, AdviceID: adviceID, Advice: advice.Text(adviceID, mr.MountPoint),
 			})
 		}
 	}
@@ -623,9 +668,9 @@ func checkDisk(snap *model.Snapshot, rates *model.RateSnapshot, result *model.An
 		checks = append(checks, CheckResult{
 			Category: "Disk", Name: "DiskGuard",
 			Status: status, Detail: fmt.Sprintf("Worst mount state: %s", dgState),
-			Advice: func() string {
+			AdviceID: func() string {
 				if status != CheckOK {
-					return "Check: sudo xtop (DiskGuard page)"
+					return "doctor.disk.diskguard"
 				}
 				return ""
 			}(),
@@ -638,36 +683,54 @@ func checkDisk(snap *model.Snapshot, rates *model.RateSnapshot, result *model.An
 			if d.AvgAwaitMs > 0 {
 				status := CheckOK
 				detail := fmt.Sprintf("await=%.0fms util=%.0f%%", d.AvgAwaitMs, d.UtilPct)
-				advice := ""
+				adviceID := ""
 				if d.AvgAwaitMs > 200 {
 					status = CheckCrit
-					advice = "Disk latency severe"
+					adviceID = "doctor.disk.latency_crit"
 				} else if d.AvgAwaitMs > 50 {
 					status = CheckWarn
-					advice = "Disk latency elevated"
+					adviceID = "doctor.disk.latency_warn"
 				}
 				checks = append(checks, CheckResult{
 					Category: "Disk", Name: fmt.Sprintf("Dev %s", d.Name),
-					Status: status, Detail: detail, Advice: advice,
+					Status: status, Detail: detail, AdviceID: adviceID,
 				})
 			}
 		}
 	}
 
+	// Queue settings (scheduler, nr_requests, read-ahead) vs the hardware
+	for _, q := range snap.Global.BlockQueues {
+		if len(q.Advice) == 0 {
+			checks = append(checks, CheckResult{
+				Category: "Disk", Name: fmt.Sprintf("Queue %s", q.Device), Status: CheckOK,
+				Detail: fmt.Sprintf("%s nr_requests=%d read_ahead=%dKB", q.Scheduler, q.NrRequests, q.ReadAheadKB),
+			})
+			continue
+		}
+		for _, a := range q.Advice {
+			checks = append(checks, CheckResult{
+				Category: "Disk", Name: fmt.Sprintf("Queue %s %s", q.Device, a.Setting),
+				Status: CheckWarn, Detail: fmt.Sprintf("%s → %s (%s)", a.Current, a.Suggested, a.Fix),
+				AdviceID: a.AdviceID, Advice: a.Reason,
+			})
+		}
+	}
+
 	// PSI IO
 	psi := snap.Global.PSI.IO.Full.Avg10
 	status := CheckOK
 	detail := fmt.Sprintf("full avg10=%.1f%%", psi)
-	advice := ""
+	adviceID := ""
 	if psi > 15 {
 		status = CheckCrit
-		advice = "Severe IO pressure"
+		adviceID = "doctor.disk.psi_crit"
 	} else if psi > 5 {
 		status = CheckWarn
-		advice = "IO pressure detected"
+		adviceID = "doctor.disk.psi_warn"
 	}
 	checks = append(checks, CheckResult{
-		Category: "Disk", Name: "PSI IO pressure", Status: status, Detail: detail, Advice: advice,
+		Category: "Disk", Name: "PSI IO pressure", Status: status, Detail: detail, AdviceID: adviceID,
 	})
 
 	return checks
@@ -693,17 +756,17 @@ func checkNetwork(snap *model.Snapshot, rates *model.RateSnapshot, result *model
 	if rates != nil && rates.RetransRate > 0 {
 		status := CheckOK
 		detail := fmt.Sprintf("%.0f/s", rates.RetransRate)
-		advice := ""
+		adviceID := ""
 		if rates.RetransRate > 50 {
 			status = CheckCrit
-			advice = "Severe packet loss or congestion"
+			adviceID = "doctor.net.retrans_crit"
 		} else if rates.RetransRate > 5 {
 			status = CheckWarn
-			advice = "TCP retransmissions elevated"
+			adviceID = "doctor.net.retrans_warn"
 		}
 		checks = append(checks, CheckResult{
 			Category: "Network", Name: "TCP retransmits",
-			Status: status, Detail: detail, Advice: advice,
+			Status: status, Detail: detail, AdviceID: adviceID,
 		})
 	}
 
@@ -732,16 +795,16 @@ func checkNetwork(snap *model.Snapshot, rates *model.RateSnapshot, result *model
 		pct := float64(ct.Count) / float64(ct.Max) * 100
 		status := CheckOK
 		detail := fmt.Sprintf("%.0f%% (%d/%d)", pct, ct.Count, ct.Max)
-		advice := ""
+		adviceID := ""
 		if pct > 90 {
 			status = CheckCrit
-			advice = "Conntrack table nearly full; sysctl net.netfilter.nf_conntrack_max"
+			adviceID = "doctor.net.conntrack"
 		} else if pct > 75 {
 			status = CheckWarn
 		}
 		checks = append(checks, CheckResult{
 			Category: "Network", Name: "Conntrack",
-			Status: status, Detail: detail, Advice: advice,
+			Status: status, Detail: detail, AdviceID: adviceID,
 		})
 	}
 
@@ -755,7 +818,7 @@ func checkNetwork(snap *model.Snapshot, rates *model.RateSnapshot, result *model
 		checks = append(checks, CheckResult{
 			Category: "Network", Name: "CLOSE_WAIT",
 			Status: status, Detail: fmt.Sprintf("%d connections", states.CloseWait),
-			Advice: "Application not closing connections properly",
+			AdviceID: "doctor.net.closewait",
 		})
 	}
 
@@ -770,17 +833,17 @@ func checkFDSystemWide(snap *model.Snapshot) []CheckResult {
 	pct := float64(fd.Allocated) / float64(fd.Max) * 100
 	status := CheckOK
 	detail := fmt.Sprintf("%.0f%% (%d/%d)", pct, fd.Allocated, fd.Max)
-	advice := ""
+	adviceID := ""
 	if pct > 90 {
 		status = CheckCrit
-		advice = "FD exhaustion imminent; sysctl fs.file-max"
+		adviceID = "doctor.sys.fd_crit"
 	} else if pct > 70 {
 		status = CheckWarn
-		advice = "FD usage high"
+		adviceID = "doctor.sys.fd_warn"
 	}
 	return []CheckResult{{
 		Category: "System", Name: "File descriptors",
-		Status: status, Detail: detail, Advice: advice,
+		Status: status, Detail: detail, AdviceID: adviceID,
 	}}
 }
 
@@ -798,7 +861,7 @@ func checkInodeUsage(snap *model.Snapshot, rates *model.RateSnapshot) []CheckRes
 			checks = append(checks, CheckResult{
 				Category: "Disk", Name: fmt.Sprintf("Inodes %s", mr.MountPoint),
 				Status: status, Detail: fmt.Sprintf("%.0f%% used", mr.InodeUsedPct),
-				Advice: "Find dirs with many small files: find / -xdev -printf '%h\\n' | sort | uniq -c | sort -rn | head",
+				AdviceID: "doctor.disk.inodes",
 			})
 		}
 	}
@@ -1009,7 +1072,7 @@ func checkSystemdFailed() []CheckResult {
 	return []CheckResult{{
 		Category: "System", Name: "Systemd failed units",
 		Status: status, Detail: fmt.Sprintf("%d failed: %s", count, strings.Join(units, ", ")),
-		Advice: "systemctl restart <unit>",
+		AdviceID: "doctor.sys.failed_units",
 	}}
 }
 
@@ -1066,7 +1129,7 @@ func checkSecurityUpdates() []CheckResult {
 	return []CheckResult{{
 		Category: "System", Name: "Security updates",
 		Status: status, Detail: fmt.Sprintf("%d security updates pending", secCount),
-		Advice: "apt upgrade",
+		AdviceID: "doctor.sys.security_updates",
 	}}
 }
 
@@ -1095,7 +1158,7 @@ func checkNTPSync() []CheckResult {
 	return []CheckResult{{
 		Category: "System", Name: "NTP sync",
 		Status: CheckWarn, Detail: "Clock NOT synchronized",
-		Advice: "systemctl enable --now systemd-timesyncd",
+		AdviceID: "doctor.sys.ntp",
 	}}
 }
 
@@ -1130,17 +1193,17 @@ func checkSSLCerts() []CheckResult {
 		daysLeft := int(cert.NotAfter.Sub(now).Hours() / 24)
 		status := CheckOK
 		detail := fmt.Sprintf("%s expires in %d days (%s)", e.Name(), daysLeft, cert.NotAfter.Format("2006-01-02"))
-		advice := ""
+		adviceID := ""
 		if daysLeft < 7 {
 			status = CheckCrit
-			advice = "certbot renew --force-renewal"
+			adviceID = "doctor.cert.expired"
 		} else if daysLeft < 30 {
 			status = CheckWarn
-			advice = "certbot renew"
+			adviceID = "doctor.cert.expiring"
 		}
 		checks = append(checks, CheckResult{
 			Category: "SSL", Name: fmt.Sprintf("Cert %s", e.Name()),
-			Status: status, Detail: detail, Advice: advice,
+			Status: status, Detail: detail, AdviceID: adviceID,
 		})
 	}
 	if len(checks) == 0 {
@@ -1156,7 +1219,7 @@ func checkSSLCerts() []CheckResult {
 
 func renderDoctorCLI(report DoctorReport, iterInfo string) {
 	// Title bar
-	ts := report.Timestamp.Format("2006-01-02 15:04:05")
+	ts := util.FmtTime(report.Timestamp, "2006-01-02 15:04:05")
 	fmt.Printf("\n %s%s xtop doctor v%s %s — %s%s%s  %s%s%s",
 		B, BBlu+FBWht, Version, R,
 		B, report.Hostname, R,
@@ -1479,6 +1542,8 @@ func sendDoctorAlert(report DoctorReport, cfg Config) {
 		SlackWebhook:     userCfg.Alerts.SlackWebhook,
 		TelegramBotToken: userCfg.Alerts.TelegramBotToken,
 		TelegramChatID:   userCfg.Alerts.TelegramChatID,
+		RateLimit:        alertRateLimit(userCfg.Alerts.RateLimit),
+		ChannelLimits:    alertChannelLimits(userCfg.Alerts.ChannelRateLimits),
 	})
 
 	if !notifier.Enabled() {
@@ -103,15 +103,15 @@ func checkDatabaseHealth() []CheckResult {
 			out, err := exec.Command(path, "ping", "--connect-timeout=2").CombinedOutput()
 			status := CheckOK
 			detail := "mysqld is alive"
-			advice := ""
+			adviceID := ""
 			if err != nil || !strings.Contains(string(out), "alive") {
 				status = CheckCrit
 				detail = "MySQL not responding to ping"
-				advice = "Check MySQL process and logs"
+				adviceID = "doctor.svc.mysql_down"
 			}
 			checks = append(checks, CheckResult{
 				Category: "Services", Name: "MySQL health",
-				Status: status, Detail: detail, Advice: advice,
+				Status: status, Detail: detail, AdviceID: adviceID,
 			})
 		}
 	}
@@ -122,15 +122,15 @@ func checkDatabaseHealth() []CheckResult {
 			err := exec.Command(path, "-t", "2").Run()
 			status := CheckOK
 			detail := "PostgreSQL accepting connections"
-			advice := ""
+			adviceID := ""
 			if err != nil {
 				status = CheckCrit
 				detail = "PostgreSQL not accepting connections"
-				advice = "Check PostgreSQL process and pg_hba.conf"
+				adviceID = "doctor.svc.postgres_down"
 			}
 			checks = append(checks, CheckResult{
 				Category: "Services", Name: "PostgreSQL health",
-				Status: status, Detail: detail, Advice: advice,
+				Status: status, Detail: detail, AdviceID: adviceID,
 			})
 		}
 	}
@@ -141,15 +141,15 @@ func checkDatabaseHealth() []CheckResult {
 			out, err := exec.Command(path, "ping").Output()
 			status := CheckOK
 			detail := "Redis responding to PING"
-			advice := ""
+			adviceID := ""
 			if err != nil || strings.TrimSpace(string(out)) != "PONG" {
 				status = CheckCrit
 				detail = "Redis not responding"
-				advice = "Check Redis process and configuration"
+				adviceID = "doctor.svc.redis_down"
 			}
 			checks = append(checks, CheckResult{
 				Category: "Services", Name: "Redis health",
-				Status: status, Detail: detail, Advice: advice,
+				Status: status, Detail: detail, AdviceID: adviceID,
 			})
 		}
 	}
@@ -215,14 +215,14 @@ func checkDockerContainers() []CheckResult {
 		checks = append(checks, CheckResult{
 			Category: "Services", Name: "Unhealthy containers",
 			Status: status, Detail: fmt.Sprintf("%d unhealthy", unhealthy),
-			Advice: "docker ps --filter health=unhealthy",
+			AdviceID: "doctor.svc.docker_unhealthy",
 		})
 	}
 	if restarting > 0 {
 		checks = append(checks, CheckResult{
 			Category: "Services", Name: "Restarting containers",
 			Status: CheckCrit, Detail: fmt.Sprintf("%d restarting (crash loop?)", restarting),
-			Advice: "docker logs <container>",
+			AdviceID: "doctor.svc.docker_restarting",
 		})
 	}
 
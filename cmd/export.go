@@ -83,6 +83,9 @@ func runExport(args []string) error {
 		if fpInfo != nil {
 			data["fingerprint"] = fpInfo
 		}
+		if c := rec.Capture(); c != nil {
+			data["culprit_capture"] = c
+		}
 		enc := json.NewEncoder(out)
 		enc.SetIndent("", "  ")
 		if err := enc.Encode(data); err != nil {
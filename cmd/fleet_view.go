@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // runFleetView implements `xtop fleet` — a live TUI showing all hosts reporting
@@ -85,6 +86,7 @@ Usage:
 		cfg:        cfg,
 		httpClient: httpClient,
 		hosts:      make(map[string]*model.FleetHost),
+		clusters:   make(map[string]*model.ClusterIncident),
 	}
 	return view.Run(*refresh)
 }
@@ -95,9 +97,10 @@ type fleetView struct {
 	cfg        model.FleetAgentConfig
 	httpClient *http.Client
 
-	mu    sync.RWMutex
-	hosts map[string]*model.FleetHost
-	err   string
+	mu       sync.RWMutex
+	hosts    map[string]*model.FleetHost
+	clusters map[string]*model.ClusterIncident
+	err      string
 }
 
 func (v *fleetView) Run(refresh time.Duration) error {
@@ -114,6 +117,14 @@ func (v *fleetView) Run(refresh time.Duration) error {
 	} else {
 		v.setErr(err.Error())
 	}
+	// Older hubs don't serve /v1/clusters — ignore the error.
+	if clusters, err := fetchClusters(v.httpClient, v.cfg); err == nil {
+		v.mu.Lock()
+		for _, c := range clusters {
+			v.clusters[c.ClusterID] = c
+		}
+		v.mu.Unlock()
+	}
 
 	// Stream in the background
 	go v.streamLoop(ctx)
@@ -221,6 +232,13 @@ func (v *fleetView) handleEvent(event string, data []byte) {
 		}
 	case "incident":
 		// Incidents are reflected in the next heartbeat — nothing to do here yet.
+	case "cluster":
+		var c model.ClusterIncident
+		if err := json.Unmarshal(data, &c); err == nil {
+			v.mu.Lock()
+			v.clusters[c.ClusterID] = &c
+			v.mu.Unlock()
+		}
 	}
 }
 
@@ -252,6 +270,8 @@ func (v *fleetView) applyHeartbeat(hb *model.FleetHeartbeat) {
 	h.LoadAvg1 = hb.LoadAvg1
 	h.NumCPUs = hb.NumCPUs
 	h.ActiveIncidentID = hb.ActiveIncidentID
+	h.CPUStealPct = hb.CPUStealPct
+	h.NoisyNeighbor = hb.NoisyNeighbor
 	h.Status = model.HostStatusLive
 }
 
@@ -264,7 +284,15 @@ func (v *fleetView) render() {
 		hosts = append(hosts, h)
 	}
 	errMsg := v.err
+	var clusters []*model.ClusterIncident
+	for _, c := range v.clusters {
+		if c.ResolvedAt != nil && time.Since(*c.ResolvedAt) > 10*time.Minute {
+			continue
+		}
+		clusters = append(clusters, c)
+	}
 	v.mu.RUnlock()
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].StartedAt.After(clusters[j].StartedAt) })
 
 	// Sort: unhealthy first, then by hostname.
 	sort.Slice(hosts, func(i, j int) bool {
@@ -283,6 +311,19 @@ func (v *fleetView) render() {
 		sb.WriteString(FBRed + errMsg + R + "\n")
 	}
 	sb.WriteString("\n")
+	for _, c := range clusters {
+		sb.WriteString(renderClusterLine(c))
+	}
+	neighbors := 0
+	for _, h := range hosts {
+		if h.NoisyNeighbor != nil {
+			sb.WriteString(renderNeighborLine(h))
+			neighbors++
+		}
+	}
+	if len(clusters)+neighbors > 0 {
+		sb.WriteString("\n")
+	}
 
 	headers := []string{"HOST", "HEALTH", "BOTTLENECK", "SCORE", "CPU%", "MEM%", "IO%", "LOAD", "CULPRIT", "LAST SEEN"}
 	rows := make([][]string, 0, len(hosts))
@@ -311,6 +352,44 @@ func (v *fleetView) render() {
 	io.WriteString(os.Stdout, sb.String())
 }
 
+// renderClusterLine renders one cluster-wide incident banner:
+// "CLUSTER INCIDENT io via 10.0.0.5:5432 — 3 hosts: web-01, web-02, web-03 (since 10:42:05)".
+func renderClusterLine(c *model.ClusterIncident) string {
+	names := make([]string, 0, len(c.Members))
+	for _, m := range c.Members {
+		names = append(names, m.Hostname)
+	}
+	what := c.Class
+	if what == "" {
+		what = "unclassified"
+	}
+	if len(c.SharedDeps) > 0 {
+		what += " via " + strings.Join(c.SharedDeps, ", ")
+	}
+	line := fmt.Sprintf("CLUSTER INCIDENT %s — %d hosts: %s (since %s)",
+		what, len(c.Members), strings.Join(names, ", "), util.FmtTime(c.StartedAt, "15:04:05"))
+	if c.ResolvedAt != nil {
+		return "\033[2m" + line + "  resolved" + R + "\n"
+	}
+	return FBRed + B + line + R + "\n"
+}
+
+// renderNeighborLine names the VM behind a guest's CPU steal:
+// "NOISY NEIGHBOR db-01 steal 18.2% ← VM batch-01 (105) on pve-02: 5.8 cores, r=0.91".
+func renderNeighborLine(h *model.FleetHost) string {
+	nn := h.NoisyNeighbor
+	vm := nn.VMName
+	if nn.VMID > 0 {
+		vm += fmt.Sprintf(" (%d)", nn.VMID)
+	}
+	if nn.VMHost != "" && !strings.EqualFold(nn.VMHost, nn.VMName) {
+		vm += " = " + nn.VMHost
+	}
+	return fmt.Sprintf("%sNOISY NEIGHBOR%s %s steal %.1f%% ← VM %s on %s: %.1f cores, r=%.2f (since %s)\n",
+		FBYel+B, R, h.Hostname, nn.StealPct, vm, nn.Hypervisor, nn.CPUCores, nn.Correlation,
+		util.FmtTime(nn.Since, "15:04:05"))
+}
+
 func orDash(s string) string {
 	if s == "" {
 		return "\033[2m" + "—" + R
@@ -387,6 +466,29 @@ func lastSeenCell(now, ts time.Time, status model.HostStatus) string {
 	}
 }
 
+func fetchClusters(client *http.Client, cfg model.FleetAgentConfig) ([]*model.ClusterIncident, error) {
+	req, err := http.NewRequest(http.MethodGet, cfg.HubURL+model.FleetEndpointClusters, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Token != "" {
+		req.Header.Set(model.FleetAuthHeader, cfg.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("hub returned %d", resp.StatusCode)
+	}
+	var out []*model.ClusterIncident
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("parse clusters json: %w", err)
+	}
+	return out, nil
+}
+
 func fetchHosts(client *http.Client, cfg model.FleetAgentConfig) ([]*model.FleetHost, error) {
 	req, err := http.NewRequest(http.MethodGet, cfg.HubURL+model.FleetEndpointHosts, nil)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // ForensicsEvent represents a single forensic finding.
@@ -548,7 +549,7 @@ func parseAuthLogForensics() []ForensicsEvent {
 		if m := authFailedRE.FindStringSubmatch(line); m != nil {
 			failCount++
 			ts := parseAuthLogTime(line)
-			minute := ts.Format("15:04")
+			minute := util.FmtTime(ts, "15:04")
 			minuteWindow[minute]++
 		}
 
@@ -639,7 +640,7 @@ func parseAuthLogTime(line string) time.Time {
 func renderForensicsCLI(report ForensicsReport) {
 	// Header
 	fmt.Printf("\n \033[1;36mxtop forensics v%s\033[0m — %s  %s\n\n",
-		Version, report.Hostname, report.AnalyzedAt.Format("2006-01-02 15:04:05"))
+		Version, report.Hostname, util.FmtTime(report.AnalyzedAt, "2006-01-02 15:04:05"))
 
 	// Active sessions
 	fmt.Printf(" \033[90m── Active Sessions (%d sessions, %d unique IPs) %s\033[0m\n",
@@ -680,7 +681,7 @@ func renderForensicsCLI(report ForensicsReport) {
 			case "crit":
 				sevColor = "31" // red
 			}
-			ts := evt.Timestamp.Format("15:04:05")
+			ts := util.FmtTime(evt.Timestamp, "15:04:05")
 			fmt.Printf("  %s  \033[%s;1m%-4s\033[0m  \033[90m[%-10s]\033[0m  %s\n",
 				ts, sevColor, strings.ToUpper(evt.Severity), evt.Category, evt.Summary)
 		}
@@ -738,7 +739,7 @@ func renderForensicsMD(report ForensicsReport) error {
 		fmt.Printf("|------|----------|----------|---------|\n")
 		for _, evt := range report.Events {
 			fmt.Printf("| %s | %s | %s | %s |\n",
-				evt.Timestamp.Format("15:04:05"),
+				util.FmtTime(evt.Timestamp, "15:04:05"),
 				strings.ToUpper(evt.Severity),
 				evt.Category, evt.Summary)
 		}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/util"
+)
+
+// runGrafanaDashboard implements `xtop grafana-dashboard`: it prints a
+// Grafana dashboard for the -prom exporter's metrics.
+func runGrafanaDashboard(args []string) error {
+	fs := flag.NewFlagSet("grafana-dashboard", flag.ExitOnError)
+	var (
+		output     = fs.String("o", "", "write the dashboard to `FILE` instead of stdout")
+		datasource = fs.String("datasource", "", "Prometheus datasource `UID` to preselect (default: pick on import)")
+		title      = fs.String("title", "xtop", "dashboard title")
+		uid        = fs.String("uid", "xtop-host", "dashboard UID; keep it stable to overwrite on re-import")
+		refresh    = fs.String("refresh", "30s", "auto-refresh interval")
+		api        = fs.Bool("api", false, `wrap as {"dashboard": ..., "overwrite": true} for POST /api/dashboards/db`)
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, `xtop grafana-dashboard — Grafana dashboard for the Prometheus exporter (-prom)
+
+  xtop grafana-dashboard -o xtop.json     then Dashboards → New → Import
+  xtop grafana-dashboard --api | curl -sf -H 'Content-Type: application/json' \
+      -H "Authorization: Bearer $GRAFANA_TOKEN" -d @- http://grafana:3000/api/dashboards/db
+
+Panels: health, RCA score, PSI, capacity headroom, CPU, memory, per-disk and
+per-interface throughput, network errors and the top cgroups. The Host picker
+lists every instance scraping xtop_up, so one dashboard covers the fleet.
+Byte units and the time zone follow the "format" config (XTOP_UNITS, XTOP_TZ).
+
+Flags:`)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	f := util.ActiveFormat()
+	dash := engine.NewGrafanaDashboard(engine.GrafanaOptions{
+		Title:      *title,
+		UID:        *uid,
+		Datasource: *datasource,
+		Refresh:    *refresh,
+		TimeZone:   f.TimeZone,
+		SIUnits:    f.Units == util.UnitsSI,
+	})
+	var v interface{} = dash
+	if *api {
+		v = map[string]interface{}{"dashboard": dash, "overwrite": true}
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if *output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(*output, data, 0o644); err != nil {
+		return fmt.Errorf("write dashboard: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote Grafana dashboard %q (%d panels) to %s\n", dash.Title, len(dash.Panels), *output)
+	return nil
+}
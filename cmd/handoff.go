@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
+)
+
+// runHandoff implements `xtop handoff [--since 8h]` — a compact shift summary
+// for the on-call handoff channel. Stitches together what the daemon and the
+// TUI already persist under the data dir:
+//
+//   - health transitions from current.jsonl (daemon per-tick summary)
+//   - incidents from events.jsonl, falling back to rca-history.jsonl
+//   - actions taken: operator notes (notes.jsonl) + guardian audit log
+//   - still-open warnings from a live sample (skip with --offline)
+//
+// Default output is plain text with no ANSI so it pastes cleanly into chat.
+func runHandoff(args []string) error {
+	fs := flag.NewFlagSet("handoff", flag.ExitOnError)
+	var (
+		sinceStr = fs.String("since", "8h", "shift window to summarize (e.g. 8h, 90m, 1d)")
+		dataDir  = fs.String("datadir", "", "xtop data directory (default: ~/.xtop/)")
+		mdOut    = fs.Bool("md", false, "render as markdown")
+		jsonOut  = fs.Bool("json", false, "render as a single JSON document")
+		offline  = fs.Bool("offline", false, "skip the live sample (no still-open warnings)")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, `xtop handoff — on-call shift summary
+
+  xtop handoff                    summarize the last 8 hours
+  xtop handoff --since 12h        custom window
+  xtop handoff --md               markdown output
+  xtop handoff --json             JSON output
+
+Flags:`)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	window, err := parseSinceWindow(*sinceStr)
+	if err != nil {
+		return err
+	}
+	dir := *dataDir
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("cannot determine home directory for data dir: %w (use --datadir)", err)
+		}
+		dir = filepath.Join(home, ".xtop")
+	}
+
+	now := time.Now()
+	rep := buildHandoffReport(dir, now.Add(-window), now)
+
+	if !*offline {
+		_, _, result := collectOrQuery(3)
+		if result != nil {
+			rep.HealthNow = result.Health.String()
+			if result.PrimaryBottleneck != "" && result.Health != model.HealthOK {
+				rep.HealthNow += " (" + result.PrimaryBottleneck + ")"
+			}
+			for _, w := range result.Warnings {
+				if w.Severity == "warn" || w.Severity == "crit" {
+					rep.OpenWarnings = append(rep.OpenWarnings, handoffWarning{
+						Severity: w.Severity, Signal: w.Signal, Detail: w.Detail, Value: w.Value,
+					})
+				}
+			}
+		}
+	}
+
+	switch {
+	case *jsonOut:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rep)
+	case *mdOut:
+		fmt.Print(renderHandoff(rep, true))
+	default:
+		fmt.Print(renderHandoff(rep, false))
+	}
+	return nil
+}
+
+// ── Types ────────────────────────────────────────────────────────────────────
+
+type handoffReport struct {
+	Host          string                `json:"host"`
+	Since         time.Time             `json:"since"`
+	Until         time.Time             `json:"until"`
+	HealthNow     string                `json:"health_now,omitempty"`
+	HealthChanges []handoffHealthChange `json:"health_changes,omitempty"`
+	Incidents     []handoffIncident     `json:"incidents,omitempty"`
+	Actions       []handoffAction       `json:"actions,omitempty"`
+	OpenWarnings  []handoffWarning      `json:"open_warnings,omitempty"`
+}
+
+type handoffHealthChange struct {
+	Time       time.Time `json:"time"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Bottleneck string    `json:"bottleneck,omitempty"`
+}
+
+type handoffIncident struct {
+	Start       time.Time `json:"start"`
+	DurationSec int       `json:"duration_sec"`
+	Health      string    `json:"health,omitempty"`
+	Bottleneck  string    `json:"bottleneck"`
+	PeakScore   int       `json:"peak_score"`
+	Culprit     string    `json:"culprit,omitempty"`
+}
+
+type handoffAction struct {
+	Time    time.Time `json:"time"`
+	Source  string    `json:"source"` // "operator" or "guardian"
+	Message string    `json:"message"`
+}
+
+type handoffWarning struct {
+	Severity string `json:"severity"`
+	Signal   string `json:"signal"`
+	Detail   string `json:"detail"`
+	Value    string `json:"value,omitempty"`
+}
+
+// ── Collection ───────────────────────────────────────────────────────────────
+
+// parseSinceWindow parses a Go duration, additionally accepting a "d" suffix
+// for whole days ("1d", "2d") since shift windows are often written that way.
+func parseSinceWindow(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "d") {
+		n, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("bad --since %q (use e.g. 8h, 90m, 1d)", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("bad --since %q (use e.g. 8h, 90m, 1d)", s)
+	}
+	return d, nil
+}
+
+// buildHandoffReport gathers everything persisted in dataDir within
+// [since, until]. Every source is best-effort: a missing file just leaves
+// its section empty.
+func buildHandoffReport(dataDir string, since, until time.Time) *handoffReport {
+	host, _ := os.Hostname()
+	rep := &handoffReport{Host: host, Since: since, Until: until}
+
+	summaryPath := filepath.Join(dataDir, "current.jsonl")
+	rep.HealthChanges = healthTransitions(append(
+		readSummaryHealth(summaryPath+".old", since),
+		readSummaryHealth(summaryPath, since)...))
+
+	if events, err := engine.ReadEventLog(filepath.Join(dataDir, "events.jsonl")); err == nil {
+		for _, e := range events {
+			if e.StartTime.Before(since) && (e.EndTime.IsZero() || e.EndTime.Before(since)) {
+				continue
+			}
+			rep.Incidents = append(rep.Incidents, handoffIncident{
+				Start:       e.StartTime,
+				DurationSec: e.Duration,
+				Health:      e.PeakHealth.String(),
+				Bottleneck:  e.Bottleneck,
+				PeakScore:   e.PeakScore,
+				Culprit:     e.CulpritProcess,
+			})
+		}
+	}
+	if len(rep.Incidents) == 0 {
+		// TUI-only hosts never write events.jsonl; the incident recorder
+		// history is the next best record of what happened.
+		if records, err := loadHistory(); err == nil {
+			for _, r := range records {
+				if r.StartedAt.Before(since) {
+					continue
+				}
+				culprit := r.CulpritApp
+				if culprit == "" {
+					culprit = r.Culprit
+				}
+				rep.Incidents = append(rep.Incidents, handoffIncident{
+					Start:       r.StartedAt,
+					DurationSec: r.DurationSec,
+					Bottleneck:  r.Bottleneck,
+					PeakScore:   r.PeakScore,
+					Culprit:     culprit,
+				})
+			}
+		}
+	}
+	sort.Slice(rep.Incidents, func(i, j int) bool { return rep.Incidents[i].Start.Before(rep.Incidents[j].Start) })
+
+	if notes, err := engine.ReadNoteLog(filepath.Join(dataDir, engine.NotesFileName)); err == nil {
+		for _, n := range notes {
+			if n.Time.Before(since) {
+				continue
+			}
+			rep.Actions = append(rep.Actions, handoffAction{Time: n.Time, Source: "operator", Message: n.Message})
+		}
+	}
+	if audit, err := engine.ReadGuardianAudit(since); err == nil {
+		for _, a := range audit {
+			rep.Actions = append(rep.Actions, handoffAction{Time: a.Time, Source: "guardian", Message: a.Message})
+		}
+	}
+	sort.Slice(rep.Actions, func(i, j int) bool { return rep.Actions[i].Time.Before(rep.Actions[j].Time) })
+
+	return rep
+}
+
+// summaryHealth is the subset of a current.jsonl line handoff needs.
+type summaryHealth struct {
+	Timestamp  time.Time `json:"ts"`
+	Health     string    `json:"health"`
+	Bottleneck string    `json:"bottleneck"`
+}
+
+func readSummaryHealth(path string, since time.Time) []summaryHealth {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var out []summaryHealth
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var s summaryHealth
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			continue
+		}
+		if s.Timestamp.Before(since) {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// healthTransitions collapses per-tick health samples into the points where
+// the health level changed. The first sample only establishes the baseline.
+func healthTransitions(samples []summaryHealth) []handoffHealthChange {
+	var out []handoffHealthChange
+	prev := ""
+	for _, s := range samples {
+		if s.Health == "" {
+			continue
+		}
+		if prev != "" && s.Health != prev {
+			out = append(out, handoffHealthChange{
+				Time: s.Timestamp, From: prev, To: s.Health, Bottleneck: s.Bottleneck,
+			})
+		}
+		prev = s.Health
+	}
+	return out
+}
+
+// ── Rendering ────────────────────────────────────────────────────────────────
+
+// renderHandoff renders the report as plain text (chat-pasteable) or markdown.
+func renderHandoff(rep *handoffReport, md bool) string {
+	var sb strings.Builder
+	const maxRows = 15
+
+	span := fmtDuration(rep.Until.Sub(rep.Since))
+	if md {
+		fmt.Fprintf(&sb, "## xtop handoff — %s (last %s)\n\n", rep.Host, span)
+		fmt.Fprintf(&sb, "_%s → %s_\n\n", util.FmtTime(rep.Since, "2006-01-02 15:04"), util.FmtTime(rep.Until, "2006-01-02 15:04"))
+	} else {
+		fmt.Fprintf(&sb, "xtop handoff — %s — last %s (%s → %s)\n",
+			rep.Host, span, util.FmtTime(rep.Since, "01-02 15:04"), util.FmtTime(rep.Until, "01-02 15:04"))
+	}
+	if rep.HealthNow != "" {
+		if md {
+			fmt.Fprintf(&sb, "**Health now:** %s\n", rep.HealthNow)
+		} else {
+			fmt.Fprintf(&sb, "Health now: %s\n", rep.HealthNow)
+		}
+	}
+
+	section := func(title string) {
+		if md {
+			fmt.Fprintf(&sb, "\n### %s\n\n", title)
+		} else {
+			fmt.Fprintf(&sb, "\n%s\n", strings.ToUpper(title))
+		}
+	}
+	bullet := func(format string, a ...interface{}) {
+		fmt.Fprintf(&sb, "- "+format+"\n", a...)
+	}
+	more := func(n int) {
+		if n > maxRows {
+			bullet("… %d more", n-maxRows)
+		}
+	}
+
+	section(fmt.Sprintf("Incidents (%d)", len(rep.Incidents)))
+	if len(rep.Incidents) == 0 {
+		bullet("none")
+	}
+	for i, inc := range rep.Incidents {
+		if i >= maxRows {
+			break
+		}
+		line := fmt.Sprintf("%s  %s %d%%", util.FmtTime(inc.Start, "15:04"), inc.Bottleneck, inc.PeakScore)
+		if inc.Health != "" {
+			line += " " + inc.Health
+		}
+		if inc.DurationSec > 0 {
+			line += ", " + fmtDurationShort(inc.DurationSec)
+		}
+		if inc.Culprit != "" {
+			line += ", culprit " + inc.Culprit
+		}
+		bullet("%s", line)
+	}
+	more(len(rep.Incidents))
+
+	section("Health changes")
+	if len(rep.HealthChanges) == 0 {
+		bullet("no transitions recorded (daemon not running?)")
+	}
+	for i, hc := range rep.HealthChanges {
+		if i >= maxRows {
+			break
+		}
+		line := fmt.Sprintf("%s  %s → %s", util.FmtTime(hc.Time, "15:04"), hc.From, hc.To)
+		if hc.Bottleneck != "" && hc.To != "OK" {
+			line += " (" + hc.Bottleneck + ")"
+		}
+		bullet("%s", line)
+	}
+	more(len(rep.HealthChanges))
+
+	section("Actions taken")
+	if len(rep.Actions) == 0 {
+		bullet("none recorded")
+	}
+	for i, a := range rep.Actions {
+		if i >= maxRows {
+			break
+		}
+		bullet("%s  [%s] %s", util.FmtTime(a.Time, "15:04"), a.Source, a.Message)
+	}
+	more(len(rep.Actions))
+
+	if rep.HealthNow != "" {
+		section("Still open")
+		if len(rep.OpenWarnings) == 0 {
+			bullet("no open warnings")
+		}
+		for i, w := range rep.OpenWarnings {
+			if i >= maxRows {
+				break
+			}
+			line := fmt.Sprintf("[%s] %s: %s", strings.ToUpper(w.Severity), w.Signal, w.Detail)
+			if w.Value != "" {
+				line += " (" + w.Value + ")"
+			}
+			bullet("%s", line)
+		}
+		more(len(rep.OpenWarnings))
+	}
+
+	return sb.String()
+}
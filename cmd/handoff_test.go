@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/model"
+)
+
+func TestParseSinceWindow(t *testing.T) {
+	cases := map[string]time.Duration{
+		"8h":  8 * time.Hour,
+		"90m": 90 * time.Minute,
+		"1d":  24 * time.Hour,
+	}
+	for in, want := range cases {
+		got, err := parseSinceWindow(in)
+		if err != nil || got != want {
+			t.Errorf("parseSinceWindow(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "abc", "-1h", "0d"} {
+		if _, err := parseSinceWindow(bad); err == nil {
+			t.Errorf("parseSinceWindow(%q) should fail", bad)
+		}
+	}
+}
+
+func TestHealthTransitions(t *testing.T) {
+	base := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	samples := []summaryHealth{
+		{Timestamp: base, Health: "OK"},
+		{Timestamp: base.Add(time.Minute), Health: "OK"},
+		{Timestamp: base.Add(2 * time.Minute), Health: "CRITICAL", Bottleneck: "IO Starvation"},
+		{Timestamp: base.Add(3 * time.Minute), Health: "CRITICAL", Bottleneck: "IO Starvation"},
+		{Timestamp: base.Add(4 * time.Minute), Health: "OK"},
+	}
+	got := healthTransitions(samples)
+	if len(got) != 2 {
+		t.Fatalf("got %d transitions, want 2: %+v", len(got), got)
+	}
+	if got[0].From != "OK" || got[0].To != "CRITICAL" || got[0].Bottleneck != "IO Starvation" {
+		t.Errorf("first transition = %+v", got[0])
+	}
+	if got[1].To != "OK" {
+		t.Errorf("second transition = %+v", got[1])
+	}
+}
+
+func TestBuildHandoffReport(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XTOP_GUARDIAN_AUDIT_PATH", filepath.Join(dir, "guardian.log"))
+
+	now := time.Now()
+	old := now.Add(-24 * time.Hour)
+
+	w := engine.NewEventLogWriter(filepath.Join(dir, "events.jsonl"))
+	_ = w.Write(model.Event{StartTime: old, EndTime: old.Add(time.Minute), Bottleneck: "stale"})
+	_ = w.Write(model.Event{StartTime: now.Add(-time.Hour), EndTime: now.Add(-50 * time.Minute),
+		Duration: 600, PeakHealth: model.HealthCritical, Bottleneck: "Memory Pressure", PeakScore: 81, CulpritProcess: "php-fpm"})
+
+	_ = engine.AppendNoteLog(filepath.Join(dir, engine.NotesFileName),
+		model.TimelineEntry{Time: now.Add(-55 * time.Minute), Message: "restarted php-fpm", Kind: model.TimelineKindNote}, "evt-1")
+
+	var lines []string
+	for i, h := range []string{"OK", "CRITICAL", "OK"} {
+		b, _ := json.Marshal(summaryHealth{Timestamp: now.Add(time.Duration(i-3) * time.Minute), Health: h})
+		lines = append(lines, string(b))
+	}
+	_ = os.WriteFile(filepath.Join(dir, "current.jsonl"), []byte(strings.Join(lines, "\n")+"\n"), 0600)
+
+	rep := buildHandoffReport(dir, now.Add(-8*time.Hour), now)
+	if len(rep.Incidents) != 1 || rep.Incidents[0].Bottleneck != "Memory Pressure" {
+		t.Fatalf("incidents = %+v", rep.Incidents)
+	}
+	if len(rep.Actions) != 1 || rep.Actions[0].Source != "operator" {
+		t.Fatalf("actions = %+v", rep.Actions)
+	}
+	if len(rep.HealthChanges) != 2 {
+		t.Fatalf("health changes = %+v", rep.HealthChanges)
+	}
+
+	out := renderHandoff(rep, false)
+	for _, want := range []string{"Memory Pressure 81%", "[operator] restarted php-fpm", "OK → CRITICAL"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("plain output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\033[") {
+		t.Error("plain output must not contain ANSI escapes")
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/ftahirops/xtop/model"
 	"github.com/ftahirops/xtop/store"
+	"github.com/ftahirops/xtop/util"
 )
 
 // runIncidents implements the `xtop incidents` subcommand.
@@ -82,7 +84,7 @@ func runIncidents(args []string) error {
 		}
 		rows = append(rows, []string{
 			subcmdTrunc(r.ID, 20),
-			r.StartTime.Format("01-02 15:04:05"),
+			util.FmtTime(r.StartTime, "01-02 15:04:05"),
 			healthStr,
 			subcmdTrunc(r.Bottleneck, 20),
 			fmt.Sprintf("%d", r.PeakScore),
@@ -169,9 +171,9 @@ func incidentANSI(rec *store.IncidentRecord, offenders []store.IncidentOffender,
 	fmt.Printf("    %-16s %s\n", "Health:", healthStr)
 	fmt.Printf("    %-16s %s\n", "Bottleneck:", rec.Bottleneck)
 	fmt.Printf("    %-16s %d\n", "Peak Score:", rec.PeakScore)
-	fmt.Printf("    %-16s %s\n", "Started:", rec.StartTime.Format("2006-01-02 15:04:05"))
+	fmt.Printf("    %-16s %s\n", "Started:", util.FmtTime(rec.StartTime, "2006-01-02 15:04:05"))
 	if !rec.EndTime.IsZero() {
-		fmt.Printf("    %-16s %s (%ds)\n", "Ended:", rec.EndTime.Format("2006-01-02 15:04:05"), rec.DurationSec)
+		fmt.Printf("    %-16s %s (%ds)\n", "Ended:", util.FmtTime(rec.EndTime, "2006-01-02 15:04:05"), rec.DurationSec)
 	} else {
 		fmt.Printf("    %-16s %sactive%s\n", "Status:", FBRed, R)
 	}
@@ -191,7 +193,7 @@ func incidentANSI(rec *store.IncidentRecord, offenders []store.IncidentOffender,
 		fmt.Printf("  %sFINGERPRINT HISTORY%s\n", B, R)
 		fmt.Printf("    This pattern has occurred %s%d times%s\n", FBYel, fp.Count, R)
 		fmt.Printf("    First seen: %s  Last seen: %s\n",
-			fp.FirstSeen.Format("Jan 02 15:04"), fp.LastSeen.Format("Jan 02 15:04"))
+			util.FmtTime(fp.FirstSeen, "Jan 02 15:04"), util.FmtTime(fp.LastSeen, "Jan 02 15:04"))
 		fmt.Printf("    Avg duration: %ds\n", fp.AvgDuration)
 		fmt.Println()
 	}
@@ -211,6 +213,22 @@ func incidentANSI(rec *store.IncidentRecord, offenders []store.IncidentOffender,
 		fmt.Println()
 	}
 
+	// Culprit command line, captured while the incident was live
+	if c := rec.Capture(); c != nil {
+		fmt.Printf("  %sCULPRIT COMMAND%s\n", B, R)
+		if c.Exe != "" {
+			fmt.Printf("    %-16s %s\n", "Exe:", c.Exe)
+		}
+		fmt.Printf("    %-16s %s\n", "Command:", c.CommandLine())
+		for _, kv := range c.Env {
+			fmt.Printf("    %-16s %s\n", "", kv)
+		}
+		if c.Redacted > 0 || c.Truncated {
+			fmt.Printf("    %s%s%s\n", FCyn, captureNote(c), R)
+		}
+		fmt.Println()
+	}
+
 	// Evidence
 	if rec.EvidenceJSON != "" {
 		var evidence []string
@@ -232,9 +250,9 @@ func incidentMarkdown(rec *store.IncidentRecord, offenders []store.IncidentOffen
 	sb.WriteString(fmt.Sprintf("# Incident %s\n\n", rec.ID))
 	sb.WriteString(fmt.Sprintf("**Health:** %s  **Score:** %d\n", rec.PeakHealth, rec.PeakScore))
 	sb.WriteString(fmt.Sprintf("**Bottleneck:** %s\n", rec.Bottleneck))
-	sb.WriteString(fmt.Sprintf("**Time:** %s", rec.StartTime.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("**Time:** %s", util.FmtTime(rec.StartTime, "2006-01-02 15:04:05")))
 	if !rec.EndTime.IsZero() {
-		sb.WriteString(fmt.Sprintf(" → %s (%ds)\n", rec.EndTime.Format("15:04:05"), rec.DurationSec))
+		sb.WriteString(fmt.Sprintf(" → %s (%ds)\n", util.FmtTime(rec.EndTime, "15:04:05"), rec.DurationSec))
 	} else {
 		sb.WriteString(" (active)\n")
 	}
@@ -255,6 +273,10 @@ func incidentMarkdown(rec *store.IncidentRecord, offenders []store.IncidentOffen
 		}
 	}
 
+	if c := rec.Capture(); c != nil {
+		sb.WriteString(captureMarkdown(c))
+	}
+
 	sb.WriteString("\n---\n*Generated by xtop*\n")
 	fmt.Print(sb.String())
 	return nil
@@ -268,3 +290,35 @@ func incidentDBPath() string {
 	}
 	return filepath.Join(home, ".xtop", "incidents.db")
 }
+
+// captureMarkdown renders a culprit capture as a Markdown section: the
+// exact command line, then the environment it ran with.
+func captureMarkdown(c *model.CulpritCapture) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n## Culprit Command (PID %d)\n\n", c.PID))
+	if c.Exe != "" {
+		sb.WriteString(fmt.Sprintf("**Exe:** `%s`\n\n", c.Exe))
+	}
+	sb.WriteString("```\n" + c.CommandLine() + "\n```\n")
+	if len(c.Env) > 0 {
+		sb.WriteString("\n<details><summary>Environment</summary>\n\n```\n")
+		sb.WriteString(strings.Join(c.Env, "\n"))
+		sb.WriteString("\n```\n</details>\n")
+	}
+	if c.Redacted > 0 || c.Truncated {
+		sb.WriteString("\n*" + captureNote(c) + "*\n")
+	}
+	return sb.String()
+}
+
+// captureNote says what was left out of a capture.
+func captureNote(c *model.CulpritCapture) string {
+	var parts []string
+	if c.Redacted > 0 {
+		parts = append(parts, fmt.Sprintf("%d secret-looking value(s) masked", c.Redacted))
+	}
+	if c.Truncated {
+		parts = append(parts, "truncated at size cap")
+	}
+	return strings.Join(parts, "; ")
+}
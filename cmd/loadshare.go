@@ -11,6 +11,7 @@ import (
 	"github.com/ftahirops/xtop/collector"
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // runLoadshare implements `xtop loadshare` — explicit per-app load
@@ -100,7 +101,7 @@ func loadshareTable(snap *model.Snapshot, apps []model.AppInstance) error {
 	memTotalGB := float64(snap.Global.Memory.Total) / (1024 * 1024 * 1024)
 
 	fmt.Println()
-	fmt.Printf("  %sxtop loadshare%s — %s\n", B, R, snap.Timestamp.Format("2006-01-02 15:04:05"))
+	fmt.Printf("  %sxtop loadshare%s — %s\n", B, R, util.FmtTime(snap.Timestamp, "2006-01-02 15:04:05"))
 	if snap.SysInfo != nil {
 		fmt.Printf("  %s%s%s — %d CPUs, %.1f GB RAM\n",
 			D, snap.SysInfo.Hostname, R, nCPU, memTotalGB)
@@ -7,6 +7,7 @@ import (
 	"text/tabwriter"
 
 	"github.com/ftahirops/xtop/collector"
+	"github.com/ftahirops/xtop/util"
 )
 
 // runModules dispatches `xtop modules <verb>` — the operator-facing CLI
@@ -68,7 +69,7 @@ func modulesShow(_ []string) error {
 
 	fmt.Printf("Profile: %s%s%s\n", B, prof, R)
 	if !cfg.UpdatedAt.IsZero() {
-		fmt.Printf("Last edited: %s\n", cfg.UpdatedAt.Local().Format("2006-01-02 15:04"))
+		fmt.Printf("Last edited: %s\n", util.FmtTime(cfg.UpdatedAt, "2006-01-02 15:04"))
 	}
 	if len(cfg.Disabled) > 0 {
 		fmt.Printf("User overrides: disabled = %s\n", strings.Join(cfg.Disabled, ", "))
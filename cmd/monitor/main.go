@@ -10,13 +10,18 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/ftahirops/xtop/config"
 	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/util"
 )
 
 func main() {
 	interval := flag.Int("interval", 1, "Collection interval in seconds")
 	duration := flag.Int("duration", 60, "How long to run in seconds (0=forever)")
 	flag.Parse()
+	if err := util.SetFormat(util.FormatFromEnv(config.Load().Format)); err != nil {
+		fmt.Fprintf(os.Stderr, "monitor: format: %v (using defaults)\n", err)
+	}
 
 	eng := engine.NewEngine(60, *interval)
 	defer eng.Close()
@@ -52,7 +57,7 @@ func main() {
 				continue
 			}
 
-			ts := snap.Timestamp.Format("15:04:05")
+			ts := util.FmtTime(snap.Timestamp, "15:04:05")
 
 			// PSI line
 			psi := snap.Global.PSI
@@ -11,6 +11,7 @@ import (
 	"github.com/ftahirops/xtop/collector/phpfpm"
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // runPHPFPM implements `xtop phpfpm` — per-site PHP-FPM diagnostic.
@@ -285,7 +286,7 @@ func renderSite(a *model.PHPFPMApp, maxIPs, maxURIs, maxSlow int) {
 				break
 			}
 			fmt.Printf("    %s %8d  %-16s  %s\n",
-				red("[shell] "), f.Size, f.ModTime.Format("2006-01-02 15:04"), f.Path)
+				red("[shell] "), f.Size, util.FmtTime(f.ModTime, "2006-01-02 15:04"), f.Path)
 			fmt.Printf("                                                %s\n", dim("→ "+f.Signal))
 		}
 		for i, f := range a.FSBinaries {
@@ -294,7 +295,7 @@ func renderSite(a *model.PHPFPMApp, maxIPs, maxURIs, maxSlow int) {
 				break
 			}
 			fmt.Printf("    %s %8d  %-16s  %s\n",
-				red("[binary]"), f.Size, f.ModTime.Format("2006-01-02 15:04"), f.Path)
+				red("[binary]"), f.Size, util.FmtTime(f.ModTime, "2006-01-02 15:04"), f.Path)
 			fmt.Printf("                                                %s\n", dim("→ "+f.Signal))
 		}
 	}
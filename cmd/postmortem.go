@@ -13,6 +13,7 @@ import (
 
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // runPostmortem implements `xtop postmortem [id-or-index]` — a rich, ticket-
@@ -228,7 +229,7 @@ func listIncidents(records []engine.RCAIncident, limit int) error {
 		rows = append(rows, []string{
 			fmt.Sprintf("@%d", i+1),
 			idFor(&r),
-			r.StartedAt.Local().Format("2006-01-02 15:04:05"),
+			util.FmtTime(r.StartedAt, "2006-01-02 15:04:05"),
 			r.Bottleneck,
 			colorByImpact(float64(r.PeakScore)),
 			fmtDurationShort(r.DurationSec),
@@ -370,6 +371,12 @@ func writeANSI(rep *postmortemReport) error {
 	if culprit != "" {
 		fmt.Printf("    %-16s %s\n", "Culprit:", culprit)
 	}
+	if c := r.CulpritCapture; c != nil {
+		fmt.Printf("    %-16s %s\n", "Command:", c.CommandLine())
+		if c.Redacted > 0 || c.Truncated {
+			fmt.Printf("    %-16s %s%s%s\n", "", FCyn, captureNote(c), R)
+		}
+	}
 	if r.Pattern != "" {
 		fmt.Printf("    %-16s %s\n", "Pattern:", r.Pattern)
 	}
@@ -380,9 +387,9 @@ func writeANSI(rep *postmortemReport) error {
 
 	// Timeline
 	fmt.Printf("  %sTIMELINE%s\n", B, R)
-	fmt.Printf("    %-16s %s\n", "Started:", r.StartedAt.Local().Format("2006-01-02 15:04:05 MST"))
+	fmt.Printf("    %-16s %s\n", "Started:", util.FmtTime(r.StartedAt, "2006-01-02 15:04:05 MST"))
 	if !r.EndedAt.IsZero() {
-		fmt.Printf("    %-16s %s\n", "Ended:", r.EndedAt.Local().Format("2006-01-02 15:04:05 MST"))
+		fmt.Printf("    %-16s %s\n", "Ended:", util.FmtTime(r.EndedAt, "2006-01-02 15:04:05 MST"))
 	}
 	if r.DurationSec > 0 {
 		fmt.Printf("    %-16s %s\n", "Duration:", fmtDurationShort(r.DurationSec))
@@ -423,7 +430,7 @@ func writeANSI(rep *postmortemReport) error {
 				culprit = "—"
 			}
 			fmt.Printf("    %s  score=%-3d  dur=%-6s  culprit=%s\n",
-				s.StartedAt.Local().Format("01-02 15:04"),
+				util.FmtTime(s.StartedAt, "01-02 15:04"),
 				s.PeakScore, fmtDurationShort(s.DurationSec), culprit)
 		}
 		fmt.Println()
@@ -514,6 +521,9 @@ func writeMarkdown(rep *postmortemReport) error {
 	if r.RootCause != "" {
 		sb.WriteString(fmt.Sprintf("\n## Root cause\n\n%s\n", r.RootCause))
 	}
+	if r.CulpritCapture != nil {
+		sb.WriteString(captureMarkdown(r.CulpritCapture))
+	}
 
 	if rep.Diff != nil {
 		d := rep.Diff
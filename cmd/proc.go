@@ -11,6 +11,7 @@ import (
 	"github.com/ftahirops/xtop/collector"
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // runProc implements the `xtop proc <pid>` subcommand.
@@ -99,7 +100,7 @@ func procANSI(info *collector.ProcDeepInfo, snap *model.Snapshot, rates *model.R
 		fmt.Printf("    %-14s %s\n", "Cgroup:", cg)
 	}
 	if !info.StartTime.IsZero() {
-		fmt.Printf("    %-14s %s (up %s)\n", "Started:", info.StartTime.Format("Jan 02 15:04:05"), fmtDuration(info.Uptime))
+		fmt.Printf("    %-14s %s (up %s)\n", "Started:", util.FmtTime(info.StartTime, "Jan 02 15:04:05"), fmtDuration(info.Uptime))
 	}
 	fmt.Printf("    %-14s %d\n", "Threads:", info.NumThreads)
 	fmt.Println()
@@ -20,6 +20,8 @@ import (
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
 	"github.com/ftahirops/xtop/ui"
+	"github.com/ftahirops/xtop/util"
+	"golang.org/x/sys/unix"
 )
 
 // Version is set at build time via ldflags.
@@ -36,6 +38,8 @@ type Config struct {
 	Section      string
 	RecordPath   string
 	ReplayPath   string
+	CastPath     string
+	ComparePath  string
 	DaemonMode   bool
 	DataDir      string
 	PromEnabled  bool
@@ -60,7 +64,8 @@ type Config struct {
 	// Privacy
 	MaskIPs bool
 	// RCA tuning
-	NoHysteresis bool // disable alert state machine sustained-threshold gating
+	NoHysteresis bool   // disable alert state machine sustained-threshold gating
+	Experimental string // comma-separated experimental analyses to turn on (NAME) or off (no-NAME)
 }
 
 // MaskIPs is a global flag accessible from UI and doctor rendering.
@@ -84,6 +89,11 @@ Subcommands:
   incident <id>     Full incident report with offenders and fingerprint
   export            Export incident to file (--incident <id> --format json|md)
   flame <pid>       CPU flamegraph (ASCII or folded format)
+  handoff           On-call shift summary (--since 8h, --md, --json)
+  diff-rca A B      Compare two saved RCA JSON files (metrics, evidence, processes)
+  sa                sar-style activity log: 'sa record' appends, 'sa' queries by time range
+  swap              Swapfile / zswap advisor from observed memory pressure (--apply to create)
+  advice            List the advice catalog behind RCA actions, diagnostics and doctor
 
 Modes:
   (default)         Interactive TUI (bubbletea, fullscreen)
@@ -117,10 +127,17 @@ Options:
   -datadir PATH     Data directory for daemon mode (default: ~/.xtop/)
   -record FILE      Run TUI while recording snapshots to FILE
   -replay FILE      Replay a recorded file through the TUI
+  -cast FILE        Also save the rendered TUI screens as an asciinema cast
+  -compare SRC      Split-screen comparison with SRC: a recorded file or another
+                    xtop daemon's API socket (= toggles the split)
   -prom             Enable Prometheus metrics endpoint
   -prom-addr ADDR   Prometheus listen address (default: :9100)
   -alert-webhook URL  Webhook URL for alert notifications
   -alert-command CMD  Command to execute on alert notifications
+  -enable-experimental LIST
+                    Turn experimental analyses on or off (comma-separated;
+                    NAME, no-NAME, all, none): hidden-latency,
+                    temporal-chains, blame (these three are on by default)
 
 Positional:
   INTERVAL          First positional arg sets interval: xtop 5 = xtop -interval 5
@@ -137,7 +154,10 @@ Examples:
   sudo xtop -json | jq '.analysis.Health'
   sudo xtop -md > /tmp/incident.md
   sudo xtop -record /var/log/xtop.wlog
+  sudo xtop -record incident.wlog -cast incident.cast   Data + the screens you saw
   xtop -replay /var/log/xtop.wlog
+  sudo xtop -compare /tmp/node-b.sock  This host next to node B (ssh -L /tmp/node-b.sock:/run/xtop.sock node-b)
+  xtop -replay a.wlog -compare b.wlog  Two recordings side by side
   sudo xtop -daemon &                  Background daemon, records events
   sudo xtop -daemon -datadir /var/lib/xtop -interval 2
   sudo xtop -doctor                    Health check report
@@ -166,6 +186,14 @@ Subcommand Examples:
   sudo xtop top --json                   Process table as JSON
   sudo xtop proc 1234                    Deep report for PID 1234
   sudo xtop proc 1234 --json             Deep report as JSON
+  sudo xtop handoff --since 8h           Shift summary for the on-call handoff
+  xtop diff-rca before.json after.json   What changed between two RCA saves
+  sudo xtop sa record --interval 1m      Append a compact sample every minute
+  xtop sa --from 09:00 --to 11:30        Query today's activity log
+  sudo xtop swap --apply                 Size and create a swapfile / enable zswap (asks first)
+  xtop advice rca.mem                    Browse the advice catalog (IDs for site overrides)
+  xtop diskguard simulate                Rehearse DiskGuard Contain/Action on synthetic incidents
+  xtop grafana-dashboard -o xtop.json    Grafana dashboard for the -prom exporter (Import in Grafana)
 `, Version)
 }
 
@@ -192,10 +220,21 @@ var subcommands = map[string]func([]string) error{
 	"loadshare":  runLoadshare,
 	"apps":       runLoadshare, // alias — natural name
 	"phpfpm":     runPHPFPM,
+	"handoff":    runHandoff,
+	"diff-rca":   runDiffRCA,
+	"sa":         runSA,
+	"swap":       runSwap,
+	"advice":     runAdvice,
+	"diskguard":  runDiskGuard,
+
+	"grafana-dashboard": runGrafanaDashboard,
 }
 
 // Run parses flags and starts the application.
 func Run() error {
+	userCfg := xtopcfg.Load()
+	applyFormat(userCfg.Format)
+
 	// Pre-parse: check for subcommands before flag.Parse().
 	// Subcommands are exact word matches on os.Args[1].
 	// Numbers still parse as interval args.
@@ -210,14 +249,13 @@ func Run() error {
 	var intervalSec int
 	var showVersion bool
 
-	userCfg := xtopcfg.Load()
-
 	// Apply threshold profile from config
 	if userCfg.ThresholdProfile != "" {
 		if p, ok := engine.Profiles[userCfg.ThresholdProfile]; ok {
 			engine.ActiveProfile = p
 		}
 	}
+	engine.SetExperimental(userCfg.Experimental)
 
 	if userCfg.IntervalSec > 0 {
 		intervalSec = userCfg.IntervalSec
@@ -248,6 +286,8 @@ func Run() error {
 	flag.StringVar(&cfg.DataDir, "datadir", "", "Data directory for daemon mode (default: ~/.xtop/)")
 	flag.StringVar(&cfg.RecordPath, "record", "", "Record snapshots to file for later replay")
 	flag.StringVar(&cfg.ReplayPath, "replay", "", "Replay snapshots from a recorded file")
+	flag.StringVar(&cfg.CastPath, "cast", "", "Save the rendered TUI frames to an asciinema cast file")
+	flag.StringVar(&cfg.ComparePath, "compare", "", "Compare side by side with a recording or another daemon's API socket")
 	flag.BoolVar(&showVersion, "version", false, "Print version and exit")
 	flag.BoolVar(&cfg.PromEnabled, "prom", userCfg.Prometheus.Enabled, "Enable Prometheus metrics endpoint")
 	flag.StringVar(&cfg.PromAddr, "prom-addr", promAddrDefault, "Prometheus listen address")
@@ -269,6 +309,7 @@ func Run() error {
 	flag.BoolVar(&cfg.MaskIPs, "mask-ips", false, "Mask IP addresses in output (for demos/screenshots)")
 	// RCA tuning
 	flag.BoolVar(&cfg.NoHysteresis, "no-hysteresis", false, "Disable sustained-threshold alert gating (one-shot mode: score maps directly to health)")
+	flag.StringVar(&cfg.Experimental, "enable-experimental", "", "Turn experimental analyses on or off (comma-separated: "+strings.Join(engine.ExperimentalNames(), ",")+", no-NAME, all, none)")
 	var updateMode bool
 	flag.BoolVar(&updateMode, "update", false, "Check for latest release on GitHub and install it")
 	// Fleet (multi-host aggregation) flags
@@ -299,6 +340,11 @@ func Run() error {
 		}
 	}
 
+	if unknown := engine.PinExperimental(engine.ParseExperimental(cfg.Experimental)); len(unknown) > 0 {
+		return fmt.Errorf("unknown experimental feature %q (available: %s, no-NAME, all, none)",
+			strings.Join(unknown, ","), strings.Join(engine.ExperimentalNames(), ", "))
+	}
+
 	cfg.Interval = time.Duration(intervalSec) * time.Second
 	MaskIPsEnabled = cfg.MaskIPs
 	model.MaskIPsEnabled = cfg.MaskIPs
@@ -435,13 +481,11 @@ func Run() error {
 			Interval: cfg.Interval,
 			History:  cfg.HistorySize,
 			Metrics:  promStore,
-			Alerts: engine.AlertConfig{
-				Webhook:          cfg.AlertWebhook,
-				Command:          cfg.AlertCommand,
-				Email:            userCfg.Alerts.Email,
-				SlackWebhook:     userCfg.Alerts.SlackWebhook,
-				TelegramBotToken: userCfg.Alerts.TelegramBotToken,
-				TelegramChatID:   userCfg.Alerts.TelegramChatID,
+			Alerts:   daemonAlerts(cfg, userCfg),
+			Runtime:  engine.RuntimeConfigFrom(userCfg),
+			Reload: func() (engine.RuntimeConfig, engine.AlertConfig) {
+				u := xtopcfg.Load()
+				return engine.RuntimeConfigFrom(u), daemonAlerts(cfg, u)
 			},
 			Fleet:   fleetCfg,
 			Version: Version,
@@ -481,10 +525,11 @@ func Run() error {
 	}
 
 	// Normal TUI mode
-	m := ui.NewModel(wrapTicker(eng), cfg.Interval, cfg.DataDir)
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
-	return err
+	m, err := withCompare(ui.NewModel(wrapTicker(eng), cfg.Interval, cfg.DataDir), cfg)
+	if err != nil {
+		return err
+	}
+	return runProgram(m, cfg.CastPath)
 }
 
 // runJSON outputs a single snapshot + analysis as JSON and exits.
@@ -506,6 +551,61 @@ func runJSON(ticker engine.Ticker, interval time.Duration) error {
 	return enc.Encode(data)
 }
 
+// applyFormat sets the display units, decimal separator, clock and time
+// zone from config.json "format" and the XTOP_UNITS / XTOP_DECIMAL /
+// XTOP_CLOCK / XTOP_TZ overrides. A bad value is reported and the
+// defaults stay in effect.
+func applyFormat(f util.Format) {
+	if err := util.SetFormat(util.FormatFromEnv(f)); err != nil {
+		fmt.Fprintf(os.Stderr, "xtop: format: %v (using defaults)\n", err)
+	}
+}
+
+// daemonAlerts builds the daemon's alert destinations from the config
+// file. --alert-webhook / --alert-command given on the command line keep
+// winning over the file across reloads.
+func daemonAlerts(cfg Config, u xtopcfg.Config) engine.AlertConfig {
+	a := engine.AlertConfig{
+		Webhook:          u.Alerts.Webhook,
+		Command:          u.Alerts.Command,
+		Email:            u.Alerts.Email,
+		SlackWebhook:     u.Alerts.SlackWebhook,
+		TelegramBotToken: u.Alerts.TelegramBotToken,
+		TelegramChatID:   u.Alerts.TelegramChatID,
+		RateLimit:        alertRateLimit(u.Alerts.RateLimit),
+		ChannelLimits:    alertChannelLimits(u.Alerts.ChannelRateLimits),
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "alert-webhook":
+			a.Webhook = cfg.AlertWebhook
+		case "alert-command":
+			a.Command = cfg.AlertCommand
+		}
+	})
+	return a
+}
+
+// alertRateLimit converts the config-file alert budget to the engine form.
+func alertRateLimit(r xtopcfg.AlertRateLimit) engine.AlertRateLimit {
+	return engine.AlertRateLimit{
+		Max:    r.MaxPerWindow,
+		Window: time.Duration(r.WindowSec) * time.Second,
+	}
+}
+
+// alertChannelLimits converts per-channel alert budgets.
+func alertChannelLimits(m map[string]xtopcfg.AlertRateLimit) map[string]engine.AlertRateLimit {
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]engine.AlertRateLimit, len(m))
+	for ch, r := range m {
+		out[ch] = alertRateLimit(r)
+	}
+	return out
+}
+
 // runMarkdown outputs a markdown incident report to stdout.
 func runMarkdown(ticker engine.Ticker, interval time.Duration) error {
 	// Collect two snapshots for rate calculation
@@ -659,16 +759,7 @@ func renderMarkdownReport(snap *model.Snapshot, rates *model.RateSnapshot, resul
 }
 
 func fmtBytesSimple(b uint64) string {
-	switch {
-	case b >= 1<<30:
-		return fmt.Sprintf("%.1fG", float64(b)/(1<<30))
-	case b >= 1<<20:
-		return fmt.Sprintf("%.1fM", float64(b)/(1<<20))
-	case b >= 1<<10:
-		return fmt.Sprintf("%.1fK", float64(b)/(1<<10))
-	default:
-		return fmt.Sprintf("%dB", b)
-	}
+	return util.FmtBytesShort(b)
 }
 
 // runRecord runs the TUI while also recording snapshots to a file.
@@ -684,12 +775,46 @@ func runRecord(eng *engine.Engine, cfg Config, wrap func(engine.Ticker) engine.T
 	ticker := wrap(rec)
 
 	m := ui.NewModel(ticker, cfg.Interval, cfg.DataDir)
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err = p.Run()
+	if m, err = withCompare(m, cfg); err != nil {
+		rec.Close()
+		return err
+	}
+	err = runProgram(m, cfg.CastPath)
 	rec.Close()
 	return err
 }
 
+// runProgram runs the TUI full-screen. With castPath set, the terminal
+// output is also saved as an asciinema v2 cast.
+func runProgram(m ui.Model, castPath string, extra ...tea.ProgramOption) error {
+	opts := append([]tea.ProgramOption{tea.WithAltScreen()}, extra...)
+	var cast *ui.CastWriter
+	if castPath != "" {
+		f, err := os.OpenFile(castPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return fmt.Errorf("cannot create cast file: %w", err)
+		}
+		defer f.Close()
+		width, height := 80, 24
+		if ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ); err == nil && ws.Col > 0 {
+			width, height = int(ws.Col), int(ws.Row)
+		}
+		host, _ := os.Hostname()
+		cast, err = ui.NewCastWriter(os.Stdout, f, width, height, "xtop "+host)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, tea.WithOutput(cast), tea.WithFilter(cast.Filter))
+	}
+	_, err := tea.NewProgram(m, opts...).Run()
+	if cast != nil {
+		if cerr := cast.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("cast %s: %w", castPath, cerr)
+		}
+	}
+	return err
+}
+
 // runReplay replays a recorded file through the TUI.
 func runReplay(cfg Config, wrap func(engine.Ticker) engine.Ticker) error {
 	f, err := os.Open(cfg.ReplayPath)
@@ -704,9 +829,12 @@ func runReplay(cfg Config, wrap func(engine.Ticker) engine.Ticker) error {
 	}
 
 	m := ui.NewModel(wrap(player), cfg.Interval, cfg.DataDir)
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err = p.Run()
-	return err
+	if m, err = withCompare(m, cfg); err != nil {
+		return err
+	}
+	// Mouse reporting lets the scrubber bar be clicked and dragged. Live
+	// mode leaves it off so terminal text selection keeps working.
+	return runProgram(m, cfg.CastPath, tea.WithMouseCellMotion())
 }
 
 // ghRelease represents the minimal GitHub release API response.
@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ftahirops/xtop/collector"
+	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/util"
+)
+
+// runSA implements `xtop sa` — a sysstat-style activity log. `xtop sa record`
+// appends one compact line per interval to a daily file under ~/.xtop/sa/;
+// plain `xtop sa` reads them back like `sar -f`, filtered by time range and
+// optionally averaged into coarser steps. Meant for long, cheap retention on
+// hosts that have no TSDB: a month at one-minute resolution is a few MB.
+func runSA(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "record":
+			return saRecord(args[1:])
+		case "help", "-h", "--help":
+			saUsage()
+			return nil
+		}
+	}
+	return saQuery(args)
+}
+
+func saUsage() {
+	fmt.Fprintln(os.Stderr, `xtop sa — sar-style activity log with PSI and RCA columns
+
+  xtop sa record                          append a sample every 60s (foreground)
+  xtop sa record --interval 10s --retain 7
+  xtop sa                                 today's samples
+  xtop sa --since 2h                      last two hours
+  xtop sa --date 2026-05-01 --from 09:00 --to 11:30
+  xtop sa --since 1d --step 15m           15-minute averages
+  xtop sa --fields cpu,psi_io,await_ms    pick columns ("all" for every one)
+  xtop sa --json                          records as JSON
+
+Files are plain text (one per day, saYYYYMMDD) and can be read with awk.
+Run 'xtop sa record' under systemd or cron @reboot for continuous logging.`)
+}
+
+// defaultSADir is ~/.xtop/sa.
+func defaultSADir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory for sa dir: %w (use --dir)", err)
+	}
+	return filepath.Join(home, ".xtop", "sa"), nil
+}
+
+func saRecord(args []string) error {
+	fs := flag.NewFlagSet("sa record", flag.ExitOnError)
+	var (
+		interval = fs.Duration("interval", time.Minute, "sampling interval")
+		dir      = fs.String("dir", "", "activity log directory (default: ~/.xtop/sa)")
+		retain   = fs.Int("retain", 28, "days of files to keep (0 = forever)")
+	)
+	fs.Usage = func() {
+		saUsage()
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *interval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+	if *dir == "" {
+		d, err := defaultSADir()
+		if err != nil {
+			return err
+		}
+		*dir = d
+	}
+	host, _ := os.Hostname()
+	w, err := engine.NewSAWriter(*dir, host, *interval, *retain)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	eng := engine.NewEngineMode(60, int(interval.Seconds()), collector.ModeLean)
+	defer eng.Close()
+	eng.Tick() // baseline for rate diffs
+
+	fmt.Fprintf(os.Stderr, "xtop sa: recording every %s to %s (retain %d days, Ctrl-C to stop)\n",
+		*interval, *dir, *retain)
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	t := time.NewTicker(*interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-sig:
+			return nil
+		case <-t.C:
+			snap, rates, result := eng.Tick()
+			if snap == nil {
+				continue
+			}
+			if err := w.Write(engine.NewSARecord(snap, rates, result)); err != nil {
+				fmt.Fprintf(os.Stderr, "xtop sa: write: %v\n", err)
+			}
+		}
+	}
+}
+
+var saDefaultFields = []string{"cpu", "iowait", "steal", "load1", "mem", "psi_cpu", "psi_mem", "psi_io", "disk_util", "await_ms"}
+
+func saQuery(args []string) error {
+	fs := flag.NewFlagSet("sa", flag.ExitOnError)
+	var (
+		dir      = fs.String("dir", "", "activity log directory (default: ~/.xtop/sa)")
+		date     = fs.String("date", "", "day to read, YYYY-MM-DD (default: today)")
+		fromStr  = fs.String("from", "", "start time, HH:MM[:SS] on --date or 'YYYY-MM-DD HH:MM'")
+		toStr    = fs.String("to", "", "end time, same forms as --from")
+		sinceStr = fs.String("since", "", "relative window ending now (e.g. 2h, 90m, 3d); overrides --date/--from/--to")
+		step     = fs.Duration("step", 0, "average samples into buckets of this size (e.g. 10m)")
+		fields   = fs.String("fields", "", "comma-separated columns, or 'all' (default: "+strings.Join(saDefaultFields, ",")+")")
+		jsonOut  = fs.Bool("json", false, "emit records as JSON")
+	)
+	fs.Usage = func() {
+		saUsage()
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		d, err := defaultSADir()
+		if err != nil {
+			return err
+		}
+		*dir = d
+	}
+
+	from, to, err := saRange(time.Now(), *date, *fromStr, *toStr, *sinceStr)
+	if err != nil {
+		return err
+	}
+	cols, err := saFields(*fields)
+	if err != nil {
+		return err
+	}
+	recs, err := engine.ReadSA(*dir, from, to)
+	if err != nil {
+		return err
+	}
+	if *step > 0 {
+		recs = saBucket(recs, *step)
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if recs == nil {
+			recs = []engine.SARecord{}
+		}
+		return enc.Encode(recs)
+	}
+	if len(recs) == 0 {
+		fmt.Printf("no samples between %s and %s\n", util.FmtTime(from, "2006-01-02 15:04"), util.FmtTime(to, "2006-01-02 15:04"))
+		return nil
+	}
+	fmt.Print(renderSATable(recs, cols, from, to))
+	return nil
+}
+
+// saRange resolves the query flags into an absolute [from, to] window.
+func saRange(now time.Time, date, from, to, since string) (time.Time, time.Time, error) {
+	if since != "" {
+		d, err := parseSinceWindow(since)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return now.Add(-d), now, nil
+	}
+	day := now
+	if date != "" {
+		d, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("bad --date %q (use YYYY-MM-DD)", date)
+		}
+		day = d
+	}
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+	start, end := midnight, midnight.Add(24*time.Hour-time.Second)
+	var err error
+	if from != "" {
+		if start, err = saParseTime(from, midnight); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("bad --from: %w", err)
+		}
+	}
+	if to != "" {
+		if end, err = saParseTime(to, midnight); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("bad --to: %w", err)
+		}
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--to %s is before --from %s", util.FmtTime(end, "2006-01-02 15:04"), util.FmtTime(start, "2006-01-02 15:04"))
+	}
+	return start, end, nil
+}
+
+// saParseTime accepts HH:MM, HH:MM:SS (on day) or a full local date-time.
+func saParseTime(s string, day time.Time) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return day.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+				time.Duration(t.Second())*time.Second), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not HH:MM[:SS] or YYYY-MM-DD HH:MM", s)
+}
+
+// saFields validates a --fields list against the known columns.
+func saFields(s string) ([]string, error) {
+	switch s {
+	case "":
+		return saDefaultFields, nil
+	case "all":
+		var all []string
+		for _, c := range engine.SAColumns {
+			all = append(all, c.Name)
+		}
+		return all, nil
+	}
+	var out []string
+	var probe engine.SARecord
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if _, ok := probe.Value(f); !ok {
+			var known []string
+			for _, c := range engine.SAColumns {
+				known = append(known, c.Name)
+			}
+			return nil, fmt.Errorf("unknown field %q (known: %s)", f, strings.Join(known, ", "))
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+// saBucket averages records into step-aligned buckets. Health, score,
+// bottleneck and culprit come from the worst sample in each bucket so a
+// short spike is not averaged away.
+func saBucket(recs []engine.SARecord, step time.Duration) []engine.SARecord {
+	var out []engine.SARecord
+	var n float64
+	var cur engine.SARecord
+	var curKey time.Time
+	flush := func() {
+		if n == 0 {
+			return
+		}
+		for _, c := range engine.SAColumns {
+			v, _ := cur.Value(c.Name)
+			cur.Set(c.Name, v/n)
+		}
+		out = append(out, cur)
+	}
+	for _, r := range recs {
+		key := r.Time.Truncate(step)
+		if n == 0 || !key.Equal(curKey) {
+			flush()
+			cur, n, curKey = engine.SARecord{Time: key, Health: r.Health}, 0, key
+		}
+		for _, c := range engine.SAColumns {
+			a, _ := cur.Value(c.Name)
+			b, _ := r.Value(c.Name)
+			cur.Set(c.Name, a+b)
+		}
+		if r.Score > cur.Score || n == 0 {
+			cur.Score, cur.Health, cur.Bottleneck, cur.Culprit = r.Score, r.Health, r.Bottleneck, r.Culprit
+		}
+		n++
+	}
+	flush()
+	return out
+}
+
+// renderSATable prints records sar-style: one row per sample, then an
+// Average row and a count of non-OK samples.
+func renderSATable(recs []engine.SARecord, cols []string, from, to time.Time) string {
+	var sb strings.Builder
+	host, _ := os.Hostname()
+	fmt.Fprintf(&sb, "%sxtop sa%s  %s  %s → %s  %d samples\n\n", B, R, host,
+		util.FmtTime(from, "2006-01-02 15:04"), util.FmtTime(to, "2006-01-02 15:04"), len(recs))
+
+	multiDay := from.Format("20060102") != to.Format("20060102")
+	tsW := 8
+	if multiDay {
+		tsW = 14
+	}
+	fmt.Fprintf(&sb, "%s%-*s  %-8s %5s", D, tsW, "time", "health", "score")
+	for _, c := range cols {
+		fmt.Fprintf(&sb, " %*s", saColWidth(c), c)
+	}
+	fmt.Fprintf(&sb, "  %s%s\n", "bottleneck (culprit)", R)
+
+	sums := make([]float64, len(cols))
+	bad := 0
+	for _, r := range recs {
+		ts := util.FmtTime(r.Time, "15:04:05")
+		if multiDay {
+			ts = util.FmtTime(r.Time, "01-02 15:04:05")
+		}
+		fmt.Fprintf(&sb, "%-*s  %s %5d", tsW, ts, saHealthCell(r.Health), r.Score)
+		for i, c := range cols {
+			v, _ := r.Value(c)
+			sums[i] += v
+			fmt.Fprintf(&sb, " %*s", saColWidth(c), saFmt(c, v))
+		}
+		if r.Bottleneck != "" {
+			bad++
+			what := r.Bottleneck
+			if r.Culprit != "" {
+				what += " (" + r.Culprit + ")"
+			}
+			fmt.Fprintf(&sb, "  %s", what)
+		}
+		sb.WriteByte('\n')
+	}
+
+	fmt.Fprintf(&sb, "%s%-*s  %-8s %5s", B, tsW, "Average:", "", "")
+	for i, c := range cols {
+		fmt.Fprintf(&sb, " %*s", saColWidth(c), saFmt(c, sums[i]/float64(len(recs))))
+	}
+	fmt.Fprintf(&sb, "%s\n", R)
+	if bad > 0 {
+		fmt.Fprintf(&sb, "\n%s%d of %d samples had an active bottleneck%s\n", FYel, bad, len(recs), R)
+	}
+	return sb.String()
+}
+
+func saColWidth(c string) int {
+	if len(c) < 7 {
+		return 7
+	}
+	return len(c)
+}
+
+func saFmt(col string, v float64) string {
+	switch col {
+	case "swap_mb", "dstate":
+		return fmt.Sprintf("%.0f", v)
+	case "load1", "rx_mbs", "tx_mbs":
+		return fmt.Sprintf("%.2f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+// saHealthCell pads before coloring so the columns stay aligned.
+func saHealthCell(h string) string {
+	cell := fmt.Sprintf("%-8s", subcmdTrunc(h, 8))
+	switch h {
+	case "CRITICAL":
+		return FBRed + cell + R
+	case "DEGRADED":
+		return FBYel + cell + R
+	case "OK":
+		return FBGrn + cell + R
+	}
+	return D + cell + R
+}
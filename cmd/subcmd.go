@@ -9,6 +9,7 @@ import (
 	"github.com/ftahirops/xtop/collector"
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // collectOrQuery returns a snapshot+rates+result for one-shot subcommands
@@ -194,20 +195,14 @@ func padStyledOrTrunc(s string, width int) string {
 
 // subcmdFmtBytes formats bytes to human-readable string.
 func subcmdFmtBytes(b uint64) string {
-	const (
-		kb = 1024
-		mb = 1024 * kb
-		gb = 1024 * mb
-	)
-	switch {
-	case b >= gb:
-		return fmt.Sprintf("%.1fG", float64(b)/float64(gb))
-	case b >= mb:
-		return fmt.Sprintf("%.1fM", float64(b)/float64(mb))
-	case b >= kb:
-		return fmt.Sprintf("%.0fK", float64(b)/float64(kb))
-	default:
+	v, i := util.ScaleBytes(float64(b))
+	switch i {
+	case 0:
 		return fmt.Sprintf("%dB", b)
+	case 1:
+		return util.FmtFloat(v, 0) + util.ByteUnit(i, true)
+	default:
+		return util.FmtFloat(v, 1) + util.ByteUnit(i, true)
 	}
 }
 
@@ -0,0 +1,652 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
+)
+
+// runSwap implements `xtop swap` — a swapfile / zswap advisor.
+//
+// Looks at how often memory ran hot over the last N days (per-minute
+// rollups in ~/.xtop/usage-history.jsonl, plus the sa activity log when
+// present), sizes swap from the live working set — cold anonymous memory
+// is what swap would actually absorb — and recommends a swapfile, a larger
+// one, zswap, or nothing. --apply performs the swapfile / zswap steps after
+// an explicit confirmation.
+//
+// Sizing: max(Inactive(anon), peak overflow above 85 % RAM) × 1.25, rounded
+// up to whole GiB, between 1 GiB and min(RAM, 32 GiB).
+func runSwap(args []string) error {
+	fs := flag.NewFlagSet("swap", flag.ExitOnError)
+	var (
+		days    = fs.Int("days", 7, "days of history to analyze")
+		jsonOut = fs.Bool("json", false, "machine-readable JSON output")
+		mdOut   = fs.Bool("md", false, "markdown output (for tickets)")
+		apply   = fs.Bool("apply", false, "create the recommended swapfile / enable zswap (asks first)")
+		yes     = fs.Bool("yes", false, "with --apply: do not ask for confirmation")
+		path    = fs.String("path", "/swapfile", "swapfile location for --apply")
+		noFstab = fs.Bool("no-fstab", false, "with --apply: do not add the swapfile to /etc/fstab")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, `xtop swap — swapfile / zswap advisor
+
+Checks how often memory pressure occurred over the last N days and whether
+swap is absent or too small for the observed working set, then recommends a
+swapfile size and/or a zswap configuration.
+
+Usage:
+  xtop swap                   # 7-day advice, ANSI
+  xtop swap --days 30 --md    # markdown for tickets
+  sudo xtop swap --apply      # create swapfile / enable zswap after confirmation
+
+Flags:`)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	in, err := gatherSwapInputs(*days)
+	if err != nil {
+		return err
+	}
+	rep := buildSwapReport(in, *path)
+
+	switch {
+	case *jsonOut:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rep); err != nil {
+			return err
+		}
+	case *mdOut:
+		fmt.Print(renderSwapMarkdown(rep))
+	default:
+		fmt.Print(renderSwapANSI(rep))
+	}
+	if *apply {
+		return applySwapPlan(rep, *yes, !*noFstab)
+	}
+	return nil
+}
+
+// ── Inputs ───────────────────────────────────────────────────────────────────
+
+// swapDevice is one /proc/swaps row.
+type swapDevice struct {
+	Path     string `json:"path"`
+	Type     string `json:"type"` // "file", "partition"
+	Size     uint64 `json:"size_bytes"`
+	Used     uint64 `json:"used_bytes"`
+	Priority int    `json:"priority"`
+}
+
+// zswapState is the running zswap configuration.
+type zswapState struct {
+	Available      bool   `json:"available"`
+	Enabled        bool   `json:"enabled"`
+	Compressor     string `json:"compressor,omitempty"`
+	MaxPoolPercent int    `json:"max_pool_percent,omitempty"`
+}
+
+type swapInputs struct {
+	Mem     model.MemoryMetrics
+	Swaps   []swapDevice
+	Zswap   zswapState
+	Zram    bool // a zram device is active swap
+	Rollups []engine.UsageRollup
+	SA      []engine.SARecord
+	Days    int
+}
+
+func gatherSwapInputs(days int) (*swapInputs, error) {
+	in := &swapInputs{Days: days}
+	snap, _, _ := collectOrQuery(3)
+	if snap == nil {
+		return nil, fmt.Errorf("could not read memory state")
+	}
+	in.Mem = snap.Global.Memory
+	in.Swaps = readProcSwaps("/proc/swaps")
+	in.Zswap = readZswap("/sys/module/zswap/parameters")
+	for _, d := range in.Swaps {
+		if strings.HasPrefix(d.Path, "/dev/zram") {
+			in.Zram = true
+		}
+	}
+
+	since := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	rollups, err := loadUsageHistory()
+	if err != nil {
+		return nil, err
+	}
+	in.Rollups = filterSince(rollups, since.UTC())
+	if dir, err := defaultSADir(); err == nil {
+		in.SA, _ = engine.ReadSA(dir, since, time.Now())
+	}
+	return in, nil
+}
+
+func readProcSwaps(path string) []swapDevice {
+	lines, err := util.ReadFileLines(path)
+	if err != nil {
+		return nil
+	}
+	var out []swapDevice
+	for _, line := range lines {
+		f := strings.Fields(line)
+		// Filename Type Size(KiB) Used(KiB) Priority
+		if len(f) < 5 || f[0] == "Filename" {
+			continue
+		}
+		prio, _ := strconv.Atoi(f[4])
+		out = append(out, swapDevice{
+			Path: f[0], Type: f[1],
+			Size:     util.ParseUint64(f[2]) * 1024,
+			Used:     util.ParseUint64(f[3]) * 1024,
+			Priority: prio,
+		})
+	}
+	return out
+}
+
+func readZswap(dir string) zswapState {
+	var z zswapState
+	s, err := util.ReadFileString(filepath.Join(dir, "enabled"))
+	if err != nil {
+		return z
+	}
+	z.Available = true
+	z.Enabled = strings.TrimSpace(s) == "Y"
+	if s, err := util.ReadFileString(filepath.Join(dir, "compressor")); err == nil {
+		z.Compressor = strings.TrimSpace(s)
+	}
+	if s, err := util.ReadFileString(filepath.Join(dir, "max_pool_percent")); err == nil {
+		z.MaxPoolPercent, _ = strconv.Atoi(strings.TrimSpace(s))
+	}
+	return z
+}
+
+// ── Report building ──────────────────────────────────────────────────────────
+
+const (
+	swapPressureMemPct = 90.0 // a minute with mem max ≥ this counts as pressure
+	swapPressurePSI    = 10.0 // ...as does memory PSI some avg10 ≥ this (sa log)
+	swapFrequentFrac   = 0.01 // ≥1 % of observed minutes is "frequent"
+	swapFrequentMins   = 30   // ...or at least this many minutes in the window
+	swapOverflowPct    = 85.0 // RAM use above this is what swap should absorb
+	swapMaxBytes       = 32 << 30
+)
+
+type swapReport struct {
+	MemTotal  uint64       `json:"mem_total_bytes"`
+	SwapTotal uint64       `json:"swap_total_bytes"`
+	SwapUsed  uint64       `json:"swap_used_bytes"`
+	Devices   []swapDevice `json:"devices"`
+	Zswap     zswapState   `json:"zswap"`
+	Zram      bool         `json:"zram"`
+
+	WindowDays      int     `json:"window_days"`
+	Minutes         int     `json:"minutes"`
+	PressureMinutes int     `json:"pressure_minutes"`
+	PressureDays    int     `json:"pressure_days"`
+	PeakMemPct      float64 `json:"peak_mem_pct"`
+	P95MemPct       float64 `json:"p95_mem_pct"`
+	PeakSwapPct     float64 `json:"peak_swap_pct"` // of current swap, from the sa log
+	CPUP95          float64 `json:"cpu_p95"`
+
+	HotSet      uint64 `json:"hot_set_bytes"`   // Active(anon) + Active(file)
+	ColdAnon    uint64 `json:"cold_anon_bytes"` // Inactive(anon): what swap absorbs
+	Needed      uint64 `json:"needed_bytes"`
+	Recommended uint64 `json:"recommended_bytes"`
+
+	Action    string          `json:"action"` // "create_swapfile", "grow_swap", "enable_zswap", "hold", "insufficient_data"
+	Reasoning []string        `json:"reasoning"`
+	Swapfile  *swapfilePlan   `json:"swapfile,omitempty"`
+	ZswapPlan *zswapPlanEntry `json:"zswap_plan,omitempty"`
+}
+
+type swapfilePlan struct {
+	Path string `json:"path"`
+	Size uint64 `json:"size_bytes"`
+}
+
+type zswapPlanEntry struct {
+	Compressor     string `json:"compressor"`
+	MaxPoolPercent int    `json:"max_pool_percent"`
+}
+
+func buildSwapReport(in *swapInputs, path string) *swapReport {
+	rep := &swapReport{
+		MemTotal: in.Mem.Total, SwapTotal: in.Mem.SwapTotal, SwapUsed: in.Mem.SwapUsed,
+		Devices: in.Swaps, Zswap: in.Zswap, Zram: in.Zram, WindowDays: in.Days,
+		HotSet:   in.Mem.ActiveAnon + in.Mem.ActiveFile,
+		ColdAnon: in.Mem.InactiveAnon,
+	}
+	swapPressureStats(rep, in)
+
+	// Size from the working set: cold anon is what the kernel would page
+	// out; the peak overflow is what RAM could not hold at the worst minute.
+	overflow := uint64(0)
+	if rep.PeakMemPct > swapOverflowPct && in.Mem.Total > 0 {
+		overflow = uint64((rep.PeakMemPct - swapOverflowPct) / 100 * float64(in.Mem.Total))
+	}
+	rep.Needed = max(rep.ColdAnon, overflow)
+	rep.Recommended = swapRoundGiB(uint64(float64(rep.Needed)*1.25), in.Mem.Total)
+
+	frequent := rep.Minutes > 0 && (float64(rep.PressureMinutes)/float64(rep.Minutes) >= swapFrequentFrac ||
+		rep.PressureMinutes >= swapFrequentMins)
+	undersized := rep.SwapTotal > 0 && (rep.SwapTotal < rep.Needed || rep.PeakSwapPct >= 80)
+
+	switch {
+	case rep.Minutes == 0:
+		rep.Action = "insufficient_data"
+		rep.Reasoning = append(rep.Reasoning,
+			"No usage history yet — run xtop (or `xtop sa record`) for a day, then re-check.")
+	case !frequent:
+		rep.Action = "hold"
+		rep.Reasoning = append(rep.Reasoning, fmt.Sprintf(
+			"Memory pressure in %d of %d minutes — not frequent enough to justify a change.",
+			rep.PressureMinutes, rep.Minutes))
+	case rep.SwapTotal == 0:
+		rep.Action = "create_swapfile"
+		rep.Swapfile = &swapfilePlan{Path: path, Size: rep.Recommended}
+		rep.Reasoning = append(rep.Reasoning,
+			fmt.Sprintf("Memory pressure in %d minutes across %d days with no swap configured.", rep.PressureMinutes, rep.PressureDays),
+			fmt.Sprintf("%s of anonymous memory is inactive and could be paged out; peak use was %.0f%% of RAM.",
+				fmtBytesShort(rep.ColdAnon), rep.PeakMemPct))
+	case undersized:
+		rep.Action = "grow_swap"
+		add := swapRoundGiB(rep.Recommended-min(rep.Recommended, rep.SwapTotal), in.Mem.Total)
+		rep.Swapfile = &swapfilePlan{Path: swapfileFreePath(path, in.Swaps), Size: add}
+		reason := fmt.Sprintf("Swap is %s but the working set needs ~%s", fmtBytesShort(rep.SwapTotal), fmtBytesShort(rep.Needed))
+		if rep.PeakSwapPct >= 80 {
+			reason += fmt.Sprintf(" (swap peaked at %.0f%% full)", rep.PeakSwapPct)
+		}
+		rep.Reasoning = append(rep.Reasoning, reason+".")
+	default:
+		rep.Action = "hold"
+		rep.Reasoning = append(rep.Reasoning, fmt.Sprintf(
+			"Swap (%s) covers the %s of inactive anonymous memory.", fmtBytesShort(rep.SwapTotal), fmtBytesShort(rep.ColdAnon)))
+	}
+
+	// zswap: compress pages on their way to swap. Worth it whenever swap
+	// is (or will be) used under frequent pressure and zram isn't already
+	// doing the same job.
+	if frequent && in.Zswap.Available && !in.Zswap.Enabled && !in.Zram {
+		rep.ZswapPlan = zswapFor(rep)
+		if rep.Action == "hold" {
+			rep.Action = "enable_zswap"
+		}
+		rep.Reasoning = append(rep.Reasoning, fmt.Sprintf(
+			"zswap keeps ~%d%% of RAM as a compressed cache in front of swap, cutting swap IO for the %s cold set.",
+			rep.ZswapPlan.MaxPoolPercent, fmtBytesShort(rep.ColdAnon)))
+	}
+	return rep
+}
+
+func swapPressureStats(rep *swapReport, in *swapInputs) {
+	// Both sources are keyed by minute, so a minute covered by the rollups
+	// and the sa log counts once, and is under pressure if either says so.
+	minutes := map[int64]bool{}
+	pressure := map[int64]bool{}
+	var mems, cpus []float64
+	for _, u := range in.Rollups {
+		minute := u.Minute.Unix() / 60
+		minutes[minute] = true
+		mems = append(mems, u.Mem.Max)
+		cpus = append(cpus, u.CPU.P95)
+		if u.Mem.Max >= swapPressureMemPct {
+			pressure[minute] = true
+		}
+	}
+	// The sa log adds memory PSI (pressure even below 90 % use), swap fill
+	// and the minutes the rollups miss; its samples feed the percentiles
+	// only when there are no rollups at all.
+	for _, r := range in.SA {
+		if in.Mem.SwapTotal > 0 {
+			pct := r.SwapMB * (1 << 20) / float64(in.Mem.SwapTotal) * 100
+			rep.PeakSwapPct = math.Max(rep.PeakSwapPct, pct)
+		}
+		minute := r.Time.Unix() / 60
+		if len(in.Rollups) == 0 && !minutes[minute] {
+			mems = append(mems, r.Mem)
+			cpus = append(cpus, r.CPU)
+		}
+		minutes[minute] = true
+		if r.Mem >= swapPressureMemPct || r.PSIMem >= swapPressurePSI {
+			pressure[minute] = true
+		}
+	}
+	days := map[string]bool{}
+	for minute := range pressure {
+		days[time.Unix(minute*60, 0).Local().Format("2006-01-02")] = true
+	}
+	rep.Minutes = len(minutes)
+	rep.PressureMinutes = len(pressure)
+	rep.PressureDays = len(days)
+	sort.Float64s(mems)
+	sort.Float64s(cpus)
+	rep.PeakMemPct = pickPercentile(mems, 1)
+	rep.P95MemPct = pickPercentile(mems, 0.95)
+	rep.CPUP95 = pickPercentile(cpus, 0.95)
+}
+
+// swapRoundGiB rounds up to whole GiB within [1 GiB, min(RAM, 32 GiB)].
+func swapRoundGiB(b, memTotal uint64) uint64 {
+	const gib = 1 << 30
+	ceil := uint64(swapMaxBytes)
+	if memTotal > 0 && memTotal < ceil {
+		ceil = (memTotal + gib - 1) / gib * gib
+	}
+	b = (b + gib - 1) / gib * gib
+	return min(max(b, gib), ceil)
+}
+
+// zswapFor sizes the pool for the cold set at a conservative 3:1
+// compression ratio, plus headroom, within 10–30 % of RAM. zstd compresses
+// better; lz4 is cheaper when the CPU is already busy.
+func zswapFor(rep *swapReport) *zswapPlanEntry {
+	z := &zswapPlanEntry{Compressor: "zstd", MaxPoolPercent: 20}
+	if rep.CPUP95 >= 60 {
+		z.Compressor = "lz4"
+	}
+	if rep.MemTotal > 0 {
+		pct := int(math.Ceil(float64(rep.ColdAnon)/3/float64(rep.MemTotal)*100)) + 5
+		z.MaxPoolPercent = max(10, min(30, pct))
+	}
+	return z
+}
+
+// swapfileFreePath picks path, or the first free of path2 … path9 ("/swapfile2")
+// when an active swap or an existing file already uses it, and path.xtop as
+// a last resort.
+func swapfileFreePath(path string, devs []swapDevice) string {
+	taken := func(p string) bool {
+		for _, d := range devs {
+			if d.Path == p {
+				return true
+			}
+		}
+		_, err := os.Stat(p)
+		return err == nil
+	}
+	if !taken(path) {
+		return path
+	}
+	for i := 2; i < 10; i++ {
+		if p := fmt.Sprintf("%s%d", path, i); !taken(p) {
+			return p
+		}
+	}
+	return path + ".xtop"
+}
+
+// ── Rendering ────────────────────────────────────────────────────────────────
+
+// swapSteps lists the commands the plan amounts to, in order.
+func swapSteps(rep *swapReport, fstab bool) []string {
+	var out []string
+	if sf := rep.Swapfile; sf != nil {
+		out = append(out,
+			fmt.Sprintf("fallocate -l %dG %s", sf.Size>>30, sf.Path),
+			"chmod 600 "+sf.Path,
+			"mkswap "+sf.Path,
+			"swapon "+sf.Path)
+		if fstab {
+			out = append(out, fmt.Sprintf("echo '%s none swap sw 0 0' >> /etc/fstab", sf.Path))
+		}
+	}
+	if z := rep.ZswapPlan; z != nil {
+		out = append(out,
+			fmt.Sprintf("echo %s > /sys/module/zswap/parameters/compressor", z.Compressor),
+			fmt.Sprintf("echo %d > /sys/module/zswap/parameters/max_pool_percent", z.MaxPoolPercent),
+			"echo Y > /sys/module/zswap/parameters/enabled")
+	}
+	return out
+}
+
+func zswapCmdline(z *zswapPlanEntry) string {
+	return fmt.Sprintf("zswap.enabled=1 zswap.compressor=%s zswap.max_pool_percent=%d", z.Compressor, z.MaxPoolPercent)
+}
+
+func colorSwapAction(action string) string {
+	switch action {
+	case "create_swapfile":
+		return fmt.Sprintf("%s%s CREATE SWAPFILE %s", B, BRed, R)
+	case "grow_swap":
+		return fmt.Sprintf("%s%s GROW SWAP %s", B, FBYel, R)
+	case "enable_zswap":
+		return fmt.Sprintf("%s%s ENABLE ZSWAP %s", B, FBYel, R)
+	case "hold":
+		return fmt.Sprintf("%s HOLD %s", FBCyn, R)
+	case "insufficient_data":
+		return fmt.Sprintf("%s INSUFFICIENT DATA %s", FBYel, R)
+	}
+	return action
+}
+
+func renderSwapANSI(rep *swapReport) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\n  %sxtop swap%s — %d-day swap / zswap advice\n\n", B, R, rep.WindowDays)
+
+	fmt.Fprintf(&sb, "  %sCURRENT%s\n", B, R)
+	fmt.Fprintf(&sb, "    %-16s %s\n", "RAM:", fmtBytesShort(rep.MemTotal))
+	if len(rep.Devices) == 0 {
+		fmt.Fprintf(&sb, "    %-16s %snone%s\n", "Swap:", FBYel, R)
+	}
+	for _, d := range rep.Devices {
+		fmt.Fprintf(&sb, "    %-16s %s (%s, %s used, prio %d)\n", "Swap:", d.Path, fmtBytesShort(d.Size), fmtBytesShort(d.Used), d.Priority)
+	}
+	switch {
+	case !rep.Zswap.Available:
+		fmt.Fprintf(&sb, "    %-16s %snot available%s\n", "zswap:", D, R)
+	case rep.Zswap.Enabled:
+		fmt.Fprintf(&sb, "    %-16s on (%s, pool %d%%)\n", "zswap:", rep.Zswap.Compressor, rep.Zswap.MaxPoolPercent)
+	default:
+		fmt.Fprintf(&sb, "    %-16s off\n", "zswap:")
+	}
+	if rep.Zram {
+		fmt.Fprintf(&sb, "    %-16s in use as swap\n", "zram:")
+	}
+	fmt.Fprintf(&sb, "    %-16s %s hot · %s inactive anon\n\n", "Working set:", fmtBytesShort(rep.HotSet), fmtBytesShort(rep.ColdAnon))
+
+	fmt.Fprintf(&sb, "  %sPRESSURE%s\n", B, R)
+	fmt.Fprintf(&sb, "    %-16s %d of %d minutes (%s of data) on %d day(s)\n", "Hot minutes:",
+		rep.PressureMinutes, rep.Minutes, fmtMinutes(rep.Minutes), rep.PressureDays)
+	fmt.Fprintf(&sb, "    %-16s peak %.0f%% · p95 %.0f%%\n", "Memory used:", rep.PeakMemPct, rep.P95MemPct)
+	if rep.PeakSwapPct > 0 {
+		fmt.Fprintf(&sb, "    %-16s peak %.0f%% full\n", "Swap used:", rep.PeakSwapPct)
+	}
+
+	fmt.Fprintf(&sb, "\n  %sRECOMMENDATION%s  %s\n", B, R, colorSwapAction(rep.Action))
+	for _, r := range rep.Reasoning {
+		fmt.Fprintf(&sb, "    - %s\n", r)
+	}
+	if steps := swapSteps(rep, true); len(steps) > 0 {
+		sb.WriteString("\n")
+		for _, s := range steps {
+			fmt.Fprintf(&sb, "    %s$ %s%s\n", D, s, R)
+		}
+		if rep.ZswapPlan != nil {
+			fmt.Fprintf(&sb, "    %sPersist zswap via the kernel command line: %s%s\n", FCyn, zswapCmdline(rep.ZswapPlan), R)
+		}
+		fmt.Fprintf(&sb, "\n    Run %ssudo xtop swap --apply%s to do this after a confirmation.\n", B, R)
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func renderSwapMarkdown(rep *swapReport) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# xtop swap advice — %d-day window\n\n", rep.WindowDays)
+	fmt.Fprintf(&sb, "- RAM: %s · swap: %s (%d device(s)) · zswap: %s\n",
+		fmtBytesShort(rep.MemTotal), fmtBytesShort(rep.SwapTotal), len(rep.Devices), map[bool]string{true: "on", false: "off"}[rep.Zswap.Enabled])
+	fmt.Fprintf(&sb, "- Working set: %s hot, %s inactive anon\n", fmtBytesShort(rep.HotSet), fmtBytesShort(rep.ColdAnon))
+	fmt.Fprintf(&sb, "- Memory pressure: %d of %d minutes on %d day(s); peak %.0f%%, p95 %.0f%%\n\n",
+		rep.PressureMinutes, rep.Minutes, rep.PressureDays, rep.PeakMemPct, rep.P95MemPct)
+	fmt.Fprintf(&sb, "## Recommendation: %s\n\n", strings.ReplaceAll(rep.Action, "_", " "))
+	for _, r := range rep.Reasoning {
+		fmt.Fprintf(&sb, "- %s\n", r)
+	}
+	if steps := swapSteps(rep, true); len(steps) > 0 {
+		sb.WriteString("\n```sh\n" + strings.Join(steps, "\n") + "\n```\n")
+		if rep.ZswapPlan != nil {
+			fmt.Fprintf(&sb, "\nPersist zswap with the kernel command line `%s`.\n", zswapCmdline(rep.ZswapPlan))
+		}
+	}
+	return sb.String()
+}
+
+// ── Apply ────────────────────────────────────────────────────────────────────
+
+const (
+	fsMagicBtrfs = 0x9123683e
+	fsMagicZFS   = 0x2fc12fc1
+	fsMagicTmpfs = 0x01021994
+)
+
+func applySwapPlan(rep *swapReport, yes, fstab bool) error {
+	steps := swapSteps(rep, fstab)
+	if len(steps) == 0 {
+		fmt.Println("Nothing to apply.")
+		return nil
+	}
+	if os.Geteuid() != 0 {
+		return fmt.Errorf("--apply needs root (sudo xtop swap --apply)")
+	}
+	if sf := rep.Swapfile; sf != nil {
+		if err := checkSwapfileTarget(sf); err != nil {
+			return err
+		}
+	}
+
+	if !yes {
+		// The prompt goes to stderr so it stays visible with stdout redirected.
+		fmt.Fprintln(os.Stderr, "  About to run:")
+		for _, s := range steps {
+			fmt.Fprintf(os.Stderr, "    %s\n", s)
+		}
+		fmt.Fprint(os.Stderr, "  Proceed? [y/N]: ")
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(line)) != "y" {
+			return fmt.Errorf("aborted")
+		}
+	}
+
+	if sf := rep.Swapfile; sf != nil {
+		if err := createSwapfile(sf.Path, sf.Size); err != nil {
+			return err
+		}
+		fmt.Printf("  %s✓%s swapfile %s (%s) active\n", FBGrn, R, sf.Path, fmtBytesShort(sf.Size))
+		if fstab {
+			if err := addFstabSwap("/etc/fstab", sf.Path); err != nil {
+				return err
+			}
+			fmt.Printf("  %s✓%s /etc/fstab updated\n", FBGrn, R)
+		}
+	}
+	if z := rep.ZswapPlan; z != nil {
+		dir := "/sys/module/zswap/parameters"
+		for _, kv := range [][2]string{
+			{"compressor", z.Compressor},
+			{"max_pool_percent", strconv.Itoa(z.MaxPoolPercent)},
+			{"enabled", "Y"},
+		} {
+			if err := os.WriteFile(filepath.Join(dir, kv[0]), []byte(kv[1]), 0644); err != nil {
+				return fmt.Errorf("zswap %s: %w", kv[0], err)
+			}
+		}
+		fmt.Printf("  %s✓%s zswap enabled (%s, pool %d%%) — add '%s' to the kernel command line to persist\n",
+			FBGrn, R, z.Compressor, z.MaxPoolPercent, zswapCmdline(z))
+	}
+	return nil
+}
+
+// checkSwapfileTarget refuses filesystems where a plain fallocate'd
+// swapfile does not work, and targets without room to spare.
+func checkSwapfileTarget(sf *swapfilePlan) error {
+	if _, err := os.Stat(sf.Path); err == nil {
+		return fmt.Errorf("%s already exists — pass --path to choose another location", sf.Path)
+	}
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(filepath.Dir(sf.Path), &st); err != nil {
+		return fmt.Errorf("statfs %s: %w", filepath.Dir(sf.Path), err)
+	}
+	switch int64(st.Type) {
+	case fsMagicBtrfs:
+		return fmt.Errorf("%s is on btrfs — create it with 'btrfs filesystem mkswapfile --size %dG %s' instead",
+			sf.Path, sf.Size>>30, sf.Path)
+	case fsMagicZFS:
+		return fmt.Errorf("%s is on ZFS, which does not support swapfiles — use a zvol or another filesystem", sf.Path)
+	case fsMagicTmpfs:
+		return fmt.Errorf("%s is on tmpfs — pick a disk-backed --path", sf.Path)
+	}
+	free := st.Bavail * uint64(st.Bsize)
+	if free < sf.Size+(1<<30) {
+		return fmt.Errorf("%s has %s free; need %s plus 1 GiB headroom", filepath.Dir(sf.Path),
+			fmtBytesShort(free), fmtBytesShort(sf.Size))
+	}
+	return nil
+}
+
+func createSwapfile(path string, size uint64) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err := syscall.Fallocate(int(f.Fd()), 0, 0, int64(size)); err != nil {
+		f.Close()
+		os.Remove(path)
+		return fmt.Errorf("fallocate %s: %w", path, err)
+	}
+	f.Close()
+	for _, argv := range [][]string{{"mkswap", path}, {"swapon", path}} {
+		if out, err := exec.Command(argv[0], argv[1:]...).CombinedOutput(); err != nil {
+			if argv[0] == "mkswap" {
+				os.Remove(path)
+			}
+			return fmt.Errorf("%s: %v: %s", strings.Join(argv, " "), err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// addFstabSwap appends a swap entry for path unless one exists.
+func addFstabSwap(fstab, path string) error {
+	data, err := os.ReadFile(fstab)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		f := strings.Fields(line)
+		if len(f) >= 3 && f[0] == path && f[2] == "swap" {
+			return nil
+		}
+	}
+	prefix := ""
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		prefix = "\n"
+	}
+	fh, err := os.OpenFile(fstab, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+	_, err = fmt.Fprintf(fh, "%s%s none swap sw 0 0\n", prefix, path)
+	return err
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/model"
+)
+
+// swapRollups returns n minutes of history, the first hot of them at 95 %.
+func swapRollups(n, hot int) []engine.UsageRollup {
+	start := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	out := make([]engine.UsageRollup, n)
+	for i := range out {
+		out[i].Minute = start.Add(time.Duration(i) * time.Minute)
+		out[i].Mem.Max = 60
+		if i < hot {
+			out[i].Mem.Max = 95
+		}
+	}
+	return out
+}
+
+func TestBuildSwapReport(t *testing.T) {
+	const gib = 1 << 30
+	mem := model.MemoryMetrics{Total: 16 * gib, InactiveAnon: 3 * gib, ActiveAnon: 8 * gib}
+	zswapOff := zswapState{Available: true, Compressor: "lzo", MaxPoolPercent: 20}
+	target := filepath.Join(t.TempDir(), "swapfile")
+
+	// No swap, pressure in 120 of 2000 minutes → create a swapfile sized
+	// from max(3 GiB cold anon, 10 % of 16 GiB overflow) × 1.25 → 4 GiB.
+	rep := buildSwapReport(&swapInputs{Mem: mem, Zswap: zswapOff, Rollups: swapRollups(2000, 120), Days: 7}, target)
+	if rep.Action != "create_swapfile" || rep.Swapfile == nil || rep.Swapfile.Size != 4*gib || rep.Swapfile.Path != target {
+		t.Fatalf("report = %+v swapfile %+v", rep, rep.Swapfile)
+	}
+	if rep.ZswapPlan == nil || rep.ZswapPlan.Compressor != "zstd" || rep.ZswapPlan.MaxPoolPercent != 12 {
+		t.Errorf("zswap plan = %+v", rep.ZswapPlan)
+	}
+	steps := strings.Join(swapSteps(rep, false), "\n")
+	if !strings.Contains(steps, "fallocate -l 4G "+target) || strings.Contains(steps, "fstab") ||
+		!strings.Contains(steps, "echo 12 > /sys/module/zswap/parameters/max_pool_percent") {
+		t.Errorf("steps:\n%s", steps)
+	}
+
+	// Rare pressure → hold, no plan.
+	rep = buildSwapReport(&swapInputs{Mem: mem, Rollups: swapRollups(10000, 5), Days: 7}, target)
+	if rep.Action != "hold" || rep.Swapfile != nil || rep.ZswapPlan != nil {
+		t.Errorf("rare pressure: %+v", rep)
+	}
+
+	// 1 GiB swap that the sa log shows filling up → grow by the difference.
+	mem.SwapTotal = gib
+	sa := []engine.SARecord{{Time: time.Unix(1775000000, 0), SwapMB: 950, Mem: 70}}
+	in := &swapInputs{Mem: mem, Swaps: []swapDevice{{Path: target, Size: gib}}, Rollups: swapRollups(2000, 120), SA: sa, Days: 7}
+	rep = buildSwapReport(in, target)
+	if rep.Action != "grow_swap" || rep.Swapfile == nil || rep.Swapfile.Size != 3*gib || rep.Swapfile.Path == target {
+		t.Errorf("grow: %+v swapfile %+v", rep, rep.Swapfile)
+	}
+	if rep.PeakSwapPct < 92 || rep.PeakSwapPct > 93 {
+		t.Errorf("peak swap = %.1f", rep.PeakSwapPct)
+	}
+
+	// No history at all.
+	rep = buildSwapReport(&swapInputs{Mem: mem, Days: 7}, target)
+	if rep.Action != "insufficient_data" {
+		t.Errorf("no data: %s", rep.Action)
+	}
+}
+
+func TestSwapPressureStatsMinuteUnion(t *testing.T) {
+	start := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC) // as swapRollups
+	at := func(min, sec int) time.Time {
+		return start.Add(time.Duration(min)*time.Minute + time.Duration(sec)*time.Second)
+	}
+	in := &swapInputs{
+		Rollups: swapRollups(10, 2), // minutes 0-9, 0 and 1 hot
+		SA: []engine.SARecord{
+			{Time: at(0, 10), Mem: 50, PSIMem: 40}, // already hot in the rollups
+			{Time: at(1, 10), Mem: 95},             // likewise
+			{Time: at(5, 0), Mem: 50},              // calm...
+			{Time: at(5, 30), Mem: 50, PSIMem: 40}, // ...then PSI in the same minute
+			{Time: at(20, 0), Mem: 95},             // outside the rollups
+			{Time: at(20, 30), Mem: 95},
+		},
+	}
+	var rep swapReport
+	swapPressureStats(&rep, in)
+	if rep.Minutes != 11 || rep.PressureMinutes != 4 || rep.PressureDays != 1 {
+		t.Errorf("minutes = %d, pressure = %d, days = %d; want 11, 4, 1", rep.Minutes, rep.PressureMinutes, rep.PressureDays)
+	}
+}
+
+func TestReadProcSwapsAndFstab(t *testing.T) {
+	dir := t.TempDir()
+	swaps := filepath.Join(dir, "swaps")
+	os.WriteFile(swaps, []byte("Filename\tType\tSize\tUsed\tPriority\n/swapfile file 2097148 1024 -2\n"), 0o644)
+	devs := readProcSwaps(swaps)
+	if len(devs) != 1 || devs[0].Size != 2097148*1024 || devs[0].Priority != -2 {
+		t.Errorf("devs = %+v", devs)
+	}
+
+	fstab := filepath.Join(dir, "fstab")
+	os.WriteFile(fstab, []byte("UUID=x / ext4 defaults 0 1"), 0o644)
+	for i := 0; i < 2; i++ {
+		if err := addFstabSwap(fstab, "/swapfile"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, _ := os.ReadFile(fstab)
+	if string(data) != "UUID=x / ext4 defaults 0 1\n/swapfile none swap sw 0 0\n" {
+		t.Errorf("fstab = %q", data)
+	}
+}
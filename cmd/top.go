@@ -8,6 +8,7 @@ import (
 
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // runTop implements the `xtop top` subcommand.
@@ -87,7 +88,7 @@ func runTop(args []string) error {
 		health = result.Health
 	}
 	fmt.Printf("\n  %sxtop top%s — %s  %s\n\n",
-		B, R, snap.Timestamp.Format("15:04:05"), healthColor(health))
+		B, R, util.FmtTime(snap.Timestamp, "15:04:05"), healthColor(health))
 
 	// Build table
 	headers := []string{"RANK", "PID", "SERVICE", "CPU%", "RSS", "IO(w)", "THREADS", "IMPACT"}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // ── ANSI color/style codes ──────────────────────────────────────────────────
@@ -310,7 +311,7 @@ func runWatch(ticker engine.Ticker, cfg Config) error {
 			fmt.Print("\033[2J\033[H")
 
 			// Title bar
-			ts := snap.Timestamp.Format("15:04:05")
+			ts := util.FmtTime(snap.Timestamp, "15:04:05")
 			iter := fmt.Sprintf("#%d", iteration)
 			if cfg.WatchCount > 0 {
 				iter = fmt.Sprintf("#%d/%d", iteration, cfg.WatchCount)
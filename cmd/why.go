@@ -9,6 +9,7 @@ import (
 
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // runWhy implements the `xtop why` subcommand.
@@ -51,7 +52,7 @@ func runWhy(args []string) error {
 // whyANSI renders the RCA summary with ANSI colors.
 func whyANSI(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult) error {
 	fmt.Println()
-	fmt.Printf("  %sxtop why%s — %s\n", B, R, snap.Timestamp.Format("2006-01-02 15:04:05"))
+	fmt.Printf("  %sxtop why%s — %s\n", B, R, util.FmtTime(snap.Timestamp, "2006-01-02 15:04:05"))
 	fmt.Println()
 
 	// 1. HEALTH
@@ -161,8 +162,8 @@ func whyANSI(snap *model.Snapshot, rates *model.RateSnapshot, result *model.Anal
 	// 4. TOP OFFENDER
 	if len(result.Blame) > 0 {
 		top := result.Blame[0]
-		fmt.Printf("  %sTOP OFFENDER:%s  %s%s%s (PID %d)\n",
-			B, R, FBYel, top.Comm, R, top.PID)
+		fmt.Printf("  %sTOP OFFENDER:%s  %s%s%s (PID %d)  %s%.0f%% of load, confidence %d%%%s\n",
+			B, R, FBYel, top.Comm, R, top.PID, D, top.SharePct, int(top.Confidence*100), R)
 		// Show metrics
 		var metricParts []string
 		for k, v := range top.Metrics {
@@ -239,6 +240,9 @@ func whyJSON(snap *model.Snapshot, rates *model.RateSnapshot, result *model.Anal
 	if len(result.Blame) > 0 {
 		out["top_offender"] = result.Blame[0]
 	}
+	if len(result.BlameDomains) > 0 {
+		out["blame"] = result.BlameDomains
+	}
 	if len(result.Actions) > 0 {
 		n := 3
 		if len(result.Actions) < n {
@@ -259,7 +263,7 @@ func whyJSON(snap *model.Snapshot, rates *model.RateSnapshot, result *model.Anal
 func whyMarkdown(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult) error {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("# xtop why — %s\n\n", snap.Timestamp.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("# xtop why — %s\n\n", util.FmtTime(snap.Timestamp, "2006-01-02 15:04:05")))
 	sb.WriteString(fmt.Sprintf("**Health:** %s (confidence %d%%)\n\n", result.Health, result.Confidence))
 
 	if result.Health == model.HealthOK {
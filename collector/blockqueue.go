@@ -0,0 +1,196 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/advice"
+	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
+)
+
+// blockQueueInterval is how often queue settings are re-read. They only
+// change when someone tunes them, so a minute is plenty.
+const blockQueueInterval = time.Minute
+
+// BlockQueueCollector reads each block device's scheduler, nr_requests,
+// read_ahead_kb and rotational flag from sysfs and flags settings that do
+// not match the hardware (bfq on NVMe, no scheduler on a spinning disk, a
+// shallow queue on an HDD RAID member).
+type BlockQueueCollector struct {
+	SysBlock string // defaults to /sys/block; tests point it elsewhere
+	last     time.Time
+	cached   []model.BlockQueue
+}
+
+func (b *BlockQueueCollector) Name() string { return "blockqueue" }
+
+func (b *BlockQueueCollector) Collect(snap *model.Snapshot) error {
+	if b.cached == nil || time.Since(b.last) >= blockQueueInterval {
+		root := b.SysBlock
+		if root == "" {
+			root = "/sys/block"
+		}
+		b.cached = readBlockQueues(root)
+		b.last = time.Now()
+	}
+	snap.Global.BlockQueues = b.cached
+	return nil
+}
+
+// RescanDevices drops the cached queue settings so a hot-plugged disk is
+// picked up (and a removed one dropped) on the next tick.
+func (b *BlockQueueCollector) RescanDevices() { b.cached = nil }
+
+// readBlockQueues reads every disk-like device under root. Loop, ram,
+// zram and optical devices are skipped: their queue knobs mean nothing.
+func readBlockQueues(root string) []model.BlockQueue {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return []model.BlockQueue{}
+	}
+	out := []model.BlockQueue{}
+	for _, e := range entries {
+		transport := blockTransport(e.Name())
+		if transport == "" {
+			continue
+		}
+		dir := filepath.Join(root, e.Name())
+		q := model.BlockQueue{
+			Device:      e.Name(),
+			Transport:   transport,
+			NrRequests:  readSysInt(filepath.Join(dir, "queue", "nr_requests")),
+			ReadAheadKB: readSysInt(filepath.Join(dir, "queue", "read_ahead_kb")),
+			Rotational:  readSysInt(filepath.Join(dir, "queue", "rotational")) == 1,
+			RAID:        blockRAID(dir),
+		}
+		if raw, err := util.ReadFileString(filepath.Join(dir, "queue", "scheduler")); err == nil {
+			q.Scheduler, q.Schedulers = parseSchedulers(raw)
+		}
+		adviseBlockQueue(&q)
+		out = append(out, q)
+	}
+	return out
+}
+
+// blockTransport classifies a /sys/block entry by name; "" means skip.
+func blockTransport(name string) string {
+	switch {
+	case strings.HasPrefix(name, "nvme"):
+		return "nvme"
+	case strings.HasPrefix(name, "vd"):
+		return "virtio"
+	case strings.HasPrefix(name, "xvd"):
+		return "xen"
+	case strings.HasPrefix(name, "md"):
+		return "md"
+	case strings.HasPrefix(name, "dm-"):
+		return "dm"
+	case strings.HasPrefix(name, "sd"), strings.HasPrefix(name, "hd"), strings.HasPrefix(name, "mmcblk"):
+		return "scsi"
+	}
+	return ""
+}
+
+// blockRAID names the md array holding dir's device, or returns "hw" when
+// the device is a volume exported by a hardware RAID controller.
+func blockRAID(dir string) string {
+	if holders, err := os.ReadDir(filepath.Join(dir, "holders")); err == nil {
+		for _, h := range holders {
+			if strings.HasPrefix(h.Name(), "md") {
+				return h.Name()
+			}
+		}
+	}
+	vendor, _ := util.ReadFileString(filepath.Join(dir, "device", "vendor"))
+	mdl, _ := util.ReadFileString(filepath.Join(dir, "device", "model"))
+	id := strings.ToUpper(vendor + " " + mdl)
+	for _, sig := range []string{"RAID", "PERC", "LOGICAL VOLUME", "SMART ARRAY", "MR9"} {
+		if strings.Contains(id, sig) {
+			return "hw"
+		}
+	}
+	return ""
+}
+
+// parseSchedulers splits "mq-deadline kyber [bfq] none" into the active
+// scheduler and the available list.
+func parseSchedulers(raw string) (string, []string) {
+	var active string
+	var all []string
+	for _, f := range strings.Fields(raw) {
+		if strings.HasPrefix(f, "[") && strings.HasSuffix(f, "]") {
+			f = strings.Trim(f, "[]")
+			active = f
+		}
+		all = append(all, f)
+	}
+	return active, all
+}
+
+func readSysInt(path string) int {
+	s, err := util.ReadFileString(path)
+	if err != nil {
+		return 0
+	}
+	v, _ := strconv.Atoi(strings.TrimSpace(s))
+	return v
+}
+
+// adviseBlockQueue fills q.Advice. Virtual disks often claim to be
+// rotational whatever backs them, so the spinning-disk rules skip them.
+func adviseBlockQueue(q *model.BlockQueue) {
+	q.Advice = nil
+	virtual := q.Transport == "virtio" || q.Transport == "xen"
+	offers := func(s string) bool { return slices.Contains(q.Schedulers, s) }
+	add := func(setting, current, suggested, sev, adviceID string, args ...any) {
+		q.Advice = append(q.Advice, model.BlockQueueAdvice{
+			Setting:   setting,
+			Current:   current,
+			Suggested: suggested,
+			Severity:  sev,
+			AdviceID:  adviceID,
+			Reason:    advice.Text(adviceID, args...),
+			Fix:       fmt.Sprintf("echo %s > /sys/block/%s/queue/%s", suggested, q.Device, setting),
+			Udev:      fmt.Sprintf(`ACTION=="add|change", KERNEL=="%s", ATTR{queue/%s}="%s"`, q.Device, setting, suggested),
+		})
+	}
+
+	switch {
+	case q.Scheduler == "" || q.Transport == "md" || q.Transport == "dm":
+		// bio-based stacking devices: the members' queues are what count
+	case q.Transport == "nvme" && q.Scheduler != "none" && offers("none"):
+		sev := "info"
+		if q.Scheduler == "bfq" {
+			sev = "warn"
+		}
+		add("scheduler", q.Scheduler, "none", sev, "doctor.blockq.sched_nvme", q.Device, q.Scheduler)
+	case virtual && q.Scheduler == "bfq" && offers("none"):
+		add("scheduler", q.Scheduler, "none", "info", "doctor.blockq.sched_virt", q.Device)
+	case !virtual && q.Rotational && q.Scheduler == "none" && offers("mq-deadline"):
+		add("scheduler", q.Scheduler, "mq-deadline", "warn", "doctor.blockq.sched_hdd", q.Device)
+	case !virtual && !q.Rotational && q.Scheduler == "bfq" && offers("mq-deadline"):
+		add("scheduler", q.Scheduler, "mq-deadline", "info", "doctor.blockq.sched_ssd", q.Device)
+	}
+
+	if q.Rotational && q.RAID != "" && q.NrRequests > 0 && q.NrRequests < 128 {
+		role := "member of " + q.RAID
+		if q.RAID == "hw" {
+			role = "hardware RAID volume"
+		}
+		add("nr_requests", strconv.Itoa(q.NrRequests), "256", "warn", "doctor.blockq.nr_raid", q.Device, role, q.NrRequests)
+	}
+
+	switch {
+	case q.ReadAheadKB <= 0 || virtual:
+	case q.Rotational && q.ReadAheadKB < 128:
+		add("read_ahead_kb", strconv.Itoa(q.ReadAheadKB), "128", "info", "doctor.blockq.ra_hdd", q.Device, q.ReadAheadKB)
+	case !q.Rotational && q.ReadAheadKB > 1024:
+		add("read_ahead_kb", strconv.Itoa(q.ReadAheadKB), "128", "info", "doctor.blockq.ra_ssd", q.Device, q.ReadAheadKB)
+	}
+}
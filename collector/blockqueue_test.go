@@ -0,0 +1,80 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func writeSysBlock(t *testing.T, root, dev string, files map[string]string) {
+	t.Helper()
+	for name, val := range files {
+		p := filepath.Join(root, dev, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(val+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBlockQueueAdvice(t *testing.T) {
+	root := t.TempDir()
+	writeSysBlock(t, root, "nvme0n1", map[string]string{
+		"queue/scheduler":     "mq-deadline kyber [bfq] none",
+		"queue/nr_requests":   "1023",
+		"queue/read_ahead_kb": "128",
+		"queue/rotational":    "0",
+	})
+	writeSysBlock(t, root, "sdb", map[string]string{
+		"queue/scheduler":     "[mq-deadline] none",
+		"queue/nr_requests":   "64",
+		"queue/read_ahead_kb": "128",
+		"queue/rotational":    "1",
+	})
+	if err := os.MkdirAll(filepath.Join(root, "sdb", "holders", "md0"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeSysBlock(t, root, "vda", map[string]string{
+		"queue/scheduler":     "[none] mq-deadline",
+		"queue/nr_requests":   "256",
+		"queue/read_ahead_kb": "16",
+		"queue/rotational":    "1",
+	})
+	writeSysBlock(t, root, "loop0", map[string]string{"queue/scheduler": "[none]"})
+
+	c := &BlockQueueCollector{SysBlock: root}
+	snap := &model.Snapshot{}
+	if err := c.Collect(snap); err != nil {
+		t.Fatal(err)
+	}
+	byDev := map[string]model.BlockQueue{}
+	for _, q := range snap.Global.BlockQueues {
+		byDev[q.Device] = q
+	}
+	if _, ok := byDev["loop0"]; ok {
+		t.Error("loop devices should be skipped")
+	}
+
+	nvme := byDev["nvme0n1"]
+	if nvme.Scheduler != "bfq" || len(nvme.Advice) != 1 {
+		t.Fatalf("nvme0n1 = %+v, want one scheduler advice", nvme)
+	}
+	if a := nvme.Advice[0]; a.Suggested != "none" || a.Severity != "warn" || a.Fix != "echo none > /sys/block/nvme0n1/queue/scheduler" {
+		t.Errorf("nvme0n1 advice = %+v", a)
+	}
+
+	sdb := byDev["sdb"]
+	if sdb.RAID != "md0" || len(sdb.Advice) != 1 || sdb.Advice[0].Setting != "nr_requests" || sdb.Advice[0].Suggested != "256" {
+		t.Errorf("sdb = %+v, want nr_requests advice for md0 member", sdb)
+	}
+
+	// virtio disks report rotational=1 regardless of backing store; no
+	// spinning-disk advice should fire for them.
+	if vda := byDev["vda"]; len(vda.Advice) != 0 {
+		t.Errorf("vda advice = %+v, want none", vda.Advice)
+	}
+}
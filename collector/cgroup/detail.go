@@ -0,0 +1,232 @@
+package cgroup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
+)
+
+// ReadDetail reads the extended metrics for one cgroup (relPath as stored
+// in CgroupMetrics.Path). It is called on demand by the detail view, not
+// on every tick, so it may read files the tree walk deliberately skips.
+func ReadDetail(relPath string) (model.CgroupDetail, error) {
+	switch DetectVersion() {
+	case V2, Hybrid:
+		return readV2Detail(CgroupRoot(), relPath)
+	default:
+		return readV1Detail("/sys/fs/cgroup", relPath)
+	}
+}
+
+// readV2Detail reads detail metrics from a cgroup v2 directory under root.
+func readV2Detail(root, relPath string) (model.CgroupDetail, error) {
+	d := model.CgroupDetail{Path: relPath, Timestamp: time.Now()}
+	cgDir := filepath.Join(root, filepath.Clean("/"+relPath))
+	if _, err := os.Stat(cgDir); err != nil {
+		return d, fmt.Errorf("cgroup %s: %w", relPath, err)
+	}
+
+	// cpu.max: "$MAX $PERIOD" ("max 100000" = unlimited)
+	if s, err := util.ReadFileString(filepath.Join(cgDir, "cpu.max")); err == nil {
+		fields := strings.Fields(s)
+		if len(fields) == 2 {
+			if fields[0] != "max" {
+				d.CPUQuotaUsec = util.ParseUint64(fields[0])
+			}
+			d.CPUPeriodUsec = util.ParseUint64(fields[1])
+		}
+	}
+	if s, err := util.ReadFileString(filepath.Join(cgDir, "cpu.weight")); err == nil {
+		d.CPUWeight = util.ParseUint64(strings.TrimSpace(s))
+	}
+
+	d.MemMin = readLimitFile(filepath.Join(cgDir, "memory.min"))
+	d.MemLow = readLimitFile(filepath.Join(cgDir, "memory.low"))
+	d.MemHigh = readLimitFile(filepath.Join(cgDir, "memory.high"))
+	d.MemMax = readLimitFile(filepath.Join(cgDir, "memory.max"))
+
+	d.PSI.CPU = readPSIFile(filepath.Join(cgDir, "cpu.pressure"))
+	d.PSI.Memory = readPSIFile(filepath.Join(cgDir, "memory.pressure"))
+	d.PSI.IO = readPSIFile(filepath.Join(cgDir, "io.pressure"))
+
+	if kv, err := util.ParseKeyValueFile(filepath.Join(cgDir, "memory.events")); err == nil {
+		d.MemEvents = parseMemEvents(kv)
+	}
+	if kv, err := util.ParseKeyValueFile(filepath.Join(cgDir, "memory.events.local")); err == nil {
+		local := parseMemEvents(kv)
+		d.MemEventsLocal = &local
+	}
+
+	d.IODevices = readV2IODevices(filepath.Join(cgDir, "io.stat"))
+	d.PIDs = readProcs(filepath.Join(cgDir, "cgroup.procs"))
+	return d, nil
+}
+
+// readV1Detail fills what cgroup v1 can offer: CPU quota, memory limit and
+// members. PSI, per-device IO and memory.events have no v1 equivalent.
+func readV1Detail(root, relPath string) (model.CgroupDetail, error) {
+	d := model.CgroupDetail{Path: relPath, Timestamp: time.Now()}
+	rel := filepath.Clean("/" + relPath)
+
+	memDir := filepath.Join(root, "memory", rel)
+	if s, err := util.ReadFileString(filepath.Join(memDir, "memory.limit_in_bytes")); err == nil {
+		if v := util.ParseUint64(strings.TrimSpace(s)); v < 1<<62 {
+			d.MemMax = v
+		}
+	}
+	d.PIDs = readProcs(filepath.Join(memDir, "cgroup.procs"))
+
+	cpuDir := filepath.Join(root, "cpu,cpuacct", rel)
+	if s, err := util.ReadFileString(filepath.Join(cpuDir, "cpu.cfs_quota_us")); err == nil {
+		if q, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil && q > 0 {
+			d.CPUQuotaUsec = uint64(q)
+		}
+	}
+	if s, err := util.ReadFileString(filepath.Join(cpuDir, "cpu.cfs_period_us")); err == nil {
+		d.CPUPeriodUsec = util.ParseUint64(strings.TrimSpace(s))
+	}
+	if d.PIDs == nil {
+		d.PIDs = readProcs(filepath.Join(cpuDir, "cgroup.procs"))
+	}
+	return d, nil
+}
+
+// parseMemEvents maps a memory.events / memory.events.local file.
+func parseMemEvents(kv map[string]string) model.CgroupMemEvents {
+	return model.CgroupMemEvents{
+		Low:          util.ParseUint64(kv["low"]),
+		High:         util.ParseUint64(kv["high"]),
+		Max:          util.ParseUint64(kv["max"]),
+		OOM:          util.ParseUint64(kv["oom"]),
+		OOMKill:      util.ParseUint64(kv["oom_kill"]),
+		OOMGroupKill: util.ParseUint64(kv["oom_group_kill"]),
+	}
+}
+
+// readLimitFile reads a single-value limit file; "max" and errors yield 0.
+func readLimitFile(path string) uint64 {
+	s, err := util.ReadFileString(path)
+	if err != nil {
+		return 0
+	}
+	s = strings.TrimSpace(s)
+	if s == "max" {
+		return 0
+	}
+	return util.ParseUint64(s)
+}
+
+// readPSIFile parses a cgroup *.pressure file. Same format as
+// /proc/pressure/*; missing files (PSI disabled) yield a zero value.
+func readPSIFile(path string) model.PSIResource {
+	var res model.PSIResource
+	lines, err := util.ReadFileLines(path)
+	if err != nil {
+		return res
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		var pl model.PSILine
+		for _, f := range fields[1:] {
+			parts := strings.SplitN(f, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			switch parts[0] {
+			case "avg10":
+				pl.Avg10 = util.ParseFloat64(parts[1])
+			case "avg60":
+				pl.Avg60 = util.ParseFloat64(parts[1])
+			case "avg300":
+				pl.Avg300 = util.ParseFloat64(parts[1])
+			case "total":
+				pl.Total = util.ParseUint64(parts[1])
+			}
+		}
+		switch fields[0] {
+		case "some":
+			res.Some = pl
+		case "full":
+			res.Full = pl
+		}
+	}
+	return res
+}
+
+// readV2IODevices parses io.stat per device, largest total bytes first.
+func readV2IODevices(path string) []model.CgroupIODevice {
+	lines, err := util.ReadFileLines(path)
+	if err != nil {
+		return nil
+	}
+	var devs []model.CgroupIODevice
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		dev := model.CgroupIODevice{Device: blockDevName(fields[0])}
+		for _, f := range fields[1:] {
+			parts := strings.SplitN(f, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			v := util.ParseUint64(parts[1])
+			switch parts[0] {
+			case "rbytes":
+				dev.RBytes = v
+			case "wbytes":
+				dev.WBytes = v
+			case "rios":
+				dev.RIOs = v
+			case "wios":
+				dev.WIOs = v
+			}
+		}
+		devs = append(devs, dev)
+	}
+	sort.Slice(devs, func(i, j int) bool {
+		return devs[i].RBytes+devs[i].WBytes > devs[j].RBytes+devs[j].WBytes
+	})
+	return devs
+}
+
+// blockDevName resolves "MAJ:MIN" to a device name via sysfs.
+func blockDevName(majMin string) string {
+	if data, err := os.ReadFile("/sys/dev/block/" + majMin + "/dm/name"); err == nil {
+		return "dm-" + strings.TrimSpace(string(data))
+	}
+	if data, err := os.ReadFile("/sys/dev/block/" + majMin + "/uevent"); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if strings.HasPrefix(line, "DEVNAME=") {
+				return strings.TrimPrefix(line, "DEVNAME=")
+			}
+		}
+	}
+	return majMin
+}
+
+// readProcs reads the PID list from a cgroup.procs file.
+func readProcs(path string) []int {
+	lines, err := util.ReadFileLines(path)
+	if err != nil {
+		return nil
+	}
+	var pids []int
+	for _, line := range lines {
+		if pid, err := strconv.Atoi(strings.TrimSpace(line)); err == nil && pid > 0 {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
@@ -0,0 +1,61 @@
+package cgroup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadV2Detail(t *testing.T) {
+	root := t.TempDir()
+	cgDir := filepath.Join(root, "system.slice", "nginx.service")
+	if err := os.MkdirAll(cgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"cpu.max":             "50000 100000\n",
+		"cpu.weight":          "100\n",
+		"memory.high":         "max\n",
+		"memory.max":          "536870912\n",
+		"memory.pressure":     "some avg10=1.50 avg60=0.80 avg300=0.10 total=1234\nfull avg10=0.50 avg60=0.20 avg300=0.00 total=99\n",
+		"memory.events":       "low 0\nhigh 12\nmax 3\noom 1\noom_kill 1\noom_group_kill 0\n",
+		"memory.events.local": "low 0\nhigh 12\nmax 3\noom 1\noom_kill 0\noom_group_kill 0\n",
+		"io.stat":             "253:7 rbytes=100 wbytes=200 rios=1 wios=2 dbytes=0 dios=0\n253:9 rbytes=4096 wbytes=8192 rios=3 wios=4\n",
+		"cgroup.procs":        "101\n202\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(cgDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d, err := readV2Detail(root, "/system.slice/nginx.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.CPUQuotaUsec != 50000 || d.CPUPeriodUsec != 100000 || d.CPUWeight != 100 {
+		t.Errorf("cpu limits = %d/%d w=%d", d.CPUQuotaUsec, d.CPUPeriodUsec, d.CPUWeight)
+	}
+	if d.MemHigh != 0 || d.MemMax != 536870912 {
+		t.Errorf("mem high=%d max=%d", d.MemHigh, d.MemMax)
+	}
+	if d.PSI.Memory.Some.Avg10 != 1.5 || d.PSI.Memory.Full.Total != 99 {
+		t.Errorf("memory PSI = %+v", d.PSI.Memory)
+	}
+	if d.MemEvents.High != 12 || d.MemEvents.Max != 3 || d.MemEvents.OOMKill != 1 {
+		t.Errorf("memory.events = %+v", d.MemEvents)
+	}
+	if d.MemEventsLocal == nil || d.MemEventsLocal.OOMKill != 0 || d.MemEventsLocal.High != 12 {
+		t.Errorf("memory.events.local = %+v", d.MemEventsLocal)
+	}
+	if len(d.IODevices) != 2 || d.IODevices[0].WBytes != 8192 {
+		t.Errorf("io devices = %+v, want largest first", d.IODevices)
+	}
+	if len(d.PIDs) != 2 || d.PIDs[1] != 202 {
+		t.Errorf("pids = %v", d.PIDs)
+	}
+
+	if _, err := readV2Detail(root, "/gone.scope"); err == nil {
+		t.Error("expected error for missing cgroup")
+	}
+}
@@ -0,0 +1,144 @@
+package cgroup
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
+)
+
+// IOControl is the IO-controller state of one cgroup v2 directory, read on
+// demand when the engine needs to turn IO contention into concrete
+// io.weight / io.max values.
+type IOControl struct {
+	Weight  int               // io.weight "default" (100 when unset or unreadable)
+	Max     map[string]string // io.max lines keyed by MAJ:MIN ("rbps=max wbps=...")
+	PSI     model.PSIResource // io.pressure
+	Devices []IODeviceStat    // io.stat, largest total bytes first
+}
+
+// IODeviceStat is one io.stat line with the raw MAJ:MIN kept, since the
+// io.max and io.cost.qos files are keyed by it.
+type IODeviceStat struct {
+	MajMin string
+	Name   string // "sda", "dm-root", or MAJ:MIN when unresolved
+	RBytes uint64
+	WBytes uint64
+}
+
+// ReadIOControl reads io.weight, io.max, io.pressure and io.stat for relPath.
+// cgroup v1 has none of these, so the zero value (Weight 100) comes back.
+func ReadIOControl(relPath string) IOControl {
+	if DetectVersion() == V1 {
+		return IOControl{Weight: 100}
+	}
+	return readIOControl(CgroupRoot(), relPath)
+}
+
+func readIOControl(root, relPath string) IOControl {
+	ioc := IOControl{Weight: 100}
+	cgDir := filepath.Join(root, filepath.Clean("/"+relPath))
+
+	// io.weight: "default 100" plus optional "MAJ:MIN N" overrides.
+	if lines, err := util.ReadFileLines(filepath.Join(cgDir, "io.weight")); err == nil {
+		for _, line := range lines {
+			f := strings.Fields(line)
+			if len(f) == 2 && f[0] == "default" {
+				if w := int(util.ParseUint64(f[1])); w > 0 {
+					ioc.Weight = w
+				}
+			}
+		}
+	}
+	if lines, err := util.ReadFileLines(filepath.Join(cgDir, "io.max")); err == nil {
+		for _, line := range lines {
+			f := strings.Fields(line)
+			if len(f) >= 2 {
+				if ioc.Max == nil {
+					ioc.Max = make(map[string]string)
+				}
+				ioc.Max[f[0]] = strings.Join(f[1:], " ")
+			}
+		}
+	}
+	ioc.PSI = readPSIFile(filepath.Join(cgDir, "io.pressure"))
+	ioc.Devices = readV2IODevicesMajMin(filepath.Join(cgDir, "io.stat"))
+	return ioc
+}
+
+// readV2IODevicesMajMin is readV2IODevices keeping MAJ:MIN.
+func readV2IODevicesMajMin(path string) []IODeviceStat {
+	lines, err := util.ReadFileLines(path)
+	if err != nil {
+		return nil
+	}
+	var devs []IODeviceStat
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		d := IODeviceStat{MajMin: fields[0], Name: blockDevName(fields[0])}
+		for _, f := range fields[1:] {
+			parts := strings.SplitN(f, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			switch parts[0] {
+			case "rbytes":
+				d.RBytes = util.ParseUint64(parts[1])
+			case "wbytes":
+				d.WBytes = util.ParseUint64(parts[1])
+			}
+		}
+		devs = append(devs, d)
+	}
+	sort.Slice(devs, func(i, j int) bool {
+		return devs[i].RBytes+devs[i].WBytes > devs[j].RBytes+devs[j].WBytes
+	})
+	return devs
+}
+
+// IOCostEnabled reports whether the io.cost controller is enabled for
+// majMin (root io.cost.qos has "MAJ:MIN enable=1 ..."). supported is false
+// when the kernel was built without CONFIG_BLK_CGROUP_IOCOST.
+func IOCostEnabled(majMin string) (enabled, supported bool) {
+	return ioCostEnabled(CgroupRoot(), majMin)
+}
+
+func ioCostEnabled(root, majMin string) (enabled, supported bool) {
+	lines, err := util.ReadFileLines(filepath.Join(root, "io.cost.qos"))
+	if err != nil {
+		return false, !os.IsNotExist(err)
+	}
+	for _, line := range lines {
+		f := strings.Fields(line)
+		if len(f) > 1 && f[0] == majMin {
+			for _, kv := range f[1:] {
+				if kv == "enable=1" {
+					return true, true
+				}
+			}
+		}
+	}
+	return false, true
+}
+
+// BlockScheduler returns the active IO scheduler for majMin ("mq-deadline",
+// "bfq", "none"), or "" when unknown.
+func BlockScheduler(majMin string) string {
+	data, err := os.ReadFile("/sys/dev/block/" + majMin + "/queue/scheduler")
+	if err != nil {
+		return ""
+	}
+	s := string(data)
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		if j := strings.IndexByte(s[i:], ']'); j > 0 {
+			return s[i+1 : i+j]
+		}
+	}
+	return strings.TrimSpace(s)
+}
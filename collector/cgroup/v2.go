@@ -47,6 +47,13 @@ func readV2Metrics(cgDir string) model.CgroupMetrics {
 		cg.PgMajFault = util.ParseUint64(kv["pgmajfault"]) // not always in events
 	}
 
+	// memory.events.local — same counters without descendants, so an OOM kill
+	// in one container of a pod is not also charged to the pod slice.
+	if kv, err := util.ParseKeyValueFile(filepath.Join(cgDir, "memory.events.local")); err == nil {
+		cg.OOMKillsLocal = util.ParseUint64(kv["oom_kill"])
+		cg.HasLocalEvents = true
+	}
+
 	// memory.stat (alternative source for pgfault)
 	if cg.PgFault == 0 {
 		if kv, err := util.ParseKeyValueFile(filepath.Join(cgDir, "memory.stat")); err == nil {
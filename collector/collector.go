@@ -22,6 +22,12 @@ type Triggerable interface {
 	Trigger()
 }
 
+// DeviceAware is a collector that caches a device inventory (block devices,
+// NICs, addresses) and has to rebuild it when hardware is hot-plugged.
+type DeviceAware interface {
+	RescanDevices()
+}
+
 // CollectorCost holds per-collector cost tracking for the Guardian. Updated
 // in-place by Registry.CollectAll on every tick. Read-only from outside the
 // registry. EWMA values use alpha=0.2 (last 5 ticks contribute most weight).
@@ -69,6 +75,17 @@ func (r *Registry) TriggerByName(name string) {
 	}
 }
 
+// RescanDevices tells every DeviceAware collector that disks, NICs or CPUs
+// came or went, so cached inventories are rebuilt on the next Collect
+// instead of keeping ghost devices or missing new ones until restart.
+func (r *Registry) RescanDevices() {
+	for _, c := range r.collectors {
+		if d, ok := c.(DeviceAware); ok {
+			d.RescanDevices()
+		}
+	}
+}
+
 // Mode controls the collector allowlist at engine construction time.
 //
 //	Rich — TUI / interactive use. All 21 built-in collectors registered,
@@ -109,7 +126,9 @@ func richCollectors() []Collector {
 		&PSICollector{},
 		&CPUCollector{},
 		&MemoryCollector{},
+		&OOMScoreCollector{},
 		&DiskCollector{},
+		&BlockQueueCollector{},
 		&NetworkCollector{},
 		&SocketCollector{},
 		&SoftIRQCollector{},
@@ -125,6 +144,7 @@ func richCollectors() []Collector {
 		&HealthCheckCollector{},
 		&DiagCollector{interval: 15 * time.Second, firstTick: true},
 		&ProxmoxCollector{},
+		&GuestVMCollector{},
 		&GPUCollector{},
 	}
 }
@@ -143,6 +163,7 @@ func leanCollectors() []Collector {
 		&FilesystemCollector{},          // statfs per mount
 		&ProcessCollector{MaxProcs: 30}, // tight cap; hub has full history
 		&IdentityCollector{},            // cached
+		&GuestVMCollector{},             // hypervisors: per-guest CPU for steal attribution
 		// Deliberately excluded in lean: socket/softirq/sysctl/security/
 		// logs/healthcheck/diag/proxmox/gpu/deletedopen/fileless/bigfile.
 		// The RCA engine treats missing signals as "no evidence for that
@@ -2,6 +2,7 @@ package collector
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/ftahirops/xtop/model"
@@ -27,12 +28,20 @@ func (c *CPUCollector) collectStat(snap *model.Snapshot) error {
 	}
 
 	var perCPU []model.CPUTimes
+	var ids []int
 	for _, line := range lines {
 		switch {
 		case strings.HasPrefix(line, "cpu "):
 			snap.Global.CPU.Total = parseCPULine(line)
 		case strings.HasPrefix(line, "cpu"):
 			perCPU = append(perCPU, parseCPULine(line))
+			// Offline cores are missing from /proc/stat, so the slice
+			// index is not the CPU number once cores are hot-plugged.
+			id, err := strconv.Atoi(strings.TrimPrefix(strings.Fields(line)[0], "cpu"))
+			if err != nil {
+				id = len(ids)
+			}
+			ids = append(ids, id)
 		case strings.HasPrefix(line, "ctxt "):
 			// "ctxt N" — total context switches since boot. The
 			// canonical kernel counter; used by rates.go to compute
@@ -44,6 +53,7 @@ func (c *CPUCollector) collectStat(snap *model.Snapshot) error {
 		}
 	}
 	snap.Global.CPU.PerCPU = perCPU
+	snap.Global.CPU.CPUIDs = ids
 	snap.Global.CPU.NumCPUs = len(perCPU)
 	return nil
 }
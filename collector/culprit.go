@@ -0,0 +1,86 @@
+package collector
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// Size caps for a culprit capture. The kernel doesn't cap cmdline at all
+// (a JVM with a long classpath runs to hundreds of KB) and environ can be
+// just as large; exports only need enough to identify the invocation.
+const (
+	culpritMaxArgsBytes = 32 << 10
+	culpritMaxEnvBytes  = 16 << 10
+)
+
+// CaptureCulprit reads the full command line — and, if withEnv, the
+// environment — of pid, masking secret-looking values. Reading another
+// user's environ needs root (or CAP_SYS_PTRACE); without it Env is left
+// empty rather than failing the capture.
+func CaptureCulprit(pid int, withEnv bool) (*model.CulpritCapture, error) {
+	procDir := fmt.Sprintf("/proc/%d", pid)
+	raw, argsCut, err := readNulList(filepath.Join(procDir, "cmdline"), culpritMaxArgsBytes)
+	if err != nil {
+		return nil, fmt.Errorf("process %d: %w", pid, err)
+	}
+
+	c := &model.CulpritCapture{PID: pid, Truncated: argsCut, CapturedAt: time.Now()}
+	if comm, err := os.ReadFile(filepath.Join(procDir, "comm")); err == nil {
+		c.Comm = strings.TrimSpace(string(comm))
+	}
+	if exe, err := os.Readlink(filepath.Join(procDir, "exe")); err == nil {
+		c.Exe = exe
+	}
+	if len(raw) == 0 {
+		// Kernel thread or zombie: nothing to run, keep the name.
+		raw = []string{"[" + c.Comm + "]"}
+	}
+
+	r := newRedactor()
+	var n int
+	c.Args, n = r.Args(raw)
+	c.Redacted += n
+
+	if withEnv {
+		if env, envCut, err := readNulList(filepath.Join(procDir, "environ"), culpritMaxEnvBytes); err == nil {
+			sort.Strings(env)
+			c.Env, n = r.Env(env)
+			c.Redacted += n
+			c.Truncated = c.Truncated || envCut
+		}
+	}
+	return c, nil
+}
+
+// readNulList reads a NUL-separated /proc list, at most max bytes. A
+// partial trailing entry is dropped when the cap is hit.
+func readNulList(path string, max int) ([]string, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, int64(max)+1))
+	if err != nil {
+		return nil, false, err
+	}
+	truncated := len(data) > max
+	if truncated {
+		data = data[:max]
+		if i := strings.LastIndexByte(string(data), 0); i >= 0 {
+			data = data[:i]
+		}
+	}
+	s := strings.TrimRight(string(data), "\x00")
+	if s == "" {
+		return nil, truncated, nil
+	}
+	return strings.Split(s, "\x00"), truncated, nil
+}
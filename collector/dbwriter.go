@@ -0,0 +1,460 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// ─── Database writer attribution ────────────────────────────────────────────
+//
+// When a database is the IO culprit, "mysqld is writing" is not actionable;
+// the statement doing the writing is. CorrelateDBWriter asks the database
+// for its running statements through the same CLI clients the service
+// diagnostics use, maps the files its processes wrote in the last few
+// seconds to tables, and matches the two.
+
+const (
+	dbWriterRecent   = 15 * time.Second // file mtime window that counts as "being written"
+	dbWriterMaxFiles = 8
+	dbWriterMaxFDs   = 8192 // mysqld with a large table_open_cache
+	dbWriterMaxStmts = 20
+	dbWriterQueryMax = 300
+)
+
+// CorrelateDBWriter returns the statement behind the writes of the given
+// database processes (highest writer first), or nil when the database can't
+// be queried and none of the written files could be mapped. kind is
+// "mysql" or "postgresql".
+func CorrelateDBWriter(kind string, pids []int) *model.DBWriterAttribution {
+	var files []model.DBWriterFile
+	seen := make(map[string]bool)
+	for _, pid := range pids {
+		for _, path := range RecentlyWrittenFiles(pid, dbWriterRecent, dbWriterMaxFiles) {
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			f := model.DBWriterFile{Path: path}
+			name := strings.TrimSuffix(path, deletedSuffix)
+			switch kind {
+			case "mysql":
+				f.Kind, f.Table = mysqlFileTable(name)
+			case "postgresql":
+				f.Kind, _ = pgFileRef(name)
+			}
+			files = append(files, f)
+		}
+	}
+	if len(files) > dbWriterMaxFiles {
+		files = files[:dbWriterMaxFiles]
+	}
+
+	var stmts []model.DBStatement
+	switch kind {
+	case "mysql":
+		stmts = mysqlRunningStatements()
+	case "postgresql":
+		pgResolveTables(files)
+		stmts = pgRunningStatements()
+	default:
+		return nil
+	}
+	return correlateDBWriter(kind, files, stmts, pids)
+}
+
+// correlateDBWriter matches written files to statements. Best match first:
+// a PostgreSQL backend that is itself a top writer, then a statement whose
+// target table owns a written file, then the longest DDL when the files are
+// an online-DDL copy.
+func correlateDBWriter(kind string, files []model.DBWriterFile, stmts []model.DBStatement, pids []int) *model.DBWriterAttribution {
+	if len(files) == 0 && len(stmts) == 0 {
+		return nil
+	}
+	sort.SliceStable(stmts, func(i, j int) bool { return stmts[i].Seconds > stmts[j].Seconds })
+	a := &model.DBWriterAttribution{Kind: kind, Files: files, Running: stmts, CheckedAt: time.Now()}
+
+	match := func(s model.DBStatement, file string) {
+		cp := s
+		a.Statement = &cp
+		a.File = file
+	}
+	if kind == "postgresql" {
+		for _, pid := range pids {
+			for _, s := range stmts {
+				if s.ID == int64(pid) && s.Verb != "" {
+					match(s, fileForTable(files, s.Table))
+					break
+				}
+			}
+			if a.Statement != nil {
+				break
+			}
+		}
+	}
+	for _, f := range files {
+		if a.Statement != nil {
+			break
+		}
+		for _, s := range stmts {
+			if (f.Table != "" && sameTable(f.Table, s.Table)) || (f.Kind == "ddl" && isDDLVerb(s.Verb)) {
+				match(s, f.Path)
+				break
+			}
+		}
+	}
+
+	switch {
+	case a.Statement != nil:
+		on := ""
+		if a.Statement.Table != "" {
+			on = " on " + a.Statement.Table
+		}
+		a.Summary = fmt.Sprintf("%s%s (%s) is the writer", a.Statement.Verb, on, fmtStmtAge(a.Statement.Seconds))
+	case firstTable(files) != "":
+		a.Summary = fmt.Sprintf("%s is being written — no running statement touches it", firstTable(files))
+	case len(stmts) == 0:
+		return nil
+	default:
+		s := stmts[0]
+		a.Summary = fmt.Sprintf("no statement matched the written files; longest running: %s (%s)",
+			truncStr(s.Query, 60), fmtStmtAge(s.Seconds))
+	}
+	return a
+}
+
+func fileForTable(files []model.DBWriterFile, table string) string {
+	for _, f := range files {
+		if table != "" && sameTable(f.Table, table) {
+			return f.Path
+		}
+	}
+	return ""
+}
+
+func firstTable(files []model.DBWriterFile) string {
+	for _, f := range files {
+		if f.Table != "" {
+			return f.Table
+		}
+	}
+	return ""
+}
+
+// sameTable compares table names, schema-qualified or not, ignoring case.
+func sameTable(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	if a == b {
+		return true
+	}
+	if strings.Contains(a, ".") && strings.Contains(b, ".") {
+		return false
+	}
+	return a[strings.LastIndexByte(a, '.')+1:] == b[strings.LastIndexByte(b, '.')+1:]
+}
+
+func isDDLVerb(v string) bool {
+	switch v {
+	case "ALTER TABLE", "OPTIMIZE TABLE", "CREATE INDEX", "CREATE UNIQUE INDEX":
+		return true
+	}
+	return false
+}
+
+func fmtStmtAge(sec int) string {
+	switch {
+	case sec < 600:
+		return fmt.Sprintf("%ds", sec)
+	case sec < 3600:
+		return fmt.Sprintf("%dm", sec/60)
+	default:
+		return fmt.Sprintf("%dh%02dm", sec/3600, sec%3600/60)
+	}
+}
+
+// ─── Statement parsing ──────────────────────────────────────────────────────
+
+const sqlName = "((?:[`\"]?[\\w$]+[`\"]?\\.)?[`\"]?[\\w$]+[`\"]?)"
+
+// stmtTargetRes extract the verb and target table of write statements.
+var stmtTargetRes = []*regexp.Regexp{
+	regexp.MustCompile(`(?is)^(ALTER\s+TABLE)\s+(?:ONLY\s+)?(?:IF\s+EXISTS\s+)?` + sqlName),
+	regexp.MustCompile(`(?is)^(OPTIMIZE)\s+(?:NO_WRITE_TO_BINLOG\s+|LOCAL\s+)?TABLE\s+` + sqlName),
+	regexp.MustCompile(`(?is)^(CREATE\s+(?:UNIQUE\s+)?INDEX)\b.*?\bON\s+(?:ONLY\s+)?` + sqlName),
+	regexp.MustCompile(`(?is)^(INSERT|REPLACE)(?:\s+(?:LOW_PRIORITY|DELAYED|HIGH_PRIORITY|IGNORE))*\s+(?:INTO\s+)?` + sqlName),
+	regexp.MustCompile(`(?is)^(UPDATE)\s+(?:(?:LOW_PRIORITY|IGNORE|ONLY)\s+)*` + sqlName),
+	regexp.MustCompile(`(?is)^(DELETE)\b.*?\bFROM\s+(?:ONLY\s+)?` + sqlName),
+	regexp.MustCompile(`(?is)^(LOAD\s+DATA)\b.*?\bINTO\s+TABLE\s+` + sqlName),
+	regexp.MustCompile(`(?is)^(COPY)\s+` + sqlName),
+	regexp.MustCompile(`(?is)^(?:autovacuum:\s+)?(VACUUM)(?:\s+\([^)]*\))?(?:\s+(?:FULL|FREEZE|VERBOSE|ANALYZE))*\s+` + sqlName),
+	regexp.MustCompile(`(?is)^(CLUSTER|REINDEX\s+TABLE|TRUNCATE(?:\s+TABLE)?)\s+` + sqlName),
+}
+
+var (
+	sqlCommentRe = regexp.MustCompile(`(?s)^\s*(?:/\*.*?\*/\s*|--[^\n]*\n\s*)*`)
+	spaceRunRe   = regexp.MustCompile(`\s+`)
+)
+
+// parseStatementTarget returns the normalized verb ("ALTER TABLE") and the
+// unquoted target table of a write statement; "", "" for reads.
+func parseStatementTarget(query string) (verb, table string) {
+	q := sqlCommentRe.ReplaceAllString(query, "")
+	for _, re := range stmtTargetRes {
+		m := re.FindStringSubmatch(q)
+		if m == nil {
+			continue
+		}
+		verb = strings.ToUpper(spaceRunRe.ReplaceAllString(m[1], " "))
+		if verb == "OPTIMIZE" {
+			verb = "OPTIMIZE TABLE"
+		}
+		if verb == "TRUNCATE TABLE" {
+			verb = "TRUNCATE"
+		}
+		return verb, strings.NewReplacer("`", "", `"`, "").Replace(m[2])
+	}
+	return "", ""
+}
+
+// newDBStatement fills the derived fields of a processlist row.
+func newDBStatement(id int64, user, db string, secs int, state, query string) model.DBStatement {
+	query = strings.TrimSpace(spaceRunRe.ReplaceAllString(query, " "))
+	verb, table := parseStatementTarget(query)
+	query, _ = redactValue(query)
+	return model.DBStatement{
+		ID: id, User: user, DB: db, Seconds: secs, State: state,
+		Query: truncStr(query, dbWriterQueryMax), Verb: verb, Table: table,
+	}
+}
+
+// ─── MySQL / MariaDB ────────────────────────────────────────────────────────
+
+var (
+	mysqlPartitionRe = regexp.MustCompile(`(?i)#p#.*$`)
+	binlogRe         = regexp.MustCompile(`(?i)(^|[-_])(bin|binlog)\.\d+$`) // mysql-bin.000123, binlog.000045, relay-bin.000002
+	undoRe           = regexp.MustCompile(`^undo(_\d+|\d+)$`)
+)
+
+// mysqlFileTable classifies a file under the MySQL datadir.
+func mysqlFileTable(path string) (kind, table string) {
+	base := filepath.Base(path)
+	dir := filepath.Base(filepath.Dir(path))
+	ext := strings.ToLower(filepath.Ext(base))
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	switch {
+	case dir == "#innodb_redo" || strings.HasPrefix(base, "ib_logfile"):
+		return "redo", ""
+	case undoRe.MatchString(base) || ext == ".ibu":
+		return "undo", ""
+	case base == "ibtmp1" || dir == "#innodb_temp":
+		return "temp", ""
+	case strings.HasPrefix(base, "ibdata"):
+		return "system", ""
+	case binlogRe.MatchString(base):
+		return "binlog", ""
+	case strings.HasPrefix(base, "#sql"):
+		// ALTER TABLE / OPTIMIZE copy: #sql-ib1234-5678.ibd, #sql-1f2e_3.ibd
+		return "ddl", ""
+	case ext == ".ibd" || ext == ".myd" || ext == ".myi":
+		return "table", dir + "." + mysqlPartitionRe.ReplaceAllString(stem, "")
+	}
+	return "other", ""
+}
+
+// mysqlRunningStatements reads the non-idle processlist.
+func mysqlRunningStatements() []model.DBStatement {
+	if _, err := exec.LookPath("mysql"); err != nil {
+		return nil
+	}
+	q := fmt.Sprintf(`SELECT ID, USER, IFNULL(DB,''), TIME, IFNULL(STATE,''),
+		REPLACE(REPLACE(INFO, CHAR(10), ' '), CHAR(9), ' ')
+		FROM information_schema.PROCESSLIST
+		WHERE COMMAND NOT IN ('Sleep','Daemon','Binlog Dump','Binlog Dump GTID')
+		AND INFO IS NOT NULL AND ID <> CONNECTION_ID()
+		ORDER BY TIME DESC LIMIT %d`, dbWriterMaxStmts)
+	out, err := runCmd("mysql", "-N", "-B", "-e", q)
+	if err != nil {
+		return nil
+	}
+	return parseStatementRows(out, "\t")
+}
+
+// ─── PostgreSQL ─────────────────────────────────────────────────────────────
+
+var pgRelFileRe = regexp.MustCompile(`(?:/base/(\d+)|/pg_tblspc/(\d+)/[^/]+/(\d+))/(\d+)(?:_(?:fsm|vm|init))?(?:\.\d+)?$`)
+
+// pgFileRef classifies a file under a PostgreSQL data directory; ref is
+// "tablespace:dboid:relfilenode" for relation files.
+func pgFileRef(path string) (kind, ref string) {
+	switch {
+	case strings.Contains(path, "/pg_wal/") || strings.Contains(path, "/pg_xlog/"):
+		return "wal", ""
+	case strings.Contains(path, "/pgsql_tmp"):
+		return "temp", ""
+	case strings.Contains(path, "/global/"):
+		return "system", ""
+	}
+	m := pgRelFileRe.FindStringSubmatch(path)
+	if m == nil {
+		return "other", ""
+	}
+	if m[1] != "" {
+		return "table", "0:" + m[1] + ":" + m[4]
+	}
+	return "table", m[2] + ":" + m[3] + ":" + m[4]
+}
+
+// pgResolveTables names the relations behind table files. Writes to an
+// index are attributed to its table.
+func pgResolveTables(files []model.DBWriterFile) {
+	byDB := make(map[string][]int) // dboid → file indexes
+	refs := make([][2]string, len(files))
+	for i, f := range files {
+		_, ref := pgFileRef(strings.TrimSuffix(f.Path, deletedSuffix))
+		parts := strings.Split(ref, ":")
+		if len(parts) != 3 {
+			continue
+		}
+		refs[i] = [2]string{parts[0], parts[2]}
+		byDB[parts[1]] = append(byDB[parts[1]], i)
+	}
+	for dboid, idx := range byDB {
+		name, err := pgQuery("", "SELECT datname FROM pg_database WHERE oid = "+dboid)
+		name = strings.TrimSpace(name)
+		if err != nil || name == "" {
+			continue
+		}
+		var values []string
+		for _, i := range idx {
+			values = append(values, fmt.Sprintf("(%d, %s::oid, %s::oid)", i, refs[i][0], refs[i][1]))
+		}
+		out, err := pgQuery(name, `SELECT i, COALESCE(
+			(SELECT indrelid::regclass::text FROM pg_index WHERE indexrelid = pg_filenode_relation(t, n)),
+			pg_filenode_relation(t, n)::text, '')
+			FROM (VALUES `+strings.Join(values, ", ")+`) v(i, t, n)`)
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(out, "\n") {
+			f := strings.Split(line, "\x1f")
+			if len(f) != 2 || f[1] == "" {
+				continue
+			}
+			if i, err := strconv.Atoi(f[0]); err == nil && i < len(files) {
+				files[i].Table = name + "." + f[1]
+			}
+		}
+	}
+}
+
+// pgRunningStatements reads non-idle backends, autovacuum workers included.
+func pgRunningStatements() []model.DBStatement {
+	q := fmt.Sprintf(`SELECT pid, COALESCE(usename,''), COALESCE(datname,''),
+		COALESCE(EXTRACT(EPOCH FROM now() - query_start)::int, 0),
+		COALESCE(wait_event_type || ':' || wait_event, state, ''),
+		regexp_replace(query, '\s+', ' ', 'g')
+		FROM pg_stat_activity
+		WHERE state <> 'idle' AND pid <> pg_backend_pid() AND query <> ''
+		ORDER BY query_start LIMIT %d`, dbWriterMaxStmts)
+	out, err := pgQuery("", q)
+	if err != nil {
+		return nil
+	}
+	return parseStatementRows(out, "\x1f")
+}
+
+// pgQuery runs one query as the postgres superuser: sudo for peer auth,
+// else psql -U postgres. Fields are separated by \x1f.
+func pgQuery(db, query string) (string, error) {
+	if _, err := exec.LookPath("psql"); err != nil {
+		return "", err
+	}
+	args := []string{"-X", "-q", "-t", "-A", "-F", "\x1f", "-c", query}
+	if db != "" {
+		args = append([]string{"-d", db}, args...)
+	}
+	if os.Geteuid() == 0 {
+		if out, err := runCmd("sudo", append([]string{"-n", "-u", "postgres", "psql"}, args...)...); err == nil {
+			return out, nil
+		}
+	}
+	return runCmd("psql", append([]string{"-U", "postgres"}, args...)...)
+}
+
+// parseStatementRows parses id, user, db, seconds, state, query rows.
+func parseStatementRows(out, sep string) []model.DBStatement {
+	var stmts []model.DBStatement
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.SplitN(line, sep, 6)
+		if len(f) != 6 {
+			continue
+		}
+		id, err := strconv.ParseInt(strings.TrimSpace(f[0]), 10, 64)
+		if err != nil {
+			continue
+		}
+		secs, _ := strconv.Atoi(strings.TrimSpace(f[3]))
+		stmts = append(stmts, newDBStatement(id, f[1], f[2], secs, f[4], f[5]))
+	}
+	return stmts
+}
+
+// ─── Files ──────────────────────────────────────────────────────────────────
+
+// deletedSuffix is how /proc/PID/fd marks a file unlinked while open.
+const deletedSuffix = " (deleted)"
+
+// RecentlyWrittenFiles lists pid's open regular files modified within the
+// window, newest first. An open file that was deleted keeps the kernel's
+// marker: "/var/log/app.log (deleted)".
+func RecentlyWrittenFiles(pid int, within time.Duration, max int) []string {
+	fdDir := fmt.Sprintf("/proc/%d/fd", pid)
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return nil
+	}
+	if len(entries) > dbWriterMaxFDs {
+		entries = entries[:dbWriterMaxFDs]
+	}
+	type cand struct {
+		path  string
+		mtime time.Time
+	}
+	var cands []cand
+	seen := make(map[string]bool)
+	cutoff := time.Now().Add(-within)
+	for _, e := range entries {
+		fdPath := filepath.Join(fdDir, e.Name())
+		link, err := os.Readlink(fdPath)
+		if err != nil || !strings.HasPrefix(link, "/") || seen[link] ||
+			strings.HasPrefix(link, "/dev/") || strings.HasPrefix(link, "/proc/") {
+			continue
+		}
+		seen[link] = true
+		info, err := os.Stat(fdPath)
+		if err != nil || !info.Mode().IsRegular() || info.ModTime().Before(cutoff) {
+			continue
+		}
+		cands = append(cands, cand{link, info.ModTime()})
+	}
+	sort.Slice(cands, func(i, j int) bool { return cands[i].mtime.After(cands[j].mtime) })
+	if len(cands) > max {
+		cands = cands[:max]
+	}
+	out := make([]string, len(cands))
+	for i, c := range cands {
+		out[i] = c.path
+	}
+	return out
+}
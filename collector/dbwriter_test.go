@@ -0,0 +1,127 @@
+package collector
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func TestParseStatementTarget(t *testing.T) {
+	cases := []struct{ q, verb, table string }{
+		{"ALTER TABLE `orders` ADD COLUMN note TEXT", "ALTER TABLE", "orders"},
+		{"/* gh-ost */ alter table shop.orders engine=InnoDB", "ALTER TABLE", "shop.orders"},
+		{"OPTIMIZE TABLE sessions", "OPTIMIZE TABLE", "sessions"},
+		{"CREATE INDEX CONCURRENTLY idx_o ON public.orders (created_at)", "CREATE INDEX", "public.orders"},
+		{"INSERT IGNORE INTO events (a) SELECT a FROM staging", "INSERT", "events"},
+		{"UPDATE orders SET state = 'x' WHERE id < 100", "UPDATE", "orders"},
+		{"DELETE o FROM orders o JOIN t ON t.id = o.id", "DELETE", "orders"},
+		{"LOAD DATA LOCAL INFILE '/tmp/x.csv' INTO TABLE imports", "LOAD DATA", "imports"},
+		{`COPY "Events" FROM STDIN`, "COPY", "Events"},
+		{"autovacuum: VACUUM ANALYZE public.orders", "VACUUM", "public.orders"},
+		{"SELECT * FROM orders", "", ""},
+	}
+	for _, c := range cases {
+		verb, table := parseStatementTarget(c.q)
+		if verb != c.verb || table != c.table {
+			t.Errorf("%q: got %q %q, want %q %q", c.q, verb, table, c.verb, c.table)
+		}
+	}
+}
+
+func TestMySQLFileTable(t *testing.T) {
+	cases := []struct{ path, kind, table string }{
+		{"/var/lib/mysql/shop/orders.ibd", "table", "shop.orders"},
+		{"/var/lib/mysql/shop/orders#p#p2023.ibd", "table", "shop.orders"},
+		{"/var/lib/mysql/shop/#sql-ib1234-5678.ibd", "ddl", ""},
+		{"/var/lib/mysql/#innodb_redo/#ib_redo12", "redo", ""},
+		{"/var/lib/mysql/undo_001", "undo", ""},
+		{"/var/lib/mysql/binlog.000045", "binlog", ""},
+		{"/var/lib/mysql/mysql-bin.000123", "binlog", ""},
+		{"/var/lib/mysql/ibtmp1", "temp", ""},
+	}
+	for _, c := range cases {
+		kind, table := mysqlFileTable(c.path)
+		if kind != c.kind || table != c.table {
+			t.Errorf("%s: got %s %q, want %s %q", c.path, kind, table, c.kind, c.table)
+		}
+	}
+}
+
+func TestPGFileRef(t *testing.T) {
+	cases := []struct{ path, kind, ref string }{
+		{"/var/lib/postgresql/16/main/base/16384/24576", "table", "0:16384:24576"},
+		{"/var/lib/postgresql/16/main/base/16384/24576.3", "table", "0:16384:24576"},
+		{"/srv/pg/pg_tblspc/16500/PG_16_202307071/16384/24580_fsm", "table", "16500:16384:24580"},
+		{"/var/lib/postgresql/16/main/pg_wal/000000010000000A000000FE", "wal", ""},
+		{"/var/lib/postgresql/16/main/base/pgsql_tmp/pgsql_tmp1234.0", "temp", ""},
+	}
+	for _, c := range cases {
+		kind, ref := pgFileRef(c.path)
+		if kind != c.kind || ref != c.ref {
+			t.Errorf("%s: got %s %q, want %s %q", c.path, kind, ref, c.kind, c.ref)
+		}
+	}
+}
+
+func TestCorrelateDBWriter(t *testing.T) {
+	stmts := []model.DBStatement{
+		newDBStatement(11, "app", "shop", 3, "executing", "SELECT * FROM orders"),
+		newDBStatement(12, "app", "shop", 240, "altering table", "ALTER TABLE orders ADD INDEX (created_at)"),
+		newDBStatement(13, "app", "shop", 2, "update", "INSERT INTO carts VALUES (1)"),
+	}
+
+	// Online DDL copy file → the ALTER, even though a table file is absent.
+	files := []model.DBWriterFile{{Path: "/var/lib/mysql/shop/#sql-ib99-1.ibd", Kind: "ddl"}}
+	a := correlateDBWriter("mysql", files, stmts, []int{100})
+	if a == nil || a.Statement == nil || a.Statement.ID != 12 {
+		t.Fatalf("ddl: got %+v", a)
+	}
+	if a.Summary != "ALTER TABLE on orders (240s) is the writer" {
+		t.Errorf("summary = %q", a.Summary)
+	}
+
+	// Table file → the statement targeting that table.
+	files = []model.DBWriterFile{{Path: "/var/lib/mysql/shop/carts.ibd", Kind: "table", Table: "shop.carts"}}
+	if a := correlateDBWriter("mysql", files, stmts, nil); a == nil || a.Statement == nil || a.Statement.ID != 13 {
+		t.Errorf("table: got %+v", a)
+	}
+
+	// PostgreSQL backend PID that is itself the writer wins outright.
+	pg := []model.DBStatement{
+		newDBStatement(4242, "app", "shop", 90, "IO:DataFileWrite", "autovacuum: VACUUM public.events"),
+		newDBStatement(4300, "app", "shop", 600, "active", "UPDATE orders SET x = 1"),
+	}
+	if a := correlateDBWriter("postgresql", nil, pg, []int{4242}); a == nil || a.Statement == nil || a.Statement.ID != 4242 {
+		t.Errorf("pg pid: got %+v", a)
+	}
+
+	// Nothing matches → say so without naming a writer.
+	files = []model.DBWriterFile{{Path: "/var/lib/mysql/shop/audit.ibd", Kind: "table", Table: "shop.audit"}}
+	if a := correlateDBWriter("mysql", files, stmts, nil); a == nil || a.Statement != nil || !strings.Contains(a.Summary, "shop.audit") {
+		t.Errorf("no match: got %+v", a)
+	}
+}
+
+func TestRecentlyWrittenFilesKeepsDeletedMarker(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "app.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString("line\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(f.Name()); err != nil {
+		t.Fatal(err)
+	}
+	want := f.Name() + " (deleted)"
+	for _, p := range RecentlyWrittenFiles(os.Getpid(), time.Minute, 64) {
+		if p == want {
+			return
+		}
+	}
+	t.Errorf("%q not listed", want)
+}
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ftahirops/xtop/advice"
 	"github.com/ftahirops/xtop/model"
 )
 
@@ -114,13 +115,15 @@ func tailFile(path string, n int) []string {
 }
 
 // addFinding appends a finding to a service diag and updates worst severity.
-func addFinding(sd *model.ServiceDiag, sev model.DiagSeverity, cat, summary, detail, advice string) {
+// adviceID names an advice catalog entry rendered with args ("" for none).
+func addFinding(sd *model.ServiceDiag, sev model.DiagSeverity, cat, summary, detail, adviceID string, args ...any) {
 	sd.Findings = append(sd.Findings, model.DiagFinding{
 		Severity: sev,
 		Category: cat,
 		Summary:  summary,
 		Detail:   detail,
-		Advice:   advice,
+		Advice:   advice.Text(adviceID, args...),
+		AdviceID: adviceID,
 	})
 	if sevRank(sev) > sevRank(sd.WorstSev) {
 		sd.WorstSev = sev
@@ -192,7 +195,7 @@ func DiagNginx() model.ServiceDiag {
 	// Config syntax check
 	out, err := runCmd("nginx", "-t")
 	if err != nil {
-		addFinding(&sd, model.DiagCrit, "config", "Config syntax error", out, "Fix nginx configuration and reload")
+		addFinding(&sd, model.DiagCrit, "config", "Config syntax error", out, "diag.nginx.config_error")
 	} else {
 		addFinding(&sd, model.DiagOK, "config", "Config syntax valid", "", "")
 	}
@@ -207,7 +210,7 @@ func DiagNginx() model.ServiceDiag {
 				addFinding(&sd, model.DiagWarn, "config",
 					fmt.Sprintf("worker_processes=1 on %d-CPU host", runtime.NumCPU()),
 					"Single worker cannot utilize multiple CPUs",
-					"Set worker_processes auto or match CPU count")
+					"diag.nginx.single_worker")
 			}
 		}
 		// worker_connections
@@ -244,7 +247,7 @@ func DiagNginx() model.ServiceDiag {
 				if n > 500 {
 					addFinding(&sd, model.DiagWarn, "performance",
 						fmt.Sprintf("High active connections: %d", n),
-						"", "Check for connection leak or increase capacity")
+						"", "diag.nginx.connections")
 				}
 			}
 			if fields := strings.Fields(l); len(fields) == 3 {
@@ -277,11 +280,11 @@ func DiagNginx() model.ServiceDiag {
 		if critCount > 0 {
 			addFinding(&sd, model.DiagCrit, "logs",
 				fmt.Sprintf("%d critical/emergency errors in error.log", critCount),
-				"", "Check /var/log/nginx/error.log immediately")
+				"", "diag.nginx.errlog_crit")
 		} else if errCount > 5 {
 			addFinding(&sd, model.DiagWarn, "logs",
 				fmt.Sprintf("%d errors in last 50 lines of error.log", errCount),
-				"", "Check /var/log/nginx/error.log")
+				"", "diag.nginx.errlog")
 		}
 	}
 
@@ -310,11 +313,11 @@ func DiagNginx() model.ServiceDiag {
 			if pct5xx > 10 {
 				addFinding(&sd, model.DiagCrit, "performance",
 					fmt.Sprintf("5xx rate: %.1f%% (%d/%d)", pct5xx, count5xx, total),
-					"", "Check upstream servers and error.log")
+					"", "diag.nginx.5xx_crit")
 			} else if pct5xx > 2 {
 				addFinding(&sd, model.DiagWarn, "performance",
 					fmt.Sprintf("5xx rate: %.1f%% (%d/%d)", pct5xx, count5xx, total),
-					"", "Monitor upstream health")
+					"", "diag.nginx.5xx")
 			}
 		}
 	}
@@ -348,7 +351,7 @@ func DiagApache() model.ServiceDiag {
 	// Config syntax
 	out, err := runCmd(apachectl, "-t")
 	if err != nil {
-		addFinding(&sd, model.DiagCrit, "config", "Config syntax error", out, "Fix Apache configuration")
+		addFinding(&sd, model.DiagCrit, "config", "Config syntax error", out, "diag.apache.config_error")
 	} else {
 		addFinding(&sd, model.DiagOK, "config", "Config syntax valid", "", "")
 	}
@@ -363,7 +366,7 @@ func DiagApache() model.ServiceDiag {
 				addFinding(&sd, model.DiagWarn, "config",
 					"Using prefork MPM",
 					"prefork uses one process per connection, limiting scalability",
-					"Consider switching to event or worker MPM")
+					"diag.apache.prefork")
 			}
 		}
 	}
@@ -385,7 +388,7 @@ func DiagApache() model.ServiceDiag {
 			if errCount > 5 {
 				addFinding(&sd, model.DiagWarn, "logs",
 					fmt.Sprintf("%d errors in last 50 lines", errCount),
-					"", fmt.Sprintf("Check %s", p))
+					"", "diag.apache.errlog", p)
 			}
 			break
 		}
@@ -400,7 +403,7 @@ func DiagApache() model.ServiceDiag {
 			if n > 0 && n < 150 {
 				addFinding(&sd, model.DiagWarn, "config",
 					fmt.Sprintf("MaxRequestWorkers=%d (low)", n),
-					"", "Consider increasing MaxRequestWorkers for production")
+					"", "diag.apache.max_workers")
 			}
 		}
 	}
@@ -431,7 +434,7 @@ func DiagMySQL() model.ServiceDiag {
 	statusOut, err := runCmd("mysql", "-N", "-e", "SHOW GLOBAL STATUS")
 	if err != nil {
 		addFinding(&sd, model.DiagWarn, "connections", "Cannot connect to MySQL", err.Error(),
-			"Check MySQL socket/permissions: mysql -N -e 'SELECT 1'")
+			"diag.mysql.connect")
 		return sd
 	}
 
@@ -450,11 +453,11 @@ func DiagMySQL() model.ServiceDiag {
 		if connPct > 95 {
 			addFinding(&sd, model.DiagCrit, "connections",
 				fmt.Sprintf("Connections near limit: %d/%d (%.0f%%)", threadsConn, maxConn, connPct),
-				"", "Increase max_connections or investigate connection leaks")
+				"", "diag.mysql.conn_limit")
 		} else if connPct > 80 {
 			addFinding(&sd, model.DiagWarn, "connections",
 				fmt.Sprintf("Connections high: %d/%d (%.0f%%)", threadsConn, maxConn, connPct),
-				"", "Monitor connection count; consider connection pooling")
+				"", "diag.mysql.conn_high")
 		} else {
 			addFinding(&sd, model.DiagOK, "connections",
 				fmt.Sprintf("Connections: %.1f%%", connPct), "", "")
@@ -470,11 +473,11 @@ func DiagMySQL() model.ServiceDiag {
 		if hitRatio < 95 {
 			addFinding(&sd, model.DiagCrit, "performance",
 				fmt.Sprintf("Buffer pool hit ratio: %.1f%%", hitRatio),
-				"", "Increase innodb_buffer_pool_size")
+				"", "diag.mysql.bufpool_hit_crit")
 		} else if hitRatio < 99 {
 			addFinding(&sd, model.DiagWarn, "performance",
 				fmt.Sprintf("Buffer pool hit ratio: %.1f%%", hitRatio),
-				"", "Consider increasing innodb_buffer_pool_size")
+				"", "diag.mysql.bufpool_hit")
 		} else {
 			addFinding(&sd, model.DiagOK, "performance",
 				fmt.Sprintf("Buffer pool hit: %.1f%%", hitRatio), "", "")
@@ -489,7 +492,7 @@ func DiagMySQL() model.ServiceDiag {
 		if poolMB < 128 {
 			addFinding(&sd, model.DiagWarn, "memory",
 				fmt.Sprintf("InnoDB buffer pool only %dMB", poolMB),
-				"", "Increase innodb_buffer_pool_size (typically 50-80% of RAM)")
+				"", "diag.mysql.bufpool_small")
 		}
 	}
 
@@ -502,7 +505,7 @@ func DiagMySQL() model.ServiceDiag {
 		if slowPerMin > 1 {
 			addFinding(&sd, model.DiagWarn, "performance",
 				fmt.Sprintf("Slow queries: %d total (%.1f/min)", slowQ, slowPerMin),
-				"", "Enable slow_query_log and optimize queries")
+				"", "diag.mysql.slow_queries")
 		}
 	}
 
@@ -519,7 +522,7 @@ func DiagMySQL() model.ServiceDiag {
 					if timeSec > 60 {
 						addFinding(&sd, model.DiagCrit, "performance",
 							fmt.Sprintf("Query running for %ds: %s", timeSec, truncStr(fields[len(fields)-1], 60)),
-							"", "Check SHOW PROCESSLIST and consider killing the query")
+							"", "diag.mysql.long_query")
 					}
 				}
 			}
@@ -527,7 +530,7 @@ func DiagMySQL() model.ServiceDiag {
 		if longCount > 0 {
 			addFinding(&sd, model.DiagWarn, "performance",
 				fmt.Sprintf("%d queries running >30s", longCount),
-				"", "Check SHOW PROCESSLIST")
+				"", "diag.mysql.long_queries")
 		}
 	}
 
@@ -546,17 +549,17 @@ func DiagMySQL() model.ServiceDiag {
 		if ioRunning != "Yes" || sqlRunning != "Yes" {
 			addFinding(&sd, model.DiagCrit, "replication",
 				fmt.Sprintf("Replication broken: IO=%s SQL=%s", ioRunning, sqlRunning),
-				"", "Check SHOW SLAVE STATUS\\G for errors")
+				"", "diag.mysql.repl_broken")
 		}
 		lag := atoiSafe(replKV["Seconds_Behind_Master"])
 		if lag > 300 {
 			addFinding(&sd, model.DiagCrit, "replication",
 				fmt.Sprintf("Replication lag: %ds", lag),
-				"", "Investigate IO/SQL thread performance")
+				"", "diag.mysql.repl_lag_crit")
 		} else if lag > 30 {
 			addFinding(&sd, model.DiagWarn, "replication",
 				fmt.Sprintf("Replication lag: %ds", lag),
-				"", "Monitor replication lag trend")
+				"", "diag.mysql.repl_lag")
 		}
 	}
 
@@ -594,7 +597,7 @@ func DiagPostgreSQL() model.ServiceDiag {
 		_, err = runCmd("psql", "-U", "postgres", "-t", "-A", "-c", "SELECT 1")
 		if err != nil {
 			addFinding(&sd, model.DiagWarn, "connections", "Cannot connect to PostgreSQL", err.Error(),
-				"Check PostgreSQL authentication: sudo -u postgres psql -c 'SELECT 1'")
+				"diag.postgres.connect")
 			return sd
 		}
 	}
@@ -641,12 +644,12 @@ func DiagPostgreSQL() model.ServiceDiag {
 		if activePct > 90 {
 			addFinding(&sd, model.DiagCrit, "connections",
 				fmt.Sprintf("Active connections at %.0f%% of max", activePct),
-				"", "Increase max_connections or investigate query bottlenecks")
+				"", "diag.postgres.conn_limit")
 		}
 		if idlePct > 50 {
 			addFinding(&sd, model.DiagWarn, "connections",
 				fmt.Sprintf("Idle connections: %d (%.0f%% of max)", idleConns, idlePct),
-				"", "Consider using a connection pooler (pgbouncer)")
+				"", "diag.postgres.idle_conns")
 		}
 		sd.Metrics["conn_pct"] = fmt.Sprintf("%.0f%%", float64(totalConns)/float64(maxConn)*100)
 	}
@@ -660,11 +663,11 @@ func DiagPostgreSQL() model.ServiceDiag {
 		if hitRatio < 95 {
 			addFinding(&sd, model.DiagCrit, "performance",
 				fmt.Sprintf("Cache hit ratio: %.1f%%", hitRatio),
-				"", "Increase shared_buffers")
+				"", "diag.postgres.cache_hit_crit")
 		} else if hitRatio < 99 {
 			addFinding(&sd, model.DiagWarn, "performance",
 				fmt.Sprintf("Cache hit ratio: %.1f%%", hitRatio),
-				"", "Consider increasing shared_buffers")
+				"", "diag.postgres.cache_hit")
 		} else {
 			addFinding(&sd, model.DiagOK, "performance",
 				fmt.Sprintf("Cache hit ratio: %.1f%%", hitRatio), "", "")
@@ -681,12 +684,12 @@ func DiagPostgreSQL() model.ServiceDiag {
 		if deadlocks > 0 {
 			addFinding(&sd, model.DiagWarn, "performance",
 				fmt.Sprintf("Deadlocks detected: %d", deadlocks),
-				"", "Investigate transaction ordering")
+				"", "diag.postgres.deadlocks")
 		}
 		if tempBytes > 1024*1024*1024 {
 			addFinding(&sd, model.DiagWarn, "performance",
 				fmt.Sprintf("Temp file usage: %dMB", tempBytes/(1024*1024)),
-				"", "Increase work_mem to reduce temp file usage")
+				"", "diag.postgres.temp_files")
 		}
 	}
 
@@ -701,7 +704,7 @@ func DiagPostgreSQL() model.ServiceDiag {
 			if dead > 100000 {
 				addFinding(&sd, model.DiagWarn, "performance",
 					fmt.Sprintf("Table %s has %dk dead tuples", table, dead/1000),
-					"", fmt.Sprintf("Run VACUUM ANALYZE %s", table))
+					"", "diag.postgres.dead_tuples", table)
 			}
 		}
 	}
@@ -792,7 +795,7 @@ func DiagHAProxy() model.ServiceDiag {
 			if downBackends > 0 {
 				addFinding(&sd, model.DiagCrit, "performance",
 					fmt.Sprintf("%d backend server(s) DOWN", downBackends),
-					"", "Check backend server health")
+					"", "diag.haproxy.backends_down")
 			}
 			if totalReq > 0 {
 				pct5xx := float64(total5xx) / float64(totalReq) * 100
@@ -800,14 +803,14 @@ func DiagHAProxy() model.ServiceDiag {
 				if pct5xx > 1 {
 					addFinding(&sd, model.DiagWarn, "performance",
 						fmt.Sprintf("5xx rate: %.1f%%", pct5xx),
-						"", "Investigate backend errors")
+						"", "diag.haproxy.5xx")
 				}
 			}
 			if queueDepth > 0 {
 				sd.Metrics["queue"] = fmt.Sprintf("%d", queueDepth)
 				addFinding(&sd, model.DiagWarn, "performance",
 					fmt.Sprintf("Queue depth: %d", queueDepth),
-					"", "Backends may be overloaded")
+					"", "diag.haproxy.queue")
 			}
 		}
 
@@ -824,7 +827,7 @@ func DiagHAProxy() model.ServiceDiag {
 				if connPct > 80 {
 					addFinding(&sd, model.DiagWarn, "connections",
 						fmt.Sprintf("Connections at %.0f%% of max", connPct),
-						"", "Increase maxconn or scale out")
+						"", "diag.haproxy.maxconn")
 				}
 			}
 		}
@@ -874,7 +877,7 @@ func DiagRedis() model.ServiceDiag {
 	infoOut, err := runCmd("redis-cli", "info", "all")
 	if err != nil {
 		addFinding(&sd, model.DiagWarn, "connections", "Cannot connect to Redis", err.Error(),
-			"Check Redis is listening: redis-cli ping")
+			"diag.redis.connect")
 		return sd
 	}
 
@@ -889,18 +892,18 @@ func DiagRedis() model.ServiceDiag {
 		if memPct > 95 {
 			addFinding(&sd, model.DiagCrit, "memory",
 				fmt.Sprintf("Memory usage: %.0f%%", memPct),
-				"", "Increase maxmemory or review eviction policy")
+				"", "diag.redis.mem_crit")
 		} else if memPct > 80 {
 			addFinding(&sd, model.DiagWarn, "memory",
 				fmt.Sprintf("Memory usage: %.0f%%", memPct),
-				"", "Monitor memory growth")
+				"", "diag.redis.mem_high")
 		}
 	} else {
 		sd.Metrics["mem"] = fmt.Sprintf("%dMB", usedMem/(1024*1024))
 		addFinding(&sd, model.DiagWarn, "memory",
 			"maxmemory not set (unbounded)",
 			"Redis can grow until OOM killer hits",
-			"Set maxmemory in redis.conf")
+			"diag.redis.no_maxmemory")
 	}
 
 	// Fragmentation
@@ -911,7 +914,7 @@ func DiagRedis() model.ServiceDiag {
 		if frag > 1.5 {
 			addFinding(&sd, model.DiagWarn, "memory",
 				fmt.Sprintf("Memory fragmentation ratio: %.1f", frag),
-				"", "Consider restarting Redis or enabling active defrag")
+				"", "diag.redis.fragmentation")
 		}
 	}
 
@@ -923,7 +926,7 @@ func DiagRedis() model.ServiceDiag {
 	if blockedClients > 0 {
 		addFinding(&sd, model.DiagWarn, "connections",
 			fmt.Sprintf("Blocked clients: %d", blockedClients),
-			"", "Check BLPOP/BRPOP/WAIT blocking commands")
+			"", "diag.redis.blocked_clients")
 	}
 
 	// Hit ratio
@@ -939,14 +942,14 @@ func DiagRedis() model.ServiceDiag {
 	if evictions > 0 {
 		addFinding(&sd, model.DiagWarn, "memory",
 			fmt.Sprintf("Evictions: %d keys evicted", evictions),
-			"", "Increase maxmemory or review data expiry")
+			"", "diag.redis.evictions")
 	}
 
 	// Persistence — last save status
 	if lastSaveStatus := info["rdb_last_bgsave_status"]; lastSaveStatus != "" && lastSaveStatus != "ok" {
 		addFinding(&sd, model.DiagWarn, "config",
 			fmt.Sprintf("Last RDB save failed: %s", lastSaveStatus),
-			"", "Check disk space and Redis logs")
+			"", "diag.redis.rdb_failed")
 	}
 
 	// Replication
@@ -957,7 +960,7 @@ func DiagRedis() model.ServiceDiag {
 		if linkStatus != "up" {
 			addFinding(&sd, model.DiagCrit, "replication",
 				fmt.Sprintf("Replication link: %s", linkStatus),
-				"", "Check master connectivity")
+				"", "diag.redis.repl_link")
 		}
 	}
 
@@ -968,7 +971,7 @@ func DiagRedis() model.ServiceDiag {
 		if slowLen > 50 {
 			addFinding(&sd, model.DiagWarn, "performance",
 				fmt.Sprintf("Slowlog entries: %d", slowLen),
-				"", "Review slow commands: redis-cli slowlog get 10")
+				"", "diag.redis.slowlog")
 		}
 	}
 
@@ -998,7 +1001,7 @@ func DiagDocker() model.ServiceDiag {
 	psOut, err := runCmd("docker", "ps", "-a", "--format", "{{.ID}}\t{{.Names}}\t{{.Status}}\t{{.State}}")
 	if err != nil {
 		addFinding(&sd, model.DiagWarn, "config", "Cannot query Docker", err.Error(),
-			"Check Docker socket permissions")
+			"diag.docker.query")
 		return sd
 	}
 
@@ -1021,7 +1024,7 @@ func DiagDocker() model.ServiceDiag {
 			restarting++
 			addFinding(&sd, model.DiagCrit, "performance",
 				fmt.Sprintf("Container %s is restarting", fields[1]),
-				"", fmt.Sprintf("Check logs: docker logs %s", fields[1]))
+				"", "diag.docker.restarting", fields[1])
 		case state == "exited" || state == "dead":
 			stopped++
 		}
@@ -1036,7 +1039,7 @@ func DiagDocker() model.ServiceDiag {
 	if stopped > 2 {
 		addFinding(&sd, model.DiagWarn, "performance",
 			fmt.Sprintf("%d stopped containers", stopped),
-			"", "Review stopped containers: docker ps -a --filter status=exited")
+			"", "diag.docker.stopped")
 	}
 
 	// Unhealthy container details
@@ -1046,7 +1049,7 @@ func DiagDocker() model.ServiceDiag {
 		if err == nil {
 			addFinding(&sd, model.DiagCrit, "performance",
 				fmt.Sprintf("Unhealthy container: %s", strings.TrimSpace(inspOut)),
-				"", fmt.Sprintf("Check: docker inspect %s", id))
+				"", "diag.docker.unhealthy", id)
 		}
 	}
 
@@ -1065,11 +1068,11 @@ func DiagDocker() model.ServiceDiag {
 			if memPct > 95 {
 				addFinding(&sd, model.DiagCrit, "memory",
 					fmt.Sprintf("Container %s at %.0f%% memory", name, memPct),
-					"", "Increase memory limit or investigate leak")
+					"", "diag.docker.mem_crit")
 			} else if memPct > 80 {
 				addFinding(&sd, model.DiagWarn, "memory",
 					fmt.Sprintf("Container %s at %.0f%% memory", name, memPct),
-					"", "Monitor memory usage")
+					"", "diag.docker.mem_high")
 			}
 		}
 	}
@@ -1092,7 +1095,7 @@ func DiagDocker() model.ServiceDiag {
 				if gb > 10 {
 					addFinding(&sd, model.DiagWarn, "config",
 						fmt.Sprintf("Docker %s: %s reclaimable", fields[0], reclaimStr),
-						"", "Run: docker system prune")
+						"", "diag.docker.reclaimable")
 				}
 			}
 		}
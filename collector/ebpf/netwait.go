@@ -0,0 +1,465 @@
+//go:build amd64
+
+package ebpf
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// netwait answers "the app is idle-waiting on which dependency?". Unlike the
+// other packs it needs no BPF program: it samples the threads'
+// /proc/PID/task/TID/syscall while the probe window is open, keeps threads
+// blocked in epoll_wait/poll/connect/recv*, and resolves the file
+// descriptors they wait on to remote TCP endpoints.
+//
+// Only processes sockio has already seen doing TCP IO are sampled, at most
+// maxNetWaitPIDs of them, so the cost does not grow with the number of
+// threads on the host. The syscall numbers and argument layout are
+// x86_64's, hence the amd64-only build.
+//
+// Only outbound connections are reported. A server parked in epoll_wait on
+// its own listening socket or accepted clients is idle, not waiting on a
+// dependency.
+
+// netWaitInterval is the sampling period. Each sample of a blocked thread
+// accounts for one interval of wait time.
+const netWaitInterval = 100 * time.Millisecond
+
+// netWaitRefresh is how often the set of sampled processes is re-picked.
+const netWaitRefresh = time.Second
+
+// maxNetWaitPIDs caps how many processes are sampled.
+const maxNetWaitPIDs = 32
+
+// maxPollFDs caps how many pollfd entries are read from a poll() call.
+const maxPollFDs = 64
+
+// x86_64 syscall numbers the sampler treats as network waits.
+var netWaitSyscalls = map[int]string{
+	0:   "read",
+	7:   "poll",
+	42:  "connect",
+	45:  "recvfrom",
+	47:  "recvmsg",
+	232: "epoll_wait",
+	271: "ppoll",
+	281: "epoll_pwait",
+	299: "recvmmsg",
+	441: "epoll_pwait2",
+}
+
+type netWaitKey struct {
+	pid     uint32
+	syscall string
+	dst     string
+}
+
+type netWaitAgg struct {
+	res NetWaitResult
+	fds map[int]bool
+}
+
+type netwaitProbe struct {
+	stop chan struct{}
+	done chan struct{}
+
+	candidates func(n int) []int
+
+	mu      sync.Mutex
+	accum   map[netWaitKey]*netWaitAgg
+	selfPID int
+}
+
+// attachNetWait starts the sampler over the PIDs candidates returns (at
+// most n), re-asked every netWaitRefresh. It fails only if per-thread syscall state
+// is unreadable (not root, or a kernel without CONFIG_HAVE_ARCH_TRACEHOOK).
+func attachNetWait(candidates func(n int) []int) (*netwaitProbe, error) {
+	self := os.Getpid()
+	if _, err := os.ReadFile(fmt.Sprintf("/proc/%d/syscall", self)); err != nil {
+		return nil, fmt.Errorf("read /proc/PID/syscall: %w", err)
+	}
+	p := &netwaitProbe{
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+		candidates: candidates,
+		accum:      make(map[netWaitKey]*netWaitAgg),
+		selfPID:    self,
+	}
+	go p.loop()
+	return p, nil
+}
+
+func (p *netwaitProbe) loop() {
+	defer close(p.done)
+	t := time.NewTicker(netWaitInterval)
+	defer t.Stop()
+	var pids []int
+	var picked time.Time
+	for {
+		select {
+		case <-p.stop:
+			return
+		case now := <-t.C:
+			if now.Sub(picked) >= netWaitRefresh {
+				pids, picked = p.candidates(maxNetWaitPIDs), now
+			}
+			p.sample(pids)
+		}
+	}
+}
+
+// sample takes one pass over the threads of pids.
+func (p *netwaitProbe) sample(pids []int) {
+	if len(pids) > maxNetWaitPIDs {
+		pids = pids[:maxNetWaitPIDs]
+	}
+	tables := make(map[string]*tcpTable) // per network namespace, this pass only
+	for _, pid := range pids {
+		if pid == p.selfPID {
+			continue
+		}
+		tasks, err := os.ReadDir(fmt.Sprintf("/proc/%d/task", pid))
+		if err != nil {
+			continue
+		}
+		perDst := make(map[netWaitKey]int)
+		fdsByKey := make(map[netWaitKey][]int)
+		connecting := make(map[netWaitKey]bool)
+		for _, te := range tasks {
+			data, err := os.ReadFile(fmt.Sprintf("/proc/%d/task/%s/syscall", pid, te.Name()))
+			if err != nil {
+				continue
+			}
+			nr, args, ok := parseSyscallLine(string(data))
+			if !ok {
+				continue
+			}
+			name, watched := netWaitSyscalls[nr]
+			if !watched {
+				continue
+			}
+			fds := waitFDs(pid, nr, args)
+			if len(fds) == 0 {
+				continue
+			}
+			tbl := tcpTableFor(pid, tables)
+			if tbl == nil {
+				continue
+			}
+			seen := make(map[string]bool)
+			for _, fd := range fds {
+				s, ok := tbl.socketForFD(pid, fd)
+				if !ok || !s.outbound(tbl) {
+					continue
+				}
+				k := netWaitKey{pid: uint32(pid), syscall: name, dst: s.remote}
+				fdsByKey[k] = append(fdsByKey[k], fd)
+				if !seen[s.remote] {
+					seen[s.remote] = true
+					perDst[k]++
+				}
+				if s.state == tcpSynSent {
+					connecting[k] = true
+				}
+			}
+		}
+		if len(perDst) == 0 {
+			continue
+		}
+		p.mu.Lock()
+		for k, threads := range perDst {
+			agg := p.aggFor(k)
+			agg.res.Samples += uint32(threads)
+			agg.res.WaitNs += uint64(threads) * uint64(netWaitInterval.Nanoseconds())
+			if threads > agg.res.MaxThreads {
+				agg.res.MaxThreads = threads
+			}
+			for _, fd := range fdsByKey[k] {
+				agg.fds[fd] = true
+			}
+			if connecting[k] {
+				agg.res.Connecting = true
+			}
+		}
+		p.mu.Unlock()
+	}
+}
+
+func (p *netwaitProbe) aggFor(k netWaitKey) *netWaitAgg {
+	agg, ok := p.accum[k]
+	if !ok {
+		agg = &netWaitAgg{
+			res: NetWaitResult{PID: k.pid, Syscall: k.syscall, DstStr: k.dst},
+			fds: make(map[int]bool),
+		}
+		p.accum[k] = agg
+	}
+	return agg
+}
+
+func (p *netwaitProbe) read() ([]NetWaitResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	results := make([]NetWaitResult, 0, len(p.accum))
+	for _, agg := range p.accum {
+		r := agg.res
+		r.Conns = len(agg.fds)
+		if i := strings.LastIndexByte(r.DstStr, ':'); i >= 0 {
+			port, _ := strconv.Atoi(r.DstStr[i+1:])
+			r.DstPort = uint16(port)
+		}
+		r.Comm = readComm(r.PID)
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func (p *netwaitProbe) close() {
+	select {
+	case <-p.stop:
+	default:
+		close(p.stop)
+	}
+	<-p.done
+}
+
+// ── syscall decoding ────────────────────────────────────────────────────────
+
+// parseSyscallLine parses /proc/PID/task/TID/syscall:
+// "232 0x5 0x7ffc3a1b 0x400 0xffffffff 0x0 0x0 0x7ffc... 0x7f..."
+// Threads that are running or not in a syscall yield ok=false.
+func parseSyscallLine(s string) (int, [6]uint64, bool) {
+	var args [6]uint64
+	fields := strings.Fields(s)
+	if len(fields) < 7 {
+		return 0, args, false
+	}
+	nr, err := strconv.Atoi(fields[0])
+	if err != nil || nr < 0 {
+		return 0, args, false
+	}
+	for i := 0; i < 6; i++ {
+		v, err := strconv.ParseUint(strings.TrimPrefix(fields[i+1], "0x"), 16, 64)
+		if err != nil {
+			return 0, args, false
+		}
+		args[i] = v
+	}
+	return nr, args, true
+}
+
+// waitFDs returns the file descriptors a blocked syscall is waiting on.
+func waitFDs(pid, nr int, args [6]uint64) []int {
+	switch nr {
+	case 232, 281, 441: // epoll_wait family: arg0 = epfd
+		return epollTargets(pid, int(args[0]))
+	case 7, 271: // poll/ppoll: arg0 = struct pollfd *, arg1 = nfds
+		return pollTargets(pid, args[0], args[1])
+	default: // read/connect/recv*: arg0 = fd
+		return []int{int(args[0])}
+	}
+}
+
+// epollTargets lists the fds registered in an epoll instance from
+// /proc/PID/fdinfo/EPFD ("tfd:        7 events:       19 data: ...").
+func epollTargets(pid, epfd int) []int {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/fdinfo/%d", pid, epfd))
+	if err != nil {
+		return nil
+	}
+	return parseEpollFdinfo(string(data))
+}
+
+func parseEpollFdinfo(s string) []int {
+	var fds []int
+	for _, line := range strings.Split(s, "\n") {
+		if !strings.HasPrefix(line, "tfd:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		if fd, err := strconv.Atoi(fields[1]); err == nil {
+			fds = append(fds, fd)
+		}
+	}
+	return fds
+}
+
+// pollTargets reads the pollfd array (int fd; short events; short revents)
+// from the target's memory.
+func pollTargets(pid int, addr, nfds uint64) []int {
+	if addr == 0 || nfds == 0 {
+		return nil
+	}
+	if nfds > maxPollFDs {
+		nfds = maxPollFDs
+	}
+	f, err := os.Open(fmt.Sprintf("/proc/%d/mem", pid))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	buf := make([]byte, nfds*8)
+	n, err := f.ReadAt(buf, int64(addr))
+	if err != nil && n == 0 {
+		return nil
+	}
+	var fds []int
+	for off := 0; off+8 <= n; off += 8 {
+		fd := int32(binary.LittleEndian.Uint32(buf[off:]))
+		if fd >= 0 {
+			fds = append(fds, int(fd))
+		}
+	}
+	return fds
+}
+
+// ── socket resolution ───────────────────────────────────────────────────────
+
+const (
+	tcpEstablished = "01"
+	tcpSynSent     = "02"
+	tcpListen      = "0A"
+)
+
+type tcpSock struct {
+	state     string
+	remote    string
+	localPort uint16
+}
+
+// outbound reports whether this socket is a connection the process made
+// (as opposed to one accepted on a listening port).
+func (s tcpSock) outbound(t *tcpTable) bool {
+	switch s.state {
+	case tcpSynSent:
+		return true
+	case tcpEstablished:
+		return !t.listenPorts[s.localPort]
+	}
+	return false
+}
+
+// tcpTable is /proc/PID/net/tcp{,6} for one network namespace.
+type tcpTable struct {
+	byInode     map[uint64]tcpSock
+	listenPorts map[uint16]bool
+}
+
+func tcpTableFor(pid int, cache map[string]*tcpTable) *tcpTable {
+	ns, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/net", pid))
+	if err != nil {
+		return nil
+	}
+	if t, ok := cache[ns]; ok {
+		return t
+	}
+	t := &tcpTable{byInode: make(map[uint64]tcpSock), listenPorts: make(map[uint16]bool)}
+	for _, name := range []string{"tcp", "tcp6"} {
+		data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "net", name))
+		if err != nil {
+			continue
+		}
+		parseTCPTable(string(data), t)
+	}
+	cache[ns] = t
+	return t
+}
+
+func parseTCPTable(s string, t *tcpTable) {
+	lines := strings.Split(s, "\n")
+	if len(lines) < 2 {
+		return
+	}
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 10 {
+			continue
+		}
+		_, lport := parseProcNetAddr(fields[1])
+		state := fields[3]
+		if state == tcpListen {
+			t.listenPorts[lport] = true
+			continue
+		}
+		if state != tcpEstablished && state != tcpSynSent {
+			continue
+		}
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil || inode == 0 {
+			continue
+		}
+		rip, rport := parseProcNetAddr(fields[2])
+		if rip == "" {
+			continue
+		}
+		remote := fmt.Sprintf("%s:%d", rip, rport)
+		if strings.Contains(rip, ":") {
+			remote = fmt.Sprintf("[%s]:%d", rip, rport)
+		}
+		t.byInode[inode] = tcpSock{state: state, remote: remote, localPort: lport}
+	}
+}
+
+// socketForFD resolves /proc/PID/fd/FD -> "socket:[INODE]" -> TCP socket.
+func (t *tcpTable) socketForFD(pid, fd int) (tcpSock, bool) {
+	link, err := os.Readlink(fmt.Sprintf("/proc/%d/fd/%d", pid, fd))
+	if err != nil || !strings.HasPrefix(link, "socket:[") {
+		return tcpSock{}, false
+	}
+	inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]"), 10, 64)
+	if err != nil {
+		return tcpSock{}, false
+	}
+	s, ok := t.byInode[inode]
+	return s, ok
+}
+
+// parseProcNetAddr decodes "0100007F:1F90" (IPv4) or the 32-hex-digit IPv6
+// form from /proc/net/tcp{,6}.
+func parseProcNetAddr(s string) (string, uint16) {
+	host, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", 0
+	}
+	port, _ := strconv.ParseUint(portHex, 16, 16)
+	b, err := hex.DecodeString(host)
+	if err != nil {
+		return "", uint16(port)
+	}
+	switch len(b) {
+	case 4:
+		return fmt.Sprintf("%d.%d.%d.%d", b[3], b[2], b[1], b[0]), uint16(port)
+	case 16:
+		// Each 32-bit word is printed in host (little-endian) order
+		for i := 0; i < 16; i += 4 {
+			b[i], b[i+1], b[i+2], b[i+3] = b[i+3], b[i+2], b[i+1], b[i]
+		}
+		mapped := true
+		for i := 0; i < 10; i++ {
+			if b[i] != 0 {
+				mapped = false
+				break
+			}
+		}
+		if mapped && b[10] == 0xff && b[11] == 0xff {
+			return fmt.Sprintf("%d.%d.%d.%d", b[12], b[13], b[14], b[15]), uint16(port)
+		}
+		var parts []string
+		for i := 0; i < 16; i += 2 {
+			parts = append(parts, strconv.FormatUint(uint64(b[i])<<8|uint64(b[i+1]), 16))
+		}
+		return strings.Join(parts, ":"), uint16(port)
+	}
+	return "", uint16(port)
+}
@@ -0,0 +1,17 @@
+//go:build 386
+
+package ebpf
+
+import "errors"
+
+// netwait decodes x86_64 syscall numbers and arguments, so on 386 the pack
+// is reported as unavailable rather than misreading i386 syscalls.
+type netwaitProbe struct{}
+
+func attachNetWait(func(n int) []int) (*netwaitProbe, error) {
+	return nil, errors.New("not supported on 386")
+}
+
+func (p *netwaitProbe) read() ([]NetWaitResult, error) { return nil, nil }
+
+func (p *netwaitProbe) close() {}
@@ -0,0 +1,48 @@
+//go:build amd64
+
+package ebpf
+
+import "testing"
+
+func TestParseSyscallLine(t *testing.T) {
+	nr, args, ok := parseSyscallLine("232 0x5 0x7ffc3a1b2c30 0x400 0xffffffff 0x0 0x0 0x7ffc3a1b2c08 0x7f2e1c0e8b46\n")
+	if !ok || nr != 232 || args[0] != 5 || args[2] != 0x400 {
+		t.Fatalf("got nr=%d args=%v ok=%v", nr, args, ok)
+	}
+	for _, s := range []string{"running\n", "-1 0x7ffc3a1b2c08 0x7f2e1c0e8b46\n"} {
+		if _, _, ok := parseSyscallLine(s); ok {
+			t.Errorf("parseSyscallLine(%q) should not be ok", s)
+		}
+	}
+}
+
+func TestParseEpollFdinfo(t *testing.T) {
+	info := "pos:\t0\nflags:\t02\nmnt_id:\t15\ntfd:        7 events:       19 data:                7  pos:0 ino:1a2b sdev:9\ntfd:       12 events:       19 data:                c  pos:0 ino:1a2c sdev:9\n"
+	fds := parseEpollFdinfo(info)
+	if len(fds) != 2 || fds[0] != 7 || fds[1] != 12 {
+		t.Fatalf("fds = %v", fds)
+	}
+}
+
+func TestParseTCPTableOutbound(t *testing.T) {
+	// 10.0.0.2:8080 listening; one accepted client on 8080; one outbound
+	// connection to 10.0.0.5:5432; one connect() in progress to :6379.
+	table := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0200000A:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 100 1 0000000000000000 100 0 0 10 0
+   1: 0200000A:1F90 0900000A:D431 01 00000000:00000000 00:00000000 00000000     0        0 101 1 0000000000000000 20 4 30 10 -1
+   2: 0200000A:9C40 0500000A:1538 01 00000000:00000000 00:00000000 00000000     0        0 102 1 0000000000000000 20 4 30 10 -1
+   3: 0200000A:9C41 0600000A:18EB 02 00000000:00000000 00:00000000 00000000     0        0 103 1 0000000000000000 20 4 30 10 -1
+`
+	tbl := &tcpTable{byInode: make(map[uint64]tcpSock), listenPorts: make(map[uint16]bool)}
+	parseTCPTable(table, tbl)
+
+	if s := tbl.byInode[101]; s.outbound(tbl) {
+		t.Error("accepted client connection must not count as a dependency")
+	}
+	if s := tbl.byInode[102]; !s.outbound(tbl) || s.remote != "10.0.0.5:5432" {
+		t.Errorf("outbound socket = %+v", s)
+	}
+	if s := tbl.byInode[103]; !s.outbound(tbl) || s.state != tcpSynSent {
+		t.Errorf("connecting socket = %+v", s)
+	}
+}
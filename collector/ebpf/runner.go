@@ -29,10 +29,26 @@ type ProbeResults struct {
 	SwapEvict      []SwapEvictResult
 	SyscallDissect []SyscallDissectResult
 	SockIO         []SockIOResult
+	NetWait        []NetWaitResult
 
 	Errors []string
 }
 
+// NetWaitResult is time threads of one process spent blocked in a wait
+// syscall on sockets connected to one remote endpoint.
+type NetWaitResult struct {
+	PID        uint32
+	Comm       string
+	Syscall    string // "epoll_wait", "connect", "recvfrom", ...
+	DstStr     string // "10.0.0.5:5432"
+	DstPort    uint16
+	Connecting bool   // SYN_SENT: still waiting for the handshake
+	Conns      int    // distinct sockets to this endpoint seen waiting
+	Samples    uint32 // thread-samples blocked on this endpoint
+	WaitNs     uint64 // Samples * sampling interval (thread-time)
+	MaxThreads int    // most threads blocked on it in one sample
+}
+
 // RunProbe attaches all available eBPF probes, collects data for the given
 // duration, reads the BPF maps, and returns the results. It is safe to call
 // from a goroutine. Each pack is best-effort: if one fails to attach, others
@@ -324,6 +340,26 @@ func RunProbeCtx(ctx context.Context, duration time.Duration) (*ProbeResults, er
 		})
 	}
 
+	// Attach network wait sampler over the processes sockio sees
+	if sio == nil {
+		results.Errors = append(results.Errors, "netwait: needs sockio")
+	} else if nw, err := attachNetWait(func(n int) []int { return sio.busiestPIDs(n, selfPID) }); err != nil {
+		results.Errors = append(results.Errors, "netwait: "+err.Error())
+	} else {
+		packs = append(packs, packEntry{
+			name: "netwait",
+			reader: func() error {
+				r, err := nw.read()
+				if err != nil {
+					return err
+				}
+				results.NetWait = topNetWait(r, 15)
+				return nil
+			},
+			closer: nw.close,
+		})
+	}
+
 	if len(packs) == 0 {
 		return nil, fmt.Errorf("no probes attached: %v", results.Errors)
 	}
@@ -333,8 +369,8 @@ func RunProbeCtx(ctx context.Context, duration time.Duration) (*ProbeResults, er
 	case <-time.After(duration):
 	case <-ctx.Done():
 		// Close probes and return early on cancellation
-		for _, p := range packs {
-			p.closer()
+		for i := len(packs) - 1; i >= 0; i-- {
+			packs[i].closer()
 		}
 		return nil, ctx.Err()
 	}
@@ -346,9 +382,9 @@ func RunProbeCtx(ctx context.Context, duration time.Duration) (*ProbeResults, er
 		}
 	}
 
-	// Close all probes
-	for _, p := range packs {
-		p.closer()
+	// Close all probes, newest first: netwait reads sockio's map
+	for i := len(packs) - 1; i >= 0; i-- {
+		packs[i].closer()
 	}
 
 	return results, nil
@@ -420,11 +456,20 @@ func filterLockWait(raw []LockWaitResult, selfPID uint32, durationNs uint64) []L
 	return out
 }
 
+// topNetWait sorts by total wait (longest first) and keeps the top n.
+func topNetWait(r []NetWaitResult, n int) []NetWaitResult {
+	sort.Slice(r, func(i, j int) bool { return r[i].WaitNs > r[j].WaitNs })
+	if len(r) > n {
+		r = r[:n]
+	}
+	return r
+}
+
 // domainPacks maps RCA bottleneck names to probe pack names.
 var domainPacks = map[string][]string{
 	"CPU Contention":   {"offcpu", "runqlat", "syscalldissect"},
 	"IO Starvation":    {"iolatency", "wbstall"},
-	"Network Overload": {"tcprtt", "netthroughput", "sockio"},
+	"Network Overload": {"tcprtt", "netthroughput", "sockio", "netwait"},
 	"Memory Pressure":  {"pgfault", "swapevict"},
 }
 
@@ -733,8 +778,10 @@ func RunProbeCtxDomain(duration time.Duration, domain string, ctxOpts ...context
 	}
 
 	// Attach socket IO attribution if needed
+	var sio *sockioProbe
 	if packSet["sockio"] {
-		sio, err := attachSockIO()
+		var err error
+		sio, err = attachSockIO()
 		if err != nil {
 			results.Errors = append(results.Errors, "sockio: "+err.Error())
 		} else {
@@ -765,6 +812,27 @@ func RunProbeCtxDomain(duration time.Duration, domain string, ctxOpts ...context
 		}
 	}
 
+	if packSet["netwait"] {
+		if sio == nil {
+			results.Errors = append(results.Errors, "netwait: needs sockio")
+		} else if nw, err := attachNetWait(func(n int) []int { return sio.busiestPIDs(n, selfPID) }); err != nil {
+			results.Errors = append(results.Errors, "netwait: "+err.Error())
+		} else {
+			attached = append(attached, packEntry{
+				name: "netwait",
+				reader: func() error {
+					r, err := nw.read()
+					if err != nil {
+						return err
+					}
+					results.NetWait = topNetWait(r, 15)
+					return nil
+				},
+				closer: nw.close,
+			})
+		}
+	}
+
 	if len(attached) == 0 {
 		return nil, fmt.Errorf("no domain probes attached: %v", results.Errors)
 	}
@@ -773,8 +841,8 @@ func RunProbeCtxDomain(duration time.Duration, domain string, ctxOpts ...context
 	select {
 	case <-time.After(duration):
 	case <-domainCtx.Done():
-		for _, p := range attached {
-			p.closer()
+		for i := len(attached) - 1; i >= 0; i-- {
+			attached[i].closer()
 		}
 		return nil, domainCtx.Err()
 	}
@@ -786,9 +854,9 @@ func RunProbeCtxDomain(duration time.Duration, domain string, ctxOpts ...context
 		}
 	}
 
-	// Close all probes
-	for _, p := range attached {
-		p.closer()
+	// Close all probes, newest first: netwait reads sockio's map
+	for i := len(attached) - 1; i >= 0; i-- {
+		attached[i].closer()
 	}
 
 	return results, nil
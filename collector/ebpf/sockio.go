@@ -4,6 +4,7 @@ package ebpf
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/cilium/ebpf/link"
 )
@@ -101,3 +102,27 @@ func (p *sockioProbe) close() {
 	}
 	p.objs.Close()
 }
+
+// busiestPIDs returns up to n PIDs with TCP traffic so far, longest recv
+// wait first, skipping skip. netwait samples only these.
+func (p *sockioProbe) busiestPIDs(n int, skip uint32) []int {
+	wait := make(map[uint32]uint64)
+	var key sockioSockioKey
+	var val sockioSockioVal
+	iter := p.objs.SockioAccum.Iterate()
+	for iter.Next(&key, &val) {
+		if key.Pid == skip || (val.TxBytes == 0 && val.RxBytes == 0) {
+			continue
+		}
+		wait[key.Pid] += val.RecvWaitNs
+	}
+	pids := make([]int, 0, len(wait))
+	for pid := range wait {
+		pids = append(pids, int(pid))
+	}
+	sort.Slice(pids, func(i, j int) bool { return wait[uint32(pids[i])] > wait[uint32(pids[j])] })
+	if len(pids) > n {
+		pids = pids[:n]
+	}
+	return pids
+}
@@ -0,0 +1,27 @@
+package collector
+
+import "testing"
+
+func TestParseQemuArgs(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		want string
+		uuid string
+		vmid int
+		cpus int
+	}{
+		{"proxmox",
+			[]string{"/usr/bin/kvm", "-id", "105", "-name", "batch-01,debug-threads=on", "-smbios", "type=1,uuid=6A1F0C2E-0000-4000-8000-000000000005", "-smp", "8,sockets=1,cores=8,maxcpus=8"},
+			"batch-01", "6a1f0c2e-0000-4000-8000-000000000005", 105, 8},
+		{"libvirt",
+			[]string{"/usr/bin/qemu-system-x86_64", "-name", "guest=web-01,debug-threads=on", "-uuid", "0f3c0000-0000-4000-8000-00000000000a", "-smp", "cpus=2,sockets=2"},
+			"web-01", "0f3c0000-0000-4000-8000-00000000000a", 0, 2},
+	}
+	for _, c := range cases {
+		vm := parseQemuArgs(c.args)
+		if vm.Name != c.want || vm.UUID != c.uuid || vm.VMID != c.vmid || vm.VCPUs != c.cpus {
+			t.Errorf("%s: got %+v", c.name, vm)
+		}
+	}
+}
@@ -1,43 +1,82 @@
 package collector
 
 import (
+	"bufio"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/ftahirops/xtop/model"
 )
 
-// #3: Shared HTTP client with proper transport reuse
-var sharedHTTPClient = &http.Client{
-	Timeout: 5 * time.Second,
-	Transport: &http.Transport{
-		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
-		MaxIdleConns:      20,
-		IdleConnTimeout:   30 * time.Second,
-		DisableKeepAlives: false,
-	},
-	CheckRedirect: func(req *http.Request, via []*http.Request) error {
-		return http.ErrUseLastResponse
-	},
-}
+// probeTimeout bounds one staged probe end to end (resolve → connect → TLS → HTTP).
+const probeTimeout = 5 * time.Second
+
+// probeWindow is how many recent outcomes per target feed ProbeStageStats.
+const probeWindow = 30
 
 // HealthCheckCollector performs active health probes against discovered services.
 type HealthCheckCollector struct {
-	mu            sync.Mutex
-	cached        []model.HealthProbeResult
-	lastProbe     time.Time
-	lastCertScan  time.Time
-	lastDiscover  time.Time // #25: re-discover periodically
-	targets       []probeTarget
-	discovered    bool
+	mu           sync.Mutex
+	cached       []model.HealthProbeResult
+	lastProbe    time.Time
+	lastCertScan time.Time
+	lastDiscover time.Time // #25: re-discover periodically
+	targets      []probeTarget
+	discovered   bool
+	targetGen    int                      // configuredProbes.gen at last discovery
+	history      map[string]*probeHistory // keyed by probeType+target
+}
+
+// configuredProbes holds the "probe_targets" list from config.json. A
+// config reload bumps gen, which makes every collector re-discover on its
+// next Collect instead of waiting out the 5-minute cycle.
+var configuredProbes struct {
+	sync.Mutex
+	targets []string
+	gen     int
+}
+
+// SetProbeTargets replaces the configured probe targets (same syntax as
+// XTOP_PROBE_TARGETS). Returns false when the list is unchanged.
+func SetProbeTargets(targets []string) bool {
+	configuredProbes.Lock()
+	defer configuredProbes.Unlock()
+	if slices.Equal(configuredProbes.targets, targets) {
+		return false
+	}
+	configuredProbes.targets = slices.Clone(targets)
+	configuredProbes.gen++
+	return true
+}
+
+func probeTargetConfig() ([]string, int) {
+	configuredProbes.Lock()
+	defer configuredProbes.Unlock()
+	return configuredProbes.targets, configuredProbes.gen
+}
+
+// probeHistory remembers recent stage outcomes and the first TLS issuer
+// seen for one target, so intermittent failures and issuer swaps show up.
+type probeHistory struct {
+	stages      []string // ring of FailStage values, "" = success
+	intercepted []bool
+	next        int
+	issuer      string // first trusted issuer organisation observed
 }
 
 type probeTarget struct {
@@ -51,15 +90,14 @@ func (h *HealthCheckCollector) Name() string { return "healthcheck" }
 func (h *HealthCheckCollector) Collect(snap *model.Snapshot) error {
 	h.mu.Lock()
 
-	if !h.discovered {
+	// #25: Re-discover targets every 5 minutes, or at once after the
+	// configured target list changed.
+	configured, gen := probeTargetConfig()
+	if !h.discovered || gen != h.targetGen || time.Since(h.lastDiscover) >= 5*time.Minute {
 		h.discoverTargets(snap)
+		h.targets = append(h.targets, parseProbeTargets(strings.Join(configured, ","))...)
 		h.discovered = true
-		h.lastDiscover = time.Now()
-	}
-
-	// #25: Re-discover targets every 5 minutes
-	if time.Since(h.lastDiscover) >= 5*time.Minute {
-		h.discoverTargets(snap)
+		h.targetGen = gen
 		h.lastDiscover = time.Now()
 	}
 
@@ -80,6 +118,9 @@ func (h *HealthCheckCollector) Collect(snap *model.Snapshot) error {
 		results := runProbesUnlocked(targetsCopy)
 
 		h.mu.Lock()
+		for i := range results {
+			h.record(&results[i])
+		}
 		// Merge with existing cert-file results
 		var merged []model.HealthProbeResult
 		merged = append(merged, results...)
@@ -166,6 +207,101 @@ func (h *HealthCheckCollector) discoverTargets(snap *model.Snapshot) {
 			})
 		}
 	}
+
+	h.targets = append(h.targets, parseProbeTargets(os.Getenv("XTOP_PROBE_TARGETS"))...)
+}
+
+// parseProbeTargets parses XTOP_PROBE_TARGETS: a comma-separated list of
+// URLs (http probe), host:port (tcp probe) or bare hostnames (dns probe).
+// Remote targets are where DNS, middlebox and TLS-interception problems
+// actually live; auto-discovery only ever probes loopback.
+func parseProbeTargets(spec string) []probeTarget {
+	var out []probeTarget
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(item, "http://") || strings.HasPrefix(item, "https://"):
+			u, err := url.Parse(item)
+			if err != nil || u.Host == "" {
+				continue
+			}
+			out = append(out, probeTarget{name: u.Hostname(), probeType: "http", target: item})
+		case strings.Contains(item, ":"):
+			host, _, err := net.SplitHostPort(item)
+			if err != nil {
+				continue
+			}
+			out = append(out, probeTarget{name: host, probeType: "tcp", target: item})
+		default:
+			out = append(out, probeTarget{name: item, probeType: "dns", target: item})
+		}
+	}
+	return out
+}
+
+// record folds one probe outcome into the target's history and fills in
+// the windowed stage counters. It also pins the first trusted TLS issuer:
+// a later certificate from a different organisation that does not chain to
+// the system roots is the classic signature of an intercepting proxy.
+func (h *HealthCheckCollector) record(r *model.HealthProbeResult) {
+	if r.ProbeType != "http" && r.ProbeType != "tcp" {
+		return
+	}
+	if h.history == nil {
+		h.history = make(map[string]*probeHistory)
+	}
+	key := r.ProbeType + " " + r.Target
+	hist := h.history[key]
+	if hist == nil {
+		hist = &probeHistory{}
+		h.history[key] = hist
+	}
+
+	if r.TLSIssuer != "" && !r.Intercepted {
+		org := issuerOrg(r.TLSIssuer)
+		switch {
+		case hist.issuer == "" && r.TLSTrusted:
+			hist.issuer = org
+		case hist.issuer != "" && org != hist.issuer && !r.TLSTrusted:
+			r.Intercepted = true
+			r.InterceptReason = fmt.Sprintf("issuer changed from %q to %q and chain is untrusted", hist.issuer, org)
+			r.Detail += " (TLS intercepted)"
+			if r.Status == "OK" {
+				r.Status = "WARN"
+			}
+		}
+	}
+
+	if len(hist.stages) < probeWindow {
+		hist.stages = append(hist.stages, r.FailStage)
+		hist.intercepted = append(hist.intercepted, r.Intercepted)
+	} else {
+		hist.stages[hist.next] = r.FailStage
+		hist.intercepted[hist.next] = r.Intercepted
+		hist.next = (hist.next + 1) % probeWindow
+	}
+
+	var st model.ProbeStageStats
+	for i, stage := range hist.stages {
+		st.Probes++
+		switch stage {
+		case "dns":
+			st.DNS++
+		case "tcp":
+			st.TCP++
+		case "tls":
+			st.TLS++
+		case "http":
+			st.HTTP++
+		}
+		if hist.intercepted[i] {
+			st.Intercepted++
+		}
+	}
+	r.Stats = st
 }
 
 // runProbesUnlocked runs probes without holding any mutex (#6).
@@ -207,43 +343,82 @@ func runProbesUnlocked(targets []probeTarget) []model.HealthProbeResult {
 	return results
 }
 
-func probeHTTP(t probeTarget) model.HealthProbeResult {
-	r := model.HealthProbeResult{
+// probeHTTP walks the request through its stages — resolve, connect, TLS
+// handshake, HTTP exchange — on one connection, so a failure is pinned to
+// the layer that produced it instead of surfacing as one opaque error.
+func probeHTTP(t probeTarget) (r model.HealthProbeResult) {
+	r = model.HealthProbeResult{
 		Name:         t.name,
 		ProbeType:    "http",
 		Target:       t.target,
 		CertDaysLeft: -1,
 	}
+	u, err := url.Parse(t.target)
+	if err != nil || u.Host == "" {
+		r.Status = "UNKNOWN"
+		r.Detail = "invalid URL"
+		return r
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
 
-	// #3: Use shared HTTP client instead of creating new one per call
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
 	start := time.Now()
-	resp, err := sharedHTTPClient.Get(t.target)
-	r.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	defer func() { r.LatencyMs = float64(time.Since(start).Microseconds()) / 1000 }()
+
+	conn, ok := probeConnect(ctx, &r, u.Hostname(), port)
+	if !ok {
+		return r
+	}
+	defer conn.Close()
+	if dl, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(dl)
+	}
+
+	if u.Scheme == "https" {
+		tc := tls.Client(conn, &tls.Config{ServerName: u.Hostname(), InsecureSkipVerify: true})
+		if err := tc.HandshakeContext(ctx); err != nil {
+			probeFail(&r, "tls", "TLS handshake: "+tlsErrDetail(err))
+			return r
+		}
+		inspectPeerCert(&r, u.Hostname(), tc.ConnectionState())
+		conn = tc
+	}
 
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, t.target, nil)
+	req.Close = true
+	req.Header.Set("User-Agent", "xtop-probe")
+	if err := req.Write(conn); err != nil {
+		probeFail(&r, "http", "HTTP write: "+truncateStr(err.Error(), 50))
+		return r
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
 	if err != nil {
-		r.Status = "CRIT"
-		r.Detail = truncateStr(err.Error(), 60)
+		probeFail(&r, "http", "HTTP read: "+truncateStr(err.Error(), 50))
 		return r
 	}
-	defer resp.Body.Close()
+	resp.Body.Close()
 
 	r.StatusCode = resp.StatusCode
+	r.Detail = fmt.Sprintf("HTTP %d", resp.StatusCode)
 	if resp.StatusCode >= 500 {
 		r.Status = "CRIT"
-		r.Detail = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		r.FailStage = "http"
 	} else if resp.StatusCode >= 400 {
 		r.Status = "WARN"
-		r.Detail = fmt.Sprintf("HTTP %d", resp.StatusCode)
 	} else {
 		r.Status = "OK"
-		r.Detail = fmt.Sprintf("HTTP %d", resp.StatusCode)
 	}
 
-	// Extract cert expiry for HTTPS
-	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
-		cert := resp.TLS.PeerCertificates[0]
-		daysLeft := int(time.Until(cert.NotAfter).Hours() / 24)
-		r.CertDaysLeft = daysLeft
+	// Cert expiry
+	if r.CertDaysLeft >= 0 {
+		daysLeft := r.CertDaysLeft
 		if daysLeft < 7 {
 			r.Status = "CRIT"
 			r.Detail += fmt.Sprintf(" (cert expires in %dd)", daysLeft)
@@ -254,25 +429,35 @@ func probeHTTP(t probeTarget) model.HealthProbeResult {
 			r.Detail += fmt.Sprintf(" (cert expires in %dd)", daysLeft)
 		}
 	}
-
+	if r.Intercepted {
+		if r.Status == "OK" {
+			r.Status = "WARN"
+		}
+		r.Detail += " (TLS intercepted)"
+	}
 	return r
 }
 
-func probeTCP(t probeTarget) model.HealthProbeResult {
-	r := model.HealthProbeResult{
+func probeTCP(t probeTarget) (r model.HealthProbeResult) {
+	r = model.HealthProbeResult{
 		Name:         t.name,
 		ProbeType:    "tcp",
 		Target:       t.target,
 		CertDaysLeft: -1,
 	}
+	host, port, err := net.SplitHostPort(t.target)
+	if err != nil {
+		r.Status = "UNKNOWN"
+		r.Detail = "invalid host:port"
+		return r
+	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
 	start := time.Now()
-	conn, err := net.DialTimeout("tcp", t.target, 3*time.Second)
+	conn, ok := probeConnect(ctx, &r, host, port)
 	r.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
-
-	if err != nil {
-		r.Status = "CRIT"
-		r.Detail = "Connection refused"
+	if !ok {
 		return r
 	}
 	conn.Close()
@@ -282,6 +467,75 @@ func probeTCP(t probeTarget) model.HealthProbeResult {
 	return r
 }
 
+// probeConnect runs the DNS and TCP stages. Literal IPs skip resolution.
+// On failure it marks r with the failing stage and returns ok=false.
+func probeConnect(ctx context.Context, r *model.HealthProbeResult, host, port string) (net.Conn, bool) {
+	addr := host
+	if net.ParseIP(host) == nil {
+		addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+		if err != nil || len(addrs) == 0 {
+			probeFail(r, "dns", "DNS: "+dnsErrDetail(err))
+			return nil, false
+		}
+		addr = addrs[0]
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(addr, port))
+	if err != nil {
+		probeFail(r, "tcp", "TCP: "+dialErrDetail(err))
+		return nil, false
+	}
+	return conn, true
+}
+
+func probeFail(r *model.HealthProbeResult, stage, detail string) {
+	r.Status = "CRIT"
+	r.FailStage = stage
+	r.Detail = truncateStr(detail, 60)
+}
+
+func dnsErrDetail(err error) string {
+	var de *net.DNSError
+	switch {
+	case err == nil:
+		return "no addresses"
+	case errors.As(err, &de) && de.IsNotFound:
+		return "NXDOMAIN"
+	case errors.As(err, &de) && de.IsTimeout, errors.Is(err, context.DeadlineExceeded):
+		return "resolver timeout"
+	}
+	return err.Error()
+}
+
+func dialErrDetail(err error) string {
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "connection refused"
+	case errors.Is(err, syscall.ECONNRESET):
+		return "connection reset"
+	case errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		return "host unreachable"
+	case errors.Is(err, context.DeadlineExceeded), os.IsTimeout(err):
+		return "connect timeout"
+	}
+	return err.Error()
+}
+
+func tlsErrDetail(err error) string {
+	var alert tls.AlertError
+	switch {
+	case errors.As(err, &alert):
+		return "alert " + alert.Error()
+	case errors.Is(err, io.EOF), errors.Is(err, syscall.ECONNRESET):
+		// A peer (or something in the path) hanging up mid-handshake is how
+		// many filtering proxies reject SNI they don't like.
+		return "connection closed during handshake"
+	case errors.Is(err, context.DeadlineExceeded), os.IsTimeout(err):
+		return "handshake timeout"
+	}
+	return err.Error()
+}
+
 func probeDNS(t probeTarget) model.HealthProbeResult {
 	r := model.HealthProbeResult{
 		Name:         t.name,
@@ -0,0 +1,99 @@
+package collector
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func TestProbeStages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+	tlsSrv := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer tlsSrv.Close()
+
+	// A port that was just released: connect is refused.
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	closed := ln.Addr().String()
+	ln.Close()
+
+	cases := []struct {
+		probe probeTarget
+		stage string
+	}{
+		{probeTarget{name: "ok", probeType: "http", target: srv.URL + "/"}, ""},
+		{probeTarget{name: "5xx", probeType: "http", target: srv.URL + "/broken"}, "http"},
+		{probeTarget{name: "tls", probeType: "http", target: tlsSrv.URL + "/"}, ""},
+		{probeTarget{name: "plain-to-tls", probeType: "http", target: "https://" + srv.Listener.Addr().String() + "/"}, "tls"},
+		{probeTarget{name: "refused", probeType: "http", target: "http://" + closed + "/"}, "tcp"},
+		{probeTarget{name: "refused", probeType: "tcp", target: closed}, "tcp"},
+		{probeTarget{name: "nx", probeType: "tcp", target: "no-such-host.invalid:80"}, "dns"},
+	}
+	for _, c := range cases {
+		var r model.HealthProbeResult
+		if c.probe.probeType == "http" {
+			r = probeHTTP(c.probe)
+		} else {
+			r = probeTCP(c.probe)
+		}
+		if r.FailStage != c.stage {
+			t.Errorf("%s %s: stage %q, want %q (%s)", c.probe.probeType, c.probe.target, r.FailStage, c.stage, r.Detail)
+		}
+		if c.probe.name == "tls" && (r.TLSIssuer == "" || r.CertDaysLeft < 0 || r.Intercepted) {
+			t.Errorf("tls probe: issuer %q days %d intercepted %v", r.TLSIssuer, r.CertDaysLeft, r.Intercepted)
+		}
+	}
+}
+
+func TestProbeHistoryAndInterception(t *testing.T) {
+	h := &HealthCheckCollector{}
+	probe := func(stage, issuer string, trusted bool) model.HealthProbeResult {
+		r := model.HealthProbeResult{ProbeType: "http", Target: "https://api.example.com/", Status: "OK",
+			FailStage: stage, TLSIssuer: issuer, TLSTrusted: trusted}
+		if stage != "" {
+			r.Status = "CRIT"
+		}
+		h.record(&r)
+		return r
+	}
+
+	probe("", "CN=R10,O=Let's Encrypt,C=US", true)
+	probe("dns", "", false)
+	probe("", "CN=R11,O=Let's Encrypt,C=US", false) // intermediate rotation, same org
+	r := probe("", "CN=Corp Inspection CA,O=Example Corp,C=US", false)
+	if !r.Intercepted || r.Status != "WARN" || !strings.Contains(r.InterceptReason, "Let's Encrypt") {
+		t.Errorf("issuer swap not flagged: %+v", r)
+	}
+	r = probe("tls", "", false)
+	want := model.ProbeStageStats{Probes: 5, DNS: 1, TLS: 1, Intercepted: 1}
+	if r.Stats != want {
+		t.Errorf("stats = %+v, want %+v", r.Stats, want)
+	}
+
+	for i := 0; i < probeWindow; i++ {
+		r = probe("", "CN=R10,O=Let's Encrypt,C=US", true)
+	}
+	if r.Stats.Probes != probeWindow || r.Stats.Failures() != 0 || r.Stats.Intercepted != 0 {
+		t.Errorf("window did not roll: %+v", r.Stats)
+	}
+
+	if v := middleboxVendor("CN=Zscaler Intermediate Root CA,O=Zscaler Inc."); v != "zscaler" {
+		t.Errorf("vendor = %q", v)
+	}
+}
+
+func TestEnvProbeTargets(t *testing.T) {
+	got := parseProbeTargets("https://api.example.com/health, db.internal:5432 ,example.org,,")
+	if len(got) != 3 || got[0].probeType != "http" || got[0].name != "api.example.com" ||
+		got[1].probeType != "tcp" || got[1].name != "db.internal" || got[2].probeType != "dns" {
+		t.Errorf("targets = %+v", got)
+	}
+}
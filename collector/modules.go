@@ -85,6 +85,8 @@ var moduleCatalog = []ModuleInfo{
 	{Name: "apps", Tier: TierStandard, CostHint: "30s detection cycle", Description: "Auto-detect MySQL / Redis / nginx / etc and basic health"},
 	{Name: "socket", Tier: TierStandard, CostHint: "few reads", Description: "TCP/UDP table summaries"},
 	{Name: "softirq", Tier: TierStandard, CostHint: "1 read", Description: "/proc/softirqs — kernel softirq distribution"},
+	{Name: "oomscore", Tier: TierStandard, CostHint: "/proc/*/oom_score every 60s", Description: "OOM-killer standing of sshd / databases / monitoring + OOMScoreAdjust advice"},
+	{Name: "blockqueue", Tier: TierStandard, CostHint: "sysfs reads every 60s", Description: "Per-disk scheduler / nr_requests / read-ahead + tuning advice"},
 
 	// ── OPTIONAL — medium cost, opt-in ───────────────────────────────────
 	{Name: "ebpf-sentinel", Tier: TierOptional, CostHint: "kernel maps + ring-buffer", Description: "Always-on eBPF probes (kfreeskb, oomkill, retransmit, etc.)"},
@@ -102,6 +104,7 @@ var moduleCatalog = []ModuleInfo{
 	{Name: "bigfiles", Tier: TierHeavy, CostHint: "scans 9 dirs", Description: "Find files ≥ 50 MB (every 60s)"},
 	{Name: "deep-scan", Tier: TierHeavy, CostHint: "FULL FILESYSTEM walk", Description: "ionice-IDLE walker; opt-in via XTOP_DEEP_SCAN=1"},
 	{Name: "profiler", Tier: TierHeavy, CostHint: "role audit", Description: "System-role detection + full optimization audit"},
+	{Name: "guestvm", Tier: TierStandard, CostHint: "1 readdir / 30s + stat per guest", Description: "QEMU/KVM guest CPU on hypervisors — fleet noisy-neighbor attribution"},
 	{Name: "proxmox", Tier: TierHeavy, CostHint: "PVE-specific", Description: "Proxmox guest detection + per-VM stats (skip on non-PVE)"},
 }
 
@@ -0,0 +1,190 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/advice"
+	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
+)
+
+// oomScoreInterval is how often oom_score_adj is re-inventoried. It only
+// changes on service restarts or manual tuning.
+const oomScoreInterval = time.Minute
+
+// oomVictimRank: a critical service among the kernel's top-N OOM
+// candidates is a likely victim.
+const oomVictimRank = 3
+
+// criticalRoles maps process names to the role that makes losing them to
+// the OOM killer worse than losing whatever caused the OOM.
+var criticalRoles = map[string]string{
+	"sshd":     "access",
+	"dropbear": "access",
+
+	"mysqld":          "database",
+	"mariadbd":        "database",
+	"postgres":        "database",
+	"postmaster":      "database",
+	"mongod":          "database",
+	"redis-server":    "database",
+	"etcd":            "database",
+	"clickhouse-serv": "database", // comm is truncated to 15 chars
+
+	"xtop":          "monitoring",
+	"node_exporter": "monitoring",
+	"prometheus":    "monitoring",
+	"telegraf":      "monitoring",
+	"zabbix_agentd": "monitoring",
+	"zabbix_agent2": "monitoring",
+	"grafana-agent": "monitoring",
+	"alloy":         "monitoring",
+	"vector":        "monitoring",
+	"fluent-bit":    "monitoring",
+	"collectd":      "monitoring",
+	"netdata":       "monitoring",
+}
+
+// oomSuggestedAdj is the OOMScoreAdjust recommended per role. Databases
+// stay killable (-500): they are often the real memory hog, and making
+// them immune just moves the kill to everything else.
+var oomSuggestedAdj = map[string]int{
+	"access":     -1000,
+	"monitoring": -900,
+	"database":   -500,
+}
+
+// OOMScoreCollector inventories oom_score_adj of critical services (sshd,
+// databases, monitoring agents) and flags the ones the kernel is likely to
+// pick when memory runs out, with a suggested systemd OOMScoreAdjust.
+type OOMScoreCollector struct {
+	Proc   string // defaults to /proc; tests point it elsewhere
+	last   time.Time
+	cached []model.OOMScoreService
+}
+
+func (o *OOMScoreCollector) Name() string { return "oomscore" }
+
+func (o *OOMScoreCollector) Collect(snap *model.Snapshot) error {
+	if o.cached == nil || time.Since(o.last) >= oomScoreInterval {
+		root := o.Proc
+		if root == "" {
+			root = "/proc"
+		}
+		o.cached = readOOMScores(root)
+		o.last = time.Now()
+	}
+	snap.Global.OOMScores = o.cached
+	return nil
+}
+
+// readOOMScores walks every process for its oom_score (to rank victims)
+// and keeps one entry per critical service: the lowest PID with that
+// name (the listener / postmaster), scored by its hungriest process,
+// since the kernel kills whichever one scores highest.
+func readOOMScores(root string) []model.OOMScoreService {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return []model.OOMScoreService{}
+	}
+	var scores []int
+	byComm := make(map[string]*model.OOMScoreService)
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		dir := filepath.Join(root, e.Name())
+		score, ok := readProcInt(filepath.Join(dir, "oom_score"))
+		if !ok {
+			continue
+		}
+		scores = append(scores, score)
+
+		comm, err := util.ReadFileString(filepath.Join(dir, "comm"))
+		if err != nil {
+			continue
+		}
+		comm = strings.TrimSpace(comm)
+		role, ok := criticalRoles[comm]
+		if !ok {
+			continue
+		}
+		s := byComm[comm]
+		if s == nil {
+			s = &model.OOMScoreService{PID: pid, Comm: comm, Role: role, Score: score}
+			byComm[comm] = s
+			readOOMMain(dir, s)
+			continue
+		}
+		if score > s.Score {
+			s.Score = score
+		}
+		if pid < s.PID {
+			s.PID = pid
+			readOOMMain(dir, s)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(scores)))
+	out := []model.OOMScoreService{}
+	for _, s := range byComm {
+		s.Rank = sort.Search(len(scores), func(i int) bool { return scores[i] <= s.Score }) + 1
+		adviseOOMScore(s)
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Rank < out[j].Rank })
+	return out
+}
+
+// readOOMMain fills the fields that come from the service's main process.
+func readOOMMain(dir string, s *model.OOMScoreService) {
+	s.ScoreAdj, _ = readProcInt(filepath.Join(dir, "oom_score_adj"))
+	var pm model.ProcessMetrics
+	readProcCgroup(dir, &pm)
+	s.Unit = resolveServiceUnit(pm.CgroupPath)
+}
+
+func readProcInt(path string) (int, bool) {
+	s, err := util.ReadFileString(path)
+	if err != nil {
+		return 0, false
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(s))
+	return v, err == nil
+}
+
+// adviseOOMScore sets Severity/Reason/Fix when the service is set up to be
+// killed: oom_score_adj raised above 0, one of the kernel's top victim
+// candidates, or (for access and monitoring) left unprotected.
+func adviseOOMScore(s *model.OOMScoreService) {
+	s.Suggested = oomSuggestedAdj[s.Role]
+	s.Severity, s.AdviceID, s.Reason, s.Fix, s.Persist = "", "", "", "", ""
+	name := s.Comm
+	if s.Unit != "" {
+		name = s.Unit
+	}
+	switch {
+	case s.ScoreAdj > 0:
+		s.Severity, s.AdviceID = "warn", "doctor.oom.adj_raised"
+		s.Reason = advice.Text(s.AdviceID, name, s.ScoreAdj, s.Suggested)
+	case s.Rank <= oomVictimRank && s.Score > 0 && s.ScoreAdj > s.Suggested:
+		s.Severity, s.AdviceID = "warn", "doctor.oom.likely_victim_"+s.Role
+		s.Reason = advice.Text(s.AdviceID, name, s.Rank, s.Score, s.Suggested)
+	case s.Role != "database" && s.ScoreAdj > -500:
+		s.Severity, s.AdviceID = "info", "doctor.oom.unprotected_"+s.Role
+		s.Reason = advice.Text(s.AdviceID, name, s.ScoreAdj, s.Suggested)
+	default:
+		return
+	}
+	s.Fix = fmt.Sprintf("echo %d > /proc/%d/oom_score_adj", s.Suggested, s.PID)
+	if s.Unit != "" {
+		s.Persist = fmt.Sprintf("systemctl edit %s → [Service] OOMScoreAdjust=%d", s.Unit, s.Suggested)
+	}
+}
@@ -0,0 +1,80 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func writeProcPID(t *testing.T, root string, pid int, comm string, score, adj int, cgroup string) {
+	t.Helper()
+	dir := filepath.Join(root, strconv.Itoa(pid))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"comm":          comm,
+		"oom_score":     strconv.Itoa(score),
+		"oom_score_adj": strconv.Itoa(adj),
+		"cgroup":        "0::" + cgroup,
+	}
+	for name, val := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(val+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestOOMScoreAdvice(t *testing.T) {
+	root := t.TempDir()
+	// sshd listener protected by OpenSSH itself; session child is not.
+	writeProcPID(t, root, 800, "sshd", 0, -1000, "/system.slice/ssh.service")
+	writeProcPID(t, root, 4100, "sshd", 2, 0, "/user.slice/user-0.slice/session-1.scope")
+	// postgres: postmaster small, one backend is the host's biggest process.
+	writeProcPID(t, root, 900, "postgres", 10, 0, "/system.slice/postgresql@16-main.service")
+	writeProcPID(t, root, 5200, "postgres", 640, 0, "/system.slice/postgresql@16-main.service")
+	// Monitoring agent someone made more killable.
+	writeProcPID(t, root, 1200, "node_exporter", 5, 300, "/system.slice/node_exporter.service")
+	// Ordinary processes for ranking.
+	writeProcPID(t, root, 3000, "java", 320, 0, "/system.slice/app.service")
+	writeProcPID(t, root, 3100, "bash", 1, 0, "/user.slice")
+	if err := os.MkdirAll(filepath.Join(root, "self"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &OOMScoreCollector{Proc: root}
+	snap := &model.Snapshot{}
+	if err := c.Collect(snap); err != nil {
+		t.Fatal(err)
+	}
+	byComm := map[string]model.OOMScoreService{}
+	for _, s := range snap.Global.OOMScores {
+		byComm[s.Comm] = s
+	}
+	if len(byComm) != 3 {
+		t.Fatalf("services = %+v, want sshd, postgres, node_exporter", snap.Global.OOMScores)
+	}
+
+	if s := byComm["sshd"]; s.PID != 800 || s.ScoreAdj != -1000 || s.Severity != "" {
+		t.Errorf("sshd = %+v, want listener 800 with no advice", s)
+	}
+
+	pg := byComm["postgres"]
+	if pg.PID != 900 || pg.Unit != "postgresql@16-main.service" || pg.Score != 640 || pg.Rank != 1 {
+		t.Errorf("postgres = %+v, want main 900 scored by its backend at rank 1", pg)
+	}
+	if pg.AdviceID != "doctor.oom.likely_victim_database" || pg.Suggested != -500 {
+		t.Errorf("postgres advice = %s → %d", pg.AdviceID, pg.Suggested)
+	}
+	if pg.Persist != "systemctl edit postgresql@16-main.service → [Service] OOMScoreAdjust=-500" {
+		t.Errorf("postgres persist = %q", pg.Persist)
+	}
+
+	ne := byComm["node_exporter"]
+	if ne.AdviceID != "doctor.oom.adj_raised" || ne.Severity != "warn" || ne.Fix != "echo -900 > /proc/1200/oom_score_adj" {
+		t.Errorf("node_exporter = %+v", ne)
+	}
+}
@@ -0,0 +1,70 @@
+package collector
+
+import (
+	"sort"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+const (
+	// outboundMaxAgeMs drops sockets that have only sent for this long:
+	// push-only streams (log shipping, metrics) never get a reply, so
+	// their "request" never completes.
+	outboundMaxAgeMs = 60_000
+	outboundMaxWaits = 32 // endpoints kept per snapshot
+)
+
+// diagSock is one established TCP socket from sock_diag with the two
+// tcp_info timers the in-flight check needs.
+type diagSock struct {
+	remote     string // "10.0.0.5:5432", "[2001:db8::1]:443"
+	localPort  uint16
+	inode      uint64
+	lastSentMs uint32 // ms since data was last sent
+	lastRecvMs uint32 // ms since data was last received
+}
+
+// collectOutboundWaits records the remote endpoints local processes are
+// waiting on right now: outbound connections (local port not listening)
+// that sent data after the last data they received, i.e. have a request
+// in flight. The age of the oldest such request is a lower bound on that
+// endpoint's current latency. Inbound connections are left out, so an
+// idle keepalive client parked in recv never looks like a slow dependency.
+func (s *SocketCollector) collectOutboundWaits(snap *model.Snapshot) {
+	socks, err := dumpTCPDiag()
+	if err != nil {
+		return
+	}
+	byEndpoint := make(map[string]*model.OutboundWait)
+	for _, sk := range socks {
+		if s.listenPorts[sk.localPort] || sk.lastSentMs >= sk.lastRecvMs || sk.lastSentMs > outboundMaxAgeMs {
+			continue
+		}
+		w := byEndpoint[sk.remote]
+		if w == nil {
+			w = &model.OutboundWait{Endpoint: sk.remote}
+			byEndpoint[sk.remote] = w
+		}
+		w.InFlight++
+		if age := float64(sk.lastSentMs); age >= w.MaxAgeMs {
+			w.MaxAgeMs = age
+			if o, ok := s.ephOwnersCache[sk.inode]; ok {
+				w.PID, w.Comm = o.pid, o.comm
+			}
+		}
+	}
+	waits := make([]model.OutboundWait, 0, len(byEndpoint))
+	for _, w := range byEndpoint {
+		waits = append(waits, *w)
+	}
+	sort.Slice(waits, func(i, j int) bool {
+		if waits[i].MaxAgeMs != waits[j].MaxAgeMs {
+			return waits[i].MaxAgeMs > waits[j].MaxAgeMs
+		}
+		return waits[i].Endpoint < waits[j].Endpoint
+	})
+	if len(waits) > outboundMaxWaits {
+		waits = waits[:outboundMaxWaits]
+	}
+	snap.Global.OutboundWaits = waits
+}
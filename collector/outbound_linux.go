@@ -0,0 +1,109 @@
+//go:build linux
+
+package collector
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// sock_diag wire constants not exported by x/sys/unix.
+const (
+	inetDiagInfo        = 2  // INET_DIAG_INFO: struct tcp_info attribute
+	tcpStateEstablished = 1  // TCP_ESTABLISHED
+	inetDiagReqLen      = 56 // struct inet_diag_req_v2
+	inetDiagMsgLen      = 72 // struct inet_diag_msg
+	tcpInfoLastSentOff  = 44 // tcpi_last_data_sent
+	tcpInfoLastRecvOff  = 52 // tcpi_last_data_recv
+)
+
+// dumpTCPDiag lists established TCP sockets with their tcp_info, the way
+// `ss -ti` does, over NETLINK_SOCK_DIAG.
+func dumpTCPDiag() ([]diagSock, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.NETLINK_SOCK_DIAG)
+	if err != nil {
+		return nil, fmt.Errorf("sock_diag socket: %w", err)
+	}
+	defer unix.Close(fd)
+
+	var out []diagSock
+	buf := make([]byte, 64<<10)
+	for _, family := range []uint8{unix.AF_INET, unix.AF_INET6} {
+		req := make([]byte, unix.NLMSG_HDRLEN+inetDiagReqLen)
+		ne := binary.NativeEndian
+		ne.PutUint32(req[0:], uint32(len(req)))
+		ne.PutUint16(req[4:], unix.SOCK_DIAG_BY_FAMILY)
+		ne.PutUint16(req[6:], unix.NLM_F_REQUEST|unix.NLM_F_DUMP)
+		r := req[unix.NLMSG_HDRLEN:]
+		r[0] = family
+		r[1] = unix.IPPROTO_TCP
+		r[2] = 1 << (inetDiagInfo - 1)
+		ne.PutUint32(r[4:], 1<<tcpStateEstablished)
+		if err := unix.Sendto(fd, req, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+			return out, fmt.Errorf("sock_diag request: %w", err)
+		}
+	recv:
+		for {
+			n, _, err := unix.Recvfrom(fd, buf, 0)
+			if err != nil {
+				return out, fmt.Errorf("sock_diag read: %w", err)
+			}
+			msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+			if err != nil {
+				return out, err
+			}
+			for _, m := range msgs {
+				switch m.Header.Type {
+				case unix.NLMSG_DONE:
+					break recv
+				case unix.NLMSG_ERROR:
+					return out, fmt.Errorf("sock_diag: netlink error")
+				}
+				if sk, ok := parseInetDiagMsg(m.Data); ok {
+					out = append(out, sk)
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+// parseInetDiagMsg decodes struct inet_diag_msg and its INET_DIAG_INFO
+// attribute. Sockets without tcp_info are skipped.
+func parseInetDiagMsg(b []byte) (diagSock, bool) {
+	if len(b) < inetDiagMsgLen {
+		return diagSock{}, false
+	}
+	ne := binary.NativeEndian
+	sk := diagSock{
+		localPort: binary.BigEndian.Uint16(b[4:]),
+		inode:     uint64(ne.Uint32(b[68:])),
+	}
+	rport := binary.BigEndian.Uint16(b[6:])
+	var dst netip.Addr
+	if b[0] == unix.AF_INET {
+		dst = netip.AddrFrom4([4]byte(b[24:28]))
+	} else {
+		dst = netip.AddrFrom16([16]byte(b[24:40])).Unmap()
+	}
+	sk.remote = netip.AddrPortFrom(dst, rport).String()
+
+	found := false
+	for attrs := b[inetDiagMsgLen:]; len(attrs) >= unix.SizeofRtAttr; {
+		l, typ := int(ne.Uint16(attrs[0:])), ne.Uint16(attrs[2:])
+		if l < unix.SizeofRtAttr || l > len(attrs) {
+			break
+		}
+		if data := attrs[unix.SizeofRtAttr:l]; typ == inetDiagInfo && len(data) >= tcpInfoLastRecvOff+4 {
+			sk.lastSentMs = ne.Uint32(data[tcpInfoLastSentOff:])
+			sk.lastRecvMs = ne.Uint32(data[tcpInfoLastRecvOff:])
+			found = true
+		}
+		attrs = attrs[min((l+unix.RTA_ALIGNTO-1)&^(unix.RTA_ALIGNTO-1), len(attrs)):]
+	}
+	return sk, found
+}
@@ -0,0 +1,68 @@
+//go:build linux
+
+package collector
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// TestCollectOutboundWaits: a client whose request is unanswered waits on
+// the server. A server that answered last looks the same from its side of
+// the socket, but it is on a listening port, so it is not outbound.
+func TestCollectOutboundWaits(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer ln.Close()
+	dial := func() (client, server net.Conn) {
+		c, err := net.Dial("tcp", ln.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := ln.Accept()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c, s
+	}
+	pending, pendingSrv := dial()
+	defer pending.Close()
+	defer pendingSrv.Close()
+	answered, answeredSrv := dial()
+	defer answered.Close()
+	defer answeredSrv.Close()
+
+	time.Sleep(10 * time.Millisecond) // let the handshake age past the request
+	pending.Write([]byte("GET\n"))    // never answered
+	answered.Write([]byte("GET\n"))
+	answeredSrv.Read(make([]byte, 16))
+	time.Sleep(10 * time.Millisecond)
+	answeredSrv.Write([]byte("OK\n"))
+	answered.Read(make([]byte, 16))
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := dumpTCPDiag(); err != nil {
+		t.Skipf("sock_diag unavailable: %v", err)
+	}
+	s := &SocketCollector{listenPorts: map[uint16]bool{uint16(ln.Addr().(*net.TCPAddr).Port): true}}
+	snap := &model.Snapshot{}
+	s.collectOutboundWaits(snap)
+
+	var toServer *model.OutboundWait
+	for i, w := range snap.Global.OutboundWaits {
+		switch w.Endpoint {
+		case ln.Addr().String():
+			toServer = &snap.Global.OutboundWaits[i]
+		case answered.LocalAddr().String():
+			t.Errorf("server side of an answered request reported as outbound: %+v", w)
+		}
+	}
+	if toServer == nil || toServer.InFlight != 1 || toServer.MaxAgeMs < 10 {
+		t.Errorf("unanswered request to %s: got %+v, want 1 in flight", ln.Addr(), toServer)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !linux
+
+package collector
+
+import "errors"
+
+func dumpTCPDiag() ([]diagSock, error) { return nil, errors.New("sock_diag is Linux-only") }
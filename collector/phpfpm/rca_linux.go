@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // evaluateRCA produces a slice of Issues for one app based on its
@@ -38,7 +39,7 @@ func evaluateRCA(a *model.PHPFPMApp, masterCfg map[string]int) {
 			Severity: "crit",
 			Code:     "phpfpm.fs.webshell",
 			Message:  fmt.Sprintf("web-shell file found in docroot: %s%s", shortPath(first.Path), extra),
-			Detail:   fmt.Sprintf("%s — kind=%s — size=%d B — modified=%s", first.Signal, first.Kind, first.Size, first.ModTime.Format("2006-01-02 15:04")),
+			Detail:   fmt.Sprintf("%s — kind=%s — size=%d B — modified=%s", first.Signal, first.Kind, first.Size, util.FmtTime(first.ModTime, "2006-01-02 15:04")),
 			Action:   "quarantine the file, audit recent uploads/, rotate any credentials, and look for siblings",
 		})
 	}
@@ -52,7 +53,7 @@ func evaluateRCA(a *model.PHPFPMApp, masterCfg map[string]int) {
 			Severity: "crit",
 			Code:     "phpfpm.fs.binary",
 			Message:  fmt.Sprintf("unexpected binary in docroot: %s%s", shortPath(first.Path), extra),
-			Detail:   fmt.Sprintf("%s — kind=%s — size=%d B — modified=%s", first.Signal, first.Kind, first.Size, first.ModTime.Format("2006-01-02 15:04")),
+			Detail:   fmt.Sprintf("%s — kind=%s — size=%d B — modified=%s", first.Signal, first.Kind, first.Size, util.FmtTime(first.ModTime, "2006-01-02 15:04")),
 			Action:   "investigate why an ELF/script is inside a public webroot — most legitimate sites have none",
 		})
 	}
@@ -0,0 +1,212 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// redactMask replaces every secret value. Fixed width so the mask doesn't
+// leak the secret's length.
+const redactMask = "***"
+
+// secretKeyRe matches option and variable names whose value is a secret:
+// --password, PGPASSWORD, AWS_SECRET_ACCESS_KEY, api-key, X_AUTH_TOKEN, …
+// Extra names can be added with XTOP_REDACT_KEYS=name1,name2.
+var secretKeyRe = regexp.MustCompile(`(?i)(pass(word|wd|phrase)?|pwd|secret|token|api[-_]?key|access[-_]?key|private[-_]?key|credential|auth|session|cookie|signature|dsn)`)
+
+// notSecretKeys match secretKeyRe but hold paths, not secrets.
+var notSecretKeys = map[string]bool{
+	"PWD": true, "OLDPWD": true, "SSH_AUTH_SOCK": true, "XAUTHORITY": true,
+}
+
+// Values that look like secrets whatever they're attached to.
+var (
+	urlUserinfoRe = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://[^:/@\s]*:)[^@/\s]+@`)
+	sqlPasswordRe = regexp.MustCompile(`(?i)((?:identified\s+by|password)\s+)('[^']*'|"[^"]*")`)
+	bearerRe      = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`)
+	tokenRes      = []*regexp.Regexp{
+		regexp.MustCompile(`eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]+`), // JWT
+		regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`),                             // AWS access key ID
+		regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`),                          // GitHub token
+		regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`),                        // Slack token
+		regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}\b`),                               // sk- style API keys
+	}
+	// Long run of mixed-case letters and digits: random keys, base64
+	// secrets. Hex digests and container IDs (no upper case) are kept.
+	opaqueRe = regexp.MustCompile(`[A-Za-z0-9+/_-]{32,}={0,2}`)
+)
+
+// mysqlFamily binaries take the password glued to -p ("-pS3cret").
+var mysqlFamily = map[string]bool{
+	"mysql": true, "mysqldump": true, "mysqladmin": true, "mysqlimport": true,
+	"mysqlcheck": true, "mysqlshow": true, "mariadb": true, "mariadb-dump": true,
+	"mariadb-admin": true,
+}
+
+// shortPasswordFlag is the short option that takes a password, per binary:
+// "redis-cli -a S3cret", "sshpass -pS3cret". Only its first use counts; a
+// later one belongs to the command sshpass runs ("ssh -p 2222").
+var shortPasswordFlag = map[string]string{
+	"redis-cli": "-a", "valkey-cli": "-a", "keydb-cli": "-a",
+	"sshpass": "-p",
+}
+
+// redactor applies the rules above plus any extra key names from the
+// environment. The zero value uses the built-in rules only.
+type redactor struct {
+	extra []string // lower-case key substrings from XTOP_REDACT_KEYS
+}
+
+func newRedactor() *redactor {
+	r := &redactor{}
+	for _, k := range strings.Split(os.Getenv("XTOP_REDACT_KEYS"), ",") {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			r.extra = append(r.extra, k)
+		}
+	}
+	return r
+}
+
+func (r *redactor) secretKey(name string) bool {
+	if notSecretKeys[name] {
+		return false
+	}
+	if secretKeyRe.MatchString(name) {
+		return true
+	}
+	l := strings.ToLower(name)
+	for _, k := range r.extra {
+		if strings.Contains(l, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// Args masks secrets in an argv and returns the count masked.
+func (r *redactor) Args(args []string) ([]string, int) {
+	out := make([]string, len(args))
+	n := 0
+	var bin string
+	if len(args) > 0 {
+		bin = filepath.Base(args[0])
+	}
+	mysql := mysqlFamily[bin]
+	pwFlag := shortPasswordFlag[bin]
+	maskNext, pwNext := false, false
+	for i, a := range args {
+		switch {
+		case pwNext || maskNext && !strings.HasPrefix(a, "-"):
+			out[i] = redactMask
+			n++
+			maskNext, pwNext = false, false
+			continue
+		case pwFlag != "" && a == pwFlag:
+			// The value is the next argument, whatever it looks like.
+			out[i] = a
+			pwNext, pwFlag = true, ""
+			continue
+		case pwFlag != "" && len(a) > 2 && strings.HasPrefix(a, pwFlag) && !strings.HasPrefix(a, "--"):
+			out[i] = pwFlag + redactMask
+			n++
+			maskNext, pwFlag = false, ""
+			continue
+		case mysql && len(a) > 2 && strings.HasPrefix(a, "-p") && !strings.HasPrefix(a, "--"):
+			out[i] = "-p" + redactMask
+			n++
+			maskNext = false
+			continue
+		}
+		maskNext = false
+		if key, _, ok := strings.Cut(a, "="); ok && r.secretKey(key) {
+			out[i] = key + "=" + redactMask
+			n++
+			continue
+		}
+		if strings.HasPrefix(a, "-") && r.secretKey(a) {
+			// "--password hunter2": the value is the next argument.
+			maskNext = true
+		}
+		var c int
+		out[i], c = redactValue(a)
+		n += c
+	}
+	return out, n
+}
+
+// Env masks secret values in KEY=VALUE entries.
+func (r *redactor) Env(env []string) ([]string, int) {
+	out := make([]string, len(env))
+	n := 0
+	for i, kv := range env {
+		key, val, ok := strings.Cut(kv, "=")
+		if !ok {
+			out[i] = kv
+			continue
+		}
+		if r.secretKey(key) && val != "" {
+			out[i] = key + "=" + redactMask
+			n++
+			continue
+		}
+		var c int
+		val, c = redactValue(val)
+		out[i] = key + "=" + val
+		n += c
+	}
+	return out, n
+}
+
+// redactValue masks secret-looking substrings of s.
+func redactValue(s string) (string, int) {
+	n := 0
+	replace := func(re *regexp.Regexp, repl func(string) string) {
+		s = re.ReplaceAllStringFunc(s, func(m string) string {
+			n++
+			return repl(m)
+		})
+	}
+	keepPrefix := func(re *regexp.Regexp) func(string) string {
+		return func(m string) string {
+			sub := re.FindStringSubmatch(m)
+			return sub[1] + redactMask
+		}
+	}
+	replace(urlUserinfoRe, func(m string) string { return keepPrefix(urlUserinfoRe)(m) + "@" })
+	replace(sqlPasswordRe, func(m string) string {
+		sub := sqlPasswordRe.FindStringSubmatch(m)
+		q := sub[2][:1]
+		return sub[1] + q + redactMask + q
+	})
+	replace(bearerRe, keepPrefix(bearerRe))
+	for _, re := range tokenRes {
+		replace(re, func(string) string { return redactMask })
+	}
+	s = opaqueRe.ReplaceAllStringFunc(s, func(m string) string {
+		if !looksRandom(m) {
+			return m
+		}
+		n++
+		return redactMask
+	})
+	return s, n
+}
+
+// looksRandom reports whether s mixes upper case, lower case and digits —
+// true of generated keys, rarely of paths, words or hex digests.
+func looksRandom(s string) bool {
+	var upper, lower, digit bool
+	for _, c := range s {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			upper = true
+		case c >= 'a' && c <= 'z':
+			lower = true
+		case c >= '0' && c <= '9':
+			digit = true
+		}
+	}
+	return upper && lower && digit && !strings.Contains(s, "/")
+}
@@ -0,0 +1,78 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactArgs(t *testing.T) {
+	r := &redactor{}
+	cases := []struct {
+		name string
+		in   []string
+		want []string
+	}{
+		{"flag=value",
+			[]string{"app", "--db-password=hunter2", "--port=8080"},
+			[]string{"app", "--db-password=***", "--port=8080"}},
+		{"flag value",
+			[]string{"app", "--api-key", "abc123", "--verbose"},
+			[]string{"app", "--api-key", "***", "--verbose"}},
+		{"flag without value",
+			[]string{"app", "--no-auth", "--port", "80"},
+			[]string{"app", "--no-auth", "--port", "80"}},
+		{"mysql -p",
+			[]string{"/usr/bin/mysql", "-uroot", "-pS3cret", "-e", "SELECT 1"},
+			[]string{"/usr/bin/mysql", "-uroot", "-p***", "-e", "SELECT 1"}},
+		{"redis-cli -a",
+			[]string{"redis-cli", "-h", "cache", "-a", "-S3cret", "ping"},
+			[]string{"redis-cli", "-h", "cache", "-a", "***", "ping"}},
+		{"sshpass -p",
+			[]string{"/usr/bin/sshpass", "-pS3cret", "ssh", "-p", "2222", "host"},
+			[]string{"/usr/bin/sshpass", "-p***", "ssh", "-p", "2222", "host"}},
+		{"url userinfo",
+			[]string{"pg_dump", "postgres://app:pw@db:5432/prod"},
+			[]string{"pg_dump", "postgres://app:***@db:5432/prod"}},
+		{"sql password",
+			[]string{"mysql", "-e", "CREATE USER x IDENTIFIED BY 'pw1'"},
+			[]string{"mysql", "-e", "CREATE USER x IDENTIFIED BY '***'"}},
+		{"sql password double-quoted",
+			[]string{"mysql", "-e", `ALTER USER x IDENTIFIED BY "pw1"`},
+			[]string{"mysql", "-e", `ALTER USER x IDENTIFIED BY "***"`}},
+		{"bearer header",
+			[]string{"curl", "-H", "Authorization: Bearer abc.def"},
+			[]string{"curl", "-H", "Authorization: Bearer ***"}},
+		{"opaque token kept when hex",
+			[]string{"run", "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", "Zx8KqP2mVnR4tW7yB1cE5gH9jL3oS6uA"},
+			[]string{"run", "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", "***"}},
+	}
+	for _, c := range cases {
+		got, _ := r.Args(c.in)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestRedactEnv(t *testing.T) {
+	t.Setenv("XTOP_REDACT_KEYS", "license")
+	got, n := newRedactor().Env([]string{
+		"AWS_SECRET_ACCESS_KEY=wJalr",
+		"DATABASE_URL=mysql://u:p@h/db",
+		"HOME=/root",
+		"PWD=/srv/app",
+		"LICENSE_ID=42",
+		"PGPASSWORD=",
+	})
+	want := []string{
+		"AWS_SECRET_ACCESS_KEY=***",
+		"DATABASE_URL=mysql://u:***@h/db",
+		"HOME=/root",
+		"PWD=/srv/app",
+		"LICENSE_ID=***",
+		"PGPASSWORD=",
+	}
+	if !reflect.DeepEqual(got, want) || n != 3 {
+		t.Errorf("got %q (%d masked), want %q (3)", got, n, want)
+	}
+}
@@ -50,6 +50,14 @@ func (s *SMARTCollector) Get() []model.SMARTDisk {
 	return disks
 }
 
+// RescanDevices forces a refresh on the next Get, so a hot-plugged disk
+// shows up without waiting out the interval.
+func (s *SMARTCollector) RescanDevices() {
+	s.mu.Lock()
+	s.lastRun = time.Time{}
+	s.mu.Unlock()
+}
+
 func (s *SMARTCollector) collect() []model.SMARTDisk {
 	// Track which devices were already covered by ioctl
 	covered := make(map[string]bool)
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,8 +24,11 @@ type cwSocketInfo struct {
 type SocketCollector struct {
 	portUsersCache    []model.PortUser
 	cwLeakersCache    []model.CloseWaitLeaker
+	ephOwnersCache    map[uint64]sockOwner // ephemeral-port socket inode -> owner
 	cacheAt           time.Time
 
+	listenPorts map[uint16]bool // local ports in LISTEN this tick
+
 	// CLOSE_WAIT age tracking: key = "local_hex->remote_hex", value = first seen time
 	cwFirstSeen  map[string]time.Time
 
@@ -40,6 +44,7 @@ func (s *SocketCollector) Name() string { return "socket" }
 func (s *SocketCollector) Collect(snap *model.Snapshot) error {
 	s.collectSockstat(snap)
 	s.collectTCPStates(snap)
+	s.collectOutboundWaits(snap)
 	return nil
 }
 
@@ -135,6 +140,7 @@ func (s *SocketCollector) collectTCPStates(snap *model.Snapshot) {
 	if s.cwFirstSeen == nil {
 		s.cwFirstSeen = make(map[string]time.Time)
 	}
+	s.listenPorts = make(map[uint16]bool)
 
 	for _, path := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
 		lines, err := util.ReadFileLines(path)
@@ -175,6 +181,7 @@ func (s *SocketCollector) collectTCPStates(snap *model.Snapshot) {
 				st.LastAck++
 			case 0x0A:
 				st.Listen++
+				s.listenPorts[uint16(ParseLocalPort(fields[1]))] = true
 			case 0x0B:
 				st.Closing++
 			}
@@ -277,7 +284,7 @@ func (s *SocketCollector) collectTCPStates(snap *model.Snapshot) {
 	// Unified PID resolution: ephemeral port users + CLOSE_WAIT leakers (time-gated)
 	if len(ephInodes) > 0 || len(cwInodes) > 0 {
 		if time.Since(s.cacheAt) >= socketCacheTTL {
-			s.portUsersCache, s.cwLeakersCache = resolveSocketOwners(ephInodes, cwInodes, now)
+			s.portUsersCache, s.cwLeakersCache, s.ephOwnersCache = resolveSocketOwners(ephInodes, cwInodes, now)
 			s.cacheAt = now
 		}
 		eph.TopUsers = s.portUsersCache
@@ -295,10 +302,17 @@ func (s *SocketCollector) collectTCPStates(snap *model.Snapshot) {
 	}
 }
 
+// sockOwner is the process holding a socket.
+type sockOwner struct {
+	pid  int
+	comm string
+}
+
 // resolveSocketOwners maps socket inodes to PIDs by scanning /proc/*/fd/.
 // Resolves both ephemeral port users and CLOSE_WAIT leakers in a single walk.
-// Returns (top 10 port users, top 15 CW leakers sorted by count desc).
-func resolveSocketOwners(ephInodes map[uint64]int, cwInodes map[uint64]cwSocketInfo, now time.Time) ([]model.PortUser, []model.CloseWaitLeaker) {
+// Returns (top 10 port users, top 15 CW leakers sorted by count desc, and
+// the owner of every ephemeral-port inode found).
+func resolveSocketOwners(ephInodes map[uint64]int, cwInodes map[uint64]cwSocketInfo, now time.Time) ([]model.PortUser, []model.CloseWaitLeaker, map[uint64]sockOwner) {
 	type ephPidAgg struct {
 		comm        string
 		ports       int
@@ -314,6 +328,7 @@ func resolveSocketOwners(ephInodes map[uint64]int, cwInodes map[uint64]cwSocketI
 	}
 	ephPidMap := make(map[int]*ephPidAgg)
 	cwPidMap := make(map[int]*cwPidAgg)
+	owners := make(map[uint64]sockOwner)
 
 	// Build target sets: "socket:[inode]" strings for fast lookup
 	// ephLookup: socket string -> state
@@ -332,7 +347,7 @@ func resolveSocketOwners(ephInodes map[uint64]int, cwInodes map[uint64]cwSocketI
 	// Scan /proc/*/fd/ — single walk for both ephemeral and CW inodes
 	procEntries, err := os.ReadDir("/proc")
 	if err != nil {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	matched := 0
@@ -377,6 +392,9 @@ func resolveSocketOwners(ephInodes map[uint64]int, cwInodes map[uint64]cwSocketI
 					ephPidMap[pid] = agg
 				}
 				agg.ports++
+				if inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(target, "socket:["), "]"), 10, 64); err == nil {
+					owners[inode] = sockOwner{pid: pid, comm: comm}
+				}
 				switch state {
 				case 0x01:
 					agg.established++
@@ -483,7 +501,7 @@ func resolveSocketOwners(ephInodes map[uint64]int, cwInodes map[uint64]cwSocketI
 		})
 	}
 
-	return users, leakers
+	return users, leakers, owners
 }
 
 // readCommForPID reads the process command name from /proc/PID/comm.
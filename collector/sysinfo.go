@@ -13,9 +13,12 @@ import (
 )
 
 // SysInfoCollector collects hostname, IPs, and virtualization type once.
+// IPs are re-read after a NIC is hot-plugged (see RescanDevices).
 type SysInfoCollector struct {
-	once   sync.Once
-	cached *model.SysInfo
+	once     sync.Once
+	mu       sync.Mutex
+	cached   *model.SysInfo
+	staleIPs bool
 }
 
 func (s *SysInfoCollector) Name() string { return "sysinfo" }
@@ -24,10 +27,25 @@ func (s *SysInfoCollector) Collect(snap *model.Snapshot) error {
 	s.once.Do(func() {
 		s.cached = collectSysInfo()
 	})
+	s.mu.Lock()
+	if s.staleIPs {
+		info := *s.cached
+		info.IPs = collectIPs()
+		s.cached = &info
+		s.staleIPs = false
+	}
 	snap.SysInfo = s.cached
+	s.mu.Unlock()
 	return nil
 }
 
+// RescanDevices re-reads the host's addresses on the next tick.
+func (s *SysInfoCollector) RescanDevices() {
+	s.mu.Lock()
+	s.staleIPs = true
+	s.mu.Unlock()
+}
+
 func collectSysInfo() *model.SysInfo {
 	info := &model.SysInfo{}
 
@@ -50,6 +68,12 @@ func collectSysInfo() *model.SysInfo {
 	// Virtualization + cloud detection
 	info.Virtualization, info.CloudProvider = detectVirtAndCloud()
 
+	// Readable by root only. The fleet hub matches it against hypervisor
+	// agents' guest lists to find the VM's neighbors.
+	if id, err := util.ReadFileString("/sys/class/dmi/id/product_uuid"); err == nil {
+		info.MachineUUID = strings.ToLower(strings.TrimSpace(id))
+	}
+
 	return info
 }
 
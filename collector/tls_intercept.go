@@ -0,0 +1,98 @@
+package collector
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// middleboxIssuers are substrings of issuer names used by TLS-inspecting
+// proxies and endpoint security products when they re-sign upstream
+// certificates. Matched case-insensitively against the issuer DN.
+var middleboxIssuers = []string{
+	"zscaler",
+	"fortinet", "fortigate",
+	"palo alto", "paloalto",
+	"blue coat", "bluecoat", "symantec web security",
+	"cisco umbrella", "opendns",
+	"netskope",
+	"sophos",
+	"check point", "checkpoint",
+	"forcepoint", "websense",
+	"barracuda",
+	"mcafee web gateway", "skyhigh",
+	"kaspersky", "eset ssl filter", "avast", "avg web/mail shield", "bitdefender",
+	"mitmproxy", "charles proxy", "fiddler", "portswigger", "burp",
+}
+
+// inspectPeerCert records the leaf certificate's issuer and expiry and
+// decides whether the handshake was intercepted. Loopback targets are
+// exempt: a self-signed cert on 127.0.0.1 is normal, and no middlebox sits
+// on the loopback path.
+func inspectPeerCert(r *model.HealthProbeResult, host string, cs tls.ConnectionState) {
+	if len(cs.PeerCertificates) == 0 {
+		return
+	}
+	leaf := cs.PeerCertificates[0]
+	r.CertDaysLeft = int(time.Until(leaf.NotAfter).Hours() / 24)
+	r.TLSIssuer = leaf.Issuer.String()
+
+	inter := x509.NewCertPool()
+	for _, c := range cs.PeerCertificates[1:] {
+		inter.AddCert(c)
+	}
+	_, err := leaf.Verify(x509.VerifyOptions{DNSName: host, Intermediates: inter})
+	r.TLSTrusted = err == nil
+
+	if isLoopbackHost(host) {
+		return
+	}
+	if vendor := middleboxVendor(leaf.Issuer.String()); vendor != "" {
+		r.Intercepted = true
+		r.InterceptReason = fmt.Sprintf("certificate issued by %s (%s), a TLS-inspecting product", issuerOrg(r.TLSIssuer), vendor)
+	}
+}
+
+// middleboxVendor returns the matching middleboxIssuers entry, or "".
+func middleboxVendor(issuer string) string {
+	low := strings.ToLower(issuer)
+	for _, m := range middleboxIssuers {
+		if strings.Contains(low, m) {
+			return m
+		}
+	}
+	return ""
+}
+
+// issuerOrg reduces an issuer DN to its organisation (O=), falling back to
+// the CN. CAs rotate intermediates (Let's Encrypt R10 → R11) far more often
+// than they change organisation, so the org is what gets pinned.
+func issuerOrg(dn string) string {
+	var cn string
+	for _, part := range strings.Split(dn, ",") {
+		part = strings.TrimSpace(part)
+		if v, ok := strings.CutPrefix(part, "O="); ok {
+			return v
+		}
+		if v, ok := strings.CutPrefix(part, "CN="); ok {
+			cn = v
+		}
+	}
+	if cn != "" {
+		return cn
+	}
+	return dn
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
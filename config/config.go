@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // Config holds user-configurable defaults and integrations.
@@ -22,8 +23,47 @@ type Config struct {
 	CriticalServices []string              `json:"critical_services,omitempty"`
 	ThresholdProfile string                `json:"threshold_profile,omitempty"`
 	ExperienceLevel  string                `json:"experience_level,omitempty"` // "beginner", "advanced", or "" (first run)
+	ChartImages      string                `json:"chart_images,omitempty"`     // "auto" (default), "off", "kitty", "iterm2", "sixel"
 	Autopilot        AutopilotConfig       `json:"autopilot,omitempty"`
 	SLO              SLOConfig             `json:"slo,omitempty"`
+
+	// Reloadable at runtime (SIGHUP / Ctrl+R) together with
+	// threshold_profile and alerts.
+	ProbeTargets []string       `json:"probe_targets,omitempty"` // XTOP_PROBE_TARGETS syntax
+	Guard        GuardConfig    `json:"guard,omitempty"`
+	OOMAvoid     OOMAvoidConfig `json:"oom_avoid,omitempty"`
+	Experimental []string       `json:"experimental,omitempty"` // hidden-latency, temporal-chains, blame, no-<name>, all, none
+
+	// Display units, decimal separator, clock and time zone for the TUI,
+	// reports and alerts. XTOP_UNITS, XTOP_DECIMAL, XTOP_CLOCK and XTOP_TZ
+	// win over the file.
+	Format util.Format `json:"format,omitempty"`
+}
+
+// GuardConfig overrides the resource guard thresholds. Zero values keep
+// the built-in defaults; XTOP_GUARD* environment variables win over both.
+type GuardConfig struct {
+	Enabled         *bool   `json:"enabled,omitempty"`
+	OwnCPUPct       float64 `json:"own_cpu_pct,omitempty"`
+	LoadWarn        float64 `json:"load_warn,omitempty"`
+	LoadCrit        float64 `json:"load_crit,omitempty"`
+	HostBusyWarnPct float64 `json:"host_busy_warn_pct,omitempty"`
+	HostBusyCritPct float64 `json:"host_busy_crit_pct,omitempty"`
+	MaxIntervalSec  int     `json:"max_interval_sec,omitempty"`
+}
+
+// OOMAvoidConfig turns on earlyoom-style OOM avoidance: when available
+// memory and free swap are both under their floors, xtop terminates a
+// victim chosen by policy before the kernel OOM killer stalls the box.
+// Zero floors keep the defaults (10%); XTOP_OOM_AVOID* environment
+// variables win over the file.
+type OOMAvoidConfig struct {
+	Enabled      bool     `json:"enabled,omitempty"`
+	MemFloorPct  float64  `json:"mem_floor_pct,omitempty"`
+	SwapFloorPct float64  `json:"swap_floor_pct,omitempty"`
+	Policy       string   `json:"policy,omitempty"`   // "largest_rss" (default) or "oom_score"
+	Denylist     []string `json:"denylist,omitempty"` // process names never killed
+	DryRun       bool     `json:"dry_run,omitempty"`
 }
 
 // AutopilotConfig configures the safe autopilot subsystem.
@@ -58,6 +98,19 @@ type AlertConfig struct {
 	SlackWebhook     string `json:"slack_webhook"`
 	TelegramBotToken string `json:"telegram_bot_token"`
 	TelegramChatID   string `json:"telegram_chat_id"`
+
+	// RateLimit caps alerts per channel; alerts over the budget are folded
+	// into one digest per window. ChannelRateLimits overrides it per channel
+	// ("webhook", "command", "email", "slack", "telegram").
+	RateLimit         AlertRateLimit            `json:"rate_limit,omitempty"`
+	ChannelRateLimits map[string]AlertRateLimit `json:"channel_rate_limits,omitempty"`
+}
+
+// AlertRateLimit is an alert budget. Zero values take the defaults
+// (5 alerts per 60s); max_per_window -1 disables limiting.
+type AlertRateLimit struct {
+	MaxPerWindow int `json:"max_per_window,omitempty"`
+	WindowSec    int `json:"window_sec,omitempty"`
 }
 
 // Default returns a config with sensible defaults.
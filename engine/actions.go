@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ftahirops/xtop/advice"
 	"github.com/ftahirops/xtop/model"
 )
 
@@ -31,15 +32,10 @@ func SuggestActions(result *model.AnalysisResult) []model.Action {
 		if result.PrimaryAppName != "" {
 			displayName = result.PrimaryAppName
 		}
-		actions = append(actions, model.Action{
-			Summary: fmt.Sprintf("Top culprit: %s (PID %d) — consuming most %s resources",
-				displayName, result.PrimaryPID, result.PrimaryBottleneck),
-		})
+		actions = append(actions, advice.Action("rca.culprit.process", displayName, result.PrimaryPID, result.PrimaryBottleneck))
 	}
 	if result.PrimaryCulprit != "" {
-		actions = append(actions, model.Action{
-			Summary: fmt.Sprintf("Owning cgroup: %s", cleanCgroupName(result.PrimaryCulprit)),
-		})
+		actions = append(actions, advice.Action("rca.culprit.cgroup", cleanCgroupName(result.PrimaryCulprit)))
 	}
 
 	// ── Point to deep diagnostics for known services ──
@@ -49,18 +45,13 @@ func SuggestActions(result *model.AnalysisResult) []model.Action {
 			diagName = result.PrimaryAppName
 		}
 		if svc := knownService(diagName); svc != "" {
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Press W for %s deep diagnostics (connections, queries, config) — press O for error rate logs", svc),
-			})
+			actions = append(actions, advice.Action("rca.service.diag", svc))
 		}
 	}
 
 	// ── Deployment correlation ──
 	if result.RecentDeploy != "" {
-		actions = append(actions, model.Action{
-			Summary: fmt.Sprintf("Recent deploy detected: %s (PID %d, started %ds ago) — likely trigger",
-				result.RecentDeploy, result.RecentDeployPID, result.RecentDeployAge),
-		})
+		actions = append(actions, advice.Action("rca.deploy.recent", result.RecentDeploy, result.RecentDeployPID, result.RecentDeployAge))
 	}
 
 	switch result.PrimaryBottleneck {
@@ -98,9 +89,7 @@ func cpuActions(result *model.AnalysisResult, primary *model.RCAEntry) []model.A
 				top = append(top, fmt.Sprintf("%s %.1f%%", o.Name, o.Pct))
 			}
 		}
-		actions = append(actions, model.Action{
-			Summary: fmt.Sprintf("Top CPU consumers: %s", strings.Join(top, ", ")),
-		})
+		actions = append(actions, advice.Action("rca.cpu.top", strings.Join(top, ", ")))
 	}
 
 	if primary == nil {
@@ -113,21 +102,13 @@ func cpuActions(result *model.AnalysisResult, primary *model.RCAEntry) []model.A
 		}
 		switch c.Group {
 		case "cpu.cgroup.throttle":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Cgroup CPU throttling detected: %s — increase cpu.max quota or reduce load", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.cpu.throttle", c.Value))
 		case "cpu.steal":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("CPU steal: %s — VM is overcommitted on hypervisor, migrate or resize", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.cpu.steal", c.Value))
 		case "cpu.runqueue":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Run queue saturated: %s — more threads than CPUs, reduce parallelism or scale out", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.cpu.runqueue", c.Value))
 		case "cpu.softirq":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("High softirq CPU: %s — network interrupt storm or packet flood", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.cpu.softirq", c.Value))
 		}
 	}
 
@@ -150,9 +131,7 @@ func memActions(result *model.AnalysisResult, primary *model.RCAEntry) []model.A
 				top = append(top, fmt.Sprintf("%s %s", o.Name, o.Value))
 			}
 		}
-		actions = append(actions, model.Action{
-			Summary: fmt.Sprintf("Top memory consumers: %s", strings.Join(top, ", ")),
-		})
+		actions = append(actions, advice.Action("rca.mem.top", strings.Join(top, ", ")))
 	}
 
 	if primary == nil {
@@ -165,21 +144,13 @@ func memActions(result *model.AnalysisResult, primary *model.RCAEntry) []model.A
 		}
 		switch c.Group {
 		case "mem.swap.activity":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Active swapping: %s — system is thrashing, reduce memory usage or add RAM", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.mem.swap", c.Value))
 		case "mem.reclaim.direct":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Direct reclaim active: %s — kernel stalling to free memory", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.mem.reclaim", c.Value))
 		case "mem.oom.kills":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("OOM kills occurred: %s — processes being killed by kernel", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.mem.oom", c.Value))
 		case "mem.available.low":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Available memory critically low: %s — risk of OOM", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.mem.available", c.Value))
 		}
 	}
 
@@ -202,9 +173,14 @@ func ioActions(result *model.AnalysisResult, primary *model.RCAEntry) []model.Ac
 				top = append(top, fmt.Sprintf("%s %s", o.Name, o.Value))
 			}
 		}
-		actions = append(actions, model.Action{
-			Summary: fmt.Sprintf("Top IO consumers: %s", strings.Join(top, ", ")),
-		})
+		actions = append(actions, advice.Action("rca.io.top", strings.Join(top, ", ")))
+	}
+
+	// A starved database with a known aggressor gets exact cgroup settings
+	// in place of the generic latency advice.
+	plan := result.IOBackpressure
+	if plan != nil {
+		actions = append(actions, plan.Actions...)
 	}
 
 	if primary == nil {
@@ -225,29 +201,21 @@ func ioActions(result *model.AnalysisResult, primary *model.RCAEntry) []model.Ac
 						if m.ETASeconds > 0 {
 							eta = fmt.Sprintf("~%.0fm to full", m.ETASeconds/60)
 						}
-						actions = append(actions, model.Action{
-							Summary: fmt.Sprintf("Filesystem %s at %.1f%% used (%s) — %s",
-								m.MountPoint, m.UsedPct, m.Device, eta),
-						})
+						actions = append(actions, advice.Action("rca.io.fsfull.mount", m.MountPoint, m.UsedPct, m.Device, eta))
 					}
 				}
 			} else {
-				actions = append(actions, model.Action{
-					Summary: fmt.Sprintf("Filesystem pressure: %s — check DiskGuard page (D)", c.Value),
-				})
+				actions = append(actions, advice.Action("rca.io.fsfull", c.Value))
 			}
 		case "io.writeback":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Heavy dirty page writeback: %s — large write burst or flushing backlog", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.io.writeback", c.Value))
 		case "io.latency":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("High disk latency: %s — storage overloaded, check IOPS limits", c.Value),
-			})
+			if plan != nil {
+				continue
+			}
+			actions = append(actions, advice.Action("rca.io.latency", c.Value))
 		case "io.dstate":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Processes stuck in D-state (uninterruptible IO): %s", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.io.dstate", c.Value))
 		}
 	}
 
@@ -266,9 +234,7 @@ func netActions(result *model.AnalysisResult, primary *model.RCAEntry) []model.A
 			}
 			top = append(top, fmt.Sprintf("%s %s", o.Name, o.Value))
 		}
-		actions = append(actions, model.Action{
-			Summary: fmt.Sprintf("Top network consumers: %s", strings.Join(top, ", ")),
-		})
+		actions = append(actions, advice.Action("rca.net.top", strings.Join(top, ", ")))
 	}
 
 	if primary == nil {
@@ -281,55 +247,33 @@ func netActions(result *model.AnalysisResult, primary *model.RCAEntry) []model.A
 		}
 		switch c.Group {
 		case "net.drops":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Packet drops detected: %s — NIC ring buffer overflow or backpressure", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.net.drops", c.Value))
 		case "net.retrans":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("TCP retransmissions: %s — network congestion or remote host issues", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.net.retrans", c.Value))
 		case "net.conntrack":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Conntrack table pressure: %s — nf_conntrack_max may need increase", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.net.conntrack", c.Value))
 		case "net.tcp.state":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("TCP state anomaly: %s — TIME_WAIT accumulation or SYN backlog", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.net.tcp_state", c.Value))
 		case "net.closewait":
-			cwSummary := fmt.Sprintf("CLOSE_WAIT leak: %s", c.Value)
 			if len(result.CloseWaitLeakers) > 0 {
 				top := result.CloseWaitLeakers[0]
-				cwSummary = fmt.Sprintf("CLOSE_WAIT leak: %s (PID %d) holding %d stale sockets, oldest %s — app not calling close()",
-					top.Comm, top.PID, top.Count, fmtAge(top.OldestAge))
+				actions = append(actions, advice.Action("rca.net.closewait.leaker",
+					top.Comm, top.PID, top.Count, fmtAge(top.OldestAge)))
+			} else {
+				actions = append(actions, advice.Action("rca.net.closewait", c.Value))
 			}
-			actions = append(actions, model.Action{
-				Summary: cwSummary,
-			})
 		case "net.errors":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Interface errors: %s — check cable/NIC health", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.net.errors", c.Value))
 		case "net.conntrack.drops":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Conntrack drops: %s — increase nf_conntrack_max or investigate connection flood", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.net.conntrack.drops", c.Value))
 		case "net.conntrack.insertfail":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Conntrack insert failures: %s — table rejecting new flows, increase max or reduce churn", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.net.conntrack.insertfail", c.Value))
 		case "net.conntrack.growth":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Conntrack growth: %s — connections accumulating faster than closing; check keepalive/pooling", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.net.conntrack.growth", c.Value))
 		case "net.conntrack.invalid":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Conntrack invalid: %s — malformed packets, check asymmetric routing or LB config", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.net.conntrack.invalid", c.Value))
 		case "net.conntrack.hashcontention":
-			actions = append(actions, model.Action{
-				Summary: fmt.Sprintf("Conntrack hash contention: %s — consider increasing buckets, check CPU/IRQ balance", c.Value),
-			})
+			actions = append(actions, advice.Action("rca.net.conntrack.hashcontention", c.Value))
 		}
 	}
 
@@ -339,41 +283,21 @@ func netActions(result *model.AnalysisResult, primary *model.RCAEntry) []model.A
 func exhaustionAction(ex model.ExhaustionPrediction) model.Action {
 	switch ex.Resource {
 	case "Memory":
-		return model.Action{
-			Summary: fmt.Sprintf("Memory exhaustion in ~%.0fm (%.1f%% used, growing %.2f%%/s) — identify growing process or add RAM",
-				ex.EstMinutes, ex.CurrentPct, ex.TrendPerS),
-		}
+		return advice.Action("rca.exhaust.memory", ex.EstMinutes, ex.CurrentPct, ex.TrendPerS)
 	case "Swap":
-		return model.Action{
-			Summary: fmt.Sprintf("Swap exhaustion in ~%.0fm (%.1f%% used) — reduce memory pressure to stop swapping",
-				ex.EstMinutes, ex.CurrentPct),
-		}
+		return advice.Action("rca.exhaust.swap", ex.EstMinutes, ex.CurrentPct)
 	case "Ephemeral ports":
-		return model.Action{
-			Summary: fmt.Sprintf("Port exhaustion in ~%.0fm (%.1f%% used) — connection churn too high, check TIME_WAIT accumulation",
-				ex.EstMinutes, ex.CurrentPct),
-		}
+		return advice.Action("rca.exhaust.ports", ex.EstMinutes, ex.CurrentPct)
 	case "File descriptors":
-		return model.Action{
-			Summary: fmt.Sprintf("FD exhaustion in ~%.0fm (%.1f%% used) — file descriptor leak in progress",
-				ex.EstMinutes, ex.CurrentPct),
-		}
+		return advice.Action("rca.exhaust.fds", ex.EstMinutes, ex.CurrentPct)
 	case "CLOSE_WAIT sockets":
-		return model.Action{
-			Summary: fmt.Sprintf("CLOSE_WAIT growing — %.0f sockets at +%.1f/s, will exhaust FDs. Check Network page (4)",
-				ex.CurrentPct, ex.TrendPerS),
-		}
+		return advice.Action("rca.exhaust.closewait", ex.CurrentPct, ex.TrendPerS)
 	default:
 		if strings.HasPrefix(ex.Resource, "Disk ") {
 			mount := strings.TrimPrefix(ex.Resource, "Disk ")
-			return model.Action{
-				Summary: fmt.Sprintf("Disk %s exhaustion in ~%.0fm (%.1f%% full) — see DiskGuard page (D) for top writers and big files",
-					mount, ex.EstMinutes, ex.CurrentPct),
-			}
-		}
-		return model.Action{
-			Summary: fmt.Sprintf("%s exhaustion in ~%.0fm (%.1f%% used)", ex.Resource, ex.EstMinutes, ex.CurrentPct),
+			return advice.Action("rca.exhaust.disk", mount, ex.EstMinutes, ex.CurrentPct)
 		}
+		return advice.Action("rca.exhaust.other", ex.Resource, ex.EstMinutes, ex.CurrentPct)
 	}
 }
 
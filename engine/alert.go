@@ -15,6 +15,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/ftahirops/xtop/util"
 )
 
 // AlertConfig defines alert destinations.
@@ -25,6 +27,11 @@ type AlertConfig struct {
 	SlackWebhook     string
 	TelegramBotToken string
 	TelegramChatID   string
+
+	// RateLimit is the per-channel alert budget; ChannelLimits overrides it
+	// for individual channels (keys are the AlertChan* names).
+	RateLimit     AlertRateLimit
+	ChannelLimits map[string]AlertRateLimit
 }
 
 // alertJob is a queued alert notification.
@@ -33,12 +40,18 @@ type alertJob struct {
 	payload interface{}
 }
 
+// digestInterval is how often the worker checks for due digests.
+const digestInterval = 5 * time.Second
+
 // Notifier sends alert notifications.
 type Notifier struct {
-	cfg    AlertConfig
-	client *http.Client
-	queue  chan alertJob
-	once   sync.Once
+	mu      sync.RWMutex // guards cfg; SetConfig swaps it on reload
+	cfg     AlertConfig
+	client  *http.Client
+	queue   chan alertJob
+	once    sync.Once
+	limiter *alertLimiter
+	now     func() time.Time // test hook
 }
 
 // NewNotifier creates a notifier.
@@ -48,15 +61,53 @@ func NewNotifier(cfg AlertConfig) *Notifier {
 		client: &http.Client{
 			Timeout: 5 * time.Second,
 		},
-		queue: make(chan alertJob, 100),
+		queue:   make(chan alertJob, 100),
+		limiter: newAlertLimiter(cfg.RateLimit, cfg.ChannelLimits),
+		now:     time.Now,
 	}
 }
 
+// config returns the current destinations.
+func (n *Notifier) config() AlertConfig {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.cfg
+}
+
+// SetConfig swaps in new destinations and budgets (config reload). The
+// queue, worker and each channel's window carry over, so alerts already
+// counted or held for a digest are not forgotten.
+func (n *Notifier) SetConfig(cfg AlertConfig) {
+	n.mu.Lock()
+	n.cfg = cfg
+	n.mu.Unlock()
+	n.limiter.SetLimits(cfg.RateLimit, cfg.ChannelLimits)
+}
+
 // Enabled returns true if any alert destination is configured.
 func (n *Notifier) Enabled() bool {
-	return n.cfg.Webhook != "" || n.cfg.Command != "" ||
-		n.cfg.Email != "" || n.cfg.SlackWebhook != "" ||
-		(n.cfg.TelegramBotToken != "" && n.cfg.TelegramChatID != "")
+	return len(n.config().Channels()) > 0
+}
+
+// Channels lists the configured destinations by AlertChan* name.
+func (c AlertConfig) Channels() []string {
+	var out []string
+	if c.Webhook != "" {
+		out = append(out, AlertChanWebhook)
+	}
+	if c.Command != "" {
+		out = append(out, AlertChanCommand)
+	}
+	if c.Email != "" {
+		out = append(out, AlertChanEmail)
+	}
+	if c.SlackWebhook != "" {
+		out = append(out, AlertChanSlack)
+	}
+	if c.TelegramBotToken != "" && c.TelegramChatID != "" {
+		out = append(out, AlertChanTelegram)
+	}
+	return out
 }
 
 // Notify sends an alert event asynchronously.
@@ -64,9 +115,7 @@ func (n *Notifier) Notify(event string, payload interface{}) {
 	if !n.Enabled() {
 		return
 	}
-	n.once.Do(func() {
-		go n.alertWorker()
-	})
+	n.startWorker()
 	// Non-blocking send: drop alert if queue is full
 	select {
 	case n.queue <- alertJob{event: event, payload: payload}:
@@ -75,36 +124,81 @@ func (n *Notifier) Notify(event string, payload interface{}) {
 	}
 }
 
-// alertWorker processes queued alerts sequentially.
+func (n *Notifier) startWorker() {
+	n.once.Do(func() {
+		go n.alertWorker()
+	})
+}
+
+// alertWorker processes queued alerts sequentially and sends rate-limit
+// digests once their window closes.
 func (n *Notifier) alertWorker() {
-	for job := range n.queue {
-		n.notify(job.event, job.payload)
+	tick := time.NewTicker(digestInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case job, ok := <-n.queue:
+			if !ok {
+				return
+			}
+			n.notify(job.event, job.payload)
+		case <-tick.C:
+			n.flushDigests()
+		}
+	}
+}
+
+// allow consults the channel's rate limit, holding the alert for the
+// digest when the budget is spent.
+func (n *Notifier) allow(channel, event, summary string) bool {
+	return n.limiter.Allow(channel, n.now(), event, summary)
+}
+
+// flushDigests sends one digest per channel that suppressed alerts during a
+// window that has now closed.
+func (n *Notifier) flushDigests() {
+	for _, d := range n.limiter.DueDigests(n.now()) {
+		log.Printf("xtop: %s: sending digest of %d suppressed alerts", d.Channel, d.Count)
+		switch d.Channel {
+		case AlertChanWebhook:
+			n.sendWebhook("alert_digest", d)
+		case AlertChanCommand:
+			n.sendCommand("alert_digest", d)
+		case AlertChanEmail:
+			n.sendEmail(d.Subject(), d.Text())
+		case AlertChanSlack:
+			n.sendSlack(d.Text())
+		case AlertChanTelegram:
+			n.sendTelegram(d.Text())
+		}
 	}
 }
 
 // SendFormatted dispatches a formatted alert to all configured channels.
 func (n *Notifier) SendFormatted(event, subject, text string, payload interface{}) {
+	cfg := n.config()
 	if !n.Enabled() {
 		return
 	}
+	n.startWorker() // flushes digests for anything suppressed below
 	// Webhook
-	if n.cfg.Webhook != "" {
+	if cfg.Webhook != "" && n.allow(AlertChanWebhook, event, subject) {
 		n.sendWebhook(event, payload)
 	}
 	// Command
-	if n.cfg.Command != "" {
+	if cfg.Command != "" && n.allow(AlertChanCommand, event, subject) {
 		n.sendCommand(event, payload)
 	}
 	// Email
-	if n.cfg.Email != "" {
+	if cfg.Email != "" && n.allow(AlertChanEmail, event, subject) {
 		n.sendEmail(subject, text)
 	}
 	// Slack
-	if n.cfg.SlackWebhook != "" {
+	if cfg.SlackWebhook != "" && n.allow(AlertChanSlack, event, subject) {
 		n.sendSlack(text)
 	}
 	// Telegram
-	if n.cfg.TelegramBotToken != "" && n.cfg.TelegramChatID != "" {
+	if cfg.TelegramBotToken != "" && cfg.TelegramChatID != "" && n.allow(AlertChanTelegram, event, subject) {
 		n.sendTelegram(text)
 	}
 }
@@ -113,7 +207,7 @@ func (n *Notifier) SendFormatted(event, subject, text string, payload interface{
 func (n *Notifier) sendEmail(subject, body string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	cmd := exec.CommandContext(ctx, "mail", "-s", subject, n.cfg.Email)
+	cmd := exec.CommandContext(ctx, "mail", "-s", subject, n.config().Email)
 	cmd.Stdin = strings.NewReader(body)
 	if err := cmd.Run(); err != nil {
 		log.Printf("xtop: email send error: %v", err)
@@ -122,7 +216,8 @@ func (n *Notifier) sendEmail(subject, body string) {
 
 // sendSlack posts a message to a Slack incoming webhook.
 func (n *Notifier) sendSlack(text string) {
-	if err := validateWebhookURL(n.cfg.SlackWebhook); err != nil {
+	cfg := n.config()
+	if err := validateWebhookURL(cfg.SlackWebhook); err != nil {
 		log.Printf("xtop: slack webhook blocked: %v", err)
 		return
 	}
@@ -131,7 +226,7 @@ func (n *Notifier) sendSlack(text string) {
 	if err != nil {
 		return
 	}
-	req, err := http.NewRequest("POST", n.cfg.SlackWebhook, bytes.NewReader(data))
+	req, err := http.NewRequest("POST", cfg.SlackWebhook, bytes.NewReader(data))
 	if err != nil {
 		return
 	}
@@ -147,9 +242,10 @@ func (n *Notifier) sendSlack(text string) {
 
 // sendTelegram posts a message via the Telegram Bot API.
 func (n *Notifier) sendTelegram(text string) {
-	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", n.cfg.TelegramBotToken)
+	cfg := n.config()
+	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", cfg.TelegramBotToken)
 	payload := map[string]string{
-		"chat_id": n.cfg.TelegramChatID,
+		"chat_id": cfg.TelegramChatID,
 		"text":    text,
 	}
 	data, err := json.Marshal(payload)
@@ -203,21 +299,22 @@ func validateWebhookURL(rawURL string) error {
 
 // sendWebhook posts JSON to the configured webhook URL.
 func (n *Notifier) sendWebhook(event string, payload interface{}) {
+	cfg := n.config()
 	body := map[string]interface{}{
 		"event":   event,
 		"payload": payload,
-		"ts":      time.Now().Format(time.RFC3339),
+		"ts":      util.LocalTime(time.Now()).Format(time.RFC3339),
 	}
 	data, err := json.Marshal(body)
 	if err != nil {
 		log.Printf("xtop: alert marshal error: %v", err)
 		return
 	}
-	if err := validateWebhookURL(n.cfg.Webhook); err != nil {
+	if err := validateWebhookURL(cfg.Webhook); err != nil {
 		log.Printf("xtop: webhook blocked: %v", err)
 		return
 	}
-	req, err := http.NewRequest("POST", n.cfg.Webhook, bytes.NewReader(data))
+	req, err := http.NewRequest("POST", cfg.Webhook, bytes.NewReader(data))
 	if err != nil {
 		return
 	}
@@ -235,32 +332,34 @@ func (n *Notifier) sendCommand(event string, payload interface{}) {
 	data, _ := json.Marshal(map[string]interface{}{
 		"event":   event,
 		"payload": payload,
-		"ts":      time.Now().Format(time.RFC3339),
+		"ts":      util.LocalTime(time.Now()).Format(time.RFC3339),
 	})
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	cmd := exec.CommandContext(ctx, "sh", "-c", n.cfg.Command)
+	cmd := exec.CommandContext(ctx, "sh", "-c", n.config().Command)
 	cmd.Env = append(os.Environ(), "XTOP_EVENT="+event, "XTOP_PAYLOAD="+string(data))
 	_ = cmd.Run()
 }
 
 func (n *Notifier) notify(event string, payload interface{}) {
+	cfg := n.config()
 	body := map[string]interface{}{
 		"event":   event,
 		"payload": payload,
-		"ts":      time.Now().Format(time.RFC3339),
+		"ts":      util.LocalTime(time.Now()).Format(time.RFC3339),
 	}
 	data, err := json.Marshal(body)
 	if err != nil {
 		log.Printf("xtop: alert marshal error: %v", err)
 		return
 	}
+	summary := alertSummary(event, payload)
 
-	if n.cfg.Webhook != "" {
-		if err := validateWebhookURL(n.cfg.Webhook); err != nil {
+	if cfg.Webhook != "" && n.allow(AlertChanWebhook, event, summary) {
+		if err := validateWebhookURL(cfg.Webhook); err != nil {
 			log.Printf("xtop: webhook blocked: %v", err)
 		} else {
-			req, err := http.NewRequest("POST", n.cfg.Webhook, bytes.NewReader(data))
+			req, err := http.NewRequest("POST", cfg.Webhook, bytes.NewReader(data))
 			if err == nil {
 				req.Header.Set("Content-Type", "application/json")
 				resp, err := n.client.Do(req)
@@ -272,21 +371,32 @@ func (n *Notifier) notify(event string, payload interface{}) {
 		}
 	}
 
-	if n.cfg.Command != "" {
+	if cfg.Command != "" && n.allow(AlertChanCommand, event, summary) {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		cmd := exec.CommandContext(ctx, "sh", "-c", n.cfg.Command)
+		cmd := exec.CommandContext(ctx, "sh", "-c", cfg.Command)
 		cmd.Env = append(os.Environ(), "XTOP_EVENT="+event, "XTOP_PAYLOAD="+string(data))
 		_ = cmd.Run()
 	}
 
-	if n.cfg.Email != "" {
+	if cfg.Email != "" && n.allow(AlertChanEmail, event, summary) {
 		n.sendEmail("xtop: "+event, string(data))
 	}
-	if n.cfg.SlackWebhook != "" {
+	if cfg.SlackWebhook != "" && n.allow(AlertChanSlack, event, summary) {
 		n.sendSlack(fmt.Sprintf("*xtop: %s*\n```\n%s\n```", event, string(data)))
 	}
-	if n.cfg.TelegramBotToken != "" && n.cfg.TelegramChatID != "" {
+	if cfg.TelegramBotToken != "" && cfg.TelegramChatID != "" && n.allow(AlertChanTelegram, event, summary) {
 		n.sendTelegram(fmt.Sprintf("xtop: %s\n%s", event, string(data)))
 	}
 }
+
+// alertSummary is the one-line form of an alert kept in digests.
+func alertSummary(event string, payload interface{}) string {
+	const max = 160
+	data, _ := json.Marshal(payload)
+	s := event + " " + string(data)
+	if len(s) > max {
+		s = s[:max-3] + "..."
+	}
+	return s
+}
@@ -0,0 +1,207 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ─── Notification rate limiting ─────────────────────────────────────────────
+//
+// When a host melts down every subsystem trips at once, and an unthrottled
+// notifier turns that into a webhook flood. Each channel gets a sliding
+// window budget; alerts over the budget are held back and folded into one
+// digest sent when the window closes ("15 alerts in the last 1m0s: ...").
+
+// Alert channel names, used as keys for per-channel rate limits.
+const (
+	AlertChanWebhook  = "webhook"
+	AlertChanCommand  = "command"
+	AlertChanEmail    = "email"
+	AlertChanSlack    = "slack"
+	AlertChanTelegram = "telegram"
+)
+
+// Default budget: five alerts per channel per minute before digesting.
+const (
+	defaultAlertMax    = 5
+	defaultAlertWindow = time.Minute
+	maxDigestSamples   = 10
+)
+
+// AlertRateLimit is a per-channel alert budget. Max alerts may be sent per
+// Window; the rest are summarized in a digest. Zero fields take the
+// defaults (5 per minute); Max < 0 disables limiting.
+type AlertRateLimit struct {
+	Max    int
+	Window time.Duration
+}
+
+func (r AlertRateLimit) withDefaults(fallback AlertRateLimit) AlertRateLimit {
+	if r.Max == 0 {
+		r.Max = fallback.Max
+	}
+	if r.Window <= 0 {
+		r.Window = fallback.Window
+	}
+	return r
+}
+
+// suppressedAlert is one alert held back for the digest.
+type suppressedAlert struct {
+	at      time.Time
+	event   string
+	summary string
+}
+
+// alertDigest is the summary of alerts suppressed during one window.
+type alertDigest struct {
+	Channel string         `json:"channel"`
+	Count   int            `json:"count"`
+	Window  string         `json:"window"`
+	Since   time.Time      `json:"since"`
+	Events  map[string]int `json:"events"`
+	Samples []string       `json:"samples,omitempty"`
+}
+
+// Subject returns a one-line title for email-style channels.
+func (d alertDigest) Subject() string {
+	return fmt.Sprintf("xtop: %d alerts suppressed (digest)", d.Count)
+}
+
+// Text renders the digest for chat/email channels.
+func (d alertDigest) Text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "xtop digest: %d more alerts in the last %s (rate limit reached)\n", d.Count, d.Window)
+	names := make([]string, 0, len(d.Events))
+	for ev := range d.Events {
+		names = append(names, ev)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if d.Events[names[i]] != d.Events[names[j]] {
+			return d.Events[names[i]] > d.Events[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, ev := range names {
+		fmt.Fprintf(&sb, "  %dx %s\n", d.Events[ev], ev)
+	}
+	for _, s := range d.Samples {
+		sb.WriteString("  - " + s + "\n")
+	}
+	return sb.String()
+}
+
+// channelLimiter tracks one channel's sliding window and pending digest.
+type channelLimiter struct {
+	limit   AlertRateLimit
+	sent    []time.Time
+	pending []suppressedAlert
+}
+
+// allow reports whether an alert may go out now; otherwise it is queued for
+// the digest.
+func (l *channelLimiter) allow(now time.Time, event, summary string) bool {
+	if l.limit.Max < 0 {
+		return true
+	}
+	cutoff := now.Add(-l.limit.Window)
+	kept := l.sent[:0]
+	for _, t := range l.sent {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	l.sent = kept
+	if len(l.sent) < l.limit.Max {
+		l.sent = append(l.sent, now)
+		return true
+	}
+	l.pending = append(l.pending, suppressedAlert{at: now, event: event, summary: summary})
+	return false
+}
+
+// takeDigest returns the pending digest once the window that started with
+// the first suppressed alert has closed. The digest itself counts against
+// the next window's budget.
+func (l *channelLimiter) takeDigest(channel string, now time.Time) (alertDigest, bool) {
+	if len(l.pending) == 0 || now.Sub(l.pending[0].at) < l.limit.Window {
+		return alertDigest{}, false
+	}
+	d := alertDigest{
+		Channel: channel,
+		Count:   len(l.pending),
+		Window:  l.limit.Window.String(),
+		Since:   l.pending[0].at,
+		Events:  make(map[string]int),
+	}
+	for _, p := range l.pending {
+		d.Events[p.event]++
+		if len(d.Samples) < maxDigestSamples && p.summary != "" {
+			d.Samples = append(d.Samples, p.summary)
+		}
+	}
+	l.pending = nil
+	l.sent = append(l.sent, now)
+	return d, true
+}
+
+// alertLimiter holds one channelLimiter per channel.
+type alertLimiter struct {
+	mu       sync.Mutex
+	def      AlertRateLimit
+	perChan  map[string]AlertRateLimit
+	channels map[string]*channelLimiter
+}
+
+func newAlertLimiter(def AlertRateLimit, perChan map[string]AlertRateLimit) *alertLimiter {
+	return &alertLimiter{
+		def:      def.withDefaults(AlertRateLimit{Max: defaultAlertMax, Window: defaultAlertWindow}),
+		perChan:  perChan,
+		channels: make(map[string]*channelLimiter),
+	}
+}
+
+// SetLimits replaces the budgets. Existing channels keep their sliding
+// window and pending digest; only the limit they are measured against changes.
+func (a *alertLimiter) SetLimits(def AlertRateLimit, perChan map[string]AlertRateLimit) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.def = def.withDefaults(AlertRateLimit{Max: defaultAlertMax, Window: defaultAlertWindow})
+	a.perChan = perChan
+	for name, l := range a.channels {
+		l.limit = perChan[name].withDefaults(a.def)
+	}
+}
+
+func (a *alertLimiter) channel(name string) *channelLimiter {
+	l := a.channels[name]
+	if l == nil {
+		l = &channelLimiter{limit: a.perChan[name].withDefaults(a.def)}
+		a.channels[name] = l
+	}
+	return l
+}
+
+// Allow reports whether channel may send an alert now.
+func (a *alertLimiter) Allow(channel string, now time.Time, event, summary string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.channel(channel).allow(now, event, summary)
+}
+
+// DueDigests returns every channel digest whose window has closed.
+func (a *alertLimiter) DueDigests(now time.Time) []alertDigest {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var out []alertDigest
+	for name, l := range a.channels {
+		if d, ok := l.takeDigest(name, now); ok {
+			out = append(out, d)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Channel < out[j].Channel })
+	return out
+}
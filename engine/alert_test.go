@@ -1,6 +1,9 @@
 package engine
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestValidateWebhookURL(t *testing.T) {
 	cases := []struct {
@@ -43,3 +46,73 @@ func TestValidateWebhookURL(t *testing.T) {
 		})
 	}
 }
+
+func TestAlertLimiterDigest(t *testing.T) {
+	lim := newAlertLimiter(AlertRateLimit{Max: 3, Window: time.Minute},
+		map[string]AlertRateLimit{AlertChanSlack: {Max: -1}})
+	now := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	// 15 alerts in one minute: 3 go out, 12 are held for the digest.
+	sent := 0
+	for i := 0; i < 15; i++ {
+		ev := "health_critical"
+		if i%3 == 0 {
+			ev = "event_closed"
+		}
+		if lim.Allow(AlertChanWebhook, now.Add(time.Duration(i)*time.Second), ev, "") {
+			sent++
+		}
+	}
+	if sent != 3 {
+		t.Fatalf("sent %d alerts, want 3", sent)
+	}
+	if d := lim.DueDigests(now.Add(30 * time.Second)); len(d) != 0 {
+		t.Fatalf("digest sent before the window closed: %+v", d)
+	}
+	d := lim.DueDigests(now.Add(2 * time.Minute))
+	if len(d) != 1 || d[0].Channel != AlertChanWebhook || d[0].Count != 12 {
+		t.Fatalf("digests = %+v", d)
+	}
+	if d[0].Events["health_critical"] != 8 || d[0].Events["event_closed"] != 4 {
+		t.Errorf("event counts = %v", d[0].Events)
+	}
+	if got := lim.DueDigests(now.Add(3 * time.Minute)); len(got) != 0 {
+		t.Errorf("digest sent twice: %+v", got)
+	}
+
+	// Slack overrides the default with no limit.
+	for i := 0; i < 20; i++ {
+		if !lim.Allow(AlertChanSlack, now, "x", "") {
+			t.Fatal("unlimited channel was throttled")
+		}
+	}
+}
+
+func TestNotifierSetConfigKeepsWindow(t *testing.T) {
+	n := NewNotifier(AlertConfig{Webhook: "https://example.com/hook", RateLimit: AlertRateLimit{Max: 2, Window: time.Minute}})
+	now := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	n.now = func() time.Time { return now }
+	for i := 0; i < 4; i++ {
+		n.allow(AlertChanWebhook, "x", "")
+	}
+
+	// Reload: swap destinations and raise the budget. The two alerts
+	// already sent still count, and the two held back stay in the digest.
+	n.SetConfig(AlertConfig{SlackWebhook: "https://hooks.slack.com/x", Webhook: "https://example.com/hook",
+		RateLimit: AlertRateLimit{Max: 3, Window: time.Minute}})
+	if got := n.config().Channels(); len(got) != 2 || got[0] != AlertChanWebhook || got[1] != AlertChanSlack {
+		t.Fatalf("channels = %v", got)
+	}
+	if !n.allow(AlertChanWebhook, "x", "") || n.allow(AlertChanWebhook, "x", "") {
+		t.Error("raised budget not applied to the existing window")
+	}
+	d := n.limiter.DueDigests(now.Add(2 * time.Minute))
+	if len(d) != 1 || d[0].Count != 3 {
+		t.Errorf("digests = %+v", d)
+	}
+
+	n.SetConfig(AlertConfig{})
+	if n.Enabled() {
+		t.Error("notifier still enabled with no destinations")
+	}
+}
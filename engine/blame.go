@@ -2,28 +2,85 @@ package engine
 
 import (
 	"fmt"
+	"math"
 	"sort"
 
 	"github.com/ftahirops/xtop/model"
 	"github.com/ftahirops/xtop/util"
 )
 
-// ComputeBlame identifies top offending processes/cgroups for the primary bottleneck.
-func ComputeBlame(result *model.AnalysisResult, curr *model.Snapshot, rates *model.RateSnapshot) []model.BlameEntry {
+// blameMaxSecondary caps how many non-primary domains get their own
+// offender list; they need at least a degraded RCA score to qualify.
+const blameMaxSecondary = 2
+
+// ComputeBlameDomains ranks offenders for the primary bottleneck and for
+// every other domain under real pressure, so automation can ask "who to
+// restart first" even when two resources are saturated at once. The primary
+// domain comes first, the rest by RCA score.
+func ComputeBlameDomains(result *model.AnalysisResult, curr *model.Snapshot, rates *model.RateSnapshot) []model.BlameDomain {
 	if result == nil || rates == nil || result.Health == model.HealthOK {
 		return nil
 	}
+	var out []model.BlameDomain
+	if result.PrimaryBottleneck != "" {
+		if entries := blameDomain(result, curr, rates, result.PrimaryBottleneck, result.PrimaryScore); len(entries) > 0 {
+			out = append(out, model.BlameDomain{
+				Bottleneck: result.PrimaryBottleneck,
+				Score:      result.PrimaryScore,
+				Primary:    true,
+				Offenders:  entries,
+			})
+		}
+	}
 
+	secondary := make([]model.RCAEntry, 0, len(result.RCA))
+	for _, rca := range result.RCA {
+		if rca.Bottleneck != result.PrimaryBottleneck && rca.Score >= rcaScoreDegraded {
+			secondary = append(secondary, rca)
+		}
+	}
+	sort.SliceStable(secondary, func(i, j int) bool { return secondary[i].Score > secondary[j].Score })
+	if len(secondary) > blameMaxSecondary {
+		secondary = secondary[:blameMaxSecondary]
+	}
+	for _, rca := range secondary {
+		if entries := blameDomain(result, curr, rates, rca.Bottleneck, rca.Score); len(entries) > 0 {
+			out = append(out, model.BlameDomain{Bottleneck: rca.Bottleneck, Score: rca.Score, Offenders: entries})
+		}
+	}
+	return out
+}
+
+// blameDomain ranks offenders for one bottleneck and fills in their share
+// of the domain's attributed load and the confidence in each.
+func blameDomain(result *model.AnalysisResult, curr *model.Snapshot, rates *model.RateSnapshot, bottleneck string, score int) []model.BlameEntry {
 	var entries []model.BlameEntry
-	switch result.PrimaryBottleneck {
+	var total float64
+	switch bottleneck {
 	case BottleneckCPU:
-		entries = blameCPU(result, rates)
+		entries, total = blameCPU(result, rates)
 	case BottleneckMemory:
-		entries = blameMemory(rates)
+		entries, total = blameMemory(rates)
 	case BottleneckIO:
-		entries = blameIO(result, rates)
+		entries, total = blameIO(result, rates)
 	case BottleneckNetwork:
-		entries = blameNetwork(result, rates, curr)
+		entries, total = blameNetwork(result, rates, curr)
+	}
+
+	for i := range entries {
+		entries[i].Domain = bottleneck
+		if total > 0 {
+			entries[i].SharePct = entries[i].ImpactPct / total * 100
+		}
+	}
+	for i := range entries {
+		next := 0.0
+		for j := range entries {
+			if j != i && entries[j].SharePct > next && entries[j].SharePct <= entries[i].SharePct {
+				next = entries[j].SharePct
+			}
+		}
+		entries[i].Confidence = blameConfidence(entries[i].SharePct, next, score)
 	}
 
 	// Resolve application identity and enrich with app-specific reasons
@@ -37,7 +94,7 @@ func ComputeBlame(result *model.AnalysisResult, curr *model.Snapshot, rates *mod
 						continue
 					}
 					var domain model.Domain
-					switch result.PrimaryBottleneck {
+					switch bottleneck {
 					case BottleneckIO:
 						domain = model.DomainIO
 					case BottleneckMemory:
@@ -59,6 +116,20 @@ func ComputeBlame(result *model.AnalysisResult, curr *model.Snapshot, rates *mod
 	return entries
 }
 
+// blameConfidence scores how likely it is that acting on an offender
+// relieves the domain. A dominant offender (large share, well ahead of the
+// runner-up) in a domain with strong RCA evidence scores high; one of many
+// similar consumers, or a domain barely past degraded, scores low.
+func blameConfidence(sharePct, nextPct float64, score int) float64 {
+	if sharePct <= 0 {
+		return 0
+	}
+	lead := (sharePct - nextPct) / sharePct
+	evidence := 0.5 + 0.5*math.Min(float64(score), 100)/100
+	c := (0.6*sharePct/100 + 0.4*lead) * evidence
+	return math.Max(0, math.Min(c, 0.98))
+}
+
 // hasActiveEvidence checks whether any RCA entry for the given bottleneck has
 // a firing evidence item (warn or critical) with the specified ID prefix.
 func hasActiveEvidence(result *model.AnalysisResult, bottleneck, evidenceID string) bool {
@@ -75,7 +146,10 @@ func hasActiveEvidence(result *model.AnalysisResult, bottleneck, evidenceID stri
 	return false
 }
 
-func blameCPU(result *model.AnalysisResult, rates *model.RateSnapshot) []model.BlameEntry {
+// Each blame* function returns its top offenders and the total impact of
+// every candidate it ranked, not just the ones returned; SharePct is
+// measured against that total.
+func blameCPU(result *model.AnalysisResult, rates *model.RateSnapshot) ([]model.BlameEntry, float64) {
 	type agg struct {
 		comm    string
 		pid     int
@@ -108,6 +182,13 @@ func blameCPU(result *model.AnalysisResult, rates *model.RateSnapshot) []model.B
 		})
 	}
 	sort.Slice(procs, func(i, j int) bool { return procs[i].cpuPct > procs[j].cpuPct })
+	var total float64
+	for _, p := range procs {
+		total += p.cpuPct
+	}
+	if stealActive {
+		total *= 0.5
+	}
 
 	n := 5
 	if len(procs) < n {
@@ -155,12 +236,13 @@ func blameCPU(result *model.AnalysisResult, rates *model.RateSnapshot) []model.B
 			},
 			ImpactPct: rates.CPUStealPct,
 		}}, entries...)
+		total += rates.CPUStealPct
 	}
 
-	return entries
+	return entries, total
 }
 
-func blameMemory(rates *model.RateSnapshot) []model.BlameEntry {
+func blameMemory(rates *model.RateSnapshot) ([]model.BlameEntry, float64) {
 	type agg struct {
 		comm   string
 		pid    int
@@ -188,6 +270,10 @@ func blameMemory(rates *model.RateSnapshot) []model.BlameEntry {
 		})
 	}
 	sort.Slice(procs, func(i, j int) bool { return procs[i].memPct > procs[j].memPct })
+	var total float64
+	for _, p := range procs {
+		total += p.memPct
+	}
 
 	n := 5
 	if len(procs) < n {
@@ -212,10 +298,10 @@ func blameMemory(rates *model.RateSnapshot) []model.BlameEntry {
 			ImpactPct:  p.memPct,
 		})
 	}
-	return entries
+	return entries, total
 }
 
-func blameIO(result *model.AnalysisResult, rates *model.RateSnapshot) []model.BlameEntry {
+func blameIO(result *model.AnalysisResult, rates *model.RateSnapshot) ([]model.BlameEntry, float64) {
 	// Detect writeback or disk latency evidence — when active, write-heavy
 	// processes deserve proportionally more blame than read-heavy ones.
 	writebackActive := hasActiveEvidence(result, BottleneckIO, "io.writeback")
@@ -260,6 +346,10 @@ func blameIO(result *model.AnalysisResult, rates *model.RateSnapshot) []model.Bl
 		})
 	}
 	sort.Slice(procs, func(i, j int) bool { return procs[i].ioMBs > procs[j].ioMBs })
+	var total float64
+	for _, p := range procs {
+		total += p.ioMBs
+	}
 
 	n := 5
 	if len(procs) < n {
@@ -286,10 +376,10 @@ func blameIO(result *model.AnalysisResult, rates *model.RateSnapshot) []model.Bl
 			ImpactPct:  p.ioMBs,
 		})
 	}
-	return entries
+	return entries, total
 }
 
-func blameNetwork(result *model.AnalysisResult, rates *model.RateSnapshot, curr *model.Snapshot) []model.BlameEntry {
+func blameNetwork(result *model.AnalysisResult, rates *model.RateSnapshot, curr *model.Snapshot) ([]model.BlameEntry, float64) {
 	var entries []model.BlameEntry
 
 	// 1. CLOSE_WAIT leakers — processes holding stale connections
@@ -457,8 +547,12 @@ func blameNetwork(result *model.AnalysisResult, rates *model.RateSnapshot, curr
 
 	// Limit to top 5
 	sort.Slice(entries, func(i, j int) bool { return entries[i].ImpactPct > entries[j].ImpactPct })
+	var total float64
+	for _, e := range entries {
+		total += e.ImpactPct
+	}
 	if len(entries) > 5 {
 		entries = entries[:5]
 	}
-	return entries
+	return entries, total
 }
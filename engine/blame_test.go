@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func TestComputeBlameDomains(t *testing.T) {
+	rates := &model.RateSnapshot{ProcessRates: []model.ProcessRate{
+		{PID: 100, Comm: "java", CPUPct: 300, MemPct: 10},
+		{PID: 200, Comm: "nginx", CPUPct: 60, MemPct: 2},
+		{PID: 300, Comm: "cron", CPUPct: 40},
+		{PID: 400, Comm: "redis-server", CPUPct: 5, MemPct: 60},
+		{PID: 500, Comm: "postgres", MemPct: 28},
+	}}
+	result := &model.AnalysisResult{
+		Health:            model.HealthCritical,
+		PrimaryBottleneck: BottleneckCPU,
+		PrimaryScore:      80,
+		RCA: []model.RCAEntry{
+			{Bottleneck: BottleneckCPU, Score: 80},
+			{Bottleneck: BottleneckMemory, Score: 40},
+			{Bottleneck: BottleneckIO, Score: 10},
+		},
+	}
+
+	domains := ComputeBlameDomains(result, nil, rates)
+	if len(domains) != 2 {
+		t.Fatalf("domains = %+v, want CPU and memory", domains)
+	}
+	cpu, mem := domains[0], domains[1]
+	if !cpu.Primary || cpu.Bottleneck != BottleneckCPU || mem.Primary || mem.Bottleneck != BottleneckMemory {
+		t.Fatalf("order = %s/%v, %s/%v", cpu.Bottleneck, cpu.Primary, mem.Bottleneck, mem.Primary)
+	}
+
+	top := cpu.Offenders[0]
+	if top.PID != 100 || top.Domain != BottleneckCPU || math.Abs(top.SharePct-300.0/405*100) > 0.01 {
+		t.Errorf("cpu top = %+v", top)
+	}
+	// Dominant offender well ahead of the runner-up in a critical domain.
+	if top.Confidence < 0.6 {
+		t.Errorf("cpu top confidence = %.2f, want >= 0.6", top.Confidence)
+	}
+	if c := cpu.Offenders[2].Confidence; c >= top.Confidence {
+		t.Errorf("minor offender confidence %.2f >= top %.2f", c, top.Confidence)
+	}
+
+	// redis-server and postgres share memory; lower confidence than the CPU hog.
+	if mem.Offenders[0].PID != 400 || mem.Offenders[0].Confidence >= top.Confidence {
+		t.Errorf("mem top = %+v", mem.Offenders[0])
+	}
+
+	result.Health = model.HealthOK
+	if d := ComputeBlameDomains(result, nil, rates); d != nil {
+		t.Errorf("healthy host blamed %+v", d)
+	}
+}
+
+func TestBlameConfidence(t *testing.T) {
+	if c := blameConfidence(100, 0, 100); c != 0.98 {
+		t.Errorf("sole offender in a saturated domain = %.2f, want capped 0.98", c)
+	}
+	if c := blameConfidence(45, 45, 60); c > 0.25 {
+		t.Errorf("tied offenders = %.2f, want low", c)
+	}
+	if c := blameConfidence(0, 0, 80); c != 0 {
+		t.Errorf("no share = %.2f", c)
+	}
+}
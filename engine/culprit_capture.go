@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"os"
+
+	"github.com/ftahirops/xtop/collector"
+	"github.com/ftahirops/xtop/model"
+)
+
+// culpritCapturer reads the full cmdline/environment of the blamed process
+// once per PID, only while the host is unhealthy. A culprit stays blamed
+// for many ticks, so the cache keeps this to a handful of /proc reads per
+// incident instead of one per tick.
+//
+// XTOP_CULPRIT_CAPTURE=0 turns capture off; XTOP_CULPRIT_ENV=0 keeps the
+// command line but skips the environment.
+type culpritCapturer struct {
+	enabled bool
+	withEnv bool
+	cache   map[int]*model.CulpritCapture // PID → capture (nil = unreadable)
+}
+
+const culpritCacheMax = 16
+
+func newCulpritCapturer() *culpritCapturer {
+	return &culpritCapturer{
+		enabled: os.Getenv("XTOP_CULPRIT_CAPTURE") != "0",
+		withEnv: os.Getenv("XTOP_CULPRIT_ENV") != "0",
+		cache:   make(map[int]*model.CulpritCapture),
+	}
+}
+
+// Attach sets result.CulpritCapture for the current primary PID. The cache
+// is dropped once the host is healthy again so a recycled PID in a later
+// incident is read afresh.
+func (c *culpritCapturer) Attach(result *model.AnalysisResult) {
+	if c == nil || !c.enabled || result == nil {
+		return
+	}
+	if result.Health == model.HealthOK {
+		if len(c.cache) > 0 {
+			c.cache = make(map[int]*model.CulpritCapture)
+		}
+		return
+	}
+	pid := result.PrimaryPID
+	if pid <= 0 {
+		return
+	}
+	cc, seen := c.cache[pid]
+	if !seen {
+		if len(c.cache) >= culpritCacheMax {
+			c.cache = make(map[int]*model.CulpritCapture)
+		}
+		cc, _ = collector.CaptureCulprit(pid, c.withEnv)
+		c.cache[pid] = cc
+	}
+	result.CulpritCapture = cc
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
@@ -28,6 +29,29 @@ type DaemonConfig struct {
 	// to the hub alongside its local event/summary writes.
 	Fleet   model.FleetAgentConfig
 	Version string // baked-in build version, passed to fleet heartbeats
+	// Runtime is applied at start-up. Reload, when set, runs on SIGHUP to
+	// re-read it and the alert destinations; the engine, event detector
+	// and notifier queue are kept, so no history or event state is lost.
+	Runtime RuntimeConfig
+	Reload  func() (RuntimeConfig, AlertConfig)
+}
+
+// reloadDaemonConfig handles SIGHUP: re-read the configuration and swap
+// the reloadable settings into the running engine and notifier.
+func reloadDaemonConfig(reload func() (RuntimeConfig, AlertConfig), eng *Engine, notifier *Notifier) {
+	if reload == nil {
+		log.Printf("SIGHUP: nothing to reload")
+		return
+	}
+	rc, alerts := reload()
+	changes := eng.ApplyRuntimeConfig(rc)
+	notifier.SetConfig(alerts)
+	channels := "none"
+	if ch := alerts.Channels(); len(ch) > 0 {
+		channels = strings.Join(ch, ", ")
+	}
+	changes = append(changes, "alert channels: "+channels)
+	log.Printf("config reloaded: %s", strings.Join(changes, "; "))
 }
 
 // compactSummary is a minimal per-tick record for the rolling log.
@@ -87,6 +111,11 @@ func RunDaemon(cfg DaemonConfig) error {
 	}
 	eng := NewEngineMode(cfg.History, int(cfg.Interval.Seconds()), mode)
 	defer eng.Close()
+	// OOM avoidance signals local processes, so only the daemon runs it:
+	// a TUI next to the daemon would double-kill, and replay or compare
+	// engines do not even watch this host.
+	eng.oomAvoid = newOOMAvoider(eng.memReliefQuit)
+	eng.ApplyRuntimeConfig(cfg.Runtime)
 
 	// Attach a fleet push client when the daemon was started with a hub
 	// configured. Matches what the foreground TUI path does in cmd/root.go.
@@ -174,6 +203,8 @@ func RunDaemon(cfg DaemonConfig) error {
 	// Signal handling
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
 
 	intervalTicker := time.NewTicker(cfg.Interval)
 	defer intervalTicker.Stop()
@@ -195,6 +226,8 @@ func RunDaemon(cfg DaemonConfig) error {
 		case <-sigCh:
 			log.Printf("xtop daemon shutting down")
 			return nil
+		case <-hupCh:
+			reloadDaemonConfig(cfg.Reload, eng, notifier)
 		case <-intervalTicker.C:
 			snap, rates, result := engTicker.Tick()
 			if snap == nil || result == nil {
@@ -209,6 +242,13 @@ func RunDaemon(cfg DaemonConfig) error {
 			// Event detection
 			detector.Process(snap, rates, result)
 
+			// OOM-avoidance victims since the last tick (already audited)
+			if notifier.Enabled() {
+				for _, act := range result.OOMAvoidActions {
+					notifier.Notify("oom_avoid", act)
+				}
+			}
+
 			// Auto-snapshot on health transition to CRITICAL
 			if result.Health == model.HealthCritical && prevHealth != model.HealthCritical {
 				snapPath := filepath.Join(incidentDir,
@@ -224,6 +264,7 @@ func RunDaemon(cfg DaemonConfig) error {
 						"process":    result.PrimaryProcess,
 						"app":        result.PrimaryAppName,
 						"pid":        result.PrimaryPID,
+						"actions":    result.Actions,
 					})
 				}
 			}
@@ -0,0 +1,203 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ftahirops/xtop/advice"
+	"github.com/ftahirops/xtop/collector"
+	"github.com/ftahirops/xtop/model"
+)
+
+// ─── Database writer attribution for D-state storms ─────────────────────────
+//
+// When IO is the bottleneck, tasks are piling up in D-state and the IO
+// culprit is a database, ask the database which statement is writing
+// (collector.CorrelateDBWriter) and put the answer in the narrative:
+// "ALTER TABLE on orders (240s) is the writer". The query shells out to
+// the mysql / psql clients, so it runs off the tick goroutine and at most
+// once per dbWriterInterval; ticks in between reuse the last answer.
+
+const (
+	dbWriterMinDState = 3                // D-state tasks that make it a storm
+	dbWriterInterval  = 30 * time.Second // re-query cadence while the storm lasts
+	dbWriterMaxAge    = 2 * time.Minute  // drop answers older than this
+	dbWriterMaxPIDs   = 3
+)
+
+type dbWriterCorrelator struct {
+	mu      sync.Mutex
+	running bool
+	lastRun time.Time
+	kind    string
+	latest  *model.DBWriterAttribution
+
+	correlate func(kind string, pids []int) *model.DBWriterAttribution
+}
+
+func newDBWriterCorrelator() *dbWriterCorrelator {
+	return &dbWriterCorrelator{correlate: collector.CorrelateDBWriter}
+}
+
+// Attach kicks off a background query when the trigger holds and copies the
+// latest answer into result: DBWriter, a narrative evidence line and
+// cancel/reschedule actions.
+func (c *dbWriterCorrelator) Attach(rates *model.RateSnapshot, result *model.AnalysisResult) {
+	if c == nil || result == nil {
+		return
+	}
+	kind, pids := dbWriterTrigger(rates, result)
+
+	c.mu.Lock()
+	if kind == "" {
+		c.latest, c.kind = nil, ""
+		c.mu.Unlock()
+		return
+	}
+	if kind != c.kind {
+		c.latest, c.kind = nil, kind
+	}
+	if !c.running && time.Since(c.lastRun) >= dbWriterInterval {
+		c.running = true
+		c.lastRun = time.Now()
+		go func() {
+			a := c.correlate(kind, pids)
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.running = false
+			if c.kind == kind {
+				c.latest = a
+			}
+		}()
+	}
+	a := c.latest
+	c.mu.Unlock()
+
+	if a == nil || time.Since(a.CheckedAt) > dbWriterMaxAge {
+		return
+	}
+	result.DBWriter = a
+	if result.Narrative != nil && a.Summary != "" {
+		result.Narrative.Evidence = append([]string{dbWriterEvidence(a)}, result.Narrative.Evidence...)
+	}
+	result.Actions = append(result.Actions, dbWriterActions(a)...)
+}
+
+// dbWriterTrigger returns the database kind and its top writer PIDs when
+// the current tick is a D-state storm with a database as the IO culprit.
+func dbWriterTrigger(rates *model.RateSnapshot, result *model.AnalysisResult) (string, []int) {
+	if rates == nil || result.Health == model.HealthOK || result.PrimaryBottleneck != BottleneckIO {
+		return "", nil
+	}
+	dstate := 0
+	var top *model.ProcessRate
+	var culprit string
+	for i := range rates.ProcessRates {
+		p := &rates.ProcessRates[i]
+		if p.State == "D" {
+			dstate++
+		}
+		if top == nil || p.ReadMBs+p.WriteMBs > top.ReadMBs+top.WriteMBs {
+			top = p
+		}
+		if p.PID == result.PrimaryPID {
+			culprit = p.Comm
+		}
+	}
+	if dstate < dbWriterMinDState {
+		return "", nil
+	}
+	kind := dbKindForComm(culprit)
+	if kind == "" && top != nil {
+		kind = dbKindForComm(top.Comm)
+	}
+	if kind == "" {
+		return "", nil
+	}
+
+	var writers []model.ProcessRate
+	for _, p := range rates.ProcessRates {
+		if dbKindForComm(p.Comm) == kind && (p.WriteMBs > 0 || p.PID == result.PrimaryPID) {
+			writers = append(writers, p)
+		}
+	}
+	sort.Slice(writers, func(i, j int) bool { return writers[i].WriteMBs > writers[j].WriteMBs })
+	var pids []int
+	for _, p := range writers {
+		if len(pids) == dbWriterMaxPIDs {
+			break
+		}
+		pids = append(pids, p.PID)
+	}
+	return kind, pids
+}
+
+// dbKindForComm maps a database process name to the client we can query.
+// Other databases in databaseComms have no statement-level view here.
+func dbKindForComm(comm string) string {
+	switch {
+	case comm == "mysqld" || comm == "mariadbd":
+		return "mysql"
+	case comm == "postmaster" || strings.HasPrefix(comm, "postgres"):
+		return "postgresql"
+	}
+	return ""
+}
+
+func dbWriterEvidence(a *model.DBWriterAttribution) string {
+	s := a.Statement
+	if s == nil {
+		return "DB writer: " + a.Summary
+	}
+	who := "conn"
+	if a.Kind == "postgresql" {
+		who = "backend"
+	}
+	line := fmt.Sprintf("DB writer: %s — %s %d", a.Summary, who, s.ID)
+	if s.User != "" {
+		line += " (" + s.User + ")"
+	}
+	if a.File != "" {
+		line += ", writing " + shortDBPath(a.File)
+	}
+	return line
+}
+
+// shortDBPath keeps the last two path elements: "shop/#sql-ib99-1.ibd".
+func shortDBPath(p string) string {
+	parts := strings.Split(p, "/")
+	if len(parts) > 2 {
+		parts = parts[len(parts)-2:]
+	}
+	return strings.Join(parts, "/")
+}
+
+func dbWriterActions(a *model.DBWriterAttribution) []model.Action {
+	s := a.Statement
+	if s == nil {
+		return nil
+	}
+	var cmd, how string
+	switch a.Kind {
+	case "mysql":
+		cmd = fmt.Sprintf(`mysql -e "KILL QUERY %d"`, s.ID)
+		how = "KILL QUERY"
+	case "postgresql":
+		cmd = fmt.Sprintf(`sudo -u postgres psql -c "SELECT pg_cancel_backend(%d)"`, s.ID)
+		how = "pg_cancel_backend"
+	default:
+		return nil
+	}
+	target := s.Verb
+	if s.Table != "" {
+		target += " on " + s.Table
+	}
+	out := []model.Action{advice.CommandAction(cmd, "rca.io.db_writer.cancel", target, how, s.ID)}
+	if a.Kind == "mysql" && (s.Verb == "ALTER TABLE" || s.Verb == "OPTIMIZE TABLE") {
+		out = append(out, advice.Action("rca.io.db_writer.online_ddl", s.Verb))
+	}
+	return out
+}
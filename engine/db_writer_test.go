@@ -0,0 +1,100 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func dStormRates() *model.RateSnapshot {
+	return &model.RateSnapshot{ProcessRates: []model.ProcessRate{
+		{PID: 100, Comm: "mysqld", State: "D", WriteMBs: 180},
+		{PID: 200, Comm: "php-fpm", State: "D", ReadMBs: 1},
+		{PID: 201, Comm: "php-fpm", State: "D"},
+		{PID: 202, Comm: "php-fpm", State: "D"},
+	}}
+}
+
+func TestDBWriterTrigger(t *testing.T) {
+	result := &model.AnalysisResult{Health: model.HealthCritical, PrimaryBottleneck: BottleneckIO, PrimaryPID: 100}
+	kind, pids := dbWriterTrigger(dStormRates(), result)
+	if kind != "mysql" || len(pids) != 1 || pids[0] != 100 {
+		t.Fatalf("got %q %v", kind, pids)
+	}
+
+	result.PrimaryBottleneck = BottleneckCPU
+	if kind, _ := dbWriterTrigger(dStormRates(), result); kind != "" {
+		t.Errorf("CPU bottleneck triggered %q", kind)
+	}
+
+	result.PrimaryBottleneck = BottleneckIO
+	calm := dStormRates()
+	for i := 1; i < len(calm.ProcessRates); i++ {
+		calm.ProcessRates[i].State = "S"
+	}
+	if kind, _ := dbWriterTrigger(calm, result); kind != "" {
+		t.Errorf("one D-state task triggered %q", kind)
+	}
+}
+
+func TestDBWriterAttach(t *testing.T) {
+	c := newDBWriterCorrelator()
+	calls := make(chan []int, 1)
+	c.correlate = func(kind string, pids []int) *model.DBWriterAttribution {
+		calls <- pids
+		return &model.DBWriterAttribution{
+			Kind:      kind,
+			Summary:   "ALTER TABLE on orders (240s) is the writer",
+			Statement: &model.DBStatement{ID: 12, User: "app", Verb: "ALTER TABLE", Table: "orders", Seconds: 240},
+			File:      "/var/lib/mysql/shop/#sql-ib99-1.ibd",
+			CheckedAt: time.Now(),
+		}
+	}
+	result := func() *model.AnalysisResult {
+		return &model.AnalysisResult{
+			Health: model.HealthCritical, PrimaryBottleneck: BottleneckIO, PrimaryPID: 100,
+			Narrative: &model.Narrative{Evidence: []string{"IO PSI full=40%"}},
+		}
+	}
+
+	// First tick starts the query; the answer lands on a later tick.
+	c.Attach(dStormRates(), result())
+	<-calls
+	for i := 0; i < 100; i++ {
+		c.mu.Lock()
+		done := !c.running
+		c.mu.Unlock()
+		if done {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	r := result()
+	c.Attach(dStormRates(), r)
+	if r.DBWriter == nil {
+		t.Fatal("no attribution attached")
+	}
+	ev := r.Narrative.Evidence[0]
+	if !strings.Contains(ev, "ALTER TABLE on orders (240s) is the writer") || !strings.Contains(ev, "conn 12") {
+		t.Errorf("evidence = %q", ev)
+	}
+	if len(r.Actions) != 2 || !strings.Contains(r.Actions[0].Command, "KILL QUERY 12") {
+		t.Errorf("actions = %+v", r.Actions)
+	}
+	select {
+	case <-calls:
+		t.Error("re-queried within the interval")
+	default:
+	}
+
+	// Storm over: answer dropped.
+	ok := result()
+	ok.Health = model.HealthOK
+	c.Attach(dStormRates(), ok)
+	if ok.DBWriter != nil || c.latest != nil {
+		t.Error("attribution kept after recovery")
+	}
+}
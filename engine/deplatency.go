@@ -0,0 +1,195 @@
+package engine
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bpf "github.com/ftahirops/xtop/collector/ebpf"
+	"github.com/ftahirops/xtop/model"
+)
+
+// ─── Dependency latency attribution ─────────────────────────────────────────
+//
+// Answers "which remote endpoint is slow, and who is paying for it". Every
+// tick the socket collector reports outbound connections with a request
+// outstanding (sent after the last receive, local port not listening) and
+// the age of the oldest one per remote ip:port. Each tick is one sample
+// per endpoint and owning process.
+//
+// Ticks taken while the host is healthy train a per-dependency baseline.
+// During an incident each dependency is ranked by how far its recent p95
+// sits above that baseline: "10.0.0.5:443 +180ms p95 → php-fpm".
+//
+// The sockio probe is deliberately not a source: its recv wait does not
+// tell an idle inbound keepalive client from a slow upstream. Neither is
+// the tcpconnlat sentinel: it only keeps a cumulative latency per PID with
+// a bare destination IP, so a process talking to several endpoints would
+// have all of it charged to one.
+
+const (
+	depWindow       = 60 * time.Second // recent window the p95 is taken over
+	depExpire       = 10 * time.Minute // forget dependencies idle this long
+	depMaxSeries    = 256              // bound memory on hosts with huge fan-out
+	depMaxSamples   = 120              // per-series sample ring
+	depBaselineA    = 0.1              // EWMA weight for healthy samples
+	depMinAddedMs   = 20.0             // below this nobody cares
+	depMinBaselineN = 3                // healthy samples before the baseline counts
+	depMinSamples   = 5                // recent samples before a dependency ranks
+)
+
+type depKey struct {
+	endpoint string // "10.0.0.5:443"
+	comm     string
+}
+
+type depSample struct {
+	at time.Time
+	ms float64
+}
+
+type depSeries struct {
+	samples   []depSample
+	baseline  float64
+	baselineN int
+	pid       int
+	source    string
+	lastSeen  time.Time
+}
+
+// DependencyTracker learns per-dependency latency baselines and ranks the
+// remote endpoints adding the most latency to local processes.
+type DependencyTracker struct {
+	mu     sync.Mutex
+	series map[depKey]*depSeries
+}
+
+// NewDependencyTracker creates an empty tracker.
+func NewDependencyTracker() *DependencyTracker {
+	return &DependencyTracker{series: make(map[depKey]*depSeries)}
+}
+
+// Observe feeds one tick of outbound in-flight requests. Only ticks taken
+// while the host is healthy train baselines.
+func (t *DependencyTracker) Observe(waits []model.OutboundWait, now time.Time, healthy bool) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, w := range waits {
+		if w.Endpoint == "" || w.InFlight == 0 {
+			continue
+		}
+		t.add(depKey{w.Endpoint, w.Comm}, w.PID, "inflight", w.MaxAgeMs, now, healthy)
+	}
+	t.expire(now)
+}
+
+// add records a sample. Caller holds t.mu.
+func (t *DependencyTracker) add(k depKey, pid int, source string, ms float64, now time.Time, healthy bool) {
+	s := t.series[k]
+	if s == nil {
+		if len(t.series) >= depMaxSeries {
+			return
+		}
+		s = &depSeries{}
+		t.series[k] = s
+	}
+	s.pid = pid
+	s.source = source
+	s.lastSeen = now
+	s.samples = append(s.samples, depSample{at: now, ms: ms})
+	if len(s.samples) > depMaxSamples {
+		s.samples = s.samples[len(s.samples)-depMaxSamples:]
+	}
+	if healthy {
+		if s.baselineN == 0 {
+			s.baseline = ms
+		} else {
+			s.baseline += depBaselineA * (ms - s.baseline)
+		}
+		s.baselineN++
+	}
+}
+
+// expire drops series idle longer than depExpire. Caller holds t.mu.
+func (t *DependencyTracker) expire(now time.Time) {
+	for k, s := range t.series {
+		if now.Sub(s.lastSeen) > depExpire {
+			delete(t.series, k)
+		}
+	}
+}
+
+// Rank returns up to n dependencies whose recent p95 exceeds their learned
+// baseline by at least depMinAddedMs, worst first. Dependencies with no
+// healthy baseline rank by their raw p95; those seen on fewer than
+// depMinSamples recent ticks are not ranked at all.
+func (t *DependencyTracker) Rank(now time.Time, n int) []model.DependencyLatency {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expire(now)
+
+	var out []model.DependencyLatency
+	for k, s := range t.series {
+		var recent []float64
+		for _, smp := range s.samples {
+			if now.Sub(smp.at) <= depWindow {
+				recent = append(recent, smp.ms)
+			}
+		}
+		if len(recent) < depMinSamples {
+			continue
+		}
+		sort.Float64s(recent)
+		p95 := percentile(recent, 0.95)
+		var base float64
+		if s.baselineN >= depMinBaselineN {
+			base = s.baseline
+		}
+		added := p95 - base
+		if added < depMinAddedMs {
+			continue
+		}
+		out = append(out, model.DependencyLatency{
+			Endpoint:   k.endpoint,
+			Service:    endpointService(k.endpoint),
+			Comm:       k.comm,
+			PID:        s.pid,
+			P95Ms:      p95,
+			BaselineMs: base,
+			AddedMs:    added,
+			Samples:    len(recent),
+			Source:     s.source,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].AddedMs != out[j].AddedMs {
+			return out[i].AddedMs > out[j].AddedMs
+		}
+		return out[i].Endpoint < out[j].Endpoint
+	})
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// endpointService names the well-known service behind "ip:port".
+func endpointService(endpoint string) string {
+	i := strings.LastIndex(endpoint, ":")
+	if i < 0 {
+		return ""
+	}
+	port, err := strconv.ParseUint(endpoint[i+1:], 10, 16)
+	if err != nil {
+		return ""
+	}
+	return bpf.WellKnownPort(uint16(port))
+}
@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// inflight builds one tick where php-fpm's oldest request to 10.0.0.5:443
+// had been outstanding for ageMs.
+func inflight(ageMs float64) []model.OutboundWait {
+	return []model.OutboundWait{
+		{Endpoint: "10.0.0.5:443", PID: 42, Comm: "php-fpm", InFlight: 1, MaxAgeMs: ageMs},
+	}
+}
+
+func TestDependencyTrackerAddedLatency(t *testing.T) {
+	tr := NewDependencyTracker()
+	now := time.Now()
+
+	// Healthy ticks: 20ms outstanding.
+	for i := 0; i < 10; i++ {
+		tr.Observe(inflight(20), now, true)
+		now = now.Add(3 * time.Second)
+	}
+	if got := tr.Rank(now, 5); len(got) != 0 {
+		t.Fatalf("healthy dependency should not rank: %+v", got)
+	}
+
+	// Degraded: the same endpoint now takes 200ms.
+	for i := 0; i < depMinSamples; i++ {
+		tr.Observe(inflight(200), now, false)
+		now = now.Add(time.Second)
+	}
+	got := tr.Rank(now, 5)
+	if len(got) != 1 {
+		t.Fatalf("got %d dependencies, want 1", len(got))
+	}
+	d := got[0]
+	if d.Endpoint != "10.0.0.5:443" || d.Service != "https" || d.Comm != "php-fpm" || d.Source != "inflight" {
+		t.Errorf("unexpected dependency: %+v", d)
+	}
+	if d.BaselineMs < 19 || d.BaselineMs > 21 {
+		t.Errorf("baseline = %.1f, want ~20 (degraded ticks must not train it)", d.BaselineMs)
+	}
+	if d.AddedMs < 170 || d.AddedMs > 190 {
+		t.Errorf("added = %.1f, want ~180", d.AddedMs)
+	}
+	if d.P95Ms < 199 {
+		t.Errorf("p95 = %.1f, want 200", d.P95Ms)
+	}
+}
+
+func TestDependencyTrackerMinSamples(t *testing.T) {
+	tr := NewDependencyTracker()
+	now := time.Now()
+	waits := []model.OutboundWait{
+		{Endpoint: "10.0.0.9:5432", PID: 7, Comm: "java", InFlight: 3, MaxAgeMs: 90},
+		{Endpoint: "10.0.0.9:6379", PID: 7, Comm: "java", InFlight: 1, MaxAgeMs: 2},
+	}
+	for i := 0; i < depMinSamples-1; i++ {
+		tr.Observe(waits, now, false)
+		now = now.Add(time.Second)
+	}
+	if got := tr.Rank(now, 5); len(got) != 0 {
+		t.Fatalf("a dependency seen on %d ticks should not rank: %+v", depMinSamples-1, got)
+	}
+
+	tr.Observe(waits, now, false)
+	got := tr.Rank(now, 5)
+	if len(got) != 1 || got[0].Service != "postgres" || got[0].Samples != depMinSamples {
+		t.Fatalf("unexpected ranking: %+v", got)
+	}
+	if got := tr.Rank(now.Add(2*depWindow), 5); len(got) != 0 {
+		t.Errorf("samples outside the window should not rank: %+v", got)
+	}
+}
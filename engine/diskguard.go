@@ -26,6 +26,19 @@ func (t *MountGrowthTracker) Smooth(rates []model.MountRate) {
 
 	now := time.Now()
 
+	// Retire mounts that went away (unmounted, volume detached) so a later
+	// mount at the same path starts from a clean slate.
+	present := make(map[string]bool, len(rates))
+	for _, r := range rates {
+		present[r.MountPoint] = true
+	}
+	for key := range t.ewma {
+		if !present[key] {
+			delete(t.ewma, key)
+			delete(t.growthStart, key)
+		}
+	}
+
 	for i := range rates {
 		r := &rates[i]
 		key := r.MountPoint
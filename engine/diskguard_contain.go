@@ -0,0 +1,337 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// DiskGuard modes, cycled with m on the DiskGuard page.
+const (
+	DiskGuardMonitor = "Monitor" // observe only
+	DiskGuardDryRun  = "DryRun"  // report what Contain would freeze
+	DiskGuardContain = "Contain" // auto-freeze the top writer on CRIT
+	DiskGuardAction  = "Action"  // Contain plus manual kill (x)
+)
+
+// Guard rails for automatic containment.
+const (
+	diskGuardAutoMinMBs     = 0.5              // writers slower than this are never auto-frozen
+	diskGuardManualMinMBs   = 0.1              // f / x need at least this much write activity
+	diskGuardCooldown       = 60 * time.Second // between automatic actions
+	diskGuardStableReset    = 30 * time.Second // continuous OK before the incident is over
+	diskGuardMaxPerIncident = 1                // automatic freezes per incident
+)
+
+// FreezeDenylist prevents critical system processes from being frozen or
+// killed, by DiskGuard or the signal menu.
+var FreezeDenylist = map[string]bool{
+	"mysqld": true, "mariadbd": true, "postgres": true, "mongod": true,
+	"redis-server": true, "journald": true, "systemd": true,
+	"systemd-journald": true, "sshd": true, "kubelet": true,
+	"containerd": true, "dockerd": true, "crio": true, "xtop": true,
+}
+
+// ProcControl sends signals and reads process identity. The live
+// implementation talks to the kernel; `xtop diskguard simulate` swaps in a
+// scripted process table so the policy runs without touching real
+// processes.
+type ProcControl interface {
+	// StartTime returns field 22 of /proc/PID/stat, unique per PID
+	// lifecycle, or "" if the process is gone.
+	StartTime(pid int) string
+	Signal(pid int, sig syscall.Signal) error
+}
+
+// LiveProcControl is the ProcControl of a running xtop.
+type LiveProcControl struct{}
+
+// StartTime reads field 22 (starttime) from /proc/PID/stat.
+func (LiveProcControl) StartTime(pid int) string {
+	return ReadProcStartTime(pid)
+}
+
+// Signal sends sig to pid.
+func (LiveProcControl) Signal(pid int, sig syscall.Signal) error {
+	return syscall.Kill(pid, sig)
+}
+
+// ReadProcStartTime reads field 22 (starttime) from /proc/PID/stat.
+// Returns empty string on error. Used to detect PID reuse.
+func ReadProcStartTime(pid int) string {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return ""
+	}
+	content := string(data)
+	closeIdx := strings.LastIndex(content, ")")
+	if closeIdx < 0 || closeIdx+2 >= len(content) {
+		return ""
+	}
+	fields := strings.Fields(content[closeIdx+2:])
+	if len(fields) < 20 {
+		return ""
+	}
+	return fields[19] // field 22 = starttime (0-indexed after comm: index 19)
+}
+
+// FrozenProc tracks a process frozen by DiskGuard.
+type FrozenProc struct {
+	Comm      string
+	WritePath string
+	FrozenAt  time.Time
+	StartTime string // /proc/PID/stat field 22 — unique per PID lifecycle
+}
+
+// DiskGuard is the containment policy behind the DiskGuard page: freeze
+// (SIGSTOP) the top writer when a filesystem goes CRIT, resume it once the
+// disk has been OK for a while, and never touch a denylisted process or a
+// PID that has since been reused.
+type DiskGuard struct {
+	Mode   string
+	Frozen map[int]FrozenProc
+	Msg    string // last action, for the page footer
+	MsgAt  time.Time
+
+	ctl             ProcControl
+	now             func() time.Time
+	notify          func(msg string) // sees every message, even ones overwritten within a tick
+	lastAction      time.Time        // cooldown: last auto-action time
+	incidentActions int              // auto-actions this incident
+	stableStart     time.Time        // when the disk became OK
+}
+
+// NewDiskGuard returns a guard in Monitor mode acting on real processes.
+func NewDiskGuard() *DiskGuard {
+	return NewDiskGuardWith(LiveProcControl{}, time.Now)
+}
+
+// NewDiskGuardWith returns a guard using ctl for signals and now as its
+// clock.
+func NewDiskGuardWith(ctl ProcControl, now func() time.Time) *DiskGuard {
+	return &DiskGuard{
+		Mode:   DiskGuardMonitor,
+		Frozen: make(map[int]FrozenProc),
+		ctl:    ctl,
+		now:    now,
+	}
+}
+
+// CycleMode advances Monitor → DryRun → Contain → Action → Monitor.
+func (g *DiskGuard) CycleMode() {
+	switch g.Mode {
+	case DiskGuardMonitor:
+		g.Mode = DiskGuardDryRun
+	case DiskGuardDryRun:
+		g.Mode = DiskGuardContain
+	case DiskGuardContain:
+		g.Mode = DiskGuardAction
+	default:
+		g.Mode = DiskGuardMonitor
+	}
+}
+
+func (g *DiskGuard) say(format string, args ...any) {
+	g.Msg = fmt.Sprintf(format, args...)
+	g.MsgAt = g.now()
+	if g.notify != nil {
+		g.notify(g.Msg)
+	}
+}
+
+func (g *DiskGuard) verify(pid int, fp FrozenProc) bool {
+	st := g.ctl.StartTime(pid)
+	return st != "" && st == fp.StartTime
+}
+
+func byWriteRate(procs []model.ProcessRate) []model.ProcessRate {
+	out := make([]model.ProcessRate, len(procs))
+	copy(out, procs)
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].WriteMBs > out[j].WriteMBs
+	})
+	return out
+}
+
+// Tick runs automatic containment for one tick. worst is the worst mount
+// state (WorstDiskGuardState); procs are this tick's process rates.
+func (g *DiskGuard) Tick(worst string, procs []model.ProcessRate) {
+	now := g.now()
+
+	// Track stable period for cooldown reset
+	if worst == "OK" {
+		if g.stableStart.IsZero() {
+			g.stableStart = now
+		}
+		// Reset incident action count after 30s continuous OK
+		if now.Sub(g.stableStart) >= diskGuardStableReset {
+			g.incidentActions = 0
+		}
+	} else {
+		g.stableStart = time.Time{}
+	}
+
+	// DryRun mode: log what WOULD happen but don't send signals. The
+	// target is picked exactly as Contain would pick it.
+	if g.Mode == DiskGuardDryRun && worst == "CRIT" {
+		if p, _, ok := g.freezeCandidate(procs); ok {
+			target := p.WritePath
+			if target == "" {
+				target = "unknown"
+			}
+			g.say("Would freeze: PID %d (%s) writing %.1f MB/s to %s", p.PID, p.Comm, p.WriteMBs, target)
+		}
+	}
+
+	// Contain mode: auto-freeze the top writer when CRIT, at most
+	// diskGuardMaxPerIncident times per incident and once per cooldown
+	if g.Mode == DiskGuardContain && worst == "CRIT" &&
+		(g.lastAction.IsZero() || now.Sub(g.lastAction) >= diskGuardCooldown) &&
+		g.incidentActions < diskGuardMaxPerIncident {
+		if p, st, ok := g.freezeCandidate(procs); ok {
+			if err := g.ctl.Signal(p.PID, syscall.SIGSTOP); err == nil {
+				g.Frozen[p.PID] = FrozenProc{
+					Comm:      p.Comm,
+					WritePath: p.WritePath,
+					FrozenAt:  now,
+					StartTime: st,
+				}
+				g.say("AUTO-FROZEN PID %d (%s) — disk CRIT, writing paused", p.PID, p.Comm)
+				g.lastAction = now
+				g.incidentActions++
+			}
+		}
+	}
+
+	// Auto-resume when disk drops to OK (any mode) — only after 30s continuous OK
+	if worst == "OK" && len(g.Frozen) > 0 && !g.stableStart.IsZero() && now.Sub(g.stableStart) >= diskGuardStableReset {
+		resumed := 0
+		for pid, fp := range g.Frozen {
+			if g.verify(pid, fp) {
+				if err := g.ctl.Signal(pid, syscall.SIGCONT); err == nil {
+					resumed++
+				}
+			}
+			delete(g.Frozen, pid)
+		}
+		if resumed > 0 {
+			g.say("AUTO-RESUMED %d process(es) — disk OK for 30s", resumed)
+		}
+	}
+
+	// Clean up dead or reused PIDs from frozen map
+	for pid, fp := range g.Frozen {
+		if !g.verify(pid, fp) {
+			delete(g.Frozen, pid)
+		}
+	}
+}
+
+// freezeCandidate returns the top writer automatic containment may
+// freeze, with its start time: writing at least diskGuardAutoMinMBs, not
+// already frozen, not denylisted, and still running.
+func (g *DiskGuard) freezeCandidate(procs []model.ProcessRate) (model.ProcessRate, string, bool) {
+	for _, p := range byWriteRate(procs) {
+		if p.WriteMBs < diskGuardAutoMinMBs {
+			break
+		}
+		if _, already := g.Frozen[p.PID]; already {
+			continue
+		}
+		if FreezeDenylist[p.Comm] {
+			g.say("Skipped: PID %d (%s) is in denylist", p.PID, p.Comm)
+			continue
+		}
+		if st := g.ctl.StartTime(p.PID); st != "" {
+			return p, st, true
+		}
+	}
+	return model.ProcessRate{}, "", false
+}
+
+// FreezeTop freezes the current top writer (f key, Contain/Action modes).
+func (g *DiskGuard) FreezeTop(procs []model.ProcessRate) {
+	procs = byWriteRate(procs)
+	if len(procs) == 0 || procs[0].WriteMBs <= diskGuardManualMinMBs {
+		return
+	}
+	p := procs[0]
+	if FreezeDenylist[p.Comm] {
+		g.say("Skipped: PID %d (%s) is in denylist", p.PID, p.Comm)
+		return
+	}
+	if _, already := g.Frozen[p.PID]; already {
+		g.say("PID %d (%s) already frozen", p.PID, p.Comm)
+		return
+	}
+	// #11: Verify PID still exists before sending SIGSTOP
+	st := g.ctl.StartTime(p.PID)
+	if st == "" {
+		g.say("PID %d no longer exists", p.PID)
+		return
+	}
+	if err := g.ctl.Signal(p.PID, syscall.SIGSTOP); err != nil {
+		g.say("Failed to freeze PID %d: %v", p.PID, err)
+		return
+	}
+	g.Frozen[p.PID] = FrozenProc{
+		Comm:      p.Comm,
+		WritePath: p.WritePath,
+		FrozenAt:  g.now(),
+		StartTime: st,
+	}
+	g.say("FROZEN PID %d (%s) — writing paused", p.PID, p.Comm)
+}
+
+// KillTop sends SIGKILL to the current top writer (x key, Action mode).
+func (g *DiskGuard) KillTop(procs []model.ProcessRate) {
+	procs = byWriteRate(procs)
+	if len(procs) == 0 || procs[0].WriteMBs <= diskGuardManualMinMBs {
+		g.say("No active writer to kill")
+		return
+	}
+	p := procs[0]
+	if FreezeDenylist[p.Comm] {
+		g.say("Skipped: PID %d (%s) is in denylist", p.PID, p.Comm)
+		return
+	}
+	// #11: Verify PID identity before killing. A PID we froze must still
+	// be the process we froze.
+	st := g.ctl.StartTime(p.PID)
+	fp, frozen := g.Frozen[p.PID]
+	switch {
+	case st == "":
+		g.say("PID %d no longer exists", p.PID)
+	case frozen && st != fp.StartTime:
+		g.say("PID %d was reused, aborting kill", p.PID)
+	default:
+		if err := g.ctl.Signal(p.PID, syscall.SIGKILL); err != nil {
+			g.say("Failed to kill PID %d (%s): %v", p.PID, p.Comm, err)
+		} else if p.WritePath != "" {
+			g.say("KILLED PID %d (%s) — was writing to %s", p.PID, p.Comm, p.WritePath)
+		} else {
+			g.say("KILLED PID %d (%s)", p.PID, p.Comm)
+		}
+	}
+	delete(g.Frozen, p.PID)
+}
+
+// ResumeAll sends SIGCONT to every frozen process that is still the one
+// we froze (r key).
+func (g *DiskGuard) ResumeAll() {
+	resumed := 0
+	for pid, fp := range g.Frozen {
+		if g.verify(pid, fp) {
+			if err := g.ctl.Signal(pid, syscall.SIGCONT); err == nil {
+				resumed++
+			}
+		}
+		delete(g.Frozen, pid)
+	}
+	g.say("RESUMED %d frozen process(es)", resumed)
+}
@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func TestSimulateDiskGuard(t *testing.T) {
+	report := SimulateDiskGuard()
+	if len(report.Runs) != 3 {
+		t.Fatalf("runs = %d, want Contain, DryRun and Action", len(report.Runs))
+	}
+	for _, run := range report.Runs {
+		if len(run.Checks) == 0 {
+			t.Errorf("%s: no checks", run.Mode)
+		}
+		for _, c := range run.Checks {
+			if !c.Pass {
+				t.Errorf("%s: %s failed: %s", run.Mode, c.Name, c.Detail)
+			}
+		}
+	}
+	if !report.Passed() {
+		t.Error("Passed() = false")
+	}
+}
+
+func TestDiskGuardContain(t *testing.T) {
+	h := newSimHost()
+	g := NewDiskGuardWith(h, h.now)
+	g.Mode = DiskGuardContain
+	h.spawn(10, "postgres", 90)
+	h.spawn(20, "dd", 50)
+	h.spawn(30, "rsync", 40)
+	procs := h.rates()
+
+	g.Tick("CRIT", procs)
+	if len(h.signals) != 1 || h.signals[0].pid != 20 || h.signals[0].sig != syscall.SIGSTOP {
+		t.Fatalf("signals = %+v, want SIGSTOP to dd only", h.signals)
+	}
+
+	// Still CRIT past the cooldown, but the incident already had its action.
+	h.elapsed += 2 * diskGuardCooldown
+	g.Tick("CRIT", procs)
+	if len(h.signals) != 1 {
+		t.Fatalf("froze %d processes in one incident", len(h.signals))
+	}
+
+	// dd exits and its PID is reused; the resume must not reach the
+	// newcomer.
+	h.spawn(20, "nginx", 0)
+	for i := 0; i <= int(diskGuardStableReset/diskGuardSimTick); i++ {
+		h.elapsed += diskGuardSimTick
+		g.Tick("OK", nil)
+	}
+	if len(h.signals) != 1 || len(g.Frozen) != 0 {
+		t.Fatalf("signals = %+v, frozen = %v after PID reuse", h.signals, g.Frozen)
+	}
+}
+
+func TestDiskGuardDryRun(t *testing.T) {
+	h := newSimHost()
+	g := NewDiskGuardWith(h, h.now)
+	g.Mode = DiskGuardDryRun
+	g.Tick("CRIT", []model.ProcessRate{
+		{PID: 10, Comm: "mysqld", WriteMBs: 90},
+		{PID: 20, Comm: "dd", WriteMBs: 50},
+	})
+	// Neither process exists on the simulated host.
+	if g.Msg != "Skipped: PID 10 (mysqld) is in denylist" || len(h.signals) != 0 {
+		t.Fatalf("msg = %q, signals = %d", g.Msg, len(h.signals))
+	}
+
+	h.spawn(20, "dd", 50)
+	h.elapsed += time.Second
+	g.Tick("CRIT", h.rates())
+	if want := "Would freeze: PID 20 (dd) writing 50.0 MB/s to /var/lib/dd/data"; g.Msg != want {
+		t.Fatalf("msg = %q, want %q", g.Msg, want)
+	}
+	if len(h.signals) != 0 || len(g.Frozen) != 0 {
+		t.Fatal("DryRun sent a signal")
+	}
+}
@@ -0,0 +1,507 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// diskGuardSimTick is the collection interval of the simulated host.
+const diskGuardSimTick = 3 * time.Second
+
+// DiskGuardSimEvent is one line of the simulation timeline.
+type DiskGuardSimEvent struct {
+	At   float64 `json:"at_sec"` // seconds since scenario start
+	Kind string  `json:"kind"`   // scenario, state, signal, guard
+	Text string  `json:"text"`
+}
+
+// DiskGuardSimCheck is one guard rail verified by the simulation.
+type DiskGuardSimCheck struct {
+	Name   string `json:"name"`
+	Pass   bool   `json:"pass"`
+	Detail string `json:"detail"`
+}
+
+// DiskGuardSimRun is the outcome of one scenario.
+type DiskGuardSimRun struct {
+	Scenario string              `json:"scenario"`
+	Mode     string              `json:"mode"`
+	Timeline []DiskGuardSimEvent `json:"timeline"`
+	Checks   []DiskGuardSimCheck `json:"checks"`
+}
+
+// DiskGuardSimReport is what `xtop diskguard simulate` prints.
+type DiskGuardSimReport struct {
+	Runs []DiskGuardSimRun `json:"runs"`
+}
+
+// Passed reports whether every check of every run passed.
+func (r DiskGuardSimReport) Passed() bool {
+	for _, run := range r.Runs {
+		for _, c := range run.Checks {
+			if !c.Pass {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SimulateDiskGuard drives the real DiskGuard policy and mount growth
+// tracker through synthetic disk-filling incidents. Processes, signals and
+// the clock are simulated, so it is safe to run anywhere: nothing is
+// stopped or killed. Operators run it before enabling Contain or Action on
+// a production host.
+func SimulateDiskGuard() DiskGuardSimReport {
+	ch, cg, clog := playDiskFill(DiskGuardContain)
+	dh, _, dlog := playDiskFill(DiskGuardDryRun)
+	return DiskGuardSimReport{Runs: []DiskGuardSimRun{
+		{Scenario: "disk-fill", Mode: DiskGuardContain, Timeline: ch.timeline, Checks: containChecks(ch, cg, clog)},
+		{Scenario: "disk-fill", Mode: DiskGuardDryRun, Timeline: dh.timeline, Checks: dryRunChecks(dh, dlog, ch)},
+		simulateAction(),
+	}}
+}
+
+// simProc is one process of the simulated host.
+type simProc struct {
+	comm     string
+	start    string
+	writeMBs float64
+	alive    bool
+	stopped  bool
+	reused   bool // took over the PID of an earlier process
+}
+
+// simSignal is a signal the policy sent, with the process it reached.
+type simSignal struct {
+	at    time.Duration
+	pid   int
+	sig   syscall.Signal
+	proc  simProc
+	state string // worst mount state on that tick
+}
+
+// simHost is the simulated process table, filesystem and clock. It is the
+// ProcControl of the guard under test.
+type simHost struct {
+	start   time.Time
+	elapsed time.Duration
+	procs   map[int]*simProc
+	nextST  int
+
+	totalBytes uint64
+	usedBytes  float64
+	tracker    *MountGrowthTracker
+	state      string
+
+	signals  []simSignal
+	timeline []DiskGuardSimEvent
+}
+
+func newSimHost() *simHost {
+	return &simHost{
+		start:      time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC),
+		procs:      make(map[int]*simProc),
+		nextST:     100000,
+		totalBytes: 100 << 30,
+		usedBytes:  75 << 30,
+		tracker:    NewMountGrowthTracker(),
+		state:      "OK",
+	}
+}
+
+func (h *simHost) now() time.Time {
+	return h.start.Add(h.elapsed)
+}
+
+func (h *simHost) log(kind, format string, args ...any) {
+	h.timeline = append(h.timeline, DiskGuardSimEvent{
+		At:   h.elapsed.Seconds(),
+		Kind: kind,
+		Text: fmt.Sprintf(format, args...),
+	})
+}
+
+// spawn starts comm as pid, replacing whatever ran there before.
+func (h *simHost) spawn(pid int, comm string, writeMBs float64) {
+	prev, existed := h.procs[pid]
+	h.nextST += 1000
+	h.procs[pid] = &simProc{
+		comm:     comm,
+		start:    strconv.Itoa(h.nextST),
+		writeMBs: writeMBs,
+		alive:    true,
+		reused:   existed && prev.start != "",
+	}
+}
+
+func (h *simHost) StartTime(pid int) string {
+	if p := h.procs[pid]; p != nil && p.alive {
+		return p.start
+	}
+	return ""
+}
+
+func (h *simHost) Signal(pid int, sig syscall.Signal) error {
+	p := h.procs[pid]
+	if p == nil || !p.alive {
+		return syscall.ESRCH
+	}
+	h.signals = append(h.signals, simSignal{at: h.elapsed, pid: pid, sig: sig, proc: *p, state: h.state})
+	h.log("signal", "%s → PID %d (%s)", sigName(sig), pid, p.comm)
+	switch sig {
+	case syscall.SIGSTOP:
+		p.stopped = true
+	case syscall.SIGCONT:
+		p.stopped = false
+	case syscall.SIGKILL:
+		p.alive = false
+	}
+	return nil
+}
+
+func sigName(sig syscall.Signal) string {
+	switch sig {
+	case syscall.SIGSTOP:
+		return "SIGSTOP"
+	case syscall.SIGCONT:
+		return "SIGCONT"
+	case syscall.SIGKILL:
+		return "SIGKILL"
+	}
+	return sig.String()
+}
+
+// rates builds this tick's process and mount rates. Stopped processes
+// write nothing; everyone else fills the disk.
+func (h *simHost) rates() []model.ProcessRate {
+	pids := make([]int, 0, len(h.procs))
+	for pid := range h.procs {
+		pids = append(pids, pid)
+	}
+	sort.Ints(pids)
+
+	var procs []model.ProcessRate
+	growth := 0.0
+	for _, pid := range pids {
+		p := h.procs[pid]
+		if !p.alive || p.stopped {
+			continue
+		}
+		growth += p.writeMBs * (1 << 20)
+		procs = append(procs, model.ProcessRate{
+			PID:       pid,
+			Comm:      p.comm,
+			WriteMBs:  p.writeMBs,
+			WritePath: "/var/lib/" + p.comm + "/data",
+		})
+	}
+	h.usedBytes += growth * diskGuardSimTick.Seconds()
+	h.usedBytes = min(h.usedBytes, float64(h.totalBytes))
+
+	free := float64(h.totalBytes) - h.usedBytes
+	mounts := []model.MountRate{{
+		MountPoint:        "/var/lib",
+		TotalBytes:        h.totalBytes,
+		UsedPct:           h.usedBytes / float64(h.totalBytes) * 100,
+		FreePct:           free / float64(h.totalBytes) * 100,
+		FreeBytes:         uint64(free),
+		GrowthBytesPerSec: growth,
+	}}
+	h.tracker.Smooth(mounts)
+	if s := WorstDiskGuardState(mounts); s != h.state {
+		m := mounts[0]
+		eta := "not growing"
+		if m.ETASeconds > 0 {
+			eta = "full in " + (time.Duration(m.ETASeconds) * time.Second).String()
+		}
+		h.log("state", "%s → %s  (/var/lib %.1f%% free, %s)", h.state, s, m.FreePct, eta)
+		h.state = s
+	}
+	return procs
+}
+
+// simStep is a scripted change to the host at a point in the scenario.
+type simStep struct {
+	at   time.Duration
+	note string
+	do   func(h *simHost)
+}
+
+// diskFillScenario is two incidents on /var/lib. A denylisted database is
+// the heaviest writer of the first; the runaway behind it must be frozen,
+// a second writer must wait out the per-incident cap, and the frozen PID
+// is reused by an unrelated process before recovery. In the second, the
+// runaway is auto-resumed once the disk is OK, fills it again, and must
+// not be re-frozen before the cooldown has passed.
+var diskFillScenario = []simStep{
+	{0, "baseline: mysqld 2 MB/s, backup 1 MB/s, /var/lib 25% free", func(h *simHost) {
+		h.spawn(2001, "mysqld", 2)
+		h.spawn(3002, "backup", 1)
+	}},
+	{60 * time.Second, "incident 1: logspam starts writing 25 MB/s, mysqld bursts to 40 MB/s", func(h *simHost) {
+		h.spawn(3001, "logspam", 25)
+		h.procs[2001].writeMBs = 40
+	}},
+	{90 * time.Second, "backup ramps to 12 MB/s while the disk is still CRIT", func(h *simHost) {
+		h.procs[3002].writeMBs = 12
+	}},
+	{150 * time.Second, "logspam (PID 3001) is killed by its supervisor; PID 3001 reused by cron-report", func(h *simHost) {
+		h.procs[3001].alive = false
+		h.spawn(3001, "cron-report", 0.2)
+	}},
+	{180 * time.Second, "operator deletes 40 GiB of old logs; mysqld and backup calm down", func(h *simHost) {
+		h.usedBytes -= 40 << 30
+		h.procs[2001].writeMBs = 2
+		h.procs[3002].writeMBs = 1
+	}},
+	{270 * time.Second, "incident 2: backup runs away at 80 MB/s", func(h *simHost) {
+		h.procs[3002].writeMBs = 80
+	}},
+	{276 * time.Second, "operator deletes 20 GiB of old backups", func(h *simHost) {
+		h.usedBytes -= 20 << 30
+	}},
+	{360 * time.Second, "operator rate-limits backup to 1 MB/s and deletes another 20 GiB", func(h *simHost) {
+		h.procs[3002].writeMBs = 1
+		h.usedBytes -= 20 << 30
+	}},
+}
+
+const diskFillScenarioEnd = 450 * time.Second
+
+// playDiskFill plays diskFillScenario against a guard in mode and returns
+// the host, the guard and every message the guard showed.
+func playDiskFill(mode string) (*simHost, *DiskGuard, []string) {
+	h := newSimHost()
+	g := NewDiskGuardWith(h, h.now)
+	g.Mode = mode
+
+	var guardLog []string
+	var prevTick, thisTick map[string]bool
+	g.notify = func(msg string) {
+		// DryRun repeats its prediction every CRIT tick; log news only.
+		if !prevTick[msg] {
+			h.log("guard", "%s", msg)
+		}
+		thisTick[msg] = true
+		guardLog = append(guardLog, msg)
+	}
+
+	steps := diskFillScenario
+	for h.elapsed = 0; h.elapsed <= diskFillScenarioEnd; h.elapsed += diskGuardSimTick {
+		for len(steps) > 0 && steps[0].at <= h.elapsed {
+			h.log("scenario", "%s", steps[0].note)
+			steps[0].do(h)
+			steps = steps[1:]
+		}
+		prevTick, thisTick = thisTick, make(map[string]bool)
+		g.Tick(h.state, h.rates())
+	}
+	return h, g, guardLog
+}
+
+func check(name string, pass bool, format string, args ...any) DiskGuardSimCheck {
+	return DiskGuardSimCheck{Name: name, Pass: pass, Detail: fmt.Sprintf(format, args...)}
+}
+
+// listed formats xs as " (a, b)", or "" when empty.
+func listed(xs []string) string {
+	if len(xs) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(xs, ", ") + ")"
+}
+
+func (h *simHost) sent(sig syscall.Signal) []simSignal {
+	var out []simSignal
+	for _, s := range h.signals {
+		if s.sig == sig {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func containChecks(h *simHost, g *DiskGuard, guardLog []string) []DiskGuardSimCheck {
+	var checks []DiskGuardSimCheck
+
+	// Denylist: mysqld is the top writer through incident 1 and must be
+	// skipped, never signalled.
+	skipped := false
+	for _, msg := range guardLog {
+		if msg == "Skipped: PID 2001 (mysqld) is in denylist" {
+			skipped = true
+		}
+	}
+	var denied []string
+	for _, s := range h.signals {
+		if FreezeDenylist[s.proc.comm] {
+			denied = append(denied, fmt.Sprintf("%s to %s", sigName(s.sig), s.proc.comm))
+		}
+	}
+	checks = append(checks, check("denylist", skipped && len(denied) == 0,
+		"mysqld skipped as top writer: %v; %d signal(s) to denylisted processes%s", skipped, len(denied), listed(denied)))
+
+	// Containment: the runaway is frozen, nobody else — logspam in
+	// incident 1, backup in incident 2 and again when it flaps back.
+	stops := h.sent(syscall.SIGSTOP)
+	var frozen []string
+	for _, s := range stops {
+		frozen = append(frozen, fmt.Sprintf("%s@%.0fs", s.proc.comm, s.at.Seconds()))
+	}
+	containOK := len(stops) == 3
+	for i, want := range []string{"logspam", "backup", "backup"} {
+		containOK = containOK && stops[i].proc.comm == want
+	}
+	checks = append(checks, check("containment", containOK,
+		"SIGSTOP sent to %s", strings.Join(frozen, ", ")))
+
+	// Per-incident cap: backup writes 12 MB/s through the rest of
+	// incident 1 but must not be frozen.
+	var capped []string
+	for _, s := range stops {
+		if s.at < 180*time.Second && s.proc.comm != "logspam" {
+			capped = append(capped, fmt.Sprintf("%s@%.0fs", s.proc.comm, s.at.Seconds()))
+		}
+	}
+	checks = append(checks, check("per-incident cap", len(capped) == 0,
+		"max %d automatic freeze(s) per incident; %d extra in incident 1%s",
+		diskGuardMaxPerIncident, len(capped), listed(capped)))
+
+	// Cooldown: the disk goes CRIT again while the last freeze is still
+	// cooling down; the guard must hold until the cooldown has passed.
+	gap, held := time.Duration(0), 0
+	for i := 1; i < len(stops); i++ {
+		if d := stops[i].at - stops[i-1].at; gap == 0 || d < gap {
+			gap = d
+		}
+	}
+	for _, e := range h.timeline {
+		at := time.Duration(e.At * float64(time.Second))
+		if e.Kind != "state" || strings.Fields(e.Text)[2] != "CRIT" {
+			continue
+		}
+		for _, s := range stops {
+			if s.at < at && at-s.at < diskGuardCooldown {
+				held++
+			}
+		}
+	}
+	checks = append(checks, check("cooldown", held > 0 && gap >= diskGuardCooldown,
+		"CRIT onsets inside the %v cooldown: %d; closest two freezes %v apart",
+		diskGuardCooldown, held, gap))
+
+	// PID reuse: after logspam's PID went to cron-report, nothing may be
+	// sent to it and the stale frozen entry must be gone.
+	var toReused []string
+	for _, s := range h.signals {
+		if s.proc.reused {
+			toReused = append(toReused, fmt.Sprintf("%s to %s", sigName(s.sig), s.proc.comm))
+		}
+	}
+	_, stale := g.Frozen[3001]
+	checks = append(checks, check("PID reuse", len(toReused) == 0 && !stale,
+		"%d signal(s) to PID 3001 after reuse%s; stale frozen entry kept: %v", len(toReused), listed(toReused), stale))
+
+	// Auto-resume: every SIGCONT goes out only after the disk has been OK
+	// for the stable period, and nothing is left frozen at the end.
+	conts := h.sent(syscall.SIGCONT)
+	resumeOK := len(conts) == 2 && len(g.Frozen) == 0 && !h.procs[3002].stopped
+	shortest := time.Duration(-1)
+	for _, c := range conts {
+		var okSince time.Duration
+		for _, e := range h.timeline {
+			if e.Kind == "state" && e.At <= c.at.Seconds() {
+				okSince = time.Duration(e.At * float64(time.Second))
+			}
+		}
+		if after := c.at - okSince; shortest < 0 || after < shortest {
+			shortest = after
+		}
+		resumeOK = resumeOK && c.state == "OK" && c.proc.comm == "backup"
+	}
+	resumeOK = resumeOK && shortest >= diskGuardStableReset
+	checks = append(checks, check("auto-resume", resumeOK,
+		"SIGCONT sent %d time(s), earliest %v after the disk turned OK (needs %v); still frozen: %d",
+		len(conts), shortest, diskGuardStableReset, len(g.Frozen)))
+
+	return checks
+}
+
+// dryRunChecks verifies DryRun sends nothing and predicts the process
+// Contain actually froze first.
+func dryRunChecks(h *simHost, guardLog []string, contain *simHost) []DiskGuardSimCheck {
+	predicted := 0
+	for _, msg := range guardLog {
+		if n, _ := fmt.Sscanf(msg, "Would freeze: PID %d", &predicted); n == 1 {
+			break
+		}
+	}
+	actual := 0
+	if stops := contain.sent(syscall.SIGSTOP); len(stops) > 0 {
+		actual = stops[0].pid
+	}
+	return []DiskGuardSimCheck{
+		check("dry run sends nothing", len(h.signals) == 0, "%d signal(s) sent", len(h.signals)),
+		check("dry run matches Contain", predicted != 0 && predicted == actual,
+			"would freeze PID %d first; Contain froze PID %d", predicted, actual),
+	}
+}
+
+// simulateAction exercises the manual kill (x) and freeze (f) keys of
+// Action mode against their refusal paths.
+func simulateAction() DiskGuardSimRun {
+	h := newSimHost()
+	g := NewDiskGuardWith(h, h.now)
+	g.Mode = DiskGuardAction
+	run := DiskGuardSimRun{Scenario: "manual-keys", Mode: DiskGuardAction}
+
+	say := func(note string) {
+		h.log("scenario", "%s", note)
+	}
+	guard := func() string {
+		h.log("guard", "%s", g.Msg)
+		return g.Msg
+	}
+	h.spawn(2001, "mysqld", 40)
+	h.spawn(4001, "dd", 30)
+
+	say("x with mysqld (40 MB/s) as top writer")
+	g.KillTop(h.rates())
+	msg := guard()
+	run.Checks = append(run.Checks, check("kill refuses denylisted", len(h.signals) == 0,
+		"%q", msg))
+
+	say("mysqld calms down; f freezes dd; after a tick dd exits and rsync reuses PID 4001")
+	h.procs[2001].writeMBs = 1
+	g.FreezeTop(h.rates())
+	guard()
+	h.elapsed += diskGuardSimTick
+	g.Tick(h.state, h.rates())
+	h.elapsed += diskGuardSimTick / 2
+	h.procs[4001].alive = false
+	h.spawn(4001, "rsync", 30)
+	before := len(h.signals)
+	g.KillTop(h.rates())
+	msg = guard()
+	run.Checks = append(run.Checks, check("kill refuses reused PID", len(h.signals) == before && h.procs[4001].alive,
+		"%q", msg))
+
+	say("x on a fresh runaway writer")
+	h.elapsed += 30 * time.Second
+	h.spawn(4002, "tar", 60)
+	g.KillTop(h.rates())
+	msg = guard()
+	kills := h.sent(syscall.SIGKILL)
+	run.Checks = append(run.Checks, check("kill reaches runaway",
+		len(kills) == 1 && kills[0].pid == 4002 && !h.procs[4002].alive, "%q", msg))
+
+	run.Timeline = h.timeline
+	return run
+}
@@ -28,6 +28,7 @@ type Engine struct {
 	History          *History
 	Smart            *collector.SMARTCollector
 	growthTracker    *MountGrowthTracker
+	writePaths       *WritePathTracker // host-wide top-K written file paths
 	Sentinel         *bpf.SentinelManager
 	Watchdog         *WatchdogTrigger
 	SecWatchdog      *bpf.SecWatchdog               // security deep-inspection watchdog
@@ -36,7 +37,11 @@ type Engine struct {
 	Autopilot        *Autopilot                     // autopilot subsystem (nil if disabled)
 	changeDetector   *ChangeDetector                // tracks system changes between ticks
 	configDrift      *ConfigDriftDetector           // watches /etc/* config files for drift
+	hotplug          *HotplugTracker                // disks/NICs/CPUs added or removed between ticks
 	incidentRecorder *IncidentRecorder              // records past RCA incidents for learning
+	culprits         *culpritCapturer               // full cmdline/env of blamed processes
+	dbWriters        *dbWriterCorrelator            // running statement behind a database's writes
+	oomAvoid         *oomAvoider                    // opt-in earlyoom-style victim selection; daemon only
 	runbooks         *RunbookLibrary                // operator runbooks matched against live incidents
 	usage            *UsageRecorder                 // per-minute utilization rollups for right-sizing
 	logTailer        *LogTailer                     // correlates incidents with app log output
@@ -44,12 +49,14 @@ type Engine struct {
 	traces           *TraceCorrelator               // optional OTel-trace correlation from a JSONL feed
 	traceArmer       *TraceArmer                    // Phase 3: arm-once full-reasoning dump (nil = off)
 	probeRunner      *ProbeRunner                   // Phase 6: opt-in active probes (XTOP_PROBES=1)
+	Deps             *DependencyTracker             // per-remote-endpoint latency baselines
 	deepScan         *collector.DeepBigFileScanner  // opt-in full-FS big-file walker
 	guard            *ResourceGuard                 // opt-in xtop self-throttle
 	intervalSec      int                            // base tick interval (for guard + callers)
 	mode             collector.Mode                 // Rich (TUI) or Lean (daemon/agent)
 	memReliefQuit    chan struct{}                  // signals the memory-relief goroutine to exit
 	tickMu           sync.Mutex                     // serializes Tick() calls to prevent concurrent collection
+	runtimeCfg       RuntimeConfig                  // last config applied by ApplyRuntimeConfig
 	peerIncidents    map[string]*model.HostIncident // hostID → latest incident
 	peerMu           sync.RWMutex
 
@@ -179,12 +186,17 @@ func NewEngineMode(historySize, intervalSec int, mode collector.Mode) *Engine {
 		History:          NewHistory(historySize, intervalSec),
 		Smart:            collector.NewSMARTCollector(5 * time.Minute),
 		growthTracker:    NewMountGrowthTracker(),
+		writePaths:       NewWritePathTracker(),
 		Sentinel:         sentinel, // nil in lean — eBPF probes never attached
 		Watchdog:         NewWatchdogTrigger(),
+		Deps:             NewDependencyTracker(),
 		SecWatchdog:      bpf.NewSecWatchdog(bpf.DetectPrimaryIface()),
 		changeDetector:   NewChangeDetector(),
 		configDrift:      NewConfigDriftDetector(),
+		hotplug:          NewHotplugTracker(),
 		incidentRecorder: NewIncidentRecorder(),
+		culprits:         newCulpritCapturer(),
+		dbWriters:        newDBWriterCorrelator(),
 		runbooks:         NewRunbookLibrary(),
 		usage:            NewUsageRecorder(),
 		logTailer:        NewLogTailer(),
@@ -469,6 +481,18 @@ func (e *Engine) Tick() (*model.Snapshot, *model.RateSnapshot, *model.AnalysisRe
 		e.lastDeepAnalysis = time.Now()
 	}
 
+	// Hot-plug: disks, NICs or CPUs that came or went since the last tick.
+	// Collectors holding a cached device inventory rebuild it next tick.
+	var devChanges []model.SystemChange
+	if e.hotplug != nil {
+		if devChanges = e.hotplug.Tick(snap); len(devChanges) > 0 {
+			e.registry.RescanDevices()
+			if e.Smart != nil {
+				e.Smart.RescanDevices()
+			}
+		}
+	}
+
 	// Get previous snapshot for rate calculations
 	prev := e.History.Latest()
 
@@ -482,6 +506,7 @@ func (e *Engine) Tick() (*model.Snapshot, *model.RateSnapshot, *model.AnalysisRe
 	if prev != nil {
 		r := ComputeRates(prev, snap)
 		e.growthTracker.Smooth(r.MountRates)
+		e.writePaths.Update(&r)
 		rates = &r
 		e.History.PushRate(r)
 		e.History.ProcessHistory.Record(rates)
@@ -516,6 +541,17 @@ func (e *Engine) Tick() (*model.Snapshot, *model.RateSnapshot, *model.AnalysisRe
 			}
 		}
 
+		// Device changes go into Changes too; during an incident a recent
+		// one (a volume attached, a NIC pulled) is surfaced like config drift.
+		if e.hotplug != nil {
+			result.Changes = append(result.Changes, devChanges...)
+			if result.Health > model.HealthOK && result.Narrative != nil {
+				if hint := formatHotplugHint(e.hotplug.Recent()); hint != "" {
+					result.Narrative.Evidence = append([]string{hint}, result.Narrative.Evidence...)
+				}
+			}
+		}
+
 		// Confidence calibration: detect incident completions to record outcomes,
 		// and apply the learned per-bottleneck bias to the live result. The order
 		// matters — we look at what the recorder had as "active" before we pass
@@ -533,6 +569,17 @@ func (e *Engine) Tick() (*model.Snapshot, *model.RateSnapshot, *model.AnalysisRe
 			}
 		}
 
+		// D-state storm with a database as the IO culprit: name the
+		// statement doing the writing.
+		e.dbWriters.Attach(rates, result)
+
+		// Capture the culprit's full command line before the recorder
+		// snapshots the result, so incident exports keep the exact flags.
+		e.culprits.Attach(result)
+
+		// Victims of the OOM-avoidance mode since the last tick.
+		e.oomAvoid.Attach(result)
+
 		// Incident recording: track active incidents, persist completed ones,
 		// and enrich current narrative with history context (recurrence info).
 		if e.incidentRecorder != nil {
@@ -675,6 +722,14 @@ func (e *Engine) Tick() (*model.Snapshot, *model.RateSnapshot, *model.AnalysisRe
 			}
 		}
 
+		// Dependency latency: every tick feeds the outbound in-flight
+		// requests; rank the endpoints adding latency over the baselines
+		// learned from healthy ticks once the host degrades.
+		e.Deps.Observe(snap.Global.OutboundWaits, snap.Timestamp, result.Health == model.HealthOK)
+		if result.Health > model.HealthOK {
+			result.SlowDependencies = e.Deps.Rank(snap.Timestamp, 5)
+		}
+
 		// Watchdog auto-trigger: check if RCA warrants domain-specific probes
 		if domain := e.Watchdog.Check(result); domain != "" {
 			result.Watchdog = model.WatchdogState{Active: true, Domain: domain}
@@ -741,7 +796,7 @@ func (e *Engine) Tick() (*model.Snapshot, *model.RateSnapshot, *model.AnalysisRe
 
 	// Fleet push: non-blocking, only after the first tick has rates+result
 	if e.fleet != nil && result != nil {
-		e.fleet.Observe(snap, result, e.fleetHostname, e.fleetVersion)
+		e.fleet.Observe(snap, rates, result, e.fleetHostname, e.fleetVersion)
 	}
 
 	// Usage recording: per-tick aggregate that the per-minute rollup absorbs.
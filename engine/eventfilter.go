@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// EventFilter narrows a list of incidents. Zero-valued fields match
+// everything, so the zero EventFilter matches all events.
+type EventFilter struct {
+	Bottleneck string            // case-insensitive substring of Event.Bottleneck
+	MinHealth  model.HealthLevel // PeakHealth must be at least this
+	Since      time.Time         // StartTime >= Since
+	Until      time.Time         // StartTime <= Until
+	Culprit    string            // substring of culprit process or cgroup
+	Terms      []string          // free text; every term must appear somewhere
+}
+
+// ParseEventFilter parses a query such as
+//
+//	bn:io sev:crit since:2d culprit:php "slow disk"
+//
+// Recognised keys: bn/bottleneck, sev/severity (warn|crit), since, until,
+// culprit. since/until take a relative age ("90m", "2d") or a date
+// ("2026-01-31", RFC3339). Anything else is free text searched in the
+// evidence, causal chain and timeline.
+func ParseEventFilter(query string, now time.Time) (EventFilter, error) {
+	var f EventFilter
+	for _, tok := range splitQuery(query) {
+		key, val, ok := strings.Cut(tok, ":")
+		if !ok || val == "" {
+			f.Terms = append(f.Terms, strings.ToLower(tok))
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "bn", "bottleneck":
+			f.Bottleneck = strings.ToLower(val)
+		case "sev", "severity":
+			switch strings.ToLower(val) {
+			case "warn", "warning", "degraded":
+				f.MinHealth = model.HealthDegraded
+			case "crit", "critical":
+				f.MinHealth = model.HealthCritical
+			default:
+				return f, fmt.Errorf("unknown severity %q (use warn or crit)", val)
+			}
+		case "since":
+			t, err := parseFilterTime(val, now)
+			if err != nil {
+				return f, err
+			}
+			f.Since = t
+		case "until":
+			t, err := parseFilterTime(val, now)
+			if err != nil {
+				return f, err
+			}
+			f.Until = t
+		case "culprit":
+			f.Culprit = strings.ToLower(val)
+		default:
+			// Not a filter key (e.g. "10:42" in a search) — treat as text.
+			f.Terms = append(f.Terms, strings.ToLower(tok))
+		}
+	}
+	return f, nil
+}
+
+// IsZero reports whether the filter matches every event.
+func (f EventFilter) IsZero() bool {
+	return f.Bottleneck == "" && f.MinHealth == model.HealthOK && f.Since.IsZero() &&
+		f.Until.IsZero() && f.Culprit == "" && len(f.Terms) == 0
+}
+
+// Match reports whether the event satisfies every set criterion.
+func (f EventFilter) Match(e model.Event) bool {
+	if f.Bottleneck != "" && !strings.Contains(strings.ToLower(e.Bottleneck), f.Bottleneck) {
+		return false
+	}
+	if e.PeakHealth < f.MinHealth {
+		return false
+	}
+	if !f.Since.IsZero() && e.StartTime.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.StartTime.After(f.Until) {
+		return false
+	}
+	if f.Culprit != "" &&
+		!strings.Contains(strings.ToLower(e.CulpritProcess), f.Culprit) &&
+		!strings.Contains(strings.ToLower(e.CulpritCgroup), f.Culprit) {
+		return false
+	}
+	if len(f.Terms) > 0 {
+		hay := eventSearchText(e)
+		for _, t := range f.Terms {
+			if !strings.Contains(hay, t) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// FilterEvents returns the events matching f, preserving order.
+func FilterEvents(events []model.Event, f EventFilter) []model.Event {
+	if f.IsZero() {
+		return events
+	}
+	var out []model.Event
+	for _, e := range events {
+		if f.Match(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// eventSearchText is the lower-cased text free-text terms are matched against.
+func eventSearchText(e model.Event) string {
+	var sb strings.Builder
+	sb.WriteString(e.Bottleneck)
+	sb.WriteByte('\n')
+	sb.WriteString(e.CausalChain)
+	sb.WriteByte('\n')
+	sb.WriteString(e.CulpritProcess)
+	sb.WriteByte('\n')
+	sb.WriteString(e.CulpritCgroup)
+	for _, ev := range e.Evidence {
+		sb.WriteByte('\n')
+		sb.WriteString(ev)
+	}
+	for _, te := range e.Timeline {
+		sb.WriteByte('\n')
+		sb.WriteString(te.Message)
+	}
+	return strings.ToLower(sb.String())
+}
+
+// splitQuery splits on whitespace, keeping "double quoted" phrases whole.
+func splitQuery(q string) []string {
+	var toks []string
+	var cur strings.Builder
+	inQuote := false
+	for _, r := range q {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case (r == ' ' || r == '\t') && !inQuote:
+			if cur.Len() > 0 {
+				toks = append(toks, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		toks = append(toks, cur.String())
+	}
+	return toks
+}
+
+// parseFilterTime accepts "90m", "2d", "2026-01-31" or an RFC3339 timestamp.
+func parseFilterTime(s string, now time.Time) (time.Time, error) {
+	if strings.HasSuffix(s, "d") {
+		if n, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil && n > 0 {
+			return now.Add(-time.Duration(n) * 24 * time.Hour), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("bad time %q (use e.g. 2h, 3d, 2026-01-31)", s)
+}
@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func TestParseEventFilter(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	f, err := ParseEventFilter(`bn:io sev:crit since:2d culprit:PHP "slow disk" fsync`, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Bottleneck != "io" || f.MinHealth != model.HealthCritical || f.Culprit != "php" {
+		t.Errorf("unexpected filter: %+v", f)
+	}
+	if !f.Since.Equal(now.Add(-48 * time.Hour)) {
+		t.Errorf("since = %v", f.Since)
+	}
+	if len(f.Terms) != 2 || f.Terms[0] != "slow disk" || f.Terms[1] != "fsync" {
+		t.Errorf("terms = %q", f.Terms)
+	}
+
+	for _, bad := range []string{"sev:meh", "since:yesterday"} {
+		if _, err := ParseEventFilter(bad, now); err == nil {
+			t.Errorf("ParseEventFilter(%q) should fail", bad)
+		}
+	}
+	if f, _ := ParseEventFilter("   ", now); !f.IsZero() {
+		t.Error("blank query should give the zero filter")
+	}
+}
+
+func TestEventFilterMatch(t *testing.T) {
+	now := time.Now()
+	events := []model.Event{
+		{Bottleneck: "IO Starvation", PeakHealth: model.HealthCritical, StartTime: now.Add(-time.Hour),
+			CulpritProcess: "php-fpm", Evidence: []string{"fsync latency 800ms on sda"}},
+		{Bottleneck: "Memory Pressure", PeakHealth: model.HealthDegraded, StartTime: now.Add(-30 * time.Minute),
+			CulpritCgroup: "/system.slice/mysql.service"},
+		{Bottleneck: "IO Starvation", PeakHealth: model.HealthDegraded, StartTime: now.Add(-72 * time.Hour)},
+	}
+
+	cases := map[string]int{
+		"":                  3,
+		"bn:io":             2,
+		"sev:crit":          1,
+		"since:1d":          2,
+		"culprit:mysql":     1,
+		"fsync sda":         1,
+		"bn:io since:1d":    1,
+		"bn:memory fsync":   0,
+		"sev:warn until:2d": 1,
+	}
+	for q, want := range cases {
+		f, err := ParseEventFilter(q, now)
+		if err != nil {
+			t.Fatalf("%q: %v", q, err)
+		}
+		if got := len(FilterEvents(events, f)); got != want {
+			t.Errorf("%q matched %d events, want %d", q, got, want)
+		}
+	}
+}
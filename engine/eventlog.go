@@ -18,6 +18,10 @@ type EventDetector struct {
 	active    *model.Event
 	completed []model.Event
 
+	// Operator notes taken while no incident was open. Notes taken during
+	// an incident live on that incident's Timeline instead.
+	notes []model.TimelineEntry
+
 	// Debounce: require consecutive non-OK ticks before opening
 	nonOKStreak int
 	debounce    int // consecutive non-OK ticks required (default 3)
@@ -137,6 +141,9 @@ func (d *EventDetector) updatePeaks(snap *model.Snapshot, rates *model.RateSnaps
 	if result.PrimaryPID > 0 {
 		d.active.CulpritPID = result.PrimaryPID
 	}
+	if c := result.CulpritCapture; c != nil && (d.active.CulpritCapture == nil || d.active.CulpritCapture.PID != c.PID) {
+		d.active.CulpritCapture = c
+	}
 
 	// Peak CPU
 	if rates != nil && rates.CPUBusyPct > d.active.PeakCPUBusy {
@@ -179,10 +186,72 @@ func (d *EventDetector) addTimelineEntry(t time.Time, msg string) {
 		Time:    t,
 		Message: msg,
 	})
-	// Cap at 20 entries
-	if len(d.active.Timeline) > 20 {
-		d.active.Timeline = d.active.Timeline[len(d.active.Timeline)-20:]
+	d.active.Timeline = capTimeline(d.active.Timeline, 20)
+}
+
+// capTimeline trims a timeline to max entries, dropping the oldest
+// machine-observed milestones first. Operator notes are only dropped once
+// nothing else is left to evict — they're the part of the record that
+// can't be reconstructed from metrics afterwards.
+func capTimeline(tl []model.TimelineEntry, max int) []model.TimelineEntry {
+	for len(tl) > max {
+		drop := 0
+		for i, te := range tl {
+			if !te.IsNote() {
+				drop = i
+				break
+			}
+		}
+		tl = append(tl[:drop], tl[drop+1:]...)
+	}
+	return tl
+}
+
+// AddNote records a free-text operator annotation at time t. When an
+// incident is open the note joins its timeline (and therefore the event
+// log and every export of it); otherwise it is kept as a standalone note.
+// Returns the stored entry so callers can persist it.
+func (d *EventDetector) AddNote(t time.Time, text string) model.TimelineEntry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	note := model.TimelineEntry{Time: t, Message: text, Kind: model.TimelineKindNote}
+	if d.active != nil {
+		d.active.Timeline = append(d.active.Timeline, note)
+		d.active.Timeline = capTimeline(d.active.Timeline, 20)
+		return note
 	}
+	d.notes = capNotes(append(d.notes, note))
+	return note
+}
+
+// maxStandaloneNotes bounds the notes kept outside any incident.
+const maxStandaloneNotes = 200
+
+// capNotes keeps the newest maxStandaloneNotes standalone notes.
+func capNotes(notes []model.TimelineEntry) []model.TimelineEntry {
+	if len(notes) > maxStandaloneNotes {
+		notes = notes[len(notes)-maxStandaloneNotes:]
+	}
+	return notes
+}
+
+// Notes returns standalone operator notes (those taken outside an
+// incident) in chronological order.
+func (d *EventDetector) Notes() []model.TimelineEntry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]model.TimelineEntry, len(d.notes))
+	copy(out, d.notes)
+	return out
+}
+
+// LoadNotes adds externally loaded standalone notes (e.g., from notes.jsonl),
+// keeping the same cap as AddNote.
+func (d *EventDetector) LoadNotes(notes []model.TimelineEntry) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.notes = capNotes(append(notes, d.notes...))
 }
 
 // ActiveEvent returns a copy of the current active event, or nil.
@@ -0,0 +1,148 @@
+package engine
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Experimental analyses can ship dark: the code is in every build but only
+// runs on hosts that opt in, so its output can be compared against stable
+// behaviour without a forked binary. Each source — --enable-experimental,
+// XTOP_EXPERIMENTAL and config.json "experimental", in that order of
+// precedence — is a comma-separated list where "name" turns a feature on,
+// "no-name" turns it off, and "all" / "none" set every feature. A feature
+// no source mentions keeps its Default.
+const (
+	FeatureHiddenLatency  = "hidden-latency"
+	FeatureTemporalChains = "temporal-chains"
+	FeatureBlame          = "blame"
+)
+
+// ExperimentalFeature describes one flag for --help and error messages.
+type ExperimentalFeature struct {
+	Name    string
+	Desc    string
+	Default bool // on when no source mentions it
+}
+
+// ExperimentalFeatures lists every flag ExperimentalEnabled accepts.
+// The first three shipped on before the flags existed and stay on by
+// default; they are listed so a host can opt out with "no-<name>".
+var ExperimentalFeatures = []ExperimentalFeature{
+	{FeatureHiddenLatency, "off-CPU wait detection when CPU, IO and memory look healthy", true},
+	{FeatureTemporalChains, "signal onset ordering and the \"X then Y then Z\" timeline", true},
+	{FeatureBlame, "top offender attribution by process and cgroup", true},
+}
+
+var (
+	experimentalMu     sync.RWMutex
+	experimentalPinned map[string]bool // --enable-experimental; survives reloads
+	experimentalConfig map[string]bool // config.json; replaced on reload
+	// A feature absent from a map is not set by that source; false is an
+	// explicit opt-out.
+)
+
+// PinExperimental sets features for the life of the process, over whatever
+// config.json and the environment say. It returns the names it did not
+// recognise.
+func PinExperimental(names []string) (unknown []string) {
+	set, unknown := experimentalSet(names)
+	experimentalMu.Lock()
+	experimentalPinned = set
+	experimentalMu.Unlock()
+	return unknown
+}
+
+// SetExperimental replaces the features enabled from config.json and
+// reports whether the set changed. Unknown names are returned and ignored.
+func SetExperimental(names []string) (changed bool, unknown []string) {
+	set, unknown := experimentalSet(names)
+	experimentalMu.Lock()
+	defer experimentalMu.Unlock()
+	changed = !maps.Equal(set, experimentalConfig)
+	experimentalConfig = set
+	return changed, unknown
+}
+
+// ExperimentalEnabled reports whether the named feature is on.
+func ExperimentalEnabled(name string) bool {
+	experimentalMu.RLock()
+	pinned, okPinned := experimentalPinned[name]
+	config, okConfig := experimentalConfig[name]
+	experimentalMu.RUnlock()
+	if okPinned {
+		return pinned
+	}
+	env, _ := experimentalSet(ParseExperimental(os.Getenv("XTOP_EXPERIMENTAL")))
+	if on, ok := env[name]; ok {
+		return on
+	}
+	if okConfig {
+		return config
+	}
+	for _, f := range ExperimentalFeatures {
+		if f.Name == name {
+			return f.Default
+		}
+	}
+	return false
+}
+
+// EnabledExperimental returns the names of the features that are on, sorted.
+func EnabledExperimental() []string {
+	var out []string
+	for _, f := range ExperimentalFeatures {
+		if ExperimentalEnabled(f.Name) {
+			out = append(out, f.Name)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// ExperimentalNames returns every known feature name, for error messages.
+func ExperimentalNames() []string {
+	out := make([]string, 0, len(ExperimentalFeatures))
+	for _, f := range ExperimentalFeatures {
+		out = append(out, f.Name)
+	}
+	return out
+}
+
+// ParseExperimental splits a --enable-experimental / XTOP_EXPERIMENTAL value.
+func ParseExperimental(s string) []string {
+	var out []string
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.ToLower(strings.TrimSpace(f)); f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func experimentalSet(names []string) (map[string]bool, []string) {
+	set := make(map[string]bool)
+	var unknown []string
+	for _, n := range names {
+		n = strings.ToLower(strings.TrimSpace(n))
+		raw, on := n, true
+		if name, ok := strings.CutPrefix(n, "no-"); ok {
+			n, on = name, false
+		}
+		switch {
+		case n == "":
+		case on && (n == "all" || n == "none"):
+			for _, f := range ExperimentalFeatures {
+				set[f.Name] = n == "all"
+			}
+		case slices.Contains(ExperimentalNames(), n):
+			set[n] = on
+		default:
+			unknown = append(unknown, raw)
+		}
+	}
+	return set, unknown
+}
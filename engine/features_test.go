@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"slices"
+	"testing"
+)
+
+func resetExperimental(t *testing.T) {
+	t.Helper()
+	t.Setenv("XTOP_EXPERIMENTAL", "")
+	PinExperimental(nil)
+	SetExperimental(nil)
+	t.Cleanup(func() {
+		PinExperimental(nil)
+		SetExperimental(nil)
+	})
+}
+
+func TestExperimentalSources(t *testing.T) {
+	resetExperimental(t)
+	all := []string{"blame", "hidden-latency", "temporal-chains"}
+	if on := EnabledExperimental(); !slices.Equal(on, all) {
+		t.Fatalf("default on = %v, want the analyses that shipped on", on)
+	}
+
+	if unknown := PinExperimental(ParseExperimental(" No-Blame, bogus ,,no-all")); !slices.Equal(unknown, []string{"bogus", "no-all"}) {
+		t.Errorf("unknown = %v", unknown)
+	}
+	if changed, _ := SetExperimental([]string{"no-temporal-chains"}); !changed {
+		t.Error("config change not reported")
+	}
+	if changed, _ := SetExperimental([]string{"no-temporal-chains"}); changed {
+		t.Error("unchanged config reported as changed")
+	}
+	// The CLI wins over the environment, which wins over config.json.
+	t.Setenv("XTOP_EXPERIMENTAL", "no-hidden-latency,blame,temporal-chains")
+	if on := EnabledExperimental(); !slices.Equal(on, []string{"temporal-chains"}) {
+		t.Errorf("on = %v, want env over config, CLI over env", on)
+	}
+
+	// A config reload drops its own entries but not the CLI pin.
+	t.Setenv("XTOP_EXPERIMENTAL", "")
+	SetExperimental([]string{"no-temporal-chains"})
+	SetExperimental(nil)
+	if ExperimentalEnabled(FeatureBlame) || !ExperimentalEnabled(FeatureTemporalChains) {
+		t.Errorf("after reload on = %v, want blame still off", EnabledExperimental())
+	}
+
+	PinExperimental(nil)
+	SetExperimental([]string{"none", "blame"})
+	if on := EnabledExperimental(); !slices.Equal(on, []string{"blame"}) {
+		t.Errorf("none,blame = %v", on)
+	}
+	SetExperimental([]string{"all"})
+	if on := EnabledExperimental(); len(on) != len(ExperimentalFeatures) {
+		t.Errorf("all = %v", on)
+	}
+}
+
+func TestApplyRuntimeConfigExperimental(t *testing.T) {
+	resetExperimental(t)
+	e := &Engine{}
+	changes := e.ApplyRuntimeConfig(RuntimeConfig{Experimental: []string{"no-blame", "nope"}})
+	if len(changes) != 1 || changes[0] != `experimental: hidden-latency, temporal-chains (unknown "nope" ignored)` {
+		t.Errorf("changes = %v", changes)
+	}
+	if changes := e.ApplyRuntimeConfig(RuntimeConfig{Experimental: []string{"no-blame"}}); len(changes) != 0 {
+		t.Errorf("unchanged reload reported %v", changes)
+	}
+	if changes := e.ApplyRuntimeConfig(RuntimeConfig{Experimental: []string{"none"}}); len(changes) != 1 || changes[0] != "experimental: none" {
+		t.Errorf("changes = %v", changes)
+	}
+}
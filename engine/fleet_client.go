@@ -162,11 +162,11 @@ func (fc *FleetClient) HubURL() string { return fc.cfg.HubURL }
 // Observe is called by the engine every tick with the current snapshot/result.
 // It builds + queues a heartbeat, and emits incident records on state changes.
 // This call must be non-blocking — any IO happens in the worker.
-func (fc *FleetClient) Observe(snap *model.Snapshot, result *model.AnalysisResult, hostname, agentVersion string) {
+func (fc *FleetClient) Observe(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult, hostname, agentVersion string) {
 	if fc == nil || snap == nil {
 		return
 	}
-	hb := buildHeartbeat(snap, result, fc.agentID, hostname, agentVersion, fc.cfg.Tags)
+	hb := buildHeartbeat(snap, rates, result, fc.agentID, hostname, agentVersion, fc.cfg.Tags)
 
 	fc.incMu.Lock()
 	// Incident state machine + quality gate.
@@ -456,7 +456,7 @@ func (fc *FleetClient) post(endpoint string, body []byte) error {
 
 // ─── Builders ────────────────────────────────────────────────────────────────
 
-func buildHeartbeat(snap *model.Snapshot, result *model.AnalysisResult, agentID, hostname, version string, tags []string) model.FleetHeartbeat {
+func buildHeartbeat(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult, agentID, hostname, version string, tags []string) model.FleetHeartbeat {
 	hb := model.FleetHeartbeat{
 		Hostname:     hostname,
 		AgentID:      agentID,
@@ -492,9 +492,30 @@ func buildHeartbeat(snap *model.Snapshot, result *model.AnalysisResult, agentID,
 		hb.MemUsedPct = float64(snap.Global.Memory.Total-snap.Global.Memory.Available) / float64(snap.Global.Memory.Total) * 100
 	}
 	hb.LoadAvg1 = snap.Global.CPU.LoadAvg.Load1
+
+	// Noisy-neighbor inputs: a guest's steal and UUID, a hypervisor's
+	// busiest guests (already sorted by CPU). The hub joins them.
+	if rates != nil {
+		hb.CPUStealPct = rates.CPUStealPct
+	}
+	if snap.SysInfo != nil && strings.HasPrefix(snap.SysInfo.Virtualization, "VM") {
+		hb.MachineUUID = snap.SysInfo.MachineUUID
+	}
+	for i, vm := range snap.Global.GuestVMs {
+		if i >= fleetMaxGuestVMs {
+			break
+		}
+		hb.GuestVMs = append(hb.GuestVMs, model.FleetGuestVM{
+			Name: vm.Name, UUID: vm.UUID, VMID: vm.VMID, VCPUs: vm.VCPUs, CPUCores: vm.CPUCores,
+		})
+	}
 	return hb
 }
 
+// fleetMaxGuestVMs caps the guest list in a hypervisor's heartbeat. A
+// neighbor loud enough to cause steal is near the top anyway.
+const fleetMaxGuestVMs = 24
+
 // readSelfRSSMB parses /proc/self/status for VmRSS. Returns 0 on any
 // error — the heartbeat ships without the field rather than failing.
 // Cheap (<100 µs) and called once per heartbeat.
@@ -535,7 +556,7 @@ func xtopModeLabel(snap *model.Snapshot) string {
 	if snap == nil || snap.CollectionHealth == nil {
 		return "rich"
 	}
-	// We use Total < 12 as a proxy: the lean collector list has 9
+	// We use Total < 12 as a proxy: the lean collector list has 10
 	// collectors; rich has 21+. Approximate but cheap and correct in
 	// practice for our two builds.
 	if snap.CollectionHealth.Total > 0 && snap.CollectionHealth.Total < 12 {
@@ -562,6 +583,11 @@ func buildIncident(snap *model.Snapshot, result *model.AnalysisResult, agentID,
 		inc.Culprit = result.PrimaryProcess
 		inc.CulpritPID = result.PrimaryPID
 		inc.CulpritApp = result.PrimaryAppName
+		if c := result.CulpritCapture; c != nil {
+			cp := *c
+			cp.Env = nil
+			inc.CulpritCapture = &cp
+		}
 		inc.Signature = fleetSignatureFromResult(result)
 		inc.Diff = result.IncidentDiff
 		// Lifecycle (TODO #5): populated from result echo of recorder state.
@@ -575,6 +601,13 @@ func buildIncident(snap *model.Snapshot, result *model.AnalysisResult, agentID,
 			inc.ChangesAtConfirm = append([]model.SystemChange(nil), result.Changes[:n]...)
 		}
 		inc.FleetPeersAtConfirm = result.CrossHostCorrelation
+		seenDep := make(map[string]bool)
+		for _, d := range result.SlowDependencies {
+			if !seenDep[d.Endpoint] {
+				seenDep[d.Endpoint] = true
+				inc.Dependencies = append(inc.Dependencies, d.Endpoint)
+			}
+		}
 		if result.Narrative != nil {
 			inc.RootCause = result.Narrative.RootCause
 			inc.Impact = result.Narrative.Impact
@@ -68,7 +68,7 @@ func incidentMessages(fc *FleetClient) []queuedMsg {
 func TestQualityGate_ZeroScoreZeroConfNeverEmits(t *testing.T) {
 	fc := stubClient(defaultFleetQuality())
 	for i := 0; i < 20; i++ {
-		fc.Observe(mkSnap(), degradedResult(0, 0, "cpu", "runqlat"),
+		fc.Observe(mkSnap(), nil, degradedResult(0, 0, "cpu", "runqlat"),
 			"host-a", "v0.43.0")
 	}
 	if n := countEmits(fc); n != 0 {
@@ -81,14 +81,14 @@ func TestQualityGate_BelowThresholdNeverEmits(t *testing.T) {
 	// score=20 below default 30; conf=50 okay — still sub-threshold
 	// because both must exceed.
 	for i := 0; i < 10; i++ {
-		fc.Observe(mkSnap(), degradedResult(20, 50, "cpu", "runqlat"),
+		fc.Observe(mkSnap(), nil, degradedResult(20, 50, "cpu", "runqlat"),
 			"host-a", "v0.43.0")
 	}
 	if n := countEmits(fc); n != 0 {
 		t.Errorf("emitted %d for below-bar stream; want 0", n)
 	}
 	// Resolved arrives — still no emission (we never announced a start).
-	fc.Observe(mkSnap(), &model.AnalysisResult{Health: model.HealthOK}, "host-a", "v0.43.0")
+	fc.Observe(mkSnap(), nil, &model.AnalysisResult{Health: model.HealthOK}, "host-a", "v0.43.0")
 	if n := countEmits(fc); n != 0 {
 		t.Errorf("stray Resolved emitted after never-announced incident; got %d", n)
 	}
@@ -99,19 +99,19 @@ func TestQualityGate_EmitsOnlyAfterSustainedBar(t *testing.T) {
 	good := degradedResult(60, 80, "cpu", "runqlat")
 
 	// First 2 good ticks → no emission yet (default MinStartTicks = 3).
-	fc.Observe(mkSnap(), good, "host-a", "v0.43.0")
-	fc.Observe(mkSnap(), good, "host-a", "v0.43.0")
+	fc.Observe(mkSnap(), nil, good, "host-a", "v0.43.0")
+	fc.Observe(mkSnap(), nil, good, "host-a", "v0.43.0")
 	if n := countEmits(fc); n != 0 {
 		t.Errorf("emitted %d after 2 ticks; default threshold is 3", n)
 	}
 	// Third tick → Started.
-	fc.Observe(mkSnap(), good, "host-a", "v0.43.0")
+	fc.Observe(mkSnap(), nil, good, "host-a", "v0.43.0")
 	if n := countEmits(fc); n != 1 {
 		t.Errorf("expected 1 Started emission after 3 sustained ticks, got %d", n)
 	}
 	// Further same-signature ticks at same score → no new emission.
-	fc.Observe(mkSnap(), good, "host-a", "v0.43.0")
-	fc.Observe(mkSnap(), good, "host-a", "v0.43.0")
+	fc.Observe(mkSnap(), nil, good, "host-a", "v0.43.0")
+	fc.Observe(mkSnap(), nil, good, "host-a", "v0.43.0")
 	if n := countEmits(fc); n != 1 {
 		t.Errorf("follow-up ticks should not emit: got %d", n)
 	}
@@ -123,13 +123,13 @@ func TestQualityGate_EmitsResolvedOnlyWhenStartWasEmitted(t *testing.T) {
 
 	// Get to Started.
 	for i := 0; i < 3; i++ {
-		fc.Observe(mkSnap(), good, "host-a", "v0.43.0")
+		fc.Observe(mkSnap(), nil, good, "host-a", "v0.43.0")
 	}
 	if countEmits(fc) != 1 {
 		t.Fatalf("precondition: expected 1 Started")
 	}
 	// Health returns to OK → Resolved emits.
-	fc.Observe(mkSnap(), &model.AnalysisResult{Health: model.HealthOK}, "host-a", "v0.43.0")
+	fc.Observe(mkSnap(), nil, &model.AnalysisResult{Health: model.HealthOK}, "host-a", "v0.43.0")
 	if countEmits(fc) != 2 {
 		t.Errorf("expected Started+Resolved (2 emissions), got %d", countEmits(fc))
 	}
@@ -152,7 +152,7 @@ func TestQualityGate_EscalationRateLimit(t *testing.T) {
 	// Establish an initial Started incident (cpu).
 	good := degradedResult(60, 80, "cpu", "runqlat")
 	for i := 0; i < 3; i++ {
-		fc.Observe(mkSnap(), good, "host-a", "v0.43.0")
+		fc.Observe(mkSnap(), nil, good, "host-a", "v0.43.0")
 	}
 	baseEmits := countEmits(fc)
 	if baseEmits != 1 {
@@ -163,7 +163,7 @@ func TestQualityGate_EscalationRateLimit(t *testing.T) {
 	// (MinEscalationGap hasn't passed).
 	flipped := degradedResult(60, 80, "memory", "swap_churn")
 	for i := 0; i < 3; i++ {
-		fc.Observe(mkSnap(), flipped, "host-a", "v0.43.0")
+		fc.Observe(mkSnap(), nil, flipped, "host-a", "v0.43.0")
 	}
 	if countEmits(fc) != baseEmits {
 		t.Errorf("signature flip within gap emitted %d extra events; want 0",
@@ -172,7 +172,7 @@ func TestQualityGate_EscalationRateLimit(t *testing.T) {
 
 	// Force the gap to expire.
 	fc.lastEscalationAt = time.Now().Add(-1 * time.Minute)
-	fc.Observe(mkSnap(), flipped, "host-a", "v0.43.0")
+	fc.Observe(mkSnap(), nil, flipped, "host-a", "v0.43.0")
 	// After the gap, the first above-bar tick opens a new incident →
 	// Resolved(escalated) + Started = +2 emissions.
 	if got := countEmits(fc) - baseEmits; got != 2 {
@@ -184,8 +184,8 @@ func TestQualityGate_OneOffSpikeProducesNothing(t *testing.T) {
 	fc := stubClient(defaultFleetQuality())
 	// Single bar-passing tick followed by recovery — the classic "normal
 	// spike" that was flooding the hub. Must produce zero emissions.
-	fc.Observe(mkSnap(), degradedResult(80, 90, "cpu", "runqlat"), "host-a", "v0.43.0")
-	fc.Observe(mkSnap(), &model.AnalysisResult{Health: model.HealthOK}, "host-a", "v0.43.0")
+	fc.Observe(mkSnap(), nil, degradedResult(80, 90, "cpu", "runqlat"), "host-a", "v0.43.0")
+	fc.Observe(mkSnap(), nil, &model.AnalysisResult{Health: model.HealthOK}, "host-a", "v0.43.0")
 	if n := countEmits(fc); n != 0 {
 		t.Errorf("one-off spike emitted %d events; want 0", n)
 	}
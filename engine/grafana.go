@@ -0,0 +1,402 @@
+package engine
+
+import (
+	"strings"
+)
+
+// GrafanaOptions controls the dashboard built by NewGrafanaDashboard.
+type GrafanaOptions struct {
+	Title      string // dashboard title (default "xtop")
+	UID        string // dashboard UID (default "xtop-host")
+	Datasource string // Prometheus datasource UID preselected in the picker; "" = choose on import
+	Refresh    string // auto-refresh interval (default "30s")
+	TimeZone   string // "", "local" = browser; "UTC" or an IANA name
+	SIUnits    bool   // 1000-based byte units instead of 1024
+}
+
+// GrafanaDashboard is a Grafana dashboard model (schema 39, Grafana 10+)
+// for the metrics served by MetricsStore.Handler. It marshals to the JSON
+// accepted by Dashboards → Import and by the /api/dashboards/db endpoint.
+type GrafanaDashboard struct {
+	UID           string            `json:"uid"`
+	Title         string            `json:"title"`
+	Description   string            `json:"description"`
+	Tags          []string          `json:"tags"`
+	Timezone      string            `json:"timezone"`
+	Refresh       string            `json:"refresh"`
+	SchemaVersion int               `json:"schemaVersion"`
+	Version       int               `json:"version"`
+	Editable      bool              `json:"editable"`
+	GraphTooltip  int               `json:"graphTooltip"`
+	Time          grafanaTimeRange  `json:"time"`
+	Templating    grafanaTemplating `json:"templating"`
+	Panels        []grafanaPanel    `json:"panels"`
+}
+
+type grafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type grafanaTemplating struct {
+	List []grafanaVariable `json:"list"`
+}
+
+type grafanaVariable struct {
+	Name       string          `json:"name"`
+	Label      string          `json:"label"`
+	Type       string          `json:"type"`
+	Query      interface{}     `json:"query"`
+	Definition string          `json:"definition,omitempty"`
+	Datasource *grafanaRef     `json:"datasource,omitempty"`
+	Current    *grafanaCurrent `json:"current,omitempty"`
+	Refresh    int             `json:"refresh"`
+	Multi      bool            `json:"multi"`
+	IncludeAll bool            `json:"includeAll"`
+	AllValue   string          `json:"allValue,omitempty"`
+	Sort       int             `json:"sort"`
+}
+
+type grafanaCurrent struct {
+	Text  interface{} `json:"text"`
+	Value interface{} `json:"value"`
+}
+
+type grafanaRef struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type grafanaGridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type grafanaTarget struct {
+	RefID        string      `json:"refId"`
+	Expr         string      `json:"expr"`
+	LegendFormat string      `json:"legendFormat"`
+	Instant      bool        `json:"instant,omitempty"`
+	Datasource   *grafanaRef `json:"datasource"`
+}
+
+type grafanaPanel struct {
+	ID              int                    `json:"id"`
+	Type            string                 `json:"type"`
+	Title           string                 `json:"title"`
+	Description     string                 `json:"description,omitempty"`
+	GridPos         grafanaGridPos         `json:"gridPos"`
+	Datasource      *grafanaRef            `json:"datasource,omitempty"`
+	Targets         []grafanaTarget        `json:"targets,omitempty"`
+	FieldConfig     map[string]interface{} `json:"fieldConfig,omitempty"`
+	Options         map[string]interface{} `json:"options,omitempty"`
+	Repeat          string                 `json:"repeat,omitempty"`
+	RepeatDirection string                 `json:"repeatDirection,omitempty"`
+	MaxPerRow       int                    `json:"maxPerRow,omitempty"`
+	Collapsed       *bool                  `json:"collapsed,omitempty"`
+	Panels          []grafanaPanel         `json:"panels,omitempty"`
+}
+
+// Label matchers shared by the panel queries.
+const (
+	grafanaHost   = `instance=~"$instance"`
+	grafanaDevice = `instance=~"$instance",device=~"$device"`
+	grafanaIface  = `instance=~"$instance",iface=~"$iface"`
+)
+
+// grafanaLayout places panels left to right on Grafana's 24-column grid,
+// wrapping to a new line when a panel no longer fits.
+type grafanaLayout struct {
+	ds     *grafanaRef
+	panels []grafanaPanel
+	x, y   int
+	lineH  int
+	nextID int
+}
+
+func (l *grafanaLayout) row(title string) {
+	l.wrap()
+	f := false
+	l.nextID++
+	l.panels = append(l.panels, grafanaPanel{
+		ID: l.nextID, Type: "row", Title: title, Collapsed: &f,
+		GridPos: grafanaGridPos{X: 0, Y: l.y, W: 24, H: 1},
+	})
+	l.y++
+}
+
+func (l *grafanaLayout) wrap() {
+	if l.x > 0 {
+		l.x = 0
+		l.y += l.lineH
+		l.lineH = 0
+	}
+}
+
+func (l *grafanaLayout) add(p grafanaPanel, w, h int) *grafanaPanel {
+	if l.x+w > 24 {
+		l.wrap()
+	}
+	l.nextID++
+	p.ID = l.nextID
+	p.GridPos = grafanaGridPos{X: l.x, Y: l.y, W: w, H: h}
+	p.Datasource = l.ds
+	for i := range p.Targets {
+		p.Targets[i].RefID = string(rune('A' + i))
+		p.Targets[i].Datasource = l.ds
+	}
+	l.x += w
+	if h > l.lineH {
+		l.lineH = h
+	}
+	l.panels = append(l.panels, p)
+	return &l.panels[len(l.panels)-1]
+}
+
+func grafanaQuery(expr, legend string) grafanaTarget {
+	return grafanaTarget{Expr: expr, LegendFormat: legend}
+}
+
+// grafanaFields builds fieldConfig.defaults. min/max < 0 are left unset;
+// steps are threshold values for yellow and red (none = plain green).
+func grafanaFields(unit string, min, max float64, steps ...float64) map[string]interface{} {
+	th := []map[string]interface{}{{"color": "green", "value": nil}}
+	for i, v := range steps {
+		color := "red"
+		if i == 0 && len(steps) > 1 {
+			color = "yellow"
+		}
+		th = append(th, map[string]interface{}{"color": color, "value": v})
+	}
+	defaults := map[string]interface{}{
+		"unit":       unit,
+		"thresholds": map[string]interface{}{"mode": "absolute", "steps": th},
+		"color":      map[string]interface{}{"mode": "palette-classic"},
+	}
+	if len(steps) > 0 {
+		defaults["color"] = map[string]interface{}{"mode": "thresholds"}
+	}
+	if min >= 0 {
+		defaults["min"] = min
+	}
+	if max >= 0 {
+		defaults["max"] = max
+	}
+	return map[string]interface{}{"defaults": defaults, "overrides": []interface{}{}}
+}
+
+// grafanaHealthFields maps xtop_health values to the TUI's state names.
+func grafanaHealthFields() map[string]interface{} {
+	fc := grafanaFields("none", 0, 3)
+	defaults := fc["defaults"].(map[string]interface{})
+	defaults["color"] = map[string]interface{}{"mode": "thresholds"}
+	defaults["mappings"] = []interface{}{map[string]interface{}{
+		"type": "value",
+		"options": map[string]interface{}{
+			"0": map[string]interface{}{"text": "OK", "color": "green", "index": 0},
+			"1": map[string]interface{}{"text": "INCONCLUSIVE", "color": "blue", "index": 1},
+			"2": map[string]interface{}{"text": "DEGRADED", "color": "orange", "index": 2},
+			"3": map[string]interface{}{"text": "CRITICAL", "color": "red", "index": 3},
+		},
+	}}
+	return fc
+}
+
+func grafanaTimeseries(title, unit string, min, max float64, targets ...grafanaTarget) grafanaPanel {
+	return grafanaPanel{
+		Type: "timeseries", Title: title, Targets: targets,
+		FieldConfig: grafanaFields(unit, min, max),
+		Options: map[string]interface{}{
+			"legend":  map[string]interface{}{"displayMode": "list", "placement": "bottom", "showLegend": true},
+			"tooltip": map[string]interface{}{"mode": "multi", "sort": "desc"},
+		},
+	}
+}
+
+func grafanaStat(title string, fc map[string]interface{}, targets ...grafanaTarget) grafanaPanel {
+	return grafanaPanel{
+		Type: "stat", Title: title, Targets: targets, FieldConfig: fc,
+		Options: map[string]interface{}{
+			"reduceOptions": map[string]interface{}{"calcs": []string{"lastNotNull"}, "fields": "", "values": false},
+			"colorMode":     "background",
+			"graphMode":     "area",
+			"textMode":      "value_and_name",
+			"justifyMode":   "auto",
+			"orientation":   "auto",
+		},
+	}
+}
+
+// NewGrafanaDashboard returns a dashboard wired to the exporter's metric
+// names: health, RCA score, PSI, capacity headroom, CPU and memory,
+// per-disk and per-interface panels and the top cgroups. A host picker
+// ($instance) over label_values(xtop_up, instance) turns it into a fleet
+// view when several xtop exporters feed the same Prometheus.
+func NewGrafanaDashboard(opts GrafanaOptions) *GrafanaDashboard {
+	if opts.Title == "" {
+		opts.Title = "xtop"
+	}
+	if opts.UID == "" {
+		opts.UID = "xtop-host"
+	}
+	if opts.Refresh == "" {
+		opts.Refresh = "30s"
+	}
+	tz := "browser"
+	switch strings.ToLower(strings.TrimSpace(opts.TimeZone)) {
+	case "", "local":
+	case "utc":
+		tz = "utc"
+	default:
+		tz = opts.TimeZone
+	}
+	bytesUnit, rateUnit := "bytes", "binBps"
+	if opts.SIUnits {
+		bytesUnit, rateUnit = "decbytes", "Bps"
+	}
+
+	ds := &grafanaRef{Type: "prometheus", UID: "${datasource}"}
+	l := &grafanaLayout{ds: ds}
+
+	l.row("Health & root cause")
+	health := l.add(grafanaStat("Health", grafanaHealthFields(),
+		grafanaQuery(`max by (instance) (xtop_health{`+grafanaHost+`})`, "{{instance}}")), 6, 5)
+	health.Options["graphMode"] = "none"
+	l.add(grafanaStat("Primary RCA score", grafanaFields("none", 0, 100, 40, 70),
+		grafanaQuery(`max by (instance) (xtop_rca_primary_score{`+grafanaHost+`})`, "{{instance}}")), 6, 5)
+	l.add(grafanaStat("CPU busy", grafanaFields("percent", 0, 100, 70, 90),
+		grafanaQuery(`xtop_cpu_busy_pct{`+grafanaHost+`}`, "{{instance}}")), 6, 5)
+	l.add(grafanaStat("Memory used", grafanaFields("percent", 0, 100, 80, 95),
+		grafanaQuery(`xtop_mem_used_pct{`+grafanaHost+`}`, "{{instance}}")), 6, 5)
+	timeline := l.add(grafanaPanel{
+		Type: "state-timeline", Title: "Health over time", FieldConfig: grafanaHealthFields(),
+		Targets: []grafanaTarget{grafanaQuery(`max by (instance) (xtop_health{`+grafanaHost+`})`, "{{instance}}")},
+		Options: map[string]interface{}{
+			"showValue": "never", "mergeValues": true, "rowHeight": 0.9,
+			"legend": map[string]interface{}{"displayMode": "list", "placement": "bottom", "showLegend": false},
+		},
+	}, 12, 8)
+	timeline.Description = "0 OK, 1 INCONCLUSIVE, 2 DEGRADED, 3 CRITICAL — the TUI's health states."
+	l.add(grafanaTimeseries("RCA primary score by bottleneck", "none", 0, 100,
+		grafanaQuery(`xtop_rca_primary_score{`+grafanaHost+`,bottleneck!=""}`, "{{instance}} {{bottleneck}}")), 12, 8)
+
+	l.row("Pressure (PSI avg10)")
+	l.add(grafanaTimeseries("PSI some", "percent", 0, -1,
+		grafanaQuery(`xtop_psi_cpu_some{`+grafanaHost+`}`, "{{instance}} cpu"),
+		grafanaQuery(`xtop_psi_mem_some{`+grafanaHost+`}`, "{{instance}} mem"),
+		grafanaQuery(`xtop_psi_io_some{`+grafanaHost+`}`, "{{instance}} io")), 12, 8)
+	l.add(grafanaTimeseries("PSI full", "percent", 0, -1,
+		grafanaQuery(`xtop_psi_cpu_full{`+grafanaHost+`}`, "{{instance}} cpu"),
+		grafanaQuery(`xtop_psi_mem_full{`+grafanaHost+`}`, "{{instance}} mem"),
+		grafanaQuery(`xtop_psi_io_full{`+grafanaHost+`}`, "{{instance}} io")), 12, 8)
+
+	l.row("Capacity")
+	headroom := l.add(grafanaPanel{
+		Type: "bargauge", Title: "Headroom left",
+		Description: "Remaining capacity per resource, as on the TUI capacity panel. Low is bad.",
+		FieldConfig: grafanaFields("percent", 0, 100),
+		Targets: []grafanaTarget{{
+			Expr: `xtop_capacity_headroom_pct{` + grafanaHost + `}`, LegendFormat: "{{instance}} {{resource}}", Instant: true,
+		}},
+		Options: map[string]interface{}{
+			"displayMode": "gradient", "orientation": "horizontal", "showUnfilled": true,
+			"reduceOptions": map[string]interface{}{"calcs": []string{"lastNotNull"}, "fields": "", "values": false},
+		},
+	}, 12, 10)
+	headroom.FieldConfig["defaults"].(map[string]interface{})["thresholds"] = map[string]interface{}{
+		"mode": "absolute",
+		"steps": []map[string]interface{}{
+			{"color": "red", "value": nil}, {"color": "yellow", "value": 15}, {"color": "green", "value": 30},
+		},
+	}
+	headroom.FieldConfig["defaults"].(map[string]interface{})["color"] = map[string]interface{}{"mode": "thresholds"}
+	l.add(grafanaTimeseries("Headroom trend", "percent", 0, 100,
+		grafanaQuery(`xtop_capacity_headroom_pct{`+grafanaHost+`}`, "{{instance}} {{resource}}")), 12, 10)
+
+	l.row("CPU & memory")
+	l.add(grafanaTimeseries("CPU breakdown", "percent", 0, 100,
+		grafanaQuery(`xtop_cpu_user_pct{`+grafanaHost+`}`, "{{instance}} user"),
+		grafanaQuery(`xtop_cpu_system_pct{`+grafanaHost+`}`, "{{instance}} system"),
+		grafanaQuery(`xtop_cpu_iowait_pct{`+grafanaHost+`}`, "{{instance}} iowait"),
+		grafanaQuery(`xtop_cpu_steal_pct{`+grafanaHost+`}`, "{{instance}} steal")), 12, 8)
+	l.add(grafanaTimeseries("Memory", bytesUnit, 0, -1,
+		grafanaQuery(`xtop_mem_total_bytes{`+grafanaHost+`} - xtop_mem_available_bytes{`+grafanaHost+`}`, "{{instance}} used"),
+		grafanaQuery(`xtop_mem_total_bytes{`+grafanaHost+`}`, "{{instance}} total")), 12, 8)
+
+	l.row("Disks")
+	l.add(grafanaTimeseries("Utilization", "percent", 0, 100,
+		grafanaQuery(`xtop_disk_util_pct{`+grafanaDevice+`}`, "{{instance}} {{device}}"),
+		grafanaQuery(`xtop_disk_util_max_pct{`+grafanaHost+`}`, "{{instance}} busiest")), 12, 8)
+	l.add(grafanaTimeseries("Await", "ms", 0, -1,
+		grafanaQuery(`xtop_disk_await_ms{`+grafanaDevice+`}`, "{{instance}} {{device}}")), 12, 8)
+	disk := l.add(grafanaTimeseries("Disk $device throughput", rateUnit, 0, -1,
+		grafanaQuery(`xtop_disk_read_mbps{`+grafanaDevice+`} * 1048576`, "{{instance}} read"),
+		grafanaQuery(`xtop_disk_write_mbps{`+grafanaDevice+`} * 1048576`, "{{instance}} write")), 8, 7)
+	disk.Repeat, disk.RepeatDirection, disk.MaxPerRow = "device", "h", 3
+
+	l.row("Network")
+	l.add(grafanaTimeseries("Utilization", "percent", 0, 100,
+		grafanaQuery(`xtop_net_util_pct{`+grafanaIface+`}`, "{{instance}} {{iface}}")), 8, 8)
+	l.add(grafanaTimeseries("Drops & errors", "pps", 0, -1,
+		grafanaQuery(`xtop_net_drops_iface_per_sec{`+grafanaIface+`}`, "{{instance}} {{iface}} drops"),
+		grafanaQuery(`xtop_net_errors_iface_per_sec{`+grafanaIface+`}`, "{{instance}} {{iface}} errors")), 8, 8)
+	l.add(grafanaTimeseries("TCP retransmits", "pps", 0, -1,
+		grafanaQuery(`xtop_net_retrans_per_sec{`+grafanaHost+`}`, "{{instance}}")), 8, 8)
+	nic := l.add(grafanaTimeseries("Interface $iface traffic", rateUnit, 0, -1,
+		grafanaQuery(`xtop_net_rx_mbps{`+grafanaIface+`} * 1048576`, "{{instance}} rx"),
+		grafanaQuery(`xtop_net_tx_mbps{`+grafanaIface+`} * 1048576`, "{{instance}} tx")), 8, 7)
+	nic.Repeat, nic.RepeatDirection, nic.MaxPerRow = "iface", "h", 3
+
+	l.row("Cgroups (top 10 of the 50 exported)")
+	l.add(grafanaTimeseries("CPU", "percent", 0, -1,
+		grafanaQuery(`topk(10, xtop_cgroup_cpu_pct{`+grafanaHost+`})`, "{{instance}} {{name}}")), 8, 8)
+	l.add(grafanaTimeseries("Memory", "percent", 0, 100,
+		grafanaQuery(`topk(10, xtop_cgroup_mem_pct{`+grafanaHost+`})`, "{{instance}} {{name}}")), 8, 8)
+	l.add(grafanaTimeseries("CPU throttled", "percent", 0, 100,
+		grafanaQuery(`topk(10, xtop_cgroup_throttle_pct{`+grafanaHost+`} > 0)`, "{{instance}} {{name}}")), 8, 8)
+
+	dsVar := grafanaVariable{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"}
+	if opts.Datasource != "" {
+		dsVar.Current = &grafanaCurrent{Text: opts.Datasource, Value: opts.Datasource}
+	}
+	labelVar := func(name, label, query string) grafanaVariable {
+		return grafanaVariable{
+			Name:       name,
+			Label:      label,
+			Type:       "query",
+			Query:      map[string]interface{}{"query": query, "refId": "PrometheusVariableQueryEditor-VariableQuery"},
+			Definition: query,
+			Datasource: ds,
+			Current:    &grafanaCurrent{Text: []string{"All"}, Value: []string{"$__all"}},
+			Refresh:    2, // on time range change
+			Multi:      true,
+			IncludeAll: true,
+			AllValue:   ".*",
+			Sort:       1,
+		}
+	}
+
+	return &GrafanaDashboard{
+		UID:   opts.UID,
+		Title: opts.Title,
+		Description: "xtop Prometheus exporter (xtop -prom): health, RCA, PSI, capacity, " +
+			"disks, interfaces and cgroups per host.",
+		Tags:          []string{"xtop", "linux"},
+		Timezone:      tz,
+		Refresh:       opts.Refresh,
+		SchemaVersion: 39,
+		Version:       1,
+		Editable:      true,
+		GraphTooltip:  1,
+		Time:          grafanaTimeRange{From: "now-6h", To: "now"},
+		Templating: grafanaTemplating{List: []grafanaVariable{
+			dsVar,
+			labelVar("instance", "Host", `label_values(xtop_up, instance)`),
+			labelVar("device", "Disk", `label_values(xtop_disk_util_pct{`+grafanaHost+`}, device)`),
+			labelVar("iface", "Interface", `label_values(xtop_net_rx_mbps{`+grafanaHost+`}, iface)`),
+		}},
+		Panels: l.panels,
+	}
+}
@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// TestGrafanaDashboardMatchesExporter keeps the dashboard and
+// writePrometheus in step: every metric a panel or variable queries must
+// be one the exporter writes.
+func TestGrafanaDashboardMatchesExporter(t *testing.T) {
+	snap := &model.Snapshot{}
+	snap.Global.Memory.Total = 8 << 30
+	snap.Global.Memory.Available = 2 << 30
+	rates := &model.RateSnapshot{
+		DiskRates:   []model.DiskRate{{Name: "sda", UtilPct: 40}},
+		NetRates:    []model.NetRate{{Name: "eth0", RxMBs: 1}},
+		CgroupRates: []model.CgroupRate{{Path: "/system.slice/nginx.service", Name: "nginx", CPUPct: 12}},
+	}
+	result := &model.AnalysisResult{
+		PrimaryBottleneck: "IO Starvation",
+		PrimaryScore:      70,
+		Capacities:        []model.Capacity{{Label: "MemAvailable", Pct: 25}},
+	}
+	var buf bytes.Buffer
+	writePrometheus(&buf, snap, rates, result)
+	exported := map[string]bool{}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if f := strings.Fields(line); len(f) == 4 && f[1] == "TYPE" {
+			exported[f[2]] = true
+		}
+	}
+
+	d := NewGrafanaDashboard(GrafanaOptions{})
+	metricRe := regexp.MustCompile(`xtop_[a-z0-9_]+`)
+	var exprs []string
+	for _, p := range d.Panels {
+		for _, tg := range p.Targets {
+			exprs = append(exprs, tg.Expr)
+		}
+	}
+	for _, v := range d.Templating.List {
+		exprs = append(exprs, v.Definition)
+	}
+	seen := map[string]bool{}
+	for _, expr := range exprs {
+		for _, m := range metricRe.FindAllString(expr, -1) {
+			seen[m] = true
+			if !exported[m] {
+				t.Errorf("dashboard queries %s, which the exporter does not write (%s)", m, expr)
+			}
+		}
+	}
+	for _, want := range []string{"xtop_health", "xtop_psi_io_some", "xtop_rca_primary_score",
+		"xtop_capacity_headroom_pct", "xtop_disk_util_pct", "xtop_net_rx_mbps"} {
+		if !seen[want] {
+			t.Errorf("no panel for %s", want)
+		}
+	}
+}
+
+func TestGrafanaDashboardModel(t *testing.T) {
+	d := NewGrafanaDashboard(GrafanaOptions{Datasource: "prom-uid", TimeZone: "UTC", SIUnits: true})
+	if d.Timezone != "utc" {
+		t.Errorf("timezone = %q, want utc", d.Timezone)
+	}
+	if cur := d.Templating.List[0].Current; cur == nil || cur.Value != "prom-uid" {
+		t.Errorf("datasource variable current = %+v", cur)
+	}
+
+	vars := map[string]bool{}
+	for _, v := range d.Templating.List {
+		vars[v.Name] = true
+	}
+	varRe := regexp.MustCompile(`\$\{?([a-z_]+)`)
+	ids := map[int]bool{}
+	for _, p := range d.Panels {
+		if ids[p.ID] {
+			t.Errorf("duplicate panel id %d", p.ID)
+		}
+		ids[p.ID] = true
+		if p.GridPos.X+p.GridPos.W > 24 {
+			t.Errorf("%q overflows the grid: %+v", p.Title, p.GridPos)
+		}
+		if p.Repeat != "" && !vars[p.Repeat] {
+			t.Errorf("%q repeats over undefined $%s", p.Title, p.Repeat)
+		}
+		for _, tg := range p.Targets {
+			for _, m := range varRe.FindAllStringSubmatch(tg.Expr, -1) {
+				if !vars[m[1]] {
+					t.Errorf("%q uses undefined $%s", p.Title, m[1])
+				}
+			}
+			if strings.Contains(tg.Expr, "_mbps") && p.FieldConfig["defaults"].(map[string]interface{})["unit"] != "Bps" {
+				t.Errorf("%q: SI byte rate unit not applied", p.Title)
+			}
+		}
+	}
+
+	if _, err := json.Marshal(d); err != nil {
+		t.Fatal(err)
+	}
+}
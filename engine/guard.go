@@ -43,7 +43,7 @@ import (
 // Opt-in: must be enabled via XTOP_GUARD=1 (or explicit Enable()). Default
 // off so existing deployments see no behavior change.
 type ResourceGuard struct {
-	// Config (set at construction, read-only thereafter)
+	// Config (set at construction; SetPolicy re-derives it on reload)
 	enabled         bool
 	ownCPUBudgetPct float64 // we consider ourselves "greedy" above this
 	loadWarnRatio   float64 // load/NumCPUs threshold for level 1
@@ -89,32 +89,62 @@ func NewResourceGuard(numCPUs, baseIntervalSec int) *ResourceGuard {
 	if numCPUs <= 0 {
 		numCPUs = 1
 	}
-	// Guardian is now ON BY DEFAULT. The whole point of a safety net that
-	// throttles xtop on busy hosts is to be there *before* the operator
-	// thinks to enable it — by the time someone runs `xtop` on a thrashing
-	// box, they don't have time to remember an env var. Set XTOP_GUARD=0
-	// to opt out (audit / debugging only).
-	enabled := true
-	if v := os.Getenv("XTOP_GUARD"); v == "0" || v == "off" || v == "false" {
-		enabled = false
-	}
 	g := &ResourceGuard{
-		enabled:         enabled,
-		// 5% of one core is the "we are contributing meaningfully" line.
-		// Earlier (v0.46.3-app-rca) used 2%, which was too tight for the
-		// interactive TUI: lipgloss reflow during a full-screen redraw on
-		// a 100+ wide terminal can briefly use 10-15% of a core, kicking
-		// the guard into L3 and blackholing the apps panel even when the
-		// host is genuinely idle.
-		ownCPUBudgetPct: 5.0,
-		loadWarnRatio:   1.5, // load > 1.5 × NumCPUs → caution
-		loadCritRatio:   3.0, // load > 3.0 × NumCPUs → degraded
-		hostBusyWarnPct: 75,
-		hostBusyCritPct: 92,
+		// Guardian is now ON BY DEFAULT. The whole point of a safety net
+		// that throttles xtop on busy hosts is to be there *before* the
+		// operator thinks to enable it — by the time someone runs `xtop`
+		// on a thrashing box, they don't have time to remember an env var.
+		// Set XTOP_GUARD=0 to opt out (audit / debugging only).
+		enabled:         true,
 		baseIntervalSec: baseIntervalSec,
-		maxIntervalSec:  baseIntervalSec * 4, // L3 = "stop running so often"
 		numCPUs:         numCPUs,
 	}
+	g.SetPolicy(GuardPolicy{})
+	g.intervalSec.Store(int32(baseIntervalSec))
+	return g
+}
+
+// GuardPolicy is the config-file view of the guard thresholds
+// ("guard" in config.json). Zero fields keep the built-in defaults and a
+// nil Enabled leaves the on/off state alone; XTOP_GUARD* environment
+// variables still take precedence over both.
+type GuardPolicy struct {
+	Enabled         *bool
+	OwnCPUPct       float64
+	LoadWarn        float64
+	LoadCrit        float64
+	HostBusyWarnPct float64
+	HostBusyCritPct float64
+	MaxIntervalSec  int
+}
+
+// SetPolicy re-derives the thresholds from the defaults, p and the
+// environment. Called at construction and again on config reload; the
+// current level and hysteresis counters are kept so a reload does not
+// make the guard forget that the host is busy.
+func (g *ResourceGuard) SetPolicy(p GuardPolicy) {
+	// 5% of one core is the "we are contributing meaningfully" line.
+	// Earlier (v0.46.3-app-rca) used 2%, which was too tight for the
+	// interactive TUI: lipgloss reflow during a full-screen redraw on
+	// a 100+ wide terminal can briefly use 10-15% of a core, kicking
+	// the guard into L3 and blackholing the apps panel even when the
+	// host is genuinely idle.
+	g.ownCPUBudgetPct = 5.0
+	g.loadWarnRatio = 1.5 // load > 1.5 × NumCPUs → caution
+	g.loadCritRatio = 3.0 // load > 3.0 × NumCPUs → degraded
+	g.hostBusyWarnPct = 75
+	g.hostBusyCritPct = 92
+	g.maxIntervalSec = g.baseIntervalSec * 4 // L3 = "stop running so often"
+
+	overlayFloat(p.OwnCPUPct, &g.ownCPUBudgetPct)
+	overlayFloat(p.LoadWarn, &g.loadWarnRatio)
+	overlayFloat(p.LoadCrit, &g.loadCritRatio)
+	overlayFloat(p.HostBusyWarnPct, &g.hostBusyWarnPct)
+	overlayFloat(p.HostBusyCritPct, &g.hostBusyCritPct)
+	if p.MaxIntervalSec > 0 {
+		g.maxIntervalSec = p.MaxIntervalSec
+	}
+
 	overlayEnvFloat("XTOP_GUARD_OWN_CPU_PCT", &g.ownCPUBudgetPct)
 	overlayEnvFloat("XTOP_GUARD_LOAD_WARN", &g.loadWarnRatio)
 	overlayEnvFloat("XTOP_GUARD_LOAD_CRIT", &g.loadCritRatio)
@@ -123,8 +153,13 @@ func NewResourceGuard(numCPUs, baseIntervalSec int) *ResourceGuard {
 	if v, err := strconv.Atoi(os.Getenv("XTOP_GUARD_MAX_INTERVAL_SEC")); err == nil && v > 0 {
 		g.maxIntervalSec = v
 	}
-	g.intervalSec.Store(int32(baseIntervalSec))
-	return g
+
+	if p.Enabled != nil {
+		g.SetEnabled(*p.Enabled)
+	}
+	if v := os.Getenv("XTOP_GUARD"); v == "0" || v == "off" || v == "false" {
+		g.SetEnabled(false)
+	}
 }
 
 // Enabled reports whether the guard is active. When false, Advise returns
@@ -398,6 +433,12 @@ func overlayEnvFloat(name string, dst *float64) {
 	}
 }
 
+func overlayFloat(v float64, dst *float64) {
+	if v > 0 {
+		*dst = v
+	}
+}
+
 func reasonFor(level int, loadRatio, hostBusy, ownCPU float64, g *ResourceGuard) string {
 	if level == 0 {
 		return ""
@@ -160,3 +160,29 @@ func TestSplitFields_HandlesStatFormat(t *testing.T) {
 		t.Errorf("utime/stime at expected offsets: %q/%q", fields[11], fields[12])
 	}
 }
+
+func TestGuard_SetPolicy(t *testing.T) {
+	for _, k := range []string{"XTOP_GUARD", "XTOP_GUARD_LOAD_WARN", "XTOP_GUARD_MAX_INTERVAL_SEC"} {
+		t.Setenv(k, "")
+	}
+	g := NewResourceGuard(4, 3)
+	g.level.Store(2)
+	off := false
+	g.SetPolicy(GuardPolicy{LoadWarn: 2, MaxIntervalSec: 30, Enabled: &off})
+	if g.loadWarnRatio != 2 || g.loadCritRatio != 3.0 || g.maxIntervalSec != 30 || g.Enabled() {
+		t.Fatalf("policy not applied: warn=%v crit=%v max=%d enabled=%v",
+			g.loadWarnRatio, g.loadCritRatio, g.maxIntervalSec, g.Enabled())
+	}
+
+	// Env wins over the file; a nil Enabled leaves the on/off state alone.
+	t.Setenv("XTOP_GUARD_LOAD_WARN", "1.2")
+	g.SetEnabled(true)
+	g.level.Store(2)
+	g.SetPolicy(GuardPolicy{LoadWarn: 2})
+	if g.loadWarnRatio != 1.2 || g.maxIntervalSec != 12 || !g.Enabled() {
+		t.Errorf("reload: warn=%v max=%d enabled=%v", g.loadWarnRatio, g.maxIntervalSec, g.Enabled())
+	}
+	if g.level.Load() != 2 {
+		t.Errorf("reload reset the guard level to %d", g.level.Load())
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -120,3 +121,36 @@ func splitLines(s string) []string {
 	}
 	return out
 }
+
+// AuditEntry is one parsed line of the guardian audit log.
+type AuditEntry struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// ReadGuardianAudit returns audit log entries recorded at or after since,
+// oldest first. A missing log is not an error — most hosts never trip the
+// guardian.
+func ReadGuardianAudit(since time.Time) ([]AuditEntry, error) {
+	data, err := os.ReadFile(guardianAuditPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []AuditEntry
+	for _, line := range splitLines(string(data)) {
+		// Format written by guardianAudit: "<RFC3339>  <msg>"
+		ts, msg, ok := strings.Cut(line, "  ")
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, ts)
+		if err != nil || t.Before(since) {
+			continue
+		}
+		out = append(out, AuditEntry{Time: t, Message: msg})
+	}
+	return out, nil
+}
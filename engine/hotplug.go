@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// hotplugRecentTTL is how long device changes stay available for RCA
+// correlation after they happened.
+const hotplugRecentTTL = 30 * time.Minute
+
+// virtualNICPrefixes are interfaces that container runtimes and hypervisors
+// create and destroy all day. Their churn is not hardware news.
+var virtualNICPrefixes = []string{"veth", "cali", "lxc", "tap", "vnet", "cni", "flannel", "vxlan", "tun", "kube-ipvs", "docker_gwbridge"}
+
+// HotplugTracker notices disks, NICs and CPUs appearing or disappearing
+// between ticks: an attached EBS volume, a pulled USB NIC, a core taken
+// offline. Collectors re-read /proc and /sys every tick so the new device
+// shows up on its own; the tracker turns the difference into change events
+// and tells the engine when cached inventories need a rescan.
+type HotplugTracker struct {
+	disks  map[string]bool
+	nics   map[string]bool
+	cpus   map[int]bool
+	primed bool
+	recent []model.SystemChange
+}
+
+// NewHotplugTracker creates a tracker; the first Tick only records the
+// inventory.
+func NewHotplugTracker() *HotplugTracker {
+	return &HotplugTracker{}
+}
+
+// Tick compares snap's devices with the previous tick's and returns one
+// change per device added or removed.
+func (h *HotplugTracker) Tick(snap *model.Snapshot) []model.SystemChange {
+	if snap == nil {
+		return nil
+	}
+	disks := make(map[string]bool, len(snap.Global.Disks))
+	for _, d := range snap.Global.Disks {
+		disks[d.Name] = true
+	}
+	nics := make(map[string]bool, len(snap.Global.Network))
+	for _, n := range snap.Global.Network {
+		if !isVirtualNIC(n.Name) {
+			nics[n.Name] = true
+		}
+	}
+	cpus := make(map[int]bool, len(snap.Global.CPU.CPUIDs))
+	for _, id := range snap.Global.CPU.CPUIDs {
+		cpus[id] = true
+	}
+
+	now := snap.Timestamp
+	if now.IsZero() {
+		now = time.Now()
+	}
+	var changes []model.SystemChange
+	if h.primed {
+		changes = append(changes, diffDevices("disk", h.disks, disks, now)...)
+		changes = append(changes, diffDevices("nic", h.nics, nics, now)...)
+		// A snapshot without CPU numbers (older recording) says nothing
+		// about which cores are online.
+		if len(cpus) > 0 && len(h.cpus) > 0 {
+			changes = append(changes, diffCPUs(h.cpus, cpus, now)...)
+		}
+	}
+	h.disks, h.nics, h.cpus, h.primed = disks, nics, cpus, true
+
+	if len(changes) > 0 {
+		h.recent = append(h.recent, changes...)
+	}
+	cutoff := now.Add(-hotplugRecentTTL)
+	kept := h.recent[:0]
+	for _, c := range h.recent {
+		if c.When.After(cutoff) {
+			kept = append(kept, c)
+		}
+	}
+	h.recent = kept
+	return changes
+}
+
+// Recent returns the device changes of the last 30 minutes, oldest first.
+func (h *HotplugTracker) Recent() []model.SystemChange {
+	return append([]model.SystemChange(nil), h.recent...)
+}
+
+func diffDevices(kind string, prev, curr map[string]bool, now time.Time) []model.SystemChange {
+	var out []model.SystemChange
+	for _, name := range sortedKeys(curr) {
+		if !prev[name] {
+			out = append(out, model.SystemChange{Type: kind + "_added", Detail: name, When: now})
+		}
+	}
+	for _, name := range sortedKeys(prev) {
+		if !curr[name] {
+			out = append(out, model.SystemChange{Type: kind + "_removed", Detail: name, When: now})
+		}
+	}
+	return out
+}
+
+func diffCPUs(prev, curr map[int]bool, now time.Time) []model.SystemChange {
+	var online, offline []int
+	for id := range curr {
+		if !prev[id] {
+			online = append(online, id)
+		}
+	}
+	for id := range prev {
+		if !curr[id] {
+			offline = append(offline, id)
+		}
+	}
+	sort.Ints(online)
+	sort.Ints(offline)
+	var out []model.SystemChange
+	for _, id := range online {
+		out = append(out, model.SystemChange{Type: "cpu_online", Detail: fmt.Sprintf("cpu%d", id), When: now})
+	}
+	for _, id := range offline {
+		out = append(out, model.SystemChange{Type: "cpu_offline", Detail: fmt.Sprintf("cpu%d", id), When: now})
+	}
+	return out
+}
+
+func sortedKeys(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func isVirtualNIC(name string) bool {
+	for _, p := range virtualNICPrefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatHotplugHint mirrors formatConfigDriftHint for device changes:
+// "HARDWARE CHANGED 4m before degradation: disk nvme2n1 added (+1 more)".
+// The newest change is the headline.
+func formatHotplugHint(changes []model.SystemChange) string {
+	if len(changes) == 0 {
+		return ""
+	}
+	c := changes[len(changes)-1]
+	kind, action, _ := strings.Cut(c.Type, "_")
+	what := kind + " " + c.Detail + " " + action
+	if kind == "cpu" {
+		what = c.Detail + " " + action
+	}
+	hint := "HARDWARE CHANGED " + fmtDriftAge(time.Since(c.When)) + " before degradation: " + what
+	if len(changes) > 1 {
+		hint += fmt.Sprintf(" (+%d more)", len(changes)-1)
+	}
+	return hint
+}
@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func hotplugSnap(at time.Time, disks, nics []string, cpus []int) *model.Snapshot {
+	s := &model.Snapshot{Timestamp: at}
+	for _, d := range disks {
+		s.Global.Disks = append(s.Global.Disks, model.DiskStats{Name: d})
+	}
+	for _, n := range nics {
+		s.Global.Network = append(s.Global.Network, model.NetworkStats{Name: n})
+	}
+	s.Global.CPU.CPUIDs = cpus
+	return s
+}
+
+func TestHotplugTracker(t *testing.T) {
+	h := NewHotplugTracker()
+	now := time.Now()
+	if c := h.Tick(hotplugSnap(now, []string{"nvme0n1"}, []string{"eth0", "veth1a2b"}, []int{0, 1, 2, 3})); len(c) != 0 {
+		t.Fatalf("first tick reported %+v", c)
+	}
+
+	// EBS volume attached, a container's veth replaced, core 2 offlined.
+	now = now.Add(3 * time.Second)
+	got := h.Tick(hotplugSnap(now, []string{"nvme0n1", "nvme1n1"}, []string{"eth0", "veth9c8d"}, []int{0, 1, 3}))
+	var types []string
+	for _, c := range got {
+		types = append(types, c.Type+":"+c.Detail)
+	}
+	if strings.Join(types, " ") != "disk_added:nvme1n1 cpu_offline:cpu2" {
+		t.Errorf("changes = %v", types)
+	}
+
+	// Volume detached, NIC pulled, core back online.
+	now = now.Add(3 * time.Second)
+	got = h.Tick(hotplugSnap(now, []string{"nvme0n1"}, nil, []int{0, 1, 2, 3}))
+	types = types[:0]
+	for _, c := range got {
+		types = append(types, c.Type+":"+c.Detail)
+	}
+	if strings.Join(types, " ") != "disk_removed:nvme1n1 nic_removed:eth0 cpu_online:cpu2" {
+		t.Errorf("changes = %v", types)
+	}
+
+	if r := h.Recent(); len(r) != 5 {
+		t.Errorf("recent = %+v", r)
+	}
+	if hint := formatHotplugHint(h.Recent()); !strings.HasPrefix(hint, "HARDWARE CHANGED") || !strings.Contains(hint, "cpu2 online (+4 more)") {
+		t.Errorf("hint = %q", hint)
+	}
+}
+
+func TestMountGrowthTrackerRetiresMounts(t *testing.T) {
+	g := NewMountGrowthTracker()
+	g.Smooth([]model.MountRate{{MountPoint: "/data", GrowthBytesPerSec: 1 << 20}})
+	g.Smooth([]model.MountRate{{MountPoint: "/"}})
+	if _, ok := g.ewma["/data"]; ok {
+		t.Error("unmounted /data still tracked")
+	}
+	r := []model.MountRate{{MountPoint: "/data"}}
+	g.Smooth(r)
+	if r[0].PrevGrowthBPS != 0 {
+		t.Errorf("remounted /data inherited growth %.0f", r[0].PrevGrowthBPS)
+	}
+}
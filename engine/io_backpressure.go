@@ -0,0 +1,379 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/ftahirops/xtop/advice"
+	cgcollector "github.com/ftahirops/xtop/collector/cgroup"
+	"github.com/ftahirops/xtop/model"
+)
+
+// ─── Database IO backpressure ───────────────────────────────────────────────
+//
+// When IO is the primary bottleneck and a database's cgroup is starving
+// (members in D-state or its own io.pressure high) while another cgroup
+// moves most of the bytes, the generic "storage overloaded" advice is not
+// actionable. Instead we read the pair's IO-controller state and emit exact
+// io.weight / io.max values derived from the shares we observe: the weight
+// ratio flips the observed split so that, under contention, the database
+// gets the share the aggressor takes today. io.weight is work-conserving —
+// the aggressor still gets every byte the database does not ask for.
+
+const (
+	ioBPMinAggMBs    = 5.0  // aggressor must move at least this much
+	ioBPMinRatio     = 2.0  // ...and at least this many times the DB's throughput
+	ioBPMaxRatio     = 10.0 // cap the weight ratio (DB gets at most ~91%)
+	ioBPPSIStarved   = 10.0 // DB cgroup io.pressure some avg10 that counts as starving
+	ioBPMaxWeight    = 10000
+	ioBPMaxBFQWeight = 1000 // io.bfq.weight range is 1-1000
+)
+
+// databaseComms are process names treated as latency-sensitive databases.
+var databaseComms = []string{
+	"mysqld", "mariadbd", "postgres", "postmaster", "mongod", "redis-server",
+	"clickhouse", "clickhouse-serv", "etcd", "influxd", "cockroach", "valkey-server",
+}
+
+func isDatabaseComm(comm string) bool {
+	for _, db := range databaseComms {
+		if comm == db || strings.HasPrefix(comm, db+":") {
+			return true
+		}
+	}
+	return strings.HasPrefix(comm, "postgres")
+}
+
+// ioBPEnv reads the cgroup IO-controller state; tests substitute fakes.
+type ioBPEnv struct {
+	control   func(relPath string) cgcollector.IOControl
+	ioCost    func(majMin string) (enabled, supported bool)
+	scheduler func(majMin string) string
+}
+
+var liveIOBPEnv = ioBPEnv{
+	control:   cgcollector.ReadIOControl,
+	ioCost:    cgcollector.IOCostEnabled,
+	scheduler: cgcollector.BlockScheduler,
+}
+
+// BuildIOBackpressure returns a plan when IO is the primary bottleneck and a
+// database cgroup is being starved by another cgroup, or nil.
+func BuildIOBackpressure(rates *model.RateSnapshot, result *model.AnalysisResult) *model.IOBackpressurePlan {
+	if rates == nil || result == nil || result.PrimaryBottleneck != BottleneckIO || result.PrimaryScore < 20 {
+		return nil
+	}
+	return buildIOBackpressure(rates, liveIOBPEnv)
+}
+
+type ioBPVictim struct {
+	comm   string
+	dstate int
+}
+
+func buildIOBackpressure(rates *model.RateSnapshot, env ioBPEnv) *model.IOBackpressurePlan {
+	victims := make(map[string]*ioBPVictim)
+	for _, p := range rates.ProcessRates {
+		if p.CgroupPath == "" || p.CgroupPath == "/" || !isDatabaseComm(p.Comm) {
+			continue
+		}
+		v := victims[p.CgroupPath]
+		if v == nil {
+			v = &ioBPVictim{comm: p.Comm}
+			victims[p.CgroupPath] = v
+		}
+		if p.State == "D" {
+			v.dstate++
+		}
+	}
+	if len(victims) == 0 {
+		return nil
+	}
+	cgRate := make(map[string]float64, len(rates.CgroupRates))
+	for _, cg := range rates.CgroupRates {
+		cgRate[cg.Path] = cg.IORateMBs + cg.IOWRateMBs
+	}
+
+	var best *model.IOBackpressurePlan
+	for dbPath, v := range victims {
+		plan := planForVictim(dbPath, v, cgRate, rates.CgroupRates, env)
+		if plan != nil && (best == nil || plan.DBShare < best.DBShare) {
+			best = plan
+		}
+	}
+	return best
+}
+
+func planForVictim(dbPath string, v *ioBPVictim, cgRate map[string]float64, cgs []model.CgroupRate, env ioBPEnv) *model.IOBackpressurePlan {
+	dbCtl := env.control(dbPath)
+	if v.dstate == 0 && dbCtl.PSI.Some.Avg10 < ioBPPSIStarved {
+		return nil
+	}
+	d := cgRate[dbPath]
+
+	agg := ioBPAggressor(dbPath, cgs)
+	if agg == nil {
+		return nil
+	}
+	a := agg.IORateMBs + agg.IOWRateMBs
+	if a < ioBPMinAggMBs || a < ioBPMinRatio*d {
+		return nil
+	}
+	aggCtl := env.control(agg.Path)
+
+	plan := &model.IOBackpressurePlan{
+		DB:           v.comm,
+		DBCgroup:     dbPath,
+		DBMBs:        d,
+		DBPSISome:    dbCtl.PSI.Some.Avg10,
+		DBDState:     v.dstate,
+		Aggressor:    agg.Path,
+		AggressorMBs: a,
+		DBShare:      d / (a + d),
+	}
+
+	// The contended device: the DB's busiest device that the aggressor also
+	// uses, else the aggressor's busiest.
+	dev := ioBPSharedDevice(dbCtl.Devices, aggCtl.Devices)
+	if dev.MajMin == "" {
+		return nil
+	}
+	plan.Device, plan.MajMin = dev.Name, dev.MajMin
+	plan.Scheduler = env.scheduler(dev.MajMin)
+	switch enabled, supported := env.ioCost(dev.MajMin); {
+	case enabled:
+		plan.IOCost = "enabled"
+	case supported:
+		plan.IOCost = "disabled"
+	default:
+		plan.IOCost = "unsupported"
+	}
+
+	// io.weight only arbitrates between siblings: apply it to the children
+	// of the lowest common ancestor on each side.
+	plan.DBWeightCgroup, plan.AggWeightCgroup = siblingPair(dbPath, agg.Path)
+	plan.DBWeightNow = weightOf(plan.DBWeightCgroup, dbPath, dbCtl, agg.Path, aggCtl, env)
+	plan.AggWeightNow = weightOf(plan.AggWeightCgroup, dbPath, dbCtl, agg.Path, aggCtl, env)
+
+	ratio := ioBPMaxRatio
+	if d > 0 {
+		ratio = math.Max(ioBPMinRatio, math.Min(ioBPMaxRatio, a/d))
+	}
+	maxW := ioBPMaxWeight
+	if plan.IOCost != "enabled" && plan.Scheduler == "bfq" {
+		maxW = ioBPMaxBFQWeight
+	}
+	plan.DBWeight = clampInt(int(math.Round(float64(plan.AggWeightNow)*ratio)), 1, maxW)
+	plan.AggWeight = clampInt(int(math.Round(float64(plan.DBWeightNow)/ratio)), 1, maxW)
+	plan.TargetShare = ratio / (ratio + 1)
+
+	// Hard cap alternative: hold the aggressor to the share it would get
+	// at the target split of today's combined throughput, keeping its own
+	// read/write mix.
+	capMBs := (a + d) / (ratio + 1)
+	if agg.IORateMBs >= 1 {
+		plan.AggRBps = mbToBps(capMBs * agg.IORateMBs / a)
+	}
+	if agg.IOWRateMBs >= 1 {
+		plan.AggWBps = mbToBps(capMBs * agg.IOWRateMBs / a)
+	}
+
+	plan.Actions = ioBackpressureActions(plan)
+	return plan
+}
+
+// ioBPAggressor picks the cgroup moving the most IO that is neither the DB
+// cgroup, its ancestor nor its descendant. Parent slices aggregate their
+// children, so it then descends while a child accounts for ≥90% of it.
+func ioBPAggressor(dbPath string, cgs []model.CgroupRate) *model.CgroupRate {
+	var top *model.CgroupRate
+	for i := range cgs {
+		cg := &cgs[i]
+		if cg.Path == "/" || cg.Path == "" || isCgroupAncestor(cg.Path, dbPath) || isCgroupAncestor(dbPath, cg.Path) || cg.Path == dbPath {
+			continue
+		}
+		if top == nil || cg.IORateMBs+cg.IOWRateMBs > top.IORateMBs+top.IOWRateMBs {
+			top = cg
+		}
+	}
+	for top != nil {
+		total := top.IORateMBs + top.IOWRateMBs
+		var next *model.CgroupRate
+		for i := range cgs {
+			cg := &cgs[i]
+			if isCgroupAncestor(top.Path, cg.Path) && cg.IORateMBs+cg.IOWRateMBs >= 0.9*total &&
+				(next == nil || len(cg.Path) > len(next.Path)) {
+				next = cg
+			}
+		}
+		if next == nil {
+			break
+		}
+		top = next
+	}
+	return top
+}
+
+// isCgroupAncestor reports whether anc is a strict ancestor of p.
+func isCgroupAncestor(anc, p string) bool {
+	if anc == "/" {
+		return p != "/"
+	}
+	return strings.HasPrefix(p, anc+"/")
+}
+
+// siblingPair returns the children of a and b's lowest common ancestor that
+// contain a and b respectively.
+func siblingPair(a, b string) (string, string) {
+	pa := strings.Split(strings.Trim(a, "/"), "/")
+	pb := strings.Split(strings.Trim(b, "/"), "/")
+	i := 0
+	for i < len(pa)-1 && i < len(pb)-1 && pa[i] == pb[i] {
+		i++
+	}
+	return "/" + strings.Join(pa[:i+1], "/"), "/" + strings.Join(pb[:i+1], "/")
+}
+
+func weightOf(p, dbPath string, dbCtl cgcollector.IOControl, aggPath string, aggCtl cgcollector.IOControl, env ioBPEnv) int {
+	switch p {
+	case dbPath:
+		return dbCtl.Weight
+	case aggPath:
+		return aggCtl.Weight
+	}
+	return env.control(p).Weight
+}
+
+func ioBPSharedDevice(db, agg []cgcollector.IODeviceStat) cgcollector.IODeviceStat {
+	for _, d := range db {
+		for _, a := range agg {
+			if a.MajMin == d.MajMin && a.RBytes+a.WBytes > 0 {
+				return d
+			}
+		}
+	}
+	if len(agg) > 0 {
+		return agg[0]
+	}
+	return cgcollector.IODeviceStat{}
+}
+
+func clampInt(v, lo, hi int) int {
+	return max(lo, min(hi, v))
+}
+
+// mbToBps rounds MB/s to whole MiB/s in bytes, at least 1 MiB/s.
+func mbToBps(mbs float64) uint64 {
+	return uint64(math.Max(1, math.Round(mbs))) << 20
+}
+
+// systemdUnit returns the unit name for a systemd-managed cgroup, or "".
+func systemdUnit(cg string) string {
+	leaf := path.Base(cg)
+	for _, suf := range []string{".service", ".scope", ".slice"} {
+		if strings.HasSuffix(leaf, suf) {
+			return leaf
+		}
+	}
+	return ""
+}
+
+func ioBackpressureActions(p *model.IOBackpressurePlan) []model.Action {
+	var out []model.Action
+	var signs []string
+	if p.DBDState > 0 {
+		signs = append(signs, fmt.Sprintf("%d in D-state", p.DBDState))
+	}
+	if p.DBPSISome > 0 {
+		signs = append(signs, fmt.Sprintf("cgroup IO PSI %.0f%%", p.DBPSISome))
+	}
+	starving := strings.Join(signs, ", ")
+	out = append(out, advice.Action("rca.io.backpressure",
+		p.DB, cleanCgroupName(p.DBCgroup), p.DBShare*100, p.Device, p.DBMBs, p.AggressorMBs,
+		cleanCgroupName(p.Aggressor), starving))
+
+	// io.weight is enforced by io.cost, or by BFQ through io.bfq.weight.
+	weightFile := "io.weight"
+	switch {
+	case p.IOCost == "enabled":
+	case p.Scheduler == "bfq":
+		weightFile = "io.bfq.weight"
+	case p.IOCost == "disabled":
+		out = append(out, advice.CommandAction(
+			fmt.Sprintf(`echo "%s enable=1 ctrl=auto" > /sys/fs/cgroup/io.cost.qos`, p.MajMin),
+			"rca.io.backpressure.enable_iocost", p.Device, p.MajMin))
+	default:
+		out = append(out, advice.CommandAction(
+			fmt.Sprintf("echo bfq > /sys/dev/block/%s/queue/scheduler", p.MajMin),
+			"rca.io.backpressure.no_iocost", p.Device, orUnknown(p.Scheduler)))
+		weightFile = "io.bfq.weight"
+	}
+
+	if p.DBWeight > p.DBWeightNow {
+		out = append(out, advice.CommandAction(ioWeightCommand(p.DBWeightCgroup, weightFile, p.DBWeight),
+			"rca.io.backpressure.raise_weight",
+			weightFile, path.Base(p.DBWeightCgroup), p.DBWeightNow, p.DBWeight, p.DB,
+			p.TargetShare*100, p.Device, path.Base(p.AggWeightCgroup)))
+	}
+	if p.AggWeight < p.AggWeightNow {
+		out = append(out, advice.CommandAction(ioWeightCommand(p.AggWeightCgroup, weightFile, p.AggWeight),
+			"rca.io.backpressure.lower_weight",
+			weightFile, path.Base(p.AggWeightCgroup), p.AggWeightNow, p.AggWeight))
+	}
+
+	if p.AggRBps > 0 || p.AggWBps > 0 {
+		var limits []string
+		if p.AggRBps > 0 {
+			limits = append(limits, fmt.Sprintf("read %dM", p.AggRBps>>20))
+		}
+		if p.AggWBps > 0 {
+			limits = append(limits, fmt.Sprintf("write %dM", p.AggWBps>>20))
+		}
+		out = append(out, advice.CommandAction(ioMaxCommand(p),
+			"rca.io.backpressure.cap",
+			cleanCgroupName(p.Aggressor), strings.Join(limits, ", "), p.Device))
+	}
+	return out
+}
+
+func ioWeightCommand(cg, file string, w int) string {
+	if unit := systemdUnit(cg); unit != "" {
+		// systemd writes io.bfq.weight from IOWeight when BFQ is active.
+		return fmt.Sprintf("systemctl set-property %s IOWeight=%d", unit, w)
+	}
+	val := fmt.Sprintf("default %d", w)
+	if file == "io.bfq.weight" {
+		val = fmt.Sprint(w)
+	}
+	return fmt.Sprintf(`echo "%s" > /sys/fs/cgroup%s/%s`, val, cg, file)
+}
+
+func ioMaxCommand(p *model.IOBackpressurePlan) string {
+	if unit := systemdUnit(p.Aggressor); unit != "" {
+		var props []string
+		if p.AggRBps > 0 {
+			props = append(props, fmt.Sprintf(`IOReadBandwidthMax="/dev/block/%s %dM"`, p.MajMin, p.AggRBps>>20))
+		}
+		if p.AggWBps > 0 {
+			props = append(props, fmt.Sprintf(`IOWriteBandwidthMax="/dev/block/%s %dM"`, p.MajMin, p.AggWBps>>20))
+		}
+		return fmt.Sprintf("systemctl set-property %s %s", unit, strings.Join(props, " "))
+	}
+	line := p.MajMin
+	if p.AggRBps > 0 {
+		line += fmt.Sprintf(" rbps=%d", p.AggRBps)
+	}
+	if p.AggWBps > 0 {
+		line += fmt.Sprintf(" wbps=%d", p.AggWBps)
+	}
+	return fmt.Sprintf(`echo "%s" > /sys/fs/cgroup%s/io.max`, line, p.Aggressor)
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "an unknown scheduler"
+	}
+	return s
+}
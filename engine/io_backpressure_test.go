@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	cgcollector "github.com/ftahirops/xtop/collector/cgroup"
+	"github.com/ftahirops/xtop/model"
+)
+
+func fakeIOBPEnv(psi map[string]float64, weights map[string]int, cost, supported bool, sched string) ioBPEnv {
+	sdb := []cgcollector.IODeviceStat{{MajMin: "8:16", Name: "sdb", WBytes: 1 << 30}}
+	return ioBPEnv{
+		control: func(p string) cgcollector.IOControl {
+			c := cgcollector.IOControl{Weight: 100, Devices: sdb}
+			if w, ok := weights[p]; ok {
+				c.Weight = w
+			}
+			c.PSI.Some.Avg10 = psi[p]
+			return c
+		},
+		ioCost:    func(string) (bool, bool) { return cost, supported },
+		scheduler: func(string) string { return sched },
+	}
+}
+
+func TestIOBackpressurePlan(t *testing.T) {
+	rates := &model.RateSnapshot{
+		ProcessRates: []model.ProcessRate{
+			{PID: 10, Comm: "mysqld", State: "D", CgroupPath: "/system.slice/mysql.service"},
+			{PID: 11, Comm: "mysqld", State: "S", CgroupPath: "/system.slice/mysql.service"},
+			{PID: 20, Comm: "restic", State: "R", CgroupPath: "/system.slice/backup.service"},
+		},
+		CgroupRates: []model.CgroupRate{
+			{Path: "/system.slice", IORateMBs: 60, IOWRateMBs: 37},
+			{Path: "/system.slice/mysql.service", IORateMBs: 2, IOWRateMBs: 3},
+			{Path: "/system.slice/backup.service", IORateMBs: 58, IOWRateMBs: 32},
+		},
+	}
+	env := fakeIOBPEnv(map[string]float64{"/system.slice/mysql.service": 42}, nil, false, true, "mq-deadline")
+	p := buildIOBackpressure(rates, env)
+	if p == nil {
+		t.Fatal("expected a plan")
+	}
+	if p.DB != "mysqld" || p.Aggressor != "/system.slice/backup.service" || p.DBDState != 1 || p.MajMin != "8:16" {
+		t.Errorf("plan = %+v", p)
+	}
+	// 90 vs 5 MB/s → ratio 18, capped at 10.
+	if p.DBWeight != 1000 || p.AggWeight != 10 || p.TargetShare < 0.90 || p.TargetShare > 0.91 {
+		t.Errorf("weights = %d/%d share %.3f", p.DBWeight, p.AggWeight, p.TargetShare)
+	}
+	// Cap: 95 MB/s / 11 = 8.6 MB/s split 58:32 → 6M read, 3M write.
+	if p.AggRBps != 6<<20 || p.AggWBps != 3<<20 {
+		t.Errorf("io.max = r%d w%d", p.AggRBps, p.AggWBps)
+	}
+
+	var cmds []string
+	for _, a := range p.Actions {
+		cmds = append(cmds, a.Command)
+	}
+	all := strings.Join(cmds, "\n")
+	for _, want := range []string{
+		`echo "8:16 enable=1 ctrl=auto" > /sys/fs/cgroup/io.cost.qos`,
+		"systemctl set-property mysql.service IOWeight=1000",
+		"systemctl set-property backup.service IOWeight=10",
+		`IOReadBandwidthMax="/dev/block/8:16 6M" IOWriteBandwidthMax="/dev/block/8:16 3M"`,
+	} {
+		if !strings.Contains(all, want) {
+			t.Errorf("missing %q in:\n%s", want, all)
+		}
+	}
+}
+
+func TestIOBackpressureSiblingsAcrossSlices(t *testing.T) {
+	rates := &model.RateSnapshot{
+		ProcessRates: []model.ProcessRate{
+			{PID: 10, Comm: "postgres", State: "S", CgroupPath: "/system.slice/postgresql.service"},
+		},
+		CgroupRates: []model.CgroupRate{
+			{Path: "/system.slice/postgresql.service", IORateMBs: 10},
+			{Path: "/user.slice", IOWRateMBs: 40},
+			{Path: "/user.slice/user-1000.slice", IOWRateMBs: 40},
+			{Path: "/user.slice/user-1000.slice/session-3.scope", IOWRateMBs: 39},
+		},
+	}
+	env := fakeIOBPEnv(map[string]float64{"/system.slice/postgresql.service": 25},
+		map[string]int{"/system.slice": 100, "/user.slice": 100}, true, true, "none")
+	p := buildIOBackpressure(rates, env)
+	if p == nil {
+		t.Fatal("expected a plan")
+	}
+	if p.Aggressor != "/user.slice/user-1000.slice/session-3.scope" {
+		t.Errorf("aggressor = %s", p.Aggressor)
+	}
+	// 39 vs 10 MB/s → ratio 3.9 against user.slice's weight of 100.
+	if p.DBWeightCgroup != "/system.slice" || p.AggWeightCgroup != "/user.slice" {
+		t.Errorf("sibling pair = %s / %s", p.DBWeightCgroup, p.AggWeightCgroup)
+	}
+	if p.DBWeight != 390 || p.AggRBps != 0 || p.AggWBps != 10<<20 {
+		t.Errorf("plan = %+v", p)
+	}
+	for _, a := range p.Actions {
+		if strings.Contains(a.Command, "io.cost.qos") {
+			t.Error("io.cost already enabled; no enable step expected")
+		}
+	}
+
+	// No starvation signal → no plan.
+	quiet := fakeIOBPEnv(nil, nil, true, true, "none")
+	if buildIOBackpressure(rates, quiet) != nil {
+		t.Error("a DB without D-state or IO pressure should not get a plan")
+	}
+}
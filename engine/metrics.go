@@ -78,6 +78,12 @@ func (t *instrumentedTicker) Base() *Engine {
 	return t.inner.Base()
 }
 
+// Unwrap returns the wrapped ticker, so the TUI can still find a replay
+// Player behind the instrumentation.
+func (t *instrumentedTicker) Unwrap() Ticker {
+	return t.inner
+}
+
 func writePrometheus(w io.Writer, snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult) {
 	write := func(format string, args ...interface{}) {
 		_, _ = fmt.Fprintf(w, format, args...)
@@ -95,6 +101,10 @@ func writePrometheus(w io.Writer, snap *model.Snapshot, rates *model.RateSnapsho
 		} else {
 			write("xtop_rca_primary_score{bottleneck=\"\"} 0\n")
 		}
+		write("# TYPE xtop_capacity_headroom_pct gauge\n")
+		for _, c := range result.Capacities {
+			write("xtop_capacity_headroom_pct{resource=%q} %f\n", c.Label, c.Pct)
+		}
 	}
 
 	psi := snap.Global.PSI
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// NotesFileName is the JSONL file (inside the data dir) that operator
+// notes are appended to, next to events.jsonl.
+const NotesFileName = "notes.jsonl"
+
+var noteLogMu sync.Mutex
+
+// noteLogLine is one notes.jsonl record. Incident is set for a note taken
+// while an incident was open: that note also lives on the incident's
+// timeline once the incident is logged, so it must not come back as a
+// standalone note as well.
+type noteLogLine struct {
+	model.TimelineEntry
+	Incident string `json:"incident,omitempty"`
+}
+
+// AppendNoteLog appends an operator note to a JSONL file so it survives
+// restarts and can be picked up by handoff/postmortem tooling. incidentID
+// is the incident the note was added to, or "" for a standalone note.
+func AppendNoteLog(path string, note model.TimelineEntry, incidentID string) error {
+	noteLogMu.Lock()
+	defer noteLogMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(noteLogLine{TimelineEntry: note, Incident: incidentID})
+}
+
+// ReadNoteLog reads all operator notes from a JSONL file, incident notes
+// included. A missing file is not an error.
+func ReadNoteLog(path string) ([]model.TimelineEntry, error) {
+	return readNoteLog(path, nil)
+}
+
+// ReadStandaloneNotes reads the notes to restore as standalone: those taken
+// outside an incident, and those whose incident is not among incidents
+// (xtop exited before it closed, so it was never logged). The rest are
+// restored with their incident's timeline.
+func ReadStandaloneNotes(path string, incidents []model.Event) ([]model.TimelineEntry, error) {
+	logged := make(map[string]bool, len(incidents))
+	for _, ev := range incidents {
+		logged[ev.ID] = true
+	}
+	return readNoteLog(path, logged)
+}
+
+// readNoteLog reads notes.jsonl, skipping notes whose incident is in skip.
+func readNoteLog(path string, skip map[string]bool) ([]model.TimelineEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var notes []model.TimelineEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024)
+	for scanner.Scan() {
+		var n noteLogLine
+		if err := json.Unmarshal(scanner.Bytes(), &n); err != nil {
+			continue // skip malformed lines
+		}
+		if n.Incident != "" && skip[n.Incident] {
+			continue
+		}
+		n.Kind = model.TimelineKindNote
+		notes = append(notes, n.TimelineEntry)
+	}
+	return notes, scanner.Err()
+}
@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func TestAddNoteWithoutIncidentIsStandalone(t *testing.T) {
+	d := NewEventDetector()
+	d.AddNote(time.Now(), "restarted php-fpm")
+
+	notes := d.Notes()
+	if len(notes) != 1 || notes[0].Message != "restarted php-fpm" || !notes[0].IsNote() {
+		t.Fatalf("expected one standalone note, got %+v", notes)
+	}
+}
+
+func TestAddNoteDuringIncidentJoinsTimeline(t *testing.T) {
+	d := NewEventDetector()
+	base := time.Now()
+	result := &model.AnalysisResult{Health: model.HealthCritical, PrimaryBottleneck: "IO Starvation", PrimaryScore: 80}
+	for i := 0; i < 3; i++ {
+		d.Process(&model.Snapshot{Timestamp: base.Add(time.Duration(i) * time.Second)}, nil, result)
+	}
+	if d.ActiveEvent() == nil {
+		t.Fatal("expected an active incident after debounce")
+	}
+
+	d.AddNote(base.Add(4*time.Second), "failing over DB")
+	if len(d.Notes()) != 0 {
+		t.Fatal("note taken during an incident should not be standalone")
+	}
+	found := false
+	for _, te := range d.ActiveEvent().Timeline {
+		if te.IsNote() && te.Message == "failing over DB" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("note missing from active incident timeline")
+	}
+}
+
+func TestCapTimelineKeepsNotes(t *testing.T) {
+	var tl []model.TimelineEntry
+	tl = append(tl, model.TimelineEntry{Message: "note", Kind: model.TimelineKindNote})
+	for i := 0; i < 25; i++ {
+		tl = append(tl, model.TimelineEntry{Message: fmt.Sprintf("m%d", i)})
+	}
+	tl = capTimeline(tl, 20)
+	if len(tl) != 20 {
+		t.Fatalf("len = %d, want 20", len(tl))
+	}
+	if !tl[0].IsNote() {
+		t.Fatal("operator note was evicted before machine milestones")
+	}
+}
+
+func TestNoteLogRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), NotesFileName)
+	if err := AppendNoteLog(path, model.TimelineEntry{Time: time.Now(), Message: "a", Kind: model.TimelineKindNote}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendNoteLog(path, model.TimelineEntry{Time: time.Now(), Message: "b", Kind: model.TimelineKindNote}, "evt-1"); err != nil {
+		t.Fatal(err)
+	}
+	// evt-2 was still open when xtop exited, so it never reached the event log.
+	if err := AppendNoteLog(path, model.TimelineEntry{Time: time.Now(), Message: "c", Kind: model.TimelineKindNote}, "evt-2"); err != nil {
+		t.Fatal(err)
+	}
+	notes, err := ReadNoteLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notes) != 3 || notes[1].Message != "b" {
+		t.Fatalf("unexpected notes: %+v", notes)
+	}
+	standalone, err := ReadStandaloneNotes(path, []model.Event{{ID: "evt-1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(standalone) != 2 || standalone[0].Message != "a" || standalone[1].Message != "c" {
+		t.Fatalf("want a and c (b lives on logged incident evt-1): %+v", standalone)
+	}
+}
+
+func TestLoadNotesCapped(t *testing.T) {
+	d := NewEventDetector()
+	notes := make([]model.TimelineEntry, maxStandaloneNotes+50)
+	for i := range notes {
+		notes[i] = model.TimelineEntry{Message: fmt.Sprint(i), Kind: model.TimelineKindNote}
+	}
+	d.LoadNotes(notes)
+	got := d.Notes()
+	if len(got) != maxStandaloneNotes || got[0].Message != "50" {
+		t.Fatalf("LoadNotes kept %d notes starting at %q, want the newest %d", len(got), got[0].Message, maxStandaloneNotes)
+	}
+}
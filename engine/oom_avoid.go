@@ -0,0 +1,377 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
+)
+
+// ─── Proactive OOM avoidance (earlyoom-style) ───────────────────────────────
+//
+// Opt-in. When MemAvailable and free swap are both under their floors,
+// pick a victim by policy and SIGTERM it before the kernel OOM killer has
+// to step in — by then the box has usually spent minutes thrashing the
+// page cache with every task stuck in reclaim. Under half of both floors
+// the victim gets SIGKILL instead. The check runs on its own one-second
+// loop rather than on the engine tick: a stalled tick is exactly the
+// situation this mode exists for.
+//
+// Every action is written to the guardian audit log and handed to the
+// next Tick as result.OOMAvoidActions; the daemon forwards those to the
+// configured alert channels.
+
+const (
+	oomAvoidPoll       = time.Second
+	oomAvoidSettle     = 10 * time.Second // a signalled victim gets this long to exit
+	oomAvoidMaxPending = 32
+)
+
+// Victim policies.
+const (
+	OOMPolicyLargestRSS = "largest_rss"
+	OOMPolicyOOMScore   = "oom_score"
+)
+
+// oomAvoidDeny is never chosen, whatever the policy: killing these takes
+// the box (or the operator's way into it) down with the memory hog.
+var oomAvoidDeny = []string{
+	"init", "systemd", "systemd-journal", "systemd-logind", "systemd-udevd",
+	"dbus-daemon", "sshd", "xtop",
+}
+
+// OOMAvoidPolicy is the config-file view of the OOM-avoidance mode
+// ("oom_avoid" in config.json). Zero floors keep the defaults (10% each);
+// XTOP_OOM_AVOID* environment variables win over the file.
+type OOMAvoidPolicy struct {
+	Enabled      bool
+	MemFloorPct  float64  // MemAvailable as % of MemTotal
+	SwapFloorPct float64  // SwapFree as % of SwapTotal; no swap counts as crossed
+	Policy       string   // OOMPolicyLargestRSS (default) or OOMPolicyOOMScore
+	Denylist     []string // process names never chosen, on top of the built-in list
+	DryRun       bool     // audit and notify, but send no signal
+}
+
+// oomCandidate is one process as the victim picker sees it.
+type oomCandidate struct {
+	PID         int
+	Comm        string
+	RSS         uint64
+	OOMScore    int
+	OOMScoreAdj int
+	StartTime   string // /proc/PID/stat starttime, to tell a reused PID apart
+}
+
+type oomAvoider struct {
+	mu        sync.Mutex
+	policy    OOMAvoidPolicy
+	deny      map[string]bool
+	running   bool
+	quit      <-chan struct{}
+	auditPath string
+	pending   []model.OOMAvoidAction
+
+	// last victim, for the settle window and SIGTERM → SIGKILL escalation
+	lastVictim *oomCandidate
+	lastSignal syscall.Signal
+	lastAt     time.Time
+
+	readMem   func() (memAvailPct, swapFreePct float64, ok bool)
+	listProcs func() []oomCandidate
+	signal    func(pid int, sig syscall.Signal) error
+	startTime func(pid int) string // "" once the PID is gone
+	now       func() time.Time
+}
+
+func newOOMAvoider(quit <-chan struct{}) *oomAvoider {
+	a := &oomAvoider{
+		quit:      quit,
+		auditPath: guardianAuditPath(),
+		readMem:   readOOMAvoidMem,
+		listProcs: listOOMCandidates,
+		signal:    syscall.Kill,
+		startTime: ReadProcStartTime,
+		now:       time.Now,
+	}
+	a.SetPolicy(OOMAvoidPolicy{})
+	return a
+}
+
+// SetPolicy re-derives the floors from the defaults, p and the
+// environment, and starts the poll loop the first time the mode is on.
+func (a *oomAvoider) SetPolicy(p OOMAvoidPolicy) {
+	if p.MemFloorPct <= 0 {
+		p.MemFloorPct = 10
+	}
+	if p.SwapFloorPct <= 0 {
+		p.SwapFloorPct = 10
+	}
+	switch v := os.Getenv("XTOP_OOM_AVOID"); v {
+	case "1", "on", "true":
+		p.Enabled = true
+	case "0", "off", "false":
+		p.Enabled = false
+	}
+	overlayEnvFloat("XTOP_OOM_AVOID_MEM_PCT", &p.MemFloorPct)
+	overlayEnvFloat("XTOP_OOM_AVOID_SWAP_PCT", &p.SwapFloorPct)
+	if v := os.Getenv("XTOP_OOM_AVOID_POLICY"); v != "" {
+		p.Policy = v
+	}
+	if os.Getenv("XTOP_OOM_AVOID_DRY_RUN") == "1" {
+		p.DryRun = true
+	}
+	if p.Policy != OOMPolicyOOMScore {
+		p.Policy = OOMPolicyLargestRSS
+	}
+	deny := make(map[string]bool, len(oomAvoidDeny)+len(p.Denylist))
+	for _, c := range oomAvoidDeny {
+		deny[c] = true
+	}
+	for _, c := range p.Denylist {
+		deny[c] = true
+	}
+	p.Denylist = slices.Clone(p.Denylist)
+
+	a.mu.Lock()
+	a.policy, a.deny = p, deny
+	start := p.Enabled && !a.running && a.quit != nil
+	if start {
+		a.running = true
+	}
+	a.mu.Unlock()
+	if start {
+		log.Printf("oom-avoid: on (MemAvailable < %.0f%% and swap free < %.0f%%, policy %s%s)",
+			p.MemFloorPct, p.SwapFloorPct, p.Policy, dryRunNote(p.DryRun))
+		go a.loop()
+	}
+}
+
+func (a *oomAvoider) loop() {
+	t := time.NewTicker(oomAvoidPoll)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			a.check()
+		case <-a.quit:
+			return
+		}
+	}
+}
+
+// check runs one poll: below both floors, signal a victim.
+func (a *oomAvoider) check() {
+	a.mu.Lock()
+	p, deny := a.policy, a.deny
+	a.mu.Unlock()
+	if !p.Enabled {
+		return
+	}
+	mem, swap, ok := a.readMem()
+	if !ok || mem >= p.MemFloorPct || swap >= p.SwapFloorPct {
+		return
+	}
+	sig := syscall.SIGTERM
+	if mem < p.MemFloorPct/2 && swap < p.SwapFloorPct/2 {
+		sig = syscall.SIGKILL
+	}
+
+	var victim *oomCandidate
+	now := a.now()
+	if v := a.lastVictim; v != nil && now.Sub(a.lastAt) < oomAvoidSettle && a.sameProcess(v) {
+		// Still exiting. Only escalate a SIGTERM that did not help.
+		if sig != syscall.SIGKILL || a.lastSignal == syscall.SIGKILL {
+			return
+		}
+		victim = v
+	} else {
+		victim = pickOOMVictim(a.listProcs(), p.Policy, deny, os.Getpid())
+	}
+	if victim == nil {
+		return
+	}
+
+	act := model.OOMAvoidAction{
+		Time:        now,
+		PID:         victim.PID,
+		Comm:        victim.Comm,
+		RSS:         victim.RSS,
+		OOMScore:    victim.OOMScore,
+		Signal:      oomSignalName(sig),
+		Policy:      p.Policy,
+		MemAvailPct: mem,
+		SwapFreePct: swap,
+		DryRun:      p.DryRun,
+	}
+	if !p.DryRun {
+		if err := a.signal(victim.PID, sig); err != nil {
+			act.Error = err.Error()
+		}
+	}
+	a.lastVictim, a.lastSignal, a.lastAt = victim, sig, now
+
+	msg := "oom-avoid: " + oomAvoidText(act)
+	if act.Error != "" {
+		msg += " · failed: " + act.Error
+	}
+	guardianAudit(a.auditPath, msg)
+	log.Print(msg)
+
+	a.mu.Lock()
+	a.pending = append(a.pending, act)
+	if len(a.pending) > oomAvoidMaxPending {
+		a.pending = a.pending[len(a.pending)-oomAvoidMaxPending:]
+	}
+	a.mu.Unlock()
+}
+
+// sameProcess reports whether v still runs under its PID: a victim that
+// exited and had its PID reused must not inherit the SIGKILL.
+func (a *oomAvoider) sameProcess(v *oomCandidate) bool {
+	st := a.startTime(v.PID)
+	return st != "" && st == v.StartTime
+}
+
+// Attach moves actions taken since the last tick into result, with a
+// narrative line each so the TUI and exports say what xtop killed.
+func (a *oomAvoider) Attach(result *model.AnalysisResult) {
+	if a == nil || result == nil {
+		return
+	}
+	a.mu.Lock()
+	acts := a.pending
+	a.pending = nil
+	a.mu.Unlock()
+	if len(acts) == 0 {
+		return
+	}
+	result.OOMAvoidActions = acts
+	if result.Narrative != nil {
+		lines := make([]string, 0, len(acts))
+		for _, act := range acts {
+			lines = append(lines, "OOM avoidance: "+oomAvoidText(act))
+		}
+		result.Narrative.Evidence = append(lines, result.Narrative.Evidence...)
+	}
+}
+
+// pickOOMVictim applies policy to procs. PID 1, xtop itself, kernel
+// threads, denylisted names and processes with oom_score_adj -1000 (the
+// operator's "never kill") are skipped.
+func pickOOMVictim(procs []oomCandidate, policy string, deny map[string]bool, self int) *oomCandidate {
+	var best *oomCandidate
+	for i := range procs {
+		c := &procs[i]
+		if c.PID <= 1 || c.PID == self || c.RSS == 0 || c.OOMScoreAdj == -1000 || deny[c.Comm] {
+			continue
+		}
+		if best == nil {
+			best = c
+			continue
+		}
+		if policy == OOMPolicyOOMScore && c.OOMScore != best.OOMScore {
+			if c.OOMScore > best.OOMScore {
+				best = c
+			}
+			continue
+		}
+		if c.RSS > best.RSS {
+			best = c
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	v := *best
+	return &v
+}
+
+// readOOMAvoidMem returns MemAvailable and SwapFree as percentages. No
+// swap reports 0% free so the swap floor never holds the mode back.
+func readOOMAvoidMem() (memAvailPct, swapFreePct float64, ok bool) {
+	kv, err := util.ParseKeyValueFile("/proc/meminfo")
+	if err != nil {
+		return 0, 0, false
+	}
+	total := util.ParseUint64(strings.TrimSuffix(kv["MemTotal"], " kB"))
+	avail := util.ParseUint64(strings.TrimSuffix(kv["MemAvailable"], " kB"))
+	if total == 0 {
+		return 0, 0, false
+	}
+	memAvailPct = float64(avail) / float64(total) * 100
+	if st := util.ParseUint64(strings.TrimSuffix(kv["SwapTotal"], " kB")); st > 0 {
+		swapFreePct = float64(util.ParseUint64(strings.TrimSuffix(kv["SwapFree"], " kB"))) / float64(st) * 100
+	}
+	return memAvailPct, swapFreePct, true
+}
+
+// listOOMCandidates walks /proc for RSS, oom_score and oom_score_adj.
+// Only runs once the floors are crossed.
+func listOOMCandidates() []oomCandidate {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+	page := uint64(os.Getpagesize())
+	var out []oomCandidate
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		dir := filepath.Join("/proc", e.Name())
+		statm, err := util.ReadFileString(filepath.Join(dir, "statm"))
+		if err != nil {
+			continue
+		}
+		f := strings.Fields(statm)
+		if len(f) < 2 {
+			continue
+		}
+		c := oomCandidate{PID: pid, RSS: util.ParseUint64(f[1]) * page}
+		if c.RSS == 0 {
+			continue
+		}
+		comm, _ := util.ReadFileString(filepath.Join(dir, "comm"))
+		c.Comm = strings.TrimSpace(comm)
+		score, _ := util.ReadFileString(filepath.Join(dir, "oom_score"))
+		c.OOMScore = util.ParseInt(strings.TrimSpace(score))
+		adj, _ := util.ReadFileString(filepath.Join(dir, "oom_score_adj"))
+		c.OOMScoreAdj = util.ParseInt(strings.TrimSpace(adj))
+		c.StartTime = ReadProcStartTime(pid)
+		out = append(out, c)
+	}
+	return out
+}
+
+func oomAvoidText(a model.OOMAvoidAction) string {
+	verb := "sent " + a.Signal + " to"
+	if a.DryRun {
+		verb = "would send " + a.Signal + " to"
+	}
+	return fmt.Sprintf("%s %s (PID %d, RSS %s, by %s) — MemAvailable %.1f%%, swap free %.1f%%",
+		verb, a.Comm, a.PID, useFmtBytes(a.RSS), a.Policy, a.MemAvailPct, a.SwapFreePct)
+}
+
+func oomSignalName(sig syscall.Signal) string {
+	if sig == syscall.SIGKILL {
+		return "SIGKILL"
+	}
+	return "SIGTERM"
+}
+
+func dryRunNote(dry bool) string {
+	if dry {
+		return ", dry run"
+	}
+	return ""
+}
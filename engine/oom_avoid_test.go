@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func oomProcs() []oomCandidate {
+	return []oomCandidate{
+		{PID: 1, Comm: "systemd", RSS: 9 << 30},
+		{PID: 300, Comm: "sshd", RSS: 8 << 30},
+		{PID: 400, Comm: "java", RSS: 6 << 30, OOMScore: 400, StartTime: "4000"},
+		{PID: 500, Comm: "chrome", RSS: 2 << 30, OOMScore: 900, OOMScoreAdj: 300},
+		{PID: 600, Comm: "postgres", RSS: 7 << 30, OOMScore: 450, OOMScoreAdj: -1000},
+		{PID: 700, Comm: "kworker/0:1"},
+	}
+}
+
+func TestPickOOMVictim(t *testing.T) {
+	deny := map[string]bool{"sshd": true}
+	if v := pickOOMVictim(oomProcs(), OOMPolicyLargestRSS, deny, 0); v == nil || v.PID != 400 {
+		t.Errorf("largest_rss: got %+v, want java", v)
+	}
+	if v := pickOOMVictim(oomProcs(), OOMPolicyOOMScore, deny, 0); v == nil || v.PID != 500 {
+		t.Errorf("oom_score: got %+v, want chrome", v)
+	}
+	deny["java"], deny["chrome"] = true, true
+	if v := pickOOMVictim(oomProcs(), OOMPolicyLargestRSS, deny, 0); v != nil {
+		t.Errorf("everything denied or protected, got %+v", v)
+	}
+}
+
+func TestOOMAvoiderCheck(t *testing.T) {
+	t.Setenv("XTOP_OOM_AVOID", "")
+	now := time.Unix(1_700_000_000, 0)
+	mem, swap := 50.0, 50.0
+	var sent []syscall.Signal
+	alive := true
+	a := &oomAvoider{
+		auditPath: filepath.Join(t.TempDir(), "guardian.log"),
+		readMem:   func() (float64, float64, bool) { return mem, swap, true },
+		listProcs: oomProcs,
+		signal: func(pid int, sig syscall.Signal) error {
+			if pid != 400 {
+				t.Errorf("signalled PID %d", pid)
+			}
+			sent = append(sent, sig)
+			return nil
+		},
+		startTime: func(int) string {
+			if alive {
+				return "4000"
+			}
+			return ""
+		},
+		now: func() time.Time { return now },
+	}
+	a.SetPolicy(OOMAvoidPolicy{Enabled: true, Denylist: []string{"chrome"}})
+
+	a.check()
+	if len(sent) != 0 {
+		t.Fatal("signalled above the floors")
+	}
+
+	// Memory low but swap still free: wait.
+	mem = 5
+	a.check()
+	if len(sent) != 0 {
+		t.Fatal("signalled with swap to spare")
+	}
+
+	// Both under the floors: SIGTERM the largest RSS.
+	swap = 8
+	a.check()
+	if len(sent) != 1 || sent[0] != syscall.SIGTERM {
+		t.Fatalf("signals = %v, want SIGTERM", sent)
+	}
+
+	// Victim still exiting: leave it alone until it is below half the floors.
+	now = now.Add(2 * time.Second)
+	a.check()
+	mem, swap = 3, 2
+	a.check()
+	if len(sent) != 2 || sent[1] != syscall.SIGKILL {
+		t.Fatalf("signals = %v, want escalation to SIGKILL", sent)
+	}
+
+	r := &model.AnalysisResult{Narrative: &model.Narrative{}}
+	a.Attach(r)
+	if len(r.OOMAvoidActions) != 2 || r.OOMAvoidActions[1].Signal != "SIGKILL" {
+		t.Fatalf("actions = %+v", r.OOMAvoidActions)
+	}
+	if ev := r.Narrative.Evidence[0]; !strings.Contains(ev, "sent SIGTERM to java (PID 400") {
+		t.Errorf("evidence = %q", ev)
+	}
+	audit, _ := os.ReadFile(a.auditPath)
+	if strings.Count(string(audit), "oom-avoid: sent") != 2 {
+		t.Errorf("audit log = %q", audit)
+	}
+
+	// Dry run records the choice but sends nothing.
+	a.SetPolicy(OOMAvoidPolicy{Enabled: true, DryRun: true, Denylist: []string{"chrome"}})
+	alive = false
+	a.check()
+	if len(sent) != 2 {
+		t.Errorf("dry run sent a signal: %v", sent)
+	}
+	a.Attach(r)
+	if len(r.OOMAvoidActions) != 1 || !r.OOMAvoidActions[0].DryRun {
+		t.Errorf("dry-run actions = %+v", r.OOMAvoidActions)
+	}
+}
+
+func TestOOMAvoiderPIDReuse(t *testing.T) {
+	t.Setenv("XTOP_OOM_AVOID", "")
+	now := time.Unix(1_700_000_000, 0)
+	mem, swap := 5.0, 8.0
+	start := "4000"
+	procs := oomProcs()
+	var sent []int
+	a := &oomAvoider{
+		auditPath: filepath.Join(t.TempDir(), "guardian.log"),
+		readMem:   func() (float64, float64, bool) { return mem, swap, true },
+		listProcs: func() []oomCandidate { return procs },
+		signal: func(pid int, sig syscall.Signal) error {
+			sent = append(sent, pid)
+			return nil
+		},
+		startTime: func(int) string { return start },
+		now:       func() time.Time { return now },
+	}
+	a.SetPolicy(OOMAvoidPolicy{Enabled: true, Denylist: []string{"chrome"}})
+	a.check()
+	if len(sent) != 1 {
+		t.Fatalf("signals = %v, want SIGTERM to java", sent)
+	}
+
+	// java exited and PID 400 now belongs to a newcomer that the next
+	// listing does not offer as a victim: no SIGKILL escalation to it.
+	start, procs = "9999", nil
+	mem, swap = 3, 2
+	now = now.Add(2 * time.Second)
+	a.check()
+	if len(sent) != 1 {
+		t.Fatalf("escalated to a reused PID: %v", sent)
+	}
+}
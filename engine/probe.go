@@ -160,6 +160,20 @@ type SockIOEntry struct {
 	RecvCount int
 }
 
+// NetWaitEntry is time a process spent blocked in epoll_wait/poll/connect/
+// recv on connections to one remote endpoint (netwait pack).
+type NetWaitEntry struct {
+	PID        int
+	Comm       string
+	Syscall    string  // "epoll_wait", "connect", ...
+	DstAddr    string  // "10.0.0.5:5432"
+	Service    string  // "postgres"
+	WaitPct    float64 // thread-time blocked / probe duration (100 = one thread the whole window)
+	Conns      int     // sockets to this endpoint seen waiting
+	MaxThreads int     // most threads blocked on it at once
+	Connecting bool    // blocked in the TCP handshake
+}
+
 // ProbeFindings holds the output of a probe session.
 type ProbeFindings struct {
 	StartTime     time.Time
@@ -183,6 +197,7 @@ type ProbeFindings struct {
 	SwapEvict      []SwapEvictEntry
 	SyscallDissect []SyscallDissectEntry
 	SockIO         []SockIOEntry
+	NetWait        []NetWaitEntry
 }
 
 // ─── ProbeManager ───────────────────────────────────────────────────────────
@@ -530,6 +545,21 @@ func convertResults(r *bpf.ProbeResults, start time.Time, duration time.Duration
 		})
 	}
 
+	// Convert network wait samples
+	for _, nw := range r.NetWait {
+		f.NetWait = append(f.NetWait, NetWaitEntry{
+			PID:        int(nw.PID),
+			Comm:       nw.Comm,
+			Syscall:    nw.Syscall,
+			DstAddr:    nw.DstStr,
+			Service:    bpf.WellKnownPort(nw.DstPort),
+			WaitPct:    float64(nw.WaitNs) / durationNs * 100,
+			Conns:      nw.Conns,
+			MaxThreads: nw.MaxThreads,
+			Connecting: nw.Connecting,
+		})
+	}
+
 	// Determine dominant bottleneck from findings
 	f.Bottleneck, f.ConfBoost = classifyBottleneck(f)
 
@@ -592,6 +622,15 @@ func classifyBottleneck(f *ProbeFindings) (string, int) {
 			netScore += e.AvgWaitMs / 10
 		}
 	}
+	// Blocked in connect() is a network problem outright; idle-waiting on
+	// an established dependency only counts once it dominates a thread.
+	for _, e := range f.NetWait {
+		if e.Connecting {
+			netScore += e.WaitPct / 5
+		} else if e.WaitPct > 50 {
+			netScore += e.WaitPct / 20
+		}
+	}
 
 	// Syscall dissect: boost lock or IO score based on dominant groups
 	for _, e := range f.SyscallDissect {
@@ -702,6 +741,14 @@ func generateSummary(f *ProbeFindings) string {
 		}
 		parts = append(parts, fmt.Sprintf("SockIO: %s->%s avg=%.0fms", top.Comm, svc, top.AvgWaitMs))
 	}
+	if len(f.NetWait) > 0 {
+		top := f.NetWait[0]
+		dst := top.DstAddr
+		if top.Service != "" {
+			dst = top.Service
+		}
+		parts = append(parts, fmt.Sprintf("NetWait: %s->%s %.0f%%", top.Comm, dst, top.WaitPct))
+	}
 
 	if len(parts) == 0 {
 		return "No significant findings"
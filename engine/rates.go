@@ -1,12 +1,11 @@
 package engine
 
 import (
-	"fmt"
-	"os"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/ftahirops/xtop/collector"
 	"github.com/ftahirops/xtop/model"
 	"github.com/ftahirops/xtop/util"
 )
@@ -281,14 +280,16 @@ func computeCgroupRates(prev, curr *model.Snapshot, dt time.Duration, r *model.R
 		}
 
 		cr := model.CgroupRate{
-			Path:         cg.Path,
-			Name:         cg.Name,
-			CPUPct:       cpuPct,
-			ThrottlePct:  throttlePct,
-			MemPct:       float64(cg.MemCurrent) / float64(totalMem) * 100,
-			IORateMBs:    util.Rate(pcg.IORBytes, cg.IORBytes, dt) / (1024 * 1024),
-			IOWRateMBs:   util.Rate(pcg.IOWBytes, cg.IOWBytes, dt) / (1024 * 1024),
-			OOMKillDelta: util.Delta(pcg.OOMKills, cg.OOMKills),
+			Path:              cg.Path,
+			Name:              cg.Name,
+			CPUPct:            cpuPct,
+			ThrottlePct:       throttlePct,
+			MemPct:            float64(cg.MemCurrent) / float64(totalMem) * 100,
+			IORateMBs:         util.Rate(pcg.IORBytes, cg.IORBytes, dt) / (1024 * 1024),
+			IOWRateMBs:        util.Rate(pcg.IOWBytes, cg.IOWBytes, dt) / (1024 * 1024),
+			OOMKillDelta:      util.Delta(pcg.OOMKills, cg.OOMKills),
+			OOMKillLocalDelta: util.Delta(pcg.OOMKillsLocal, cg.OOMKillsLocal),
+			HasLocalEvents:    cg.HasLocalEvents && pcg.HasLocalEvents,
 		}
 		r.CgroupRates = append(r.CgroupRates, cr)
 	}
@@ -362,6 +363,10 @@ var writePathCache = struct {
 
 const writePathCacheTTL = 5 * time.Second
 
+// writePathRecent is how recently a file must have been modified to count
+// as the one a process is writing.
+const writePathRecent = 15 * time.Second
+
 // resolveWritePaths populates WritePath for the top writer processes by
 // reading /proc/<pid>/fd/ symlinks to find open regular files.
 // Results are cached for 5 seconds to avoid expensive fd scans every tick.
@@ -410,65 +415,15 @@ func resolveWritePaths(rates []model.ProcessRate) {
 	}
 }
 
-// resolveProcessWritePath reads /proc/<pid>/fd/ to find the primary file being written.
-// It picks the largest open regular file, skipping deleted files, pipes, sockets, and internal tool paths.
+// resolveProcessWritePath returns the file pid is writing: the open
+// regular file it modified most recently. The largest open file is more
+// often a data file or an old log than where the bytes are going now. A
+// file deleted while open reads "/var/log/app.log (deleted)".
 func resolveProcessWritePath(pid int) string {
-	fdDir := fmt.Sprintf("/proc/%d/fd", pid)
-	entries, err := os.ReadDir(fdDir)
-	if err != nil {
-		return ""
-	}
-
-	type candidate struct {
-		path string
-		size int64
+	if files := collector.RecentlyWrittenFiles(pid, writePathRecent, 1); len(files) > 0 {
+		return files[0]
 	}
-	var candidates []candidate
-
-	for _, e := range entries {
-		fdPath := fmt.Sprintf("%s/%s", fdDir, e.Name())
-		link, err := os.Readlink(fdPath)
-		if err != nil {
-			continue
-		}
-		// Skip non-file FDs
-		if strings.HasPrefix(link, "pipe:") ||
-			strings.HasPrefix(link, "socket:") ||
-			strings.HasPrefix(link, "anon_inode:") ||
-			strings.HasPrefix(link, "/dev/") ||
-			strings.HasPrefix(link, "/proc/") ||
-			strings.HasPrefix(link, "/sys/") {
-			continue
-		}
-		if link == "" {
-			continue
-		}
-		// Skip deleted files
-		if strings.HasSuffix(link, " (deleted)") {
-			continue
-		}
-		// Stat via the fd path (works even if filename has special chars)
-		info, err := os.Stat(fdPath)
-		if err != nil {
-			// Can't stat — still include but with size 0
-			candidates = append(candidates, candidate{path: link, size: 0})
-			continue
-		}
-		candidates = append(candidates, candidate{path: link, size: info.Size()})
-	}
-
-	if len(candidates) == 0 {
-		return ""
-	}
-
-	// Pick the largest file — most likely the write target
-	best := candidates[0]
-	for _, c := range candidates[1:] {
-		if c.size > best.size {
-			best = c
-		}
-	}
-	return best.path
+	return ""
 }
 
 // resolveServiceName extracts a human-readable service/container name from a cgroup path.
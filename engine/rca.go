@@ -472,7 +472,13 @@ func AnalyzeRCA(curr *model.Snapshot, rates *model.RateSnapshot, hist *History,
 	trackAnomaly(result, hist)
 
 	// Hidden latency detection: use scheduler metrics when available
-	DetectHiddenLatencyV2(curr, rates, result)
+	if ExperimentalEnabled(FeatureHiddenLatency) {
+		DetectHiddenLatencyV2(curr, rates, result)
+	}
+
+	// Database IO backpressure: exact io.weight / io.max values when a DB
+	// cgroup is starved by another cgroup (feeds the IO actions below)
+	result.IOBackpressure = BuildIOBackpressure(rates, result)
 
 	// Actions
 	result.Actions = SuggestActions(result)
@@ -486,17 +492,25 @@ func AnalyzeRCA(curr *model.Snapshot, rates *model.RateSnapshot, hist *History,
 	}
 
 	// Temporal causality: update signal onsets and build chain
+	// (onsets also feed the evidence tracker, so only the chain is gated)
 	UpdateSignalOnsets(hist, result)
-	result.TemporalChain = BuildTemporalChain(result, hist)
-	if result.Narrative != nil && result.TemporalChain != nil {
-		result.Narrative.Temporal = result.TemporalChain.Summary
+	if ExperimentalEnabled(FeatureTemporalChains) {
+		result.TemporalChain = BuildTemporalChain(result, hist)
+		if result.Narrative != nil && result.TemporalChain != nil {
+			result.Narrative.Temporal = result.TemporalChain.Summary
+		}
 	}
 
 	// Cross-signal correlation: detect cause-effect pairs across domains
 	result.CrossCorrelations = BuildCrossCorrelation(result, hist)
 
 	// Blame attribution: identify top offenders
-	result.Blame = ComputeBlame(result, curr, rates)
+	if ExperimentalEnabled(FeatureBlame) {
+		result.BlameDomains = ComputeBlameDomains(result, curr, rates)
+		if len(result.BlameDomains) > 0 && result.BlameDomains[0].Primary {
+			result.Blame = result.BlameDomains[0].Offenders
+		}
+	}
 
 	// Statistical intelligence
 	runStatisticalAnalysis(result, curr, rates, hist)
@@ -48,6 +48,11 @@ type RCAIncident struct {
 	// degrades to using the narrative Evidence strings instead.
 	EvidenceIDs []string `json:"evidence_ids,omitempty"`
 
+	// CulpritCapture is the redacted full cmdline/env of the culprit
+	// process, so the post-mortem still shows the exact invocation after
+	// the process is gone.
+	CulpritCapture *model.CulpritCapture `json:"culprit_capture,omitempty"`
+
 	// Signature for similarity matching — stable hash of firing evidence IDs
 	Signature string `json:"signature"`
 
@@ -192,6 +197,7 @@ func (r *IncidentRecorder) Record(result *model.AnalysisResult) *RCAIncident {
 			Signature:  sig,
 			State:      IncidentSuspected,
 		}
+		r.active.CulpritCapture = result.CulpritCapture
 		if result.Narrative != nil {
 			r.active.RootCause = result.Narrative.RootCause
 			r.active.Pattern = result.Narrative.Pattern
@@ -241,6 +247,7 @@ func (r *IncidentRecorder) Record(result *model.AnalysisResult) *RCAIncident {
 			Signature:  sig,
 			State:      IncidentSuspected,
 		}
+		r.active.CulpritCapture = result.CulpritCapture
 		if result.Narrative != nil {
 			r.active.RootCause = result.Narrative.RootCause
 			r.active.Pattern = result.Narrative.Pattern
@@ -259,6 +266,9 @@ func (r *IncidentRecorder) Record(result *model.AnalysisResult) *RCAIncident {
 	if ids := collectFiringEvidenceIDs(result); len(ids) > 0 {
 		r.active.EvidenceIDs = ids
 	}
+	if r.active.CulpritCapture == nil {
+		r.active.CulpritCapture = result.CulpritCapture
+	}
 	if r.active.State == IncidentSuspected && gatePassed {
 		r.active.State = IncidentConfirmed
 		r.active.ConfirmedAt = time.Now()
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ftahirops/xtop/model"
 )
@@ -318,17 +319,10 @@ func analyzeMemory(curr *model.Snapshot, rates *model.RateSnapshot, sp systemPro
 		}
 	}
 
-	// 2nd priority: cgroup with OOM delta > 0 (from cgroup memory.events)
+	// 2nd priority: cgroup with OOM kills since last tick (memory.events.local,
+	// falling back to the deepest cgroup in the hierarchical memory.events)
 	if r.TopCgroup == "" && rates != nil {
-		for _, cr := range rates.CgroupRates {
-			if cr.Path == "/" || cr.Path == "" {
-				continue
-			}
-			if cr.OOMKillDelta > 0 {
-				r.TopCgroup = cr.Path
-				break
-			}
-		}
+		r.TopCgroup = oomKillCgroup(rates.CgroupRates)
 	}
 
 	// 3rd priority: cgroup closest to memory limit (existing logic for non-OOM pressure)
@@ -405,3 +399,33 @@ func analyzeMemory(curr *model.Snapshot, rates *model.RateSnapshot, sp systemPro
 
 	return r
 }
+
+// oomKillCgroup picks the cgroup that owns this tick's OOM kills.
+// memory.events is hierarchical: a kill in one container of a pod bumps the
+// container, the pod slice and every parent, so the first cgroup with a
+// delta is usually the pod (or kubepods.slice) rather than the container.
+// memory.events.local counts only the cgroup's own members and names the
+// exact container; without it, the deepest cgroup with a delta is the best
+// guess.
+func oomKillCgroup(crs []model.CgroupRate) string {
+	var local, deepest string
+	var localKills uint64
+	deepestDepth := -1
+	for _, cr := range crs {
+		if cr.Path == "/" || cr.Path == "" {
+			continue
+		}
+		if cr.HasLocalEvents && cr.OOMKillLocalDelta > localKills {
+			local, localKills = cr.Path, cr.OOMKillLocalDelta
+		}
+		if cr.OOMKillDelta > 0 {
+			if d := strings.Count(strings.TrimSuffix(cr.Path, "/"), "/"); d > deepestDepth {
+				deepest, deepestDepth = cr.Path, d
+			}
+		}
+	}
+	if local != "" {
+		return local
+	}
+	return deepest
+}
@@ -276,6 +276,35 @@ func TestRCA_OOMKill_HighSeverity(t *testing.T) {
 	}
 }
 
+func TestOOMKillCgroup_NestedContainer(t *testing.T) {
+	pod := "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1234.slice"
+	ctr := pod + "/cri-containerd-abcd.scope"
+	sidecar := pod + "/cri-containerd-ef01.scope"
+
+	// memory.events.local: only the container that lost a task has a local delta.
+	crs := []model.CgroupRate{
+		{Path: "/kubepods.slice", OOMKillDelta: 1, HasLocalEvents: true},
+		{Path: pod, OOMKillDelta: 1, HasLocalEvents: true},
+		{Path: sidecar, HasLocalEvents: true},
+		{Path: ctr, OOMKillDelta: 1, OOMKillLocalDelta: 1, HasLocalEvents: true},
+	}
+	if got := oomKillCgroup(crs); got != ctr {
+		t.Errorf("local events: got %q, want %q", got, ctr)
+	}
+
+	// No memory.events.local: deepest cgroup with a hierarchical delta.
+	for i := range crs {
+		crs[i].HasLocalEvents, crs[i].OOMKillLocalDelta = false, 0
+	}
+	if got := oomKillCgroup(crs); got != ctr {
+		t.Errorf("fallback: got %q, want %q", got, ctr)
+	}
+
+	if got := oomKillCgroup([]model.CgroupRate{{Path: "/", OOMKillDelta: 3}}); got != "" {
+		t.Errorf("root cgroup picked: %q", got)
+	}
+}
+
 // ─── IO Bottleneck Tests ─────────────────────────────────────────────────────
 
 func TestRCA_IOSaturation_CorrectBottleneck(t *testing.T) {
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"io"
 	"log"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/ftahirops/xtop/model"
 )
@@ -75,11 +77,21 @@ func (r *Recorder) RecordTickWithProbe(probe *ProbeFindings) (*model.Snapshot, *
 
 // Player replays recorded frames through a virtual engine.
 type Player struct {
-	Engine *Engine
-	frames []recordFrame
-	idx    int
-	mu     sync.Mutex
-	last   *recordFrame
+	Engine  *Engine
+	frames  []recordFrame
+	markers []ReplayMarker
+	idx     int
+	mu      sync.Mutex
+	last    *recordFrame
+}
+
+// ReplayMarker flags a frame where health got worse — where an incident
+// starts or escalates — so the scrubber can show it and seeking can jump
+// straight to it.
+type ReplayMarker struct {
+	Index      int
+	Health     model.HealthLevel
+	Bottleneck string
 }
 
 // NewPlayer creates a player from a recorded file (JSON lines).
@@ -101,8 +113,9 @@ func NewPlayer(r io.Reader, historySize int) (*Player, error) {
 	eng := NewEngine(historySize, 3) // replay uses default 3s calibration
 
 	p := &Player{
-		Engine: eng,
-		frames: frames,
+		Engine:  eng,
+		frames:  frames,
+		markers: replayMarkers(frames),
 	}
 
 	return p, nil
@@ -181,3 +194,80 @@ func (p *Player) Seek(i int) (*model.Snapshot, *model.RateSnapshot, *model.Analy
 	}
 	return &f.Snapshot, f.Rates, f.Result
 }
+
+// Position returns the index of the frame last shown, or -1 before the
+// first Tick.
+func (p *Player) Position() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.last == nil {
+		return -1
+	}
+	return p.idx - 1
+}
+
+// FrameTime returns the snapshot time of frame i (zero if out of range).
+func (p *Player) FrameTime(i int) time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i < 0 || i >= len(p.frames) {
+		return time.Time{}
+	}
+	return p.frames[i].Snapshot.Timestamp
+}
+
+// IndexAt returns the first frame recorded at or after t, clamped to the
+// recording.
+func (p *Player) IndexAt(t time.Time) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	i := sort.Search(len(p.frames), func(i int) bool {
+		return !p.frames[i].Snapshot.Timestamp.Before(t)
+	})
+	if i >= len(p.frames) {
+		i = len(p.frames) - 1
+	}
+	return i
+}
+
+// Markers returns the frames where health worsened, in recording order.
+func (p *Player) Markers() []ReplayMarker {
+	return p.markers
+}
+
+// NextMarker returns the first marker after frame i, or the last one
+// before it when dir is negative. ok is false when there is none.
+func (p *Player) NextMarker(i, dir int) (ReplayMarker, bool) {
+	if dir < 0 {
+		for k := len(p.markers) - 1; k >= 0; k-- {
+			if p.markers[k].Index < i {
+				return p.markers[k], true
+			}
+		}
+		return ReplayMarker{}, false
+	}
+	for _, mk := range p.markers {
+		if mk.Index > i {
+			return mk, true
+		}
+	}
+	return ReplayMarker{}, false
+}
+
+func replayMarkers(frames []recordFrame) []ReplayMarker {
+	var out []ReplayMarker
+	prev := model.HealthOK
+	for i, f := range frames {
+		h := model.HealthOK
+		bottleneck := ""
+		if f.Result != nil {
+			h = f.Result.Health
+			bottleneck = f.Result.PrimaryBottleneck
+		}
+		if h >= model.HealthDegraded && h > prev {
+			out = append(out, ReplayMarker{Index: i, Health: h, Bottleneck: bottleneck})
+		}
+		prev = h
+	}
+	return out
+}
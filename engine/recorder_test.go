@@ -48,3 +48,59 @@ func TestPlayerTickReplaysFrames(t *testing.T) {
 		t.Fatalf("expected history len 2, got %d", player.Engine.History.Len())
 	}
 }
+
+func TestPlayerMarkersAndSeek(t *testing.T) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	base := time.Unix(1000, 0)
+	// ok ok degraded critical critical ok degraded, 3s apart
+	healths := []model.HealthLevel{0, 0, 2, 3, 3, 0, 2}
+	for i, h := range healths {
+		f := recordFrame{
+			Snapshot: model.Snapshot{Timestamp: base.Add(time.Duration(i) * 3 * time.Second)},
+			Result:   &model.AnalysisResult{Health: h, PrimaryBottleneck: BottleneckIO},
+		}
+		if err := enc.Encode(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p, err := NewPlayer(&buf, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var idx []int
+	for _, mk := range p.Markers() {
+		idx = append(idx, mk.Index)
+	}
+	if len(idx) != 3 || idx[0] != 2 || idx[1] != 3 || idx[2] != 6 {
+		t.Fatalf("markers at %v, want [2 3 6]", idx)
+	}
+
+	if p.Position() != -1 {
+		t.Errorf("position before first tick = %d", p.Position())
+	}
+	p.Seek(4)
+	if p.Position() != 4 {
+		t.Errorf("position after Seek(4) = %d", p.Position())
+	}
+	if mk, ok := p.NextMarker(4, 1); !ok || mk.Index != 6 {
+		t.Errorf("next marker = %+v %v", mk, ok)
+	}
+	if mk, ok := p.NextMarker(4, -1); !ok || mk.Index != 3 || mk.Health != model.HealthCritical {
+		t.Errorf("prev marker = %+v %v", mk, ok)
+	}
+	if _, ok := p.NextMarker(6, 1); ok {
+		t.Error("marker past the last one")
+	}
+
+	if i := p.IndexAt(base.Add(7 * time.Second)); i != 3 {
+		t.Errorf("IndexAt(+7s) = %d, want 3", i)
+	}
+	if i := p.IndexAt(base.Add(time.Hour)); i != len(healths)-1 {
+		t.Errorf("IndexAt past end = %d", i)
+	}
+	if !p.FrameTime(1).Equal(base.Add(3*time.Second)) || !p.FrameTime(99).IsZero() {
+		t.Error("FrameTime")
+	}
+}
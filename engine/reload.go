@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ftahirops/xtop/collector"
+	"github.com/ftahirops/xtop/config"
+)
+
+// RuntimeConfig is the part of config.json that can change under a running
+// engine: SIGHUP in the daemon and Ctrl+R in the TUI re-read the file and
+// hand the result to ApplyRuntimeConfig. Everything else — history, open
+// events, baselines, the guard's current level — survives the reload.
+type RuntimeConfig struct {
+	ThresholdProfile string         // key into Profiles; "" = built-in thresholds
+	ProbeTargets     []string       // extra service probes, XTOP_PROBE_TARGETS syntax
+	Guard            GuardPolicy    // resource guard thresholds
+	OOMAvoid         OOMAvoidPolicy // earlyoom-style proactive OOM avoidance
+	Experimental     []string       // experimental analyses to enable (see features.go)
+}
+
+// RuntimeConfigFrom extracts the reloadable settings from config.json.
+func RuntimeConfigFrom(cfg config.Config) RuntimeConfig {
+	return RuntimeConfig{
+		ThresholdProfile: cfg.ThresholdProfile,
+		ProbeTargets:     cfg.ProbeTargets,
+		Guard:            GuardPolicy(cfg.Guard),
+		OOMAvoid:         OOMAvoidPolicy(cfg.OOMAvoid),
+		Experimental:     cfg.Experimental,
+	}
+}
+
+// ApplyRuntimeConfig swaps rc into the engine between ticks and returns a
+// short description of each setting that changed (empty when none did).
+func (e *Engine) ApplyRuntimeConfig(rc RuntimeConfig) []string {
+	e.tickMu.Lock()
+	defer e.tickMu.Unlock()
+
+	var changes []string
+	prev := e.runtimeCfg
+	if rc.ThresholdProfile != prev.ThresholdProfile {
+		p, ok := Profiles[rc.ThresholdProfile]
+		switch {
+		case rc.ThresholdProfile == "":
+			ActiveProfile = nil
+			changes = append(changes, "thresholds: built-in defaults")
+		case ok:
+			ActiveProfile = p
+			changes = append(changes, "thresholds: "+rc.ThresholdProfile+" profile")
+		default:
+			changes = append(changes, fmt.Sprintf("thresholds: unknown profile %q ignored", rc.ThresholdProfile))
+			rc.ThresholdProfile = prev.ThresholdProfile
+		}
+	}
+	if collector.SetProbeTargets(rc.ProbeTargets) {
+		changes = append(changes, fmt.Sprintf("probe targets: %d configured", len(rc.ProbeTargets)))
+	}
+	if e.guard != nil {
+		e.guard.SetPolicy(rc.Guard)
+		if !guardPolicyEqual(rc.Guard, prev.Guard) {
+			changes = append(changes, "guard policy updated")
+		}
+	}
+	if e.oomAvoid != nil {
+		e.oomAvoid.SetPolicy(rc.OOMAvoid)
+		if !oomAvoidPolicyEqual(rc.OOMAvoid, prev.OOMAvoid) {
+			changes = append(changes, "oom-avoid policy updated")
+		}
+	}
+	if changed, unknown := SetExperimental(rc.Experimental); changed || len(unknown) > 0 {
+		on := EnabledExperimental()
+		if len(on) == 0 {
+			on = []string{"none"}
+		}
+		note := "experimental: " + strings.Join(on, ", ")
+		if len(unknown) > 0 {
+			note += fmt.Sprintf(" (unknown %q ignored)", strings.Join(unknown, ","))
+		}
+		changes = append(changes, note)
+	}
+	rc.ProbeTargets = slices.Clone(rc.ProbeTargets)
+	rc.OOMAvoid.Denylist = slices.Clone(rc.OOMAvoid.Denylist)
+	e.runtimeCfg = rc
+	return changes
+}
+
+func guardPolicyEqual(a, b GuardPolicy) bool {
+	if (a.Enabled == nil) != (b.Enabled == nil) || (a.Enabled != nil && *a.Enabled != *b.Enabled) {
+		return false
+	}
+	a.Enabled, b.Enabled = nil, nil
+	return a == b
+}
+
+func oomAvoidPolicyEqual(a, b OOMAvoidPolicy) bool {
+	return a.Enabled == b.Enabled && a.MemFloorPct == b.MemFloorPct &&
+		a.SwapFloorPct == b.SwapFloorPct && a.Policy == b.Policy &&
+		a.DryRun == b.DryRun && slices.Equal(a.Denylist, b.Denylist)
+}
@@ -0,0 +1,420 @@
+package engine
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// ─── sar-style activity log ─────────────────────────────────────────────────
+//
+// `xtop sa record` appends one compact, fixed-interval line per sample to a
+// daily file (sa20260501), much like sysstat's sa files but with PSI and the
+// RCA verdict alongside the usual CPU/memory/disk/network columns. Files are
+// plain whitespace-separated text with a "#"-prefixed header naming the
+// columns, so they stay greppable and awk-able without xtop, and the reader
+// tolerates columns being added later. A day at one-minute resolution is
+// ~200 KB; old days are pruned on rotation.
+
+// SAFormatVersion is written into each file's first header line.
+const SAFormatVersion = 1
+
+// SARecord is one activity-log sample.
+type SARecord struct {
+	Time       time.Time `json:"time"`
+	Health     string    `json:"health"`
+	Score      int       `json:"score"`
+	Bottleneck string    `json:"bottleneck,omitempty"`
+	Culprit    string    `json:"culprit,omitempty"`
+
+	CPU       float64 `json:"cpu"`
+	IOWait    float64 `json:"iowait"`
+	Steal     float64 `json:"steal"`
+	Load1     float64 `json:"load1"`
+	Mem       float64 `json:"mem"`
+	SwapMB    float64 `json:"swap_mb"`
+	PSICPU    float64 `json:"psi_cpu"`
+	PSIMem    float64 `json:"psi_mem"`
+	PSIIO     float64 `json:"psi_io"`
+	PSIIOFull float64 `json:"psi_io_full"`
+	DiskUtil  float64 `json:"disk_util"`
+	AwaitMs   float64 `json:"await_ms"`
+	RxMBs     float64 `json:"rx_mbs"`
+	TxMBs     float64 `json:"tx_mbs"`
+	Retrans   float64 `json:"retrans"`
+	DState    float64 `json:"dstate"`
+}
+
+// saColumn describes one numeric column of the on-disk format.
+type saColumn struct {
+	Name string
+	Prec int
+	get  func(*SARecord) *float64
+}
+
+// SAColumns lists the numeric columns in file order. The text columns
+// (time, health, score, bottleneck, culprit) come first on every line.
+var SAColumns = []saColumn{
+	{"cpu", 1, func(r *SARecord) *float64 { return &r.CPU }},
+	{"iowait", 1, func(r *SARecord) *float64 { return &r.IOWait }},
+	{"steal", 1, func(r *SARecord) *float64 { return &r.Steal }},
+	{"load1", 2, func(r *SARecord) *float64 { return &r.Load1 }},
+	{"mem", 1, func(r *SARecord) *float64 { return &r.Mem }},
+	{"swap_mb", 0, func(r *SARecord) *float64 { return &r.SwapMB }},
+	{"psi_cpu", 1, func(r *SARecord) *float64 { return &r.PSICPU }},
+	{"psi_mem", 1, func(r *SARecord) *float64 { return &r.PSIMem }},
+	{"psi_io", 1, func(r *SARecord) *float64 { return &r.PSIIO }},
+	{"psi_io_full", 1, func(r *SARecord) *float64 { return &r.PSIIOFull }},
+	{"disk_util", 1, func(r *SARecord) *float64 { return &r.DiskUtil }},
+	{"await_ms", 1, func(r *SARecord) *float64 { return &r.AwaitMs }},
+	{"rx_mbs", 2, func(r *SARecord) *float64 { return &r.RxMBs }},
+	{"tx_mbs", 2, func(r *SARecord) *float64 { return &r.TxMBs }},
+	{"retrans", 1, func(r *SARecord) *float64 { return &r.Retrans }},
+	{"dstate", 0, func(r *SARecord) *float64 { return &r.DState }},
+}
+
+var saTextColumns = []string{"time", "health", "score", "bottleneck", "culprit"}
+
+// Value returns the named numeric column, or false if there is none.
+func (r *SARecord) Value(name string) (float64, bool) {
+	for _, c := range SAColumns {
+		if c.Name == name {
+			return *c.get(r), true
+		}
+	}
+	return 0, false
+}
+
+// Set assigns the named numeric column; unknown names are ignored.
+func (r *SARecord) Set(name string, v float64) {
+	for _, c := range SAColumns {
+		if c.Name == name {
+			*c.get(r) = v
+			return
+		}
+	}
+}
+
+// NewSARecord condenses one engine tick into an activity-log sample.
+func NewSARecord(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult) SARecord {
+	rec := SARecord{Time: time.Now(), Health: "-"}
+	if snap != nil {
+		rec.Time = snap.Timestamp
+		g := snap.Global
+		rec.Load1 = g.CPU.LoadAvg.Load1
+		if g.Memory.Total > 0 {
+			rec.Mem = float64(g.Memory.Total-g.Memory.Available) / float64(g.Memory.Total) * 100
+		}
+		rec.SwapMB = float64(g.Memory.SwapUsed) / (1024 * 1024)
+		rec.PSICPU = g.PSI.CPU.Some.Avg10
+		rec.PSIMem = g.PSI.Memory.Some.Avg10
+		rec.PSIIO = g.PSI.IO.Some.Avg10
+		rec.PSIIOFull = g.PSI.IO.Full.Avg10
+		for _, p := range snap.Processes {
+			if p.State == "D" {
+				rec.DState++
+			}
+		}
+	}
+	if rates != nil {
+		rec.CPU = rates.CPUBusyPct
+		rec.IOWait = rates.CPUIOWaitPct
+		rec.Steal = rates.CPUStealPct
+		rec.Retrans = rates.RetransRate
+		for _, d := range rates.DiskRates {
+			rec.DiskUtil = math.Max(rec.DiskUtil, d.UtilPct)
+			rec.AwaitMs = math.Max(rec.AwaitMs, d.AvgAwaitMs)
+		}
+		// Physical NICs only — bridges, bonds and veths would double count.
+		physical := false
+		for _, n := range rates.NetRates {
+			if n.IfType == "physical" {
+				physical = true
+				break
+			}
+		}
+		for _, n := range rates.NetRates {
+			if n.Name == "lo" || (physical && n.IfType != "physical") {
+				continue
+			}
+			rec.RxMBs += n.RxMBs
+			rec.TxMBs += n.TxMBs
+		}
+	}
+	if result != nil {
+		rec.Health = result.Health.String()
+		rec.Score = result.PrimaryScore
+		if result.Health != model.HealthOK {
+			rec.Bottleneck = result.PrimaryBottleneck
+			rec.Culprit = result.PrimaryAppName
+			if rec.Culprit == "" {
+				rec.Culprit = result.PrimaryProcess
+			}
+		}
+	}
+	return rec
+}
+
+// saToken makes a free-text value safe for a whitespace-separated column.
+func saToken(s string, max int) string {
+	s = strings.Join(strings.Fields(s), "_")
+	if s == "" {
+		return "-"
+	}
+	if len(s) > max {
+		s = s[:max]
+	}
+	return s
+}
+
+func saUntoken(s string) string {
+	if s == "-" {
+		return ""
+	}
+	return strings.ReplaceAll(s, "_", " ")
+}
+
+// formatSALine renders a record as one log line (no trailing newline).
+func formatSALine(r *SARecord) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d %s %d %s %s", r.Time.Unix(), saToken(r.Health, 16), r.Score,
+		saToken(r.Bottleneck, 32), saToken(r.Culprit, 32))
+	for _, c := range SAColumns {
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.FormatFloat(*c.get(r), 'f', c.Prec, 64))
+	}
+	return sb.String()
+}
+
+// saColumnNames is the header's column line: every column, space separated.
+func saColumnNames() string {
+	names := append([]string(nil), saTextColumns...)
+	for _, c := range SAColumns {
+		names = append(names, c.Name)
+	}
+	return strings.Join(names, " ")
+}
+
+// saHeader returns the two header lines written at the top of each file.
+func saHeader(host string, interval time.Duration) string {
+	return fmt.Sprintf("# xtop-sa v%d host=%s interval=%s\n# %s\n",
+		SAFormatVersion, saToken(host, 64), interval, saColumnNames())
+}
+
+// parseSALine parses a data line using the column order from the header.
+func parseSALine(line string, cols []string) (SARecord, error) {
+	f := strings.Fields(line)
+	if len(f) != len(cols) {
+		return SARecord{}, fmt.Errorf("got %d fields, header has %d", len(f), len(cols))
+	}
+	var r SARecord
+	for i, name := range cols {
+		v := f[i]
+		switch name {
+		case "time":
+			sec, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return SARecord{}, fmt.Errorf("bad time %q", v)
+			}
+			r.Time = time.Unix(sec, 0)
+		case "health":
+			r.Health = v
+		case "score":
+			r.Score, _ = strconv.Atoi(v)
+		case "bottleneck":
+			r.Bottleneck = saUntoken(v)
+		case "culprit":
+			r.Culprit = v
+			if v == "-" {
+				r.Culprit = ""
+			}
+		default:
+			for _, c := range SAColumns {
+				if c.Name == name {
+					*c.get(&r), _ = strconv.ParseFloat(v, 64)
+					break
+				}
+			}
+		}
+	}
+	return r, nil
+}
+
+// SAFilePath is the daily file holding samples taken on day (local time).
+func SAFilePath(dir string, day time.Time) string {
+	return filepath.Join(dir, "sa"+day.Local().Format("20060102"))
+}
+
+// SAWriter appends records to the daily activity log, rotating at local
+// midnight and pruning files older than the retention window.
+type SAWriter struct {
+	dir        string
+	host       string
+	interval   time.Duration
+	retainDays int
+
+	day string
+	f   *os.File
+}
+
+// NewSAWriter creates dir if needed. retainDays <= 0 keeps files forever.
+func NewSAWriter(dir, host string, interval time.Duration, retainDays int) (*SAWriter, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create sa dir: %w", err)
+	}
+	return &SAWriter{dir: dir, host: host, interval: interval, retainDays: retainDays}, nil
+}
+
+// Write appends one record, opening a new daily file when the day changes.
+func (w *SAWriter) Write(r SARecord) error {
+	day := r.Time.Local().Format("20060102")
+	if w.f == nil || day != w.day {
+		if err := w.rotate(r.Time); err != nil {
+			return err
+		}
+	}
+	_, err := w.f.WriteString(formatSALine(&r) + "\n")
+	return err
+}
+
+func (w *SAWriter) rotate(t time.Time) error {
+	if w.f != nil {
+		w.f.Close()
+		w.f = nil
+	}
+	path := SAFilePath(w.dir, t)
+	// New file, or the previous writer used different columns: (re)state
+	// the header so readers always know the column order.
+	restate := lastSAColumns(path) != saColumnNames()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if restate {
+		if _, err := f.WriteString(saHeader(w.host, w.interval)); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	w.f = f
+	w.day = t.Local().Format("20060102")
+	w.prune(t)
+	return nil
+}
+
+// lastSAColumns returns the column names of the last header in path, space
+// separated, or "" if it has none.
+func lastSAColumns(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	cols := ""
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if line := sc.Text(); strings.HasPrefix(line, "# time ") {
+			cols = strings.Join(strings.Fields(line[2:]), " ")
+		}
+	}
+	return cols
+}
+
+// prune removes daily files older than retainDays.
+func (w *SAWriter) prune(now time.Time) {
+	if w.retainDays <= 0 {
+		return
+	}
+	cutoff := now.Local().AddDate(0, 0, -w.retainDays).Format("20060102")
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if len(name) != 10 || !strings.HasPrefix(name, "sa") {
+			continue
+		}
+		if name[2:] < cutoff {
+			os.Remove(filepath.Join(w.dir, name))
+		}
+	}
+}
+
+// Close closes the current daily file.
+func (w *SAWriter) Close() error {
+	if w.f == nil {
+		return nil
+	}
+	err := w.f.Close()
+	w.f = nil
+	return err
+}
+
+// ReadSA returns every record in [from, to] from the daily files in dir,
+// oldest first. Malformed lines are skipped.
+func ReadSA(dir string, from, to time.Time) ([]SARecord, error) {
+	var out []SARecord
+	found := false
+	for day := startOfDay(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		recs, err := readSAFile(SAFilePath(dir, day), from, to)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return out, err
+		}
+		found = true
+		out = append(out, recs...)
+	}
+	if !found {
+		return nil, fmt.Errorf("no activity files in %s between %s and %s",
+			dir, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	return out, nil
+}
+
+func readSAFile(path string, from, to time.Time) ([]SARecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []SARecord
+	var cols []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.HasPrefix(line, "#") {
+			fields := strings.Fields(strings.TrimPrefix(line, "#"))
+			if len(fields) > 0 && fields[0] == "time" {
+				cols = fields
+			}
+			continue
+		}
+		if cols == nil || strings.TrimSpace(line) == "" {
+			continue
+		}
+		r, err := parseSALine(line, cols)
+		if err != nil || r.Time.Before(from) || r.Time.After(to) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out, sc.Err()
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func TestSARoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewSAWriter(dir, "web 1", time.Minute, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2026, 5, 1, 23, 58, 0, 0, time.Local)
+	for i := 0; i < 4; i++ {
+		r := SARecord{Time: day.Add(time.Duration(i) * time.Minute), Health: "OK", CPU: float64(10 * i), PSIIO: 1.25}
+		if i == 2 {
+			r.Health, r.Score, r.Bottleneck, r.Culprit = "CRITICAL", 80, "IO Starvation", "rsync"
+		}
+		if err := w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Close()
+
+	// Midnight rotation: two samples on each day, each file with a header.
+	for _, d := range []time.Time{day, day.Add(time.Hour)} {
+		data, err := os.ReadFile(SAFilePath(dir, d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(data), "# xtop-sa v1 host=web_1 interval=1m0s\n# time health") {
+			t.Errorf("header = %q", strings.SplitN(string(data), "\n", 3)[:2])
+		}
+		if n := strings.Count(string(data), "\n"); n != 4 {
+			t.Errorf("%s has %d lines, want 4", SAFilePath(dir, d), n)
+		}
+	}
+
+	recs, err := ReadSA(dir, day.Add(time.Minute), day.Add(10*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 3 {
+		t.Fatalf("got %d records, want 3", len(recs))
+	}
+	r := recs[1]
+	if r.Health != "CRITICAL" || r.Score != 80 || r.Bottleneck != "IO Starvation" || r.Culprit != "rsync" ||
+		r.CPU != 20 || r.PSIIO != 1.2 && r.PSIIO != 1.3 {
+		t.Errorf("record = %+v", r)
+	}
+	if recs[0].Bottleneck != "" || !recs[2].Time.Equal(day.Add(3*time.Minute)) {
+		t.Errorf("records = %+v", recs)
+	}
+
+	if _, err := ReadSA(dir, day.AddDate(0, 1, 0), day.AddDate(0, 1, 1)); err == nil {
+		t.Error("expected an error for a range with no files")
+	}
+}
+
+func TestSAReaderFollowsHeader(t *testing.T) {
+	// A file from a future version with an extra column in the middle.
+	dir := t.TempDir()
+	day := time.Date(2026, 5, 2, 0, 0, 0, 0, time.Local)
+	line := "# time health score extra cpu bottleneck culprit\n" +
+		"1777680000 OK 3 42 55.5 - -\n"
+	os.WriteFile(SAFilePath(dir, day), []byte(line), 0o644)
+	recs, err := ReadSA(dir, time.Unix(0, 0), time.Unix(1<<40, 0))
+	if err != nil || len(recs) != 1 || recs[0].CPU != 55.5 || recs[0].Score != 3 {
+		t.Errorf("recs = %+v, err = %v", recs, err)
+	}
+}
+
+func TestSAWriterRestatesChangedHeader(t *testing.T) {
+	// Today's file was started by an older xtop with fewer columns.
+	dir := t.TempDir()
+	at := time.Date(2026, 5, 2, 10, 0, 0, 0, time.Local)
+	path := SAFilePath(dir, at)
+	old := "# xtop-sa v1 host=h interval=1m0s\n# time health score cpu\n" +
+		fmt.Sprintf("%d OK 3 12.5\n", at.Add(-time.Minute).Unix())
+	os.WriteFile(path, []byte(old), 0o644)
+
+	for i := 0; i < 2; i++ { // reopening with the same columns adds nothing
+		w, err := NewSAWriter(dir, "h", time.Minute, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Write(SARecord{Time: at.Add(time.Duration(i) * time.Minute), Health: "OK", CPU: 40}); err != nil {
+			t.Fatal(err)
+		}
+		w.Close()
+	}
+	data, _ := os.ReadFile(path)
+	if n := strings.Count(string(data), "# xtop-sa"); n != 2 {
+		t.Errorf("%d headers, want 2:\n%s", n, data)
+	}
+	recs, err := ReadSA(dir, at.Add(-time.Hour), at.Add(time.Hour))
+	if err != nil || len(recs) != 3 || recs[0].CPU != 12.5 || recs[2].CPU != 40 {
+		t.Errorf("recs = %+v, err = %v", recs, err)
+	}
+}
+
+func TestNewSARecord(t *testing.T) {
+	snap := &model.Snapshot{Timestamp: time.Unix(1000, 0)}
+	snap.Global.Memory.Total = 100
+	snap.Global.Memory.Available = 25
+	rates := &model.RateSnapshot{NetRates: []model.NetRate{
+		{Name: "eth0", IfType: "physical", RxMBs: 2},
+		{Name: "br0", IfType: "bridge", RxMBs: 2},
+		{Name: "lo", RxMBs: 50},
+	}}
+	res := &model.AnalysisResult{Health: model.HealthOK, PrimaryBottleneck: "CPU Contention"}
+	r := NewSARecord(snap, rates, res)
+	if r.Mem != 75 || r.RxMBs != 2 || r.Health != "OK" || r.Bottleneck != "" {
+		t.Errorf("record = %+v", r)
+	}
+}
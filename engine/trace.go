@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // TraceMode controls when a TraceArmer dumps a trace.
@@ -531,7 +532,7 @@ func renderTraceMarkdown(tf *TraceFile) string {
 	if len(tf.Changes) > 0 {
 		fmt.Fprintf(&b, "## Recent system changes\n\n")
 		for _, c := range tf.Changes {
-			fmt.Fprintf(&b, "- [%s] %s — %s\n", c.When.Format("15:04:05"), c.Type, c.Detail)
+			fmt.Fprintf(&b, "- [%s] %s — %s\n", util.FmtTime(c.When, "15:04:05"), c.Type, c.Detail)
 		}
 		b.WriteString("\n")
 	}
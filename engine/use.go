@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // BuildUSEChecklist generates Brendan Gregg's USE method checks from the current
@@ -150,13 +151,13 @@ func useStatusCount(val, warn, crit float64) string {
 
 // useFmtBytes formats bytes into a short human-readable string.
 func useFmtBytes(b uint64) string {
-	const gb = 1024 * 1024 * 1024
-	const mb = 1024 * 1024
-	if b >= gb {
-		return fmt.Sprintf("%.1fG", float64(b)/float64(gb))
+	v, i := util.ScaleBytes(float64(b))
+	switch {
+	case i >= 3:
+		return util.FmtFloat(v, 1) + util.ByteUnit(i, true)
+	case i == 2:
+		return util.FmtFloat(v, 0) + util.ByteUnit(i, true)
+	default:
+		return fmt.Sprintf("%d%s", b/uint64(util.ByteBase()), util.ByteUnit(1, true))
 	}
-	if b >= mb {
-		return fmt.Sprintf("%.0fM", float64(b)/float64(mb))
-	}
-	return fmt.Sprintf("%dK", b/1024)
 }
@@ -306,6 +306,11 @@ func ComputeWarnings(snap *model.Snapshot, rates *model.RateSnapshot) []model.Wa
 		}
 	}
 
+	// Probe diagnostics: name the failing layer instead of "service down"
+	for _, p := range snap.Global.HealthChecks.Probes {
+		warns = append(warns, probeStageWarnings(p)...)
+	}
+
 	// Security signals
 	sec := snap.Global.Security
 	if sec.BruteForce {
@@ -441,3 +446,53 @@ func severity(value, warnThresh, critThresh float64) string {
 	}
 	return "info"
 }
+
+// probeStageWarnings turns a probe's windowed stage counters into one
+// warning per failing layer. A stage that failed on every probe in the
+// window is crit; a stage that fails only some of the time is reported as
+// intermittent at warn. Interception is reported separately because the
+// probe itself may well succeed through the middlebox.
+func probeStageWarnings(p model.HealthProbeResult) []model.Warning {
+	var warns []model.Warning
+	if p.Intercepted {
+		warns = append(warns, model.Warning{
+			Severity: "warn",
+			Signal:   "probe-intercept",
+			Detail:   fmt.Sprintf("TLS to %s intercepted: %s", p.Target, p.InterceptReason),
+			Value:    fmt.Sprintf("%d/%d probes", p.Stats.Intercepted, p.Stats.Probes),
+		})
+	}
+	st := p.Stats
+	if st.Probes < 3 {
+		return warns
+	}
+	stages := []struct {
+		stage, what string
+		n           int
+	}{
+		{"dns", "DNS resolution", st.DNS},
+		{"tcp", "TCP connect", st.TCP},
+		{"tls", "TLS handshake", st.TLS},
+		{"http", "HTTP 5xx/protocol errors", st.HTTP},
+	}
+	for _, s := range stages {
+		if s.n == 0 {
+			continue
+		}
+		sev, how := "warn", "failing intermittently"
+		if s.n == st.Probes {
+			sev, how = "crit", "failing"
+		}
+		detail := fmt.Sprintf("%s %s %s", p.Name, s.what, how)
+		if p.FailStage == s.stage && p.Detail != "" {
+			detail += " (" + p.Detail + ")"
+		}
+		warns = append(warns, model.Warning{
+			Severity: sev,
+			Signal:   "probe-" + s.stage,
+			Detail:   detail,
+			Value:    fmt.Sprintf("%d/%d probes", s.n, st.Probes),
+		})
+	}
+	return warns
+}
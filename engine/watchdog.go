@@ -10,7 +10,7 @@ import (
 var DomainPacks = map[string][]string{
 	BottleneckCPU:     {"offcpu", "runqlat", "syscalldissect"},
 	BottleneckIO:      {"iolatency", "wbstall"},
-	BottleneckNetwork: {"tcprtt", "netthroughput", "sockio"},
+	BottleneckNetwork: {"tcprtt", "netthroughput", "sockio", "netwait"},
 	BottleneckMemory:  {"pgfault", "swapevict"},
 }
 
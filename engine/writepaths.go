@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+const (
+	writePathTopK     = 10   // paths reported in RateSnapshot.TopWritePaths
+	writePathTracked  = 64   // candidates remembered between ticks
+	writePathAlpha    = 0.3  // EWMA weight of the current tick
+	writePathFloorMBs = 0.01 // smoothed rate below which a path is forgotten
+)
+
+// WritePathTracker keeps a host-wide top-K of file paths by write
+// throughput — "which file is filling the disk" is the first question of
+// every disk incident. It needs no eBPF: the per-process write rate is
+// charged to the open file the process modified most recently, summed per
+// path, and smoothed so a path that writes in bursts keeps its rank between
+// bursts.
+type WritePathTracker struct {
+	paths map[string]*pathWriteState
+}
+
+type pathWriteState struct {
+	ewma    float64
+	writers int
+	topPID  int
+	topComm string
+	topRate float64
+}
+
+// NewWritePathTracker creates an empty tracker.
+func NewWritePathTracker() *WritePathTracker {
+	return &WritePathTracker{paths: make(map[string]*pathWriteState)}
+}
+
+// Update folds this tick's writers into the tracker and sets
+// r.TopWritePaths.
+func (t *WritePathTracker) Update(r *model.RateSnapshot) {
+	current := make(map[string]*pathWriteState)
+	sums := make(map[string]float64)
+	for _, p := range r.ProcessRates {
+		if p.WritePath == "" || p.WriteMBs <= 0 {
+			continue
+		}
+		sums[p.WritePath] += p.WriteMBs
+		st := current[p.WritePath]
+		if st == nil {
+			st = &pathWriteState{}
+			current[p.WritePath] = st
+		}
+		st.writers++
+		if p.WriteMBs > st.topRate {
+			st.topRate, st.topPID, st.topComm = p.WriteMBs, p.PID, p.Comm
+		}
+	}
+
+	// Decay paths that went quiet; blend the ones written this tick. A
+	// path seen for the first time enters at its raw rate so a new
+	// runaway writer ranks immediately.
+	for path, st := range t.paths {
+		if _, ok := current[path]; !ok {
+			st.ewma *= 1 - writePathAlpha
+			st.writers = 0
+		}
+	}
+	for path, cur := range current {
+		st, ok := t.paths[path]
+		if !ok {
+			cur.ewma = sums[path]
+			t.paths[path] = cur
+			continue
+		}
+		st.ewma = writePathAlpha*sums[path] + (1-writePathAlpha)*st.ewma
+		st.writers, st.topPID, st.topComm, st.topRate = cur.writers, cur.topPID, cur.topComm, cur.topRate
+	}
+
+	ranked := make([]string, 0, len(t.paths))
+	for path, st := range t.paths {
+		if st.ewma < writePathFloorMBs {
+			delete(t.paths, path)
+			continue
+		}
+		ranked = append(ranked, path)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := t.paths[ranked[i]].ewma, t.paths[ranked[j]].ewma
+		if a != b {
+			return a > b
+		}
+		return ranked[i] < ranked[j]
+	})
+	for _, path := range ranked[min(len(ranked), writePathTracked):] {
+		delete(t.paths, path)
+	}
+
+	r.TopWritePaths = r.TopWritePaths[:0]
+	for _, path := range ranked[:min(len(ranked), writePathTopK)] {
+		st := t.paths[path]
+		r.TopWritePaths = append(r.TopWritePaths, model.PathWriteRate{
+			Path:     path,
+			Mount:    mountFor(path, r.MountRates),
+			WriteMBs: st.ewma,
+			Writers:  st.writers,
+			TopPID:   st.topPID,
+			TopComm:  st.topComm,
+		})
+	}
+}
+
+// mountFor returns the longest mount point containing path.
+func mountFor(path string, mounts []model.MountRate) string {
+	best := ""
+	for _, m := range mounts {
+		mp := m.MountPoint
+		if mp == "" || len(mp) <= len(best) {
+			continue
+		}
+		if path == mp || mp == "/" || strings.HasPrefix(path, mp+"/") {
+			best = mp
+		}
+	}
+	return best
+}
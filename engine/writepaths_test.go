@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func TestWritePathTracker(t *testing.T) {
+	mounts := []model.MountRate{{MountPoint: "/"}, {MountPoint: "/var"}, {MountPoint: "/var/lib/mysql"}}
+	tick := func(procs ...model.ProcessRate) *model.RateSnapshot {
+		return &model.RateSnapshot{ProcessRates: procs, MountRates: mounts}
+	}
+	tr := NewWritePathTracker()
+
+	// Four php workers appending to one log outrank a single faster writer.
+	r := tick(
+		model.ProcessRate{PID: 11, Comm: "php-fpm", WriteMBs: 3, WritePath: "/var/log/app.log"},
+		model.ProcessRate{PID: 12, Comm: "php-fpm", WriteMBs: 3, WritePath: "/var/log/app.log"},
+		model.ProcessRate{PID: 13, Comm: "php-fpm", WriteMBs: 4, WritePath: "/var/log/app.log"},
+		model.ProcessRate{PID: 14, Comm: "php-fpm", WriteMBs: 2, WritePath: "/var/log/app.log"},
+		model.ProcessRate{PID: 20, Comm: "mysqld", WriteMBs: 8, WritePath: "/var/lib/mysql/ibdata1"},
+		model.ProcessRate{PID: 30, Comm: "cat", ReadMBs: 50},
+	)
+	tr.Update(r)
+	if len(r.TopWritePaths) != 2 {
+		t.Fatalf("top = %+v", r.TopWritePaths)
+	}
+	top := r.TopWritePaths[0]
+	if top.Path != "/var/log/app.log" || top.WriteMBs != 12 || top.Writers != 4 || top.TopPID != 13 || top.Mount != "/var" {
+		t.Errorf("top = %+v", top)
+	}
+	if m := r.TopWritePaths[1].Mount; m != "/var/lib/mysql" {
+		t.Errorf("mysql mount = %q", m)
+	}
+
+	// The log goes quiet for a tick: it decays but keeps its rank, so a
+	// bursty writer is not lost between bursts.
+	r = tick(model.ProcessRate{PID: 20, Comm: "mysqld", WriteMBs: 8, WritePath: "/var/lib/mysql/ibdata1"})
+	tr.Update(r)
+	if len(r.TopWritePaths) != 2 || r.TopWritePaths[0].Path != "/var/log/app.log" || r.TopWritePaths[0].Writers != 0 {
+		t.Fatalf("after quiet tick = %+v", r.TopWritePaths)
+	}
+	if got := r.TopWritePaths[0].WriteMBs; got > 8.41 || got < 8.39 {
+		t.Errorf("decayed rate = %.2f, want 8.4", got)
+	}
+
+	// Long enough idle and it is forgotten.
+	for i := 0; i < 30; i++ {
+		tr.Update(tick())
+	}
+	if len(tr.paths) != 0 {
+		t.Errorf("still tracking %d idle paths", len(tr.paths))
+	}
+}
+
+func TestWritePathTrackerBounded(t *testing.T) {
+	tr := NewWritePathTracker()
+	var procs []model.ProcessRate
+	for i := 0; i < 200; i++ {
+		procs = append(procs, model.ProcessRate{PID: i + 1, WriteMBs: float64(i + 1), WritePath: fmt.Sprintf("/data/f%d", i)})
+	}
+	r := &model.RateSnapshot{ProcessRates: procs}
+	tr.Update(r)
+	if len(r.TopWritePaths) != writePathTopK || len(tr.paths) != writePathTracked {
+		t.Errorf("reported %d, tracked %d", len(r.TopWritePaths), len(tr.paths))
+	}
+	if r.TopWritePaths[0].WriteMBs != 200 {
+		t.Errorf("top = %+v", r.TopWritePaths[0])
+	}
+}
+
+// TestResolveProcessWritePathPrefersRecent: a writer is charged to the file
+// it touched last, not the biggest one it holds open.
+func TestResolveProcessWritePathPrefersRecent(t *testing.T) {
+	dir := t.TempDir()
+	big, err := os.Create(filepath.Join(dir, "big.ibd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer big.Close()
+	if err := big.Truncate(64 << 20); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(big.Name(), old, old); err != nil {
+		t.Fatal(err)
+	}
+	small, err := os.Create(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer small.Close()
+	if _, err := small.WriteString("line\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := resolveProcessWritePath(os.Getpid()); got != small.Name() {
+		t.Errorf("write path = %q, want %q", got, small.Name())
+	}
+}
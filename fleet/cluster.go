@@ -0,0 +1,370 @@
+package fleet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// ─── Cluster-wide incident correlation ───────────────────────────────────────
+//
+// When a shared dependency (database, DNS, storage array) degrades, every
+// host that talks to it opens its own incident within seconds of the others.
+// The hub folds those into one ClusterIncident so operators get a single
+// "cluster-wide" event instead of N independent pages.
+//
+// Agents' clocks drift, so member start times are shifted onto the hub clock
+// using a per-agent skew estimate taken from heartbeats. Two incidents join
+// the same cluster when their corrected starts are within clusterWindow and
+// they share a bottleneck class or a dependency endpoint.
+
+const (
+	clusterWindow    = 2 * time.Minute  // max spread of member start times
+	clusterRetention = 30 * time.Minute // keep resolved clusters visible this long
+	clusterMaxAge    = 24 * time.Hour   // drop clusters whose members never resolved
+	skewWindow       = 5 * time.Minute  // heartbeat samples used for the skew estimate
+	skewMaxSamples   = 128
+)
+
+// bottleneckClass maps an RCA bottleneck name ("IO Starvation") to the coarse
+// class used for matching ("io"). Unknown names match nothing by class.
+func bottleneckClass(b string) string {
+	l := strings.ToLower(b)
+	switch {
+	case strings.Contains(l, "cpu"):
+		return "cpu"
+	case strings.Contains(l, "mem"), strings.Contains(l, "oom"):
+		return "memory"
+	case strings.Contains(l, "net"):
+		return "network"
+	case strings.HasPrefix(l, "io"), strings.Contains(l, " io"), strings.Contains(l, "disk"):
+		return "io"
+	}
+	return ""
+}
+
+type incidentGroup struct {
+	cluster model.ClusterIncident
+	deps    map[string]map[string]bool // endpoint → agent IDs reporting it
+}
+
+func (g *incidentGroup) isCluster() bool { return len(g.cluster.Members) >= 2 }
+
+func (g *incidentGroup) memberIndex(incidentID string) int {
+	for i, m := range g.cluster.Members {
+		if m.IncidentID == incidentID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *incidentGroup) hasAgent(agentID string) bool {
+	for _, m := range g.cluster.Members {
+		if m.AgentID == agentID {
+			return true
+		}
+	}
+	return false
+}
+
+// sharesDep reports whether any of deps is already reported by a member.
+func (g *incidentGroup) sharesDep(deps []string) bool {
+	for _, d := range deps {
+		if len(g.deps[d]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *incidentGroup) addDeps(agentID string, deps []string) {
+	for _, d := range deps {
+		if g.deps[d] == nil {
+			g.deps[d] = make(map[string]bool)
+		}
+		g.deps[d][agentID] = true
+	}
+	g.cluster.SharedDeps = g.cluster.SharedDeps[:0]
+	for d, agents := range g.deps {
+		if len(agents) >= 2 {
+			g.cluster.SharedDeps = append(g.cluster.SharedDeps, d)
+		}
+	}
+	sort.Strings(g.cluster.SharedDeps)
+}
+
+// snapshot returns a deep copy safe to hand to other goroutines.
+func (g *incidentGroup) snapshot() *model.ClusterIncident {
+	cp := g.cluster
+	cp.Members = append([]model.ClusterMember(nil), g.cluster.Members...)
+	cp.SharedDeps = append([]string(nil), g.cluster.SharedDeps...)
+	if g.cluster.ResolvedAt != nil {
+		t := *g.cluster.ResolvedAt
+		cp.ResolvedAt = &t
+	}
+	return &cp
+}
+
+// clusterCorrelator groups incoming incidents into cluster-wide incidents.
+type clusterCorrelator struct {
+	mu     sync.Mutex
+	groups []*incidentGroup
+	seq    int
+}
+
+func newClusterCorrelator() *clusterCorrelator {
+	return &clusterCorrelator{}
+}
+
+// observe folds inc into its group and sets inc.ClusterID when the group is
+// a cluster. skew is added to inc.StartedAt to move it onto the hub clock.
+// Returns the cluster to broadcast when a cluster forms, gains a member or
+// resolves; nil otherwise.
+func (c *clusterCorrelator) observe(inc *model.FleetIncident, skew time.Duration, now time.Time) *model.ClusterIncident {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Updates and resolutions of an incident we already placed.
+	for _, g := range c.groups {
+		i := g.memberIndex(inc.IncidentID)
+		if i < 0 {
+			continue
+		}
+		m := &g.cluster.Members[i]
+		if inc.UpdateType == model.IncidentResolved {
+			m.Resolved = true
+		} else {
+			if inc.Bottleneck != "" {
+				m.Bottleneck = inc.Bottleneck
+			}
+			if inc.PeakScore > m.PeakScore {
+				m.PeakScore = inc.PeakScore
+			}
+			g.addDeps(inc.AgentID, inc.Dependencies)
+		}
+		if !g.isCluster() {
+			return nil
+		}
+		inc.ClusterID = g.cluster.ClusterID
+		g.cluster.UpdatedAt = now
+		if inc.UpdateType == model.IncidentResolved && g.cluster.ResolvedAt == nil && allResolved(g) {
+			t := now
+			g.cluster.ResolvedAt = &t
+			g.cluster.UpdateType = model.IncidentResolved
+			return g.snapshot()
+		}
+		return nil
+	}
+	if inc.UpdateType == model.IncidentResolved {
+		return nil // never saw it open (hub restart) — nothing to fold
+	}
+
+	start := inc.StartedAt.Add(skew)
+	class := bottleneckClass(inc.Bottleneck)
+	member := model.ClusterMember{
+		Hostname:   inc.Hostname,
+		AgentID:    inc.AgentID,
+		IncidentID: inc.IncidentID,
+		Bottleneck: inc.Bottleneck,
+		PeakScore:  inc.PeakScore,
+		StartedAt:  start,
+	}
+
+	// Prefer a group sharing a dependency endpoint; fall back to class.
+	var best *incidentGroup
+	bestShared := false
+	for _, g := range c.groups {
+		if g.cluster.ResolvedAt != nil || g.hasAgent(inc.AgentID) {
+			continue
+		}
+		if absDuration(start.Sub(g.cluster.StartedAt)) > clusterWindow {
+			continue
+		}
+		shared := g.sharesDep(inc.Dependencies)
+		sameClass := class != "" && class == g.cluster.Class
+		if !shared && !sameClass {
+			continue
+		}
+		if best == nil || (shared && !bestShared) {
+			best, bestShared = g, shared
+		}
+	}
+
+	if best == nil {
+		c.seq++
+		g := &incidentGroup{
+			cluster: model.ClusterIncident{
+				ClusterID: fmt.Sprintf("cluster-%d-%d", start.Unix(), c.seq),
+				StartedAt: start,
+				UpdatedAt: now,
+				Class:     class,
+				Members:   []model.ClusterMember{member},
+			},
+			deps: make(map[string]map[string]bool),
+		}
+		g.addDeps(inc.AgentID, inc.Dependencies)
+		c.groups = append(c.groups, g)
+		return nil
+	}
+
+	best.cluster.Members = append(best.cluster.Members, member)
+	best.addDeps(inc.AgentID, inc.Dependencies)
+	if start.Before(best.cluster.StartedAt) {
+		best.cluster.StartedAt = start
+	}
+	if class != best.cluster.Class {
+		best.cluster.Class = "mixed"
+	}
+	best.cluster.UpdatedAt = now
+	best.cluster.UpdateType = model.IncidentUpdated
+	if len(best.cluster.Members) == 2 {
+		best.cluster.UpdateType = model.IncidentStarted
+	}
+	inc.ClusterID = best.cluster.ClusterID
+	return best.snapshot()
+}
+
+func allResolved(g *incidentGroup) bool {
+	for _, m := range g.cluster.Members {
+		if !m.Resolved {
+			return false
+		}
+	}
+	return true
+}
+
+// prune drops single-host groups that can no longer gain members and
+// clusters past their retention.
+func (c *clusterCorrelator) prune(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	kept := c.groups[:0]
+	for _, g := range c.groups {
+		age := now.Sub(g.cluster.StartedAt)
+		drop := (!g.isCluster() && age > clusterWindow) ||
+			(g.cluster.ResolvedAt != nil && now.Sub(*g.cluster.ResolvedAt) > clusterRetention) ||
+			age > clusterMaxAge
+		if !drop {
+			kept = append(kept, g)
+		}
+	}
+	for i := len(kept); i < len(c.groups); i++ {
+		c.groups[i] = nil
+	}
+	c.groups = kept
+}
+
+// list returns every multi-host cluster, newest first.
+func (c *clusterCorrelator) list() []*model.ClusterIncident {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []*model.ClusterIncident
+	for _, g := range c.groups {
+		if g.isCluster() {
+			out = append(out, g.snapshot())
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartedAt.After(out[j].StartedAt) })
+	return out
+}
+
+// clusterSummary is a one-line description for logs and notifications:
+// "io via 10.0.0.5:5432 — web-01, web-02, web-03".
+func clusterSummary(cl *model.ClusterIncident) string {
+	var sb strings.Builder
+	if cl.Class != "" {
+		sb.WriteString(cl.Class)
+	} else {
+		sb.WriteString("unclassified")
+	}
+	if len(cl.SharedDeps) > 0 {
+		sb.WriteString(" via ")
+		sb.WriteString(strings.Join(cl.SharedDeps, ", "))
+	}
+	sb.WriteString(" — ")
+	for i, m := range cl.Members {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(m.Hostname)
+	}
+	return sb.String()
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// ─── Agent clock skew ────────────────────────────────────────────────────────
+
+type skewSample struct {
+	at time.Time
+	d  time.Duration
+}
+
+// skewTracker estimates each agent's clock offset from heartbeats. Network
+// and queueing delay only ever add to (receive - sent), so the minimum over
+// a short window is the best estimate of the true offset — the same trick
+// NTP uses to filter delayed samples.
+type skewTracker struct {
+	mu      sync.Mutex
+	samples map[string][]skewSample
+}
+
+func newSkewTracker() *skewTracker {
+	return &skewTracker{samples: make(map[string][]skewSample)}
+}
+
+// observe records one heartbeat and returns the current estimate.
+func (s *skewTracker) observe(agentID string, sent, recv time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := s.samples[agentID]
+	kept := list[:0]
+	for _, smp := range list {
+		if recv.Sub(smp.at) <= skewWindow {
+			kept = append(kept, smp)
+		}
+	}
+	kept = append(kept, skewSample{at: recv, d: recv.Sub(sent)})
+	if len(kept) > skewMaxSamples {
+		kept = kept[len(kept)-skewMaxSamples:]
+	}
+	s.samples[agentID] = kept
+	return minSkew(kept)
+}
+
+// estimate returns the agent's skew, or 0 if no heartbeat has been seen.
+func (s *skewTracker) estimate(agentID string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return minSkew(s.samples[agentID])
+}
+
+// forget drops samples for an expired agent.
+func (s *skewTracker) forget(agentID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.samples, agentID)
+}
+
+func minSkew(list []skewSample) time.Duration {
+	if len(list) == 0 {
+		return 0
+	}
+	m := list[0].d
+	for _, smp := range list[1:] {
+		if smp.d < m {
+			m = smp.d
+		}
+	}
+	return m
+}
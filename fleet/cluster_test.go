@@ -0,0 +1,83 @@
+package fleet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func TestClusterCorrelatorGroupsIncidents(t *testing.T) {
+	c := newClusterCorrelator()
+	now := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	web1 := &model.FleetIncident{AgentID: "a1", Hostname: "web-01", IncidentID: "i1",
+		Bottleneck: "Network Overload", PeakScore: 60, StartedAt: now,
+		Dependencies: []string{"10.0.0.5:5432"}, UpdateType: model.IncidentStarted}
+	if cl := c.observe(web1, 0, now); cl != nil || web1.ClusterID != "" {
+		t.Fatalf("a single host must not form a cluster: %+v", cl)
+	}
+
+	// web-02's clock runs 40s fast; corrected it starts 10s after web-01.
+	// Different bottleneck class but the same slow database.
+	web2 := &model.FleetIncident{AgentID: "a2", Hostname: "web-02", IncidentID: "i2",
+		Bottleneck: "CPU Contention", PeakScore: 70, StartedAt: now.Add(50 * time.Second),
+		Dependencies: []string{"10.0.0.5:5432"}, UpdateType: model.IncidentStarted}
+	cl := c.observe(web2, -40*time.Second, now.Add(10*time.Second))
+	if cl == nil || cl.UpdateType != model.IncidentStarted || len(cl.Members) != 2 {
+		t.Fatalf("expected a new two-host cluster, got %+v", cl)
+	}
+	if len(cl.SharedDeps) != 1 || cl.SharedDeps[0] != "10.0.0.5:5432" || cl.Class != "mixed" {
+		t.Errorf("cluster = %+v", cl)
+	}
+	if web2.ClusterID != cl.ClusterID {
+		t.Errorf("incident cluster_id = %q, want %q", web2.ClusterID, cl.ClusterID)
+	}
+	if cl.Members[0].IncidentID != "i1" {
+		t.Errorf("first member = %q, want i1 (the hub backfills its stored cluster_id)", cl.Members[0].IncidentID)
+	}
+
+	// Unrelated IO incident on a third host: no shared class or endpoint.
+	db := &model.FleetIncident{AgentID: "a3", Hostname: "db-01", IncidentID: "i3",
+		Bottleneck: "IO Starvation", StartedAt: now.Add(5 * time.Second), UpdateType: model.IncidentStarted}
+	if cl := c.observe(db, 0, now.Add(15*time.Second)); cl != nil {
+		t.Errorf("unrelated incident joined a cluster: %+v", cl)
+	}
+
+	// Too late to join even with the shared endpoint.
+	late := &model.FleetIncident{AgentID: "a4", Hostname: "web-03", IncidentID: "i4",
+		Bottleneck: "Network Overload", StartedAt: now.Add(10 * time.Minute),
+		Dependencies: []string{"10.0.0.5:5432"}, UpdateType: model.IncidentStarted}
+	if cl := c.observe(late, 0, now.Add(10*time.Minute)); cl != nil {
+		t.Errorf("incident outside the window joined: %+v", cl)
+	}
+
+	// The cluster resolves only once every member has.
+	res1 := &model.FleetIncident{AgentID: "a1", IncidentID: "i1", UpdateType: model.IncidentResolved}
+	if cl := c.observe(res1, 0, now.Add(time.Minute)); cl != nil {
+		t.Errorf("cluster resolved early: %+v", cl)
+	}
+	res2 := &model.FleetIncident{AgentID: "a2", IncidentID: "i2", UpdateType: model.IncidentResolved}
+	if cl := c.observe(res2, 0, now.Add(2*time.Minute)); cl == nil || cl.ResolvedAt == nil {
+		t.Errorf("expected resolved cluster, got %+v", cl)
+	}
+
+	c.prune(now.Add(time.Hour))
+	if got := c.list(); len(got) != 0 {
+		t.Errorf("resolved cluster should be pruned after retention: %+v", got)
+	}
+}
+
+func TestSkewTrackerUsesMinimum(t *testing.T) {
+	s := newSkewTracker()
+	sent := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	// Agent clock 3s behind; the second heartbeat sat in the offline queue.
+	s.observe("a1", sent, sent.Add(3*time.Second+20*time.Millisecond))
+	s.observe("a1", sent.Add(time.Second), sent.Add(30*time.Second))
+	if got := s.estimate("a1"); got != 3*time.Second+20*time.Millisecond {
+		t.Errorf("skew = %v", got)
+	}
+	if got := s.estimate("unknown"); got != 0 {
+		t.Errorf("unknown agent skew = %v", got)
+	}
+}
@@ -48,6 +48,15 @@ type Hub struct {
 	dedupeMu sync.Mutex
 	dedupe   map[string]time.Time
 
+	// Cluster-wide incident correlation (see cluster.go). skews holds the
+	// per-agent clock offset used to line incident start times up.
+	clusters *clusterCorrelator
+	skews    *skewTracker
+
+	// Guest steal vs hypervisor guest CPU, for noisy-neighbor attribution
+	// (see steal.go).
+	steal *stealCorrelator
+
 	// Tracks stale/expired hosts in background
 	quitCh chan struct{}
 	wg     sync.WaitGroup
@@ -71,10 +80,13 @@ func NewHub(cfg model.FleetHubConfig) (*Hub, error) {
 	_ = os.MkdirAll(filepath.Dir(cfg.SQLiteCachePath), 0o755)
 
 	h := &Hub{
-		cfg:    cfg,
-		hosts:  make(map[string]*model.FleetHost),
-		subs:   make(map[int]chan []byte),
-		quitCh: make(chan struct{}),
+		cfg:      cfg,
+		hosts:    make(map[string]*model.FleetHost),
+		subs:     make(map[int]chan []byte),
+		clusters: newClusterCorrelator(),
+		skews:    newSkewTracker(),
+		steal:    newStealCorrelator(),
+		quitCh:   make(chan struct{}),
 	}
 
 	// Open Postgres (required)
@@ -124,6 +136,7 @@ func (h *Hub) Start() error {
 	mux.HandleFunc(model.FleetEndpointHosts, h.handleListHosts)
 	mux.HandleFunc(model.FleetEndpointHost, h.handleGetHost)
 	mux.HandleFunc(model.FleetEndpointIncidents, h.handleListIncidents)
+	mux.HandleFunc(model.FleetEndpointClusters, h.handleListClusters)
 	mux.HandleFunc(model.FleetEndpointStream, h.handleStream)
 	mux.HandleFunc("/health", h.handleHealth)
 	// Web UI is registered here by RegisterWebUI() — see web.go.
@@ -231,8 +244,9 @@ func (h *Hub) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "hostname and agent_id required", http.StatusBadRequest)
 		return
 	}
-	// Update in-memory registry
-	h.updateHostFromHeartbeat(&hb)
+	// Update in-memory registry. This also fills hb.NoisyNeighbor so
+	// stream subscribers see the attribution with the heartbeat.
+	h.updateHostFromHeartbeat(&hb, time.Now())
 
 	// Persist asynchronously — don't block the agent
 	go h.persistHeartbeat(&hb)
@@ -279,6 +293,17 @@ func (h *Hub) handleIncident(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNoContent)
 		return
 	}
+	// Fold into a cluster-wide incident before persisting so the stored
+	// record carries its cluster_id. The incident that opened the window
+	// was stored while still alone; stamp its rows once the cluster forms.
+	if cl := h.clusters.observe(&inc, h.skews.estimate(inc.AgentID), time.Now()); cl != nil {
+		log.Printf("hub: cluster %s %s: %d hosts (%s)", cl.ClusterID, cl.UpdateType, len(cl.Members), clusterSummary(cl))
+		if cl.UpdateType == model.IncidentStarted {
+			first := cl.Members[0]
+			go h.persistClusterID(first.AgentID, first.IncidentID, cl.ClusterID)
+		}
+		h.broadcast("cluster", cl)
+	}
 	go h.persistIncident(&inc)
 	h.broadcast("incident", inc)
 	w.WriteHeader(http.StatusNoContent)
@@ -311,6 +336,14 @@ func (h *Hub) isDuplicateIncident(inc *model.FleetIncident) bool {
 	return false
 }
 
+func (h *Hub) handleListClusters(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAuth(w, r) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(h.clusters.list())
+}
+
 func (h *Hub) handleListHosts(w http.ResponseWriter, r *http.Request) {
 	if !h.requireAuth(w, r) {
 		return
@@ -511,13 +544,18 @@ func (h *Hub) broadcast(event string, payload interface{}) {
 
 // ─── Host registry ───────────────────────────────────────────────────────────
 
-func (h *Hub) updateHostFromHeartbeat(hb *model.FleetHeartbeat) {
+func (h *Hub) updateHostFromHeartbeat(hb *model.FleetHeartbeat, recv time.Time) {
+	var skewMs int64
+	if !hb.Timestamp.IsZero() {
+		skewMs = h.skews.observe(hb.AgentID, hb.Timestamp, recv).Milliseconds()
+	}
+	hb.NoisyNeighbor = h.steal.observe(hb, recv)
 	h.hostsMu.Lock()
 	defer h.hostsMu.Unlock()
 	existing := h.hosts[hb.AgentID]
 	now := hb.Timestamp
 	if now.IsZero() {
-		now = time.Now()
+		now = recv
 	}
 	if existing == nil {
 		h.hosts[hb.AgentID] = &model.FleetHost{
@@ -546,9 +584,15 @@ func (h *Hub) updateHostFromHeartbeat(hb *model.FleetHeartbeat) {
 			XtopOwnRSSMB:      hb.XtopOwnRSSMB,
 			XtopGuardLevel:    hb.XtopGuardLevel,
 			XtopMode:          hb.XtopMode,
+			ClockSkewMs:       skewMs,
+			CPUStealPct:       hb.CPUStealPct,
+			NoisyNeighbor:     hb.NoisyNeighbor,
 		}
 		return
 	}
+	if nn := hb.NoisyNeighbor; nn != nil && (existing.NoisyNeighbor == nil || existing.NoisyNeighbor.VMName != nn.VMName) {
+		log.Printf("hub: %s steal %.1f%% tracks VM %s on %s (r=%.2f)", hb.Hostname, nn.StealPct, nn.VMName, nn.Hypervisor, nn.Correlation)
+	}
 	existing.LastSeen = now
 	existing.Status = model.HostStatusLive
 	existing.Hostname = hb.Hostname
@@ -572,6 +616,9 @@ func (h *Hub) updateHostFromHeartbeat(hb *model.FleetHeartbeat) {
 	existing.XtopOwnRSSMB = hb.XtopOwnRSSMB
 	existing.XtopGuardLevel = hb.XtopGuardLevel
 	existing.XtopMode = hb.XtopMode
+	existing.ClockSkewMs = skewMs
+	existing.CPUStealPct = hb.CPUStealPct
+	existing.NoisyNeighbor = hb.NoisyNeighbor
 }
 
 // ─── Background janitor ──────────────────────────────────────────────────────
@@ -616,7 +663,10 @@ func (h *Hub) updateStaleStatus() {
 	}
 	for _, id := range expired {
 		delete(h.hosts, id)
+		h.skews.forget(id)
+		h.steal.forget(id)
 	}
+	h.clusters.prune(now)
 }
 
 func (h *Hub) pruneOldRecords() {
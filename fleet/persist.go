@@ -99,3 +99,19 @@ func (h *Hub) persistIncident(inc *model.FleetIncident) {
 		log.Printf("hub: persist incident pg: %v", err)
 	}
 }
+
+// persistClusterID sets cluster_id on the stored updates of an incident that
+// was persisted before its cluster formed — the cluster's first member.
+func (h *Hub) persistClusterID(agentID, incidentID, clusterID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := h.pg.ExecContext(ctx, `
+		UPDATE fleet_incidents
+		SET data = jsonb_set(data, '{cluster_id}', to_jsonb($3::text))
+		WHERE agent_id = $1 AND incident_id = $2`,
+		agentID, incidentID, clusterID,
+	); err != nil {
+		log.Printf("hub: persist cluster id pg: %v", err)
+	}
+}
@@ -0,0 +1,300 @@
+package fleet
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+// ─── Noisy-neighbor attribution ──────────────────────────────────────────────
+//
+// A guest only sees steal — time its vCPUs were runnable but the hypervisor
+// ran something else. When the hypervisor also runs an agent, its
+// heartbeats list per-guest CPU use, so the hub can line the two series up
+// and name the co-resident VM whose load rises and falls with the guest's
+// steal.
+//
+// Guests are placed on a hypervisor by SMBIOS UUID (qemu's -uuid is what
+// the guest reads as product_uuid), falling back to the qemu -name matching
+// the guest's hostname. All samples are stamped with hub receive time, so
+// agent clock skew doesn't matter, and averaged into stealBucket slots
+// before correlating since guest and hypervisor heartbeats never line up.
+
+const (
+	stealWindow     = 5 * time.Minute  // history used for the correlation
+	stealBucket     = 10 * time.Second // alignment slot for the two series
+	stealMinPct     = 5.0              // guest steal that counts as "high"
+	stealMinCorr    = 0.6              // weakest correlation we'll name
+	stealMinBuckets = 6                // need a minute of overlap
+	stealMinCores   = 0.5              // neighbor must burn real CPU while steal is high
+)
+
+type timedVal struct {
+	at time.Time
+	v  float64
+}
+
+type stealGuest struct {
+	hostname string
+	uuid     string
+	steal    []timedVal
+	named    *model.NoisyNeighbor
+}
+
+type stealVM struct {
+	info    model.FleetGuestVM
+	samples []timedVal
+}
+
+type stealHypervisor struct {
+	hostname string
+	vms      map[string]*stealVM // vmKey → series
+}
+
+// stealCorrelator keeps the short steal and guest-CPU histories the hub
+// needs to attribute steal. Safe for concurrent use.
+type stealCorrelator struct {
+	mu          sync.Mutex
+	guests      map[string]*stealGuest      // agent ID → guest
+	hypervisors map[string]*stealHypervisor // agent ID → hypervisor
+}
+
+func newStealCorrelator() *stealCorrelator {
+	return &stealCorrelator{
+		guests:      make(map[string]*stealGuest),
+		hypervisors: make(map[string]*stealHypervisor),
+	}
+}
+
+func vmKey(vm model.FleetGuestVM) string {
+	if vm.UUID != "" {
+		return vm.UUID
+	}
+	return "name:" + strings.ToLower(vm.Name)
+}
+
+// observe records one heartbeat and returns the agent's current noisy
+// neighbor, or nil. Hypervisor heartbeats never get one themselves.
+func (s *stealCorrelator) observe(hb *model.FleetHeartbeat, recv time.Time) *model.NoisyNeighbor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cutoff := recv.Add(-stealWindow)
+
+	if len(hb.GuestVMs) > 0 {
+		hv := s.hypervisors[hb.AgentID]
+		if hv == nil {
+			hv = &stealHypervisor{vms: make(map[string]*stealVM)}
+			s.hypervisors[hb.AgentID] = hv
+		}
+		hv.hostname = hb.Hostname
+		for _, vm := range hb.GuestVMs {
+			k := vmKey(vm)
+			sv := hv.vms[k]
+			if sv == nil {
+				sv = &stealVM{}
+				hv.vms[k] = sv
+			}
+			sv.info = vm
+			sv.samples = append(trimBefore(sv.samples, cutoff), timedVal{recv, vm.CPUCores})
+		}
+		for k, sv := range hv.vms {
+			if sv.samples = trimBefore(sv.samples, cutoff); len(sv.samples) == 0 {
+				delete(hv.vms, k)
+			}
+		}
+	}
+
+	g := s.guests[hb.AgentID]
+	if g == nil {
+		if hb.CPUStealPct <= 0 && hb.MachineUUID == "" {
+			return nil // not a guest, or one without steal accounting
+		}
+		g = &stealGuest{}
+		s.guests[hb.AgentID] = g
+	}
+	g.hostname = hb.Hostname
+	g.uuid = strings.ToLower(hb.MachineUUID)
+	g.steal = append(trimBefore(g.steal, cutoff), timedVal{recv, hb.CPUStealPct})
+
+	if hb.CPUStealPct < stealMinPct {
+		g.named = nil
+		return nil
+	}
+	nn := s.attribute(hb.AgentID, g)
+	switch {
+	case nn == nil:
+		g.named = nil
+	case g.named != nil && g.named.Hypervisor == nn.Hypervisor && g.named.VMName == nn.VMName:
+		nn.Since = g.named.Since
+		g.named = nn
+	default:
+		nn.Since = recv
+		g.named = nn
+	}
+	return copyNeighbor(g.named)
+}
+
+// attribute finds g's hypervisor and the co-resident VM whose CPU best
+// tracks g's steal. Callers hold mu.
+func (s *stealCorrelator) attribute(agentID string, g *stealGuest) *model.NoisyNeighbor {
+	hv, self := s.placeGuest(g)
+	if hv == nil {
+		return nil
+	}
+	steal := bucketize(g.steal)
+
+	var best *model.NoisyNeighbor
+	bestR := 0.0
+	for k, sv := range hv.vms {
+		if k == self {
+			continue
+		}
+		r, hot, n := correlate(steal, bucketize(sv.samples))
+		if n < stealMinBuckets || r < stealMinCorr || hot.cores < stealMinCores {
+			continue
+		}
+		if best != nil && r <= bestR {
+			continue
+		}
+		bestR = r
+		best = &model.NoisyNeighbor{
+			Hypervisor:  hv.hostname,
+			VMName:      sv.info.Name,
+			VMID:        sv.info.VMID,
+			VMHost:      s.guestHostname(sv.info, agentID),
+			Correlation: math.Round(r*100) / 100,
+			CPUCores:    math.Round(hot.cores*10) / 10,
+			StealPct:    math.Round(hot.steal*10) / 10,
+		}
+	}
+	return best
+}
+
+// placeGuest returns the hypervisor running g and g's own key in its VM
+// list. UUID matches win over name matches.
+func (s *stealCorrelator) placeGuest(g *stealGuest) (*stealHypervisor, string) {
+	full := strings.ToLower(g.hostname)
+	short, _, _ := strings.Cut(full, ".")
+	var byName *stealHypervisor
+	var byNameKey string
+	for _, hv := range s.hypervisors {
+		for k, sv := range hv.vms {
+			if g.uuid != "" && sv.info.UUID == g.uuid {
+				return hv, k
+			}
+			if name := strings.ToLower(sv.info.Name); byName == nil && (name == full || name == short) {
+				byName, byNameKey = hv, k
+			}
+		}
+	}
+	return byName, byNameKey
+}
+
+// guestHostname returns the fleet hostname of a VM that runs its own
+// agent, so the UI can link the neighbor to its host card.
+func (s *stealCorrelator) guestHostname(vm model.FleetGuestVM, skip string) string {
+	for id, g := range s.guests {
+		if id == skip {
+			continue
+		}
+		if vm.UUID != "" && g.uuid == vm.UUID {
+			return g.hostname
+		}
+	}
+	return ""
+}
+
+// forget drops state for an expired agent.
+func (s *stealCorrelator) forget(agentID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.guests, agentID)
+	delete(s.hypervisors, agentID)
+}
+
+func trimBefore(list []timedVal, cutoff time.Time) []timedVal {
+	i := 0
+	for i < len(list) && list[i].at.Before(cutoff) {
+		i++
+	}
+	return list[i:]
+}
+
+// bucketize averages samples into stealBucket slots keyed by slot start.
+func bucketize(list []timedVal) map[int64]float64 {
+	sum := make(map[int64]float64)
+	cnt := make(map[int64]int)
+	for _, tv := range list {
+		k := tv.at.Truncate(stealBucket).Unix()
+		sum[k] += tv.v
+		cnt[k]++
+	}
+	for k := range sum {
+		sum[k] /= float64(cnt[k])
+	}
+	return sum
+}
+
+// hotMeans are the means over slots where the guest's steal was high.
+type hotMeans struct {
+	steal, cores float64
+}
+
+// correlate returns Pearson's r between steal and cpu over their common
+// slots, the means over the high-steal slots, and the number of common
+// slots. r is 0 when either series is flat.
+func correlate(steal, cpu map[int64]float64) (float64, hotMeans, int) {
+	var xs, ys []float64
+	var hot hotMeans
+	nHot := 0
+	for k, x := range steal {
+		y, ok := cpu[k]
+		if !ok {
+			continue
+		}
+		xs = append(xs, x)
+		ys = append(ys, y)
+		if x >= stealMinPct {
+			hot.steal += x
+			hot.cores += y
+			nHot++
+		}
+	}
+	if nHot > 0 {
+		hot.steal /= float64(nHot)
+		hot.cores /= float64(nHot)
+	}
+	n := len(xs)
+	if n < 2 {
+		return 0, hot, n
+	}
+	var mx, my float64
+	for i := range xs {
+		mx += xs[i]
+		my += ys[i]
+	}
+	mx /= float64(n)
+	my /= float64(n)
+	var cov, vx, vy float64
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		cov += dx * dy
+		vx += dx * dx
+		vy += dy * dy
+	}
+	if vx < 1e-9 || vy < 1e-9 {
+		return 0, hot, n
+	}
+	return cov / math.Sqrt(vx*vy), hot, n
+}
+
+func copyNeighbor(nn *model.NoisyNeighbor) *model.NoisyNeighbor {
+	if nn == nil {
+		return nil
+	}
+	cp := *nn
+	return &cp
+}
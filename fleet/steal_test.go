@@ -0,0 +1,65 @@
+package fleet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ftahirops/xtop/model"
+)
+
+func TestStealCorrelatorNamesNeighbor(t *testing.T) {
+	s := newStealCorrelator()
+	t0 := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	const guestUUID = "6a1f0c2e-0000-4000-8000-000000000001"
+
+	var nn *model.NoisyNeighbor
+	// Three minutes at 3s heartbeats. batch-01 bursts every other 30s and
+	// the guest's steal follows; idle-01 stays flat-ish and uncorrelated.
+	for i := 0; i < 60; i++ {
+		now := t0.Add(time.Duration(i) * 3 * time.Second)
+		burst := (i/10)%2 == 1
+		batch, steal := 0.3, 1.0
+		if burst {
+			batch, steal = 6.0, 22.0
+		}
+		s.observe(&model.FleetHeartbeat{
+			AgentID: "hv", Hostname: "pve-02",
+			GuestVMs: []model.FleetGuestVM{
+				{Name: "db-01", UUID: guestUUID, VMID: 101, CPUCores: 1.5},
+				{Name: "batch-01", VMID: 105, CPUCores: batch},
+				{Name: "idle-01", VMID: 107, CPUCores: 0.8 + float64(i%3)*0.1},
+			},
+		}, now)
+		nn = s.observe(&model.FleetHeartbeat{
+			AgentID: "g1", Hostname: "db-01.example.com",
+			MachineUUID: "6A1F0C2E-0000-4000-8000-000000000001", CPUStealPct: steal,
+		}, now.Add(time.Second))
+		if !burst && nn != nil {
+			t.Fatalf("tick %d: neighbor named while steal is low: %+v", i, nn)
+		}
+	}
+
+	// Last tick was a burst.
+	if nn == nil {
+		t.Fatal("expected batch-01 to be named")
+	}
+	if nn.VMName != "batch-01" || nn.VMID != 105 || nn.Hypervisor != "pve-02" {
+		t.Errorf("neighbor = %+v", nn)
+	}
+	if nn.Correlation < 0.9 || nn.CPUCores < 5 || nn.StealPct < 20 {
+		t.Errorf("neighbor stats = %+v", nn)
+	}
+
+	s.forget("hv")
+	if nn := s.observe(&model.FleetHeartbeat{AgentID: "g1", Hostname: "db-01", CPUStealPct: 25}, t0.Add(4*time.Minute)); nn != nil {
+		t.Errorf("neighbor named after hypervisor expired: %+v", nn)
+	}
+}
+
+func TestCorrelateFlatSeries(t *testing.T) {
+	steal := map[int64]float64{0: 10, 10: 10, 20: 10}
+	cpu := map[int64]float64{0: 1, 10: 5, 20: 9}
+	if r, _, n := correlate(steal, cpu); r != 0 || n != 3 {
+		t.Errorf("flat steal: r=%v n=%d, want 0, 3", r, n)
+	}
+}
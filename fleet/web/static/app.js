@@ -164,6 +164,22 @@
       metaChildren.push(el('span', { class: 'host-culprit' }, culpritName));
     }
 
+    // Noisy neighbor — the hub matched this guest's steal to a VM on the
+    // same hypervisor (both must run agents).
+    let neighborRow = null;
+    const nn = h.noisy_neighbor;
+    if (nn) {
+      const vm = nn.vm_name + (nn.vmid ? ` (${nn.vmid})` : '') +
+        (nn.vm_host && nn.vm_host.toLowerCase() !== nn.vm_name.toLowerCase() ? ` = ${nn.vm_host}` : '');
+      neighborRow = el('div', {
+          class: 'host-meta host-neighbor',
+          title: `correlation r=${nn.correlation.toFixed(2)} · since ${fmtLocalDateTime(nn.since)}`,
+        },
+        `steal ${nn.steal_pct.toFixed(1)}% ← noisy neighbor `,
+        el('span', { class: 'host-culprit' }, vm),
+        ` on ${nn.hypervisor} · ${nn.cpu_cores.toFixed(1)} cores`);
+    }
+
     // Primary 4 metrics stay prominent.
     const metric = (val, label, klass) =>
       el('div', { class: 'metric' },
@@ -223,6 +239,7 @@
         el('span', { class: 'host-badge ' + badgeCls }, label),
       ),
       el('div', { class: 'host-meta' }, ...metaChildren),
+      neighborRow,
       el('div', { class: 'metrics' },
         metric(fmtPct(h.cpu_busy_pct), 'cpu', metricClass(h.cpu_busy_pct, 70, 90)),
         metric(fmtPct(h.mem_used_pct), 'mem', metricClass(h.mem_used_pct, 80, 95)),
@@ -278,6 +295,32 @@
     }
   }
 
+  // ── Cluster-wide incidents ─────────────────────────────────
+  async function loadClusters() {
+    try {
+      const r = await fetch('/v1/clusters');
+      if (!r.ok) return; // older hub — no cluster endpoint
+      const body = await r.json();
+      renderClusters(Array.isArray(body) ? body : []);
+    } catch (e) { console.error(e); }
+  }
+
+  function renderClusters(list) {
+    const box = $('#clusters');
+    clear(box);
+    for (const c of list) {
+      const members = Array.isArray(c.members) ? c.members : [];
+      const via = Array.isArray(c.shared_deps) && c.shared_deps.length ? ' via ' + c.shared_deps.join(', ') : '';
+      const status = c.resolved_at ? 'resolved ' + timeAgo(c.resolved_at) : 'since ' + fmtLocalTime(c.started_at);
+      box.appendChild(el('div', { class: 'cluster' + (c.resolved_at ? ' resolved' : '') },
+        el('div', { class: 'cluster-title' },
+          `Cluster-wide incident: ${c.class || 'unclassified'}${via} — ${members.length} hosts · ${status}`),
+        el('div', { class: 'cluster-hosts' },
+          members.map(m => `${m.hostname} (${m.bottleneck || '—'} ${m.peak_score | 0}%)`).join(' · ')),
+      ));
+    }
+  }
+
   function renderIncidents(list) {
     const tbody = $('#incidents tbody');
     clear(tbody);
@@ -297,7 +340,8 @@
       tbody.appendChild(el('tr',
         { class: 'clickable', dataset: { host: i.hostname || '' }, onclick: () => openDrawer(i.hostname) },
         el('td', null, i.hostname || '—'),
-        el('td', null, i.bottleneck || '—'),
+        el('td', null, i.bottleneck || '—',
+           i.cluster_id ? el('span', { class: 'cluster-tag', title: i.cluster_id }, 'cluster') : null),
         el('td', null, (i.peak_score | 0) + '%'),
         el('td', null, (i.confidence | 0) + '%'),
         el('td', null, i.culprit_app || i.culprit || '—'),
@@ -482,6 +526,7 @@
       clearTimeout(connectStream._incT);
       connectStream._incT = setTimeout(loadIncidents, 1500);
     });
+    es.addEventListener('cluster', () => loadClusters());
     es.onerror = () => markDisconnected();
   }
 
@@ -510,6 +555,8 @@
         load_avg_1: hb.load_avg_1,
         num_cpus: hb.num_cpus,
         active_incident_id: hb.active_incident_id,
+        cpu_steal_pct: hb.cpu_steal_pct,
+        noisy_neighbor: hb.noisy_neighbor,
         status: 'live',
       },
     );
@@ -544,6 +591,7 @@
     });
 
     loadIncidents();
+    loadClusters();
     connectStream();
     setInterval(renderHosts, 5000);
   });
@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Event represents a detected performance incident.
 type Event struct {
@@ -21,10 +25,48 @@ type Event struct {
 	PeakIOPSI      float64          `json:"peak_io_psi,omitempty"`
 	Active         bool             `json:"active"`
 	Timeline       []TimelineEntry  `json:"timeline,omitempty"`
+	CulpritCapture *CulpritCapture  `json:"culprit_capture,omitempty"`
+}
+
+// CulpritCapture is the full command line and environment of an incident's
+// culprit process, read once when it is first blamed so "which exact
+// query/flag was it running" survives the process exiting. Secret-looking
+// values are masked before the capture leaves the collector.
+type CulpritCapture struct {
+	PID        int       `json:"pid"`
+	Comm       string    `json:"comm"`
+	Exe        string    `json:"exe,omitempty"`
+	Args       []string  `json:"args"`
+	Env        []string  `json:"env,omitempty"`       // KEY=VALUE, sorted
+	Redacted   int       `json:"redacted,omitempty"`  // values masked
+	Truncated  bool      `json:"truncated,omitempty"` // args or env hit the size cap
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// CommandLine joins Args for display, quoting arguments with spaces.
+func (c *CulpritCapture) CommandLine() string {
+	parts := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		if a == "" || strings.ContainsAny(a, " \t\"'") {
+			a = strconv.Quote(a)
+		}
+		parts[i] = a
+	}
+	return strings.Join(parts, " ")
 }
 
 // TimelineEntry is a timestamped milestone within an incident.
 type TimelineEntry struct {
 	Time    time.Time `json:"time"`
 	Message string    `json:"message"`
+	Kind    string    `json:"kind,omitempty"` // "" = machine-observed, TimelineKindNote = operator note
+}
+
+// TimelineKindNote marks a timeline entry written by a human operator
+// ("restarted php-fpm") rather than derived from collected metrics.
+const TimelineKindNote = "note"
+
+// IsNote reports whether the entry is an operator annotation.
+func (t TimelineEntry) IsNote() bool {
+	return t.Kind == TimelineKindNote
 }
@@ -57,6 +57,39 @@ type FleetHeartbeat struct {
 	XtopOwnRSSMB   float64 `json:"xtop_rss_mb,omitempty"`
 	XtopGuardLevel int     `json:"xtop_guard_level,omitempty"`
 	XtopMode       string  `json:"xtop_mode,omitempty"` // "lean" or "rich"
+
+	// Noisy-neighbor inputs. Guests send their steal and SMBIOS UUID;
+	// hypervisors send their busiest guests. The hub joins the two.
+	CPUStealPct float64        `json:"cpu_steal_pct,omitempty"`
+	MachineUUID string         `json:"machine_uuid,omitempty"`
+	GuestVMs    []FleetGuestVM `json:"guest_vms,omitempty"`
+
+	// NoisyNeighbor is set by the hub before it relays the heartbeat to
+	// stream subscribers; agents never send it.
+	NoisyNeighbor *NoisyNeighbor `json:"noisy_neighbor,omitempty"`
+}
+
+// FleetGuestVM is one guest's CPU use as seen by its hypervisor.
+type FleetGuestVM struct {
+	Name     string  `json:"name"`
+	UUID     string  `json:"uuid,omitempty"`
+	VMID     int     `json:"vmid,omitempty"`
+	VCPUs    int     `json:"vcpus,omitempty"`
+	CPUCores float64 `json:"cpu_cores"`
+}
+
+// NoisyNeighbor names the co-resident VM whose CPU use tracks a guest's
+// steal. Computed by the hub from heartbeats of a guest and the
+// hypervisor it runs on, both of which must run an agent.
+type NoisyNeighbor struct {
+	Hypervisor  string    `json:"hypervisor"`        // hypervisor agent's hostname
+	VMName      string    `json:"vm_name"`           // neighbor's qemu -name
+	VMID        int       `json:"vmid,omitempty"`    // Proxmox VMID
+	VMHost      string    `json:"vm_host,omitempty"` // neighbor's own hostname, when it runs an agent
+	Correlation float64   `json:"correlation"`       // Pearson r, guest steal vs neighbor CPU
+	CPUCores    float64   `json:"cpu_cores"`         // neighbor's mean CPU while steal was high
+	StealPct    float64   `json:"steal_pct"`         // guest's mean steal while it was high
+	Since       time.Time `json:"since"`             // first named, hub clock
 }
 
 // FleetIncident is the richer payload sent once when an incident starts
@@ -87,6 +120,9 @@ type FleetIncident struct {
 	Culprit    string `json:"culprit,omitempty"`
 	CulpritPID int    `json:"culprit_pid,omitempty"`
 	CulpritApp string `json:"culprit_app,omitempty"`
+	// Full redacted command line of the culprit. Env is stripped before
+	// sending; it stays on the agent.
+	CulpritCapture *CulpritCapture `json:"culprit_capture,omitempty"`
 
 	// Narrative
 	RootCause string   `json:"root_cause,omitempty"`
@@ -120,6 +156,41 @@ type FleetIncident struct {
 	ConfirmedAt        time.Time            `json:"confirmed_at,omitempty"`
 	ChangesAtConfirm   []SystemChange       `json:"changes_at_confirm,omitempty"`
 	FleetPeersAtConfirm string              `json:"fleet_peers_at_confirm,omitempty"`
+
+	// Dependencies lists remote endpoints ("10.0.0.5:5432") adding latency
+	// on this host at incident time. The hub uses shared endpoints to
+	// correlate incidents across hosts.
+	Dependencies []string `json:"dependencies,omitempty"`
+
+	// ClusterID is set by the hub when this incident is part of a
+	// cluster-wide incident (see ClusterIncident).
+	ClusterID string `json:"cluster_id,omitempty"`
+}
+
+// ClusterIncident groups incidents from several hosts that started within a
+// short, clock-skew-corrected window and share a bottleneck class or a
+// dependency endpoint. The hub emits one of these instead of leaving
+// operators to page on N independent host incidents.
+type ClusterIncident struct {
+	ClusterID  string             `json:"cluster_id"`
+	StartedAt  time.Time          `json:"started_at"` // earliest member start, hub clock
+	UpdatedAt  time.Time          `json:"updated_at"`
+	ResolvedAt *time.Time         `json:"resolved_at,omitempty"` // set once every member resolved
+	Class      string             `json:"class,omitempty"`       // "io", "memory", "cpu", "network"
+	SharedDeps []string           `json:"shared_deps,omitempty"` // endpoints reported by 2+ members
+	Members    []ClusterMember    `json:"members"`
+	UpdateType IncidentUpdateType `json:"update_type"`
+}
+
+// ClusterMember is one host's incident inside a ClusterIncident.
+type ClusterMember struct {
+	Hostname   string    `json:"hostname"`
+	AgentID    string    `json:"agent_id"`
+	IncidentID string    `json:"incident_id"`
+	Bottleneck string    `json:"bottleneck"`
+	PeakScore  int       `json:"peak_score"`
+	StartedAt  time.Time `json:"started_at"` // corrected to hub clock
+	Resolved   bool      `json:"resolved,omitempty"`
 }
 
 // IncidentUpdateType signals what kind of update the hub is receiving.
@@ -174,12 +245,21 @@ type FleetHost struct {
 	// Active incident, if any
 	ActiveIncidentID string `json:"active_incident_id,omitempty"`
 
+	// ClockSkewMs is hub receive time minus the agent's heartbeat timestamp
+	// (includes one-way network latency). Positive = agent clock behind.
+	ClockSkewMs int64 `json:"clock_skew_ms,omitempty"`
+
 	// Mirrored self-resource fields (latest known) so UIs can show xtop's
 	// own footprint per host without joining against heartbeats.
 	XtopOwnCPUPct  float64 `json:"xtop_cpu_pct,omitempty"`
 	XtopOwnRSSMB   float64 `json:"xtop_rss_mb,omitempty"`
 	XtopGuardLevel int     `json:"xtop_guard_level,omitempty"`
 	XtopMode       string  `json:"xtop_mode,omitempty"`
+
+	// CPU steal from the last heartbeat, and the hub's attribution of it
+	// to a co-resident VM (nil when steal is low or nothing correlates).
+	CPUStealPct   float64        `json:"cpu_steal_pct,omitempty"`
+	NoisyNeighbor *NoisyNeighbor `json:"noisy_neighbor,omitempty"`
 }
 
 // HostStatus reflects the liveness of an agent relative to its expected interval.
@@ -209,6 +289,9 @@ const (
 	// GET: UI → hub, list recent incidents across fleet
 	FleetEndpointIncidents = "/v1/incidents"
 
+	// GET: UI → hub, list active and recently resolved cluster-wide incidents
+	FleetEndpointClusters = "/v1/clusters"
+
 	// GET: UI → hub, stream events (SSE)
 	FleetEndpointStream = "/v1/stream"
 
@@ -84,6 +84,7 @@ type LoadAvg struct {
 type CPUMetrics struct {
 	Total   CPUTimes
 	PerCPU  []CPUTimes
+	CPUIDs  []int // kernel CPU number of each PerCPU entry; offline cores leave gaps
 	LoadAvg LoadAvg
 	NumCPUs int
 	// CtxSwitches is the cumulative total system context switches
@@ -179,6 +180,63 @@ type DiskStats struct {
 	FlushTimeMs       uint64
 }
 
+// BlockQueue is one block device's request-queue configuration from
+// /sys/block/<dev>/queue, with any setting that does not suit the hardware.
+type BlockQueue struct {
+	Device      string
+	Scheduler   string   // active IO scheduler ("none", "mq-deadline", "bfq", "kyber")
+	Schedulers  []string // schedulers the kernel offers for this queue
+	NrRequests  int
+	ReadAheadKB int
+	Rotational  bool
+	Transport   string // "nvme", "virtio", "xen", "scsi", "md", "dm"
+	RAID        string // holding md array ("md0"), "hw" for a RAID controller volume, "" if neither
+	Advice      []BlockQueueAdvice
+}
+
+// BlockQueueAdvice is a suggested change to one queue setting.
+type BlockQueueAdvice struct {
+	Setting   string // "scheduler", "nr_requests", "read_ahead_kb"
+	Current   string
+	Suggested string
+	Severity  string // "warn" or "info"
+	AdviceID  string // key into the advice catalog
+	Reason    string
+	Fix       string // applies Suggested until reboot
+	Udev      string // rule that persists it
+}
+
+// GuestVM is one QEMU/KVM guest seen from the hypervisor as a process.
+// Found by process scan rather than management APIs, so it covers
+// Proxmox, libvirt and hand-started qemu alike.
+type GuestVM struct {
+	PID      int
+	Name     string  // -name (libvirt's guest= prefix stripped)
+	UUID     string  // -uuid or -smbios type=1,uuid=; lower-case
+	VMID     int     // Proxmox -id, 0 elsewhere
+	VCPUs    int     // -smp
+	CPUCores float64 // host CPU consumed, in cores (1.0 = one full core)
+}
+
+// OOMScoreService is a critical service's standing with the OOM killer,
+// from /proc/<pid>/oom_score_adj and oom_score, with a suggested systemd
+// OOMScoreAdjust when it is set up to be killed.
+type OOMScoreService struct {
+	PID       int    // main process: lowest PID with this name
+	Comm      string
+	Unit      string // systemd unit, "" when not started by systemd
+	Role      string // "access", "database", "monitoring"
+	ScoreAdj  int    // oom_score_adj of the main process (-1000..1000)
+	Score     int    // highest oom_score among the service's processes
+	Rank      int    // 1 = the process the kernel would kill next
+	Suggested int    // recommended OOMScoreAdjust
+	Severity  string // "warn", "info", "" when fine
+	AdviceID  string // key into the advice catalog
+	Reason    string
+	Fix       string // applies Suggested to the running process
+	Persist   string // systemd drop-in that keeps it across restarts
+}
+
 // MountStats holds per-filesystem stats from statfs(2).
 type MountStats struct {
 	MountPoint  string
@@ -413,6 +471,17 @@ type CloseWaitLeaker struct {
 	TopRemotes []string // up to 3 remote IPs
 }
 
+// OutboundWait is a remote endpoint local processes had requests in
+// flight to at collection time: outbound TCP connections that sent data
+// after the last data they received (sock_diag tcp_info).
+type OutboundWait struct {
+	Endpoint string  // "10.0.0.5:5432"
+	PID      int     // owner of the oldest request; 0 if not resolved yet
+	Comm     string
+	InFlight int     // connections with a request outstanding
+	MaxAgeMs float64 // age of the oldest outstanding request
+}
+
 // CloseWaitTrend holds CLOSE_WAIT growth trend data.
 type CloseWaitTrend struct {
 	Current    int
@@ -508,6 +577,34 @@ type HealthProbeResult struct {
 	Detail       string
 	LastCheck    time.Time
 	CertDaysLeft int // -1 if N/A
+
+	// Staged diagnostics for http/tcp probes. FailStage names the first
+	// stage that failed on the latest probe: "dns", "tcp", "tls", "http",
+	// or "" when the probe got through.
+	FailStage string
+	Stats     ProbeStageStats
+	// TLS details for https probes. Intercepted is set when the presented
+	// certificate looks like it was minted by a TLS-inspecting middlebox.
+	TLSIssuer       string
+	TLSTrusted      bool // chain verifies against the system roots
+	Intercepted     bool
+	InterceptReason string
+}
+
+// ProbeStageStats counts outcomes per probe stage over the recent probe
+// window, so an intermittently failing service shows which layer breaks.
+type ProbeStageStats struct {
+	Probes      int // probes in the window
+	DNS         int // name resolution failures
+	TCP         int // connect failures (refused, timeout, unreachable)
+	TLS         int // handshake failures
+	HTTP        int // 5xx or malformed responses
+	Intercepted int // handshakes that presented a middlebox certificate
+}
+
+// Failures returns the number of probes in the window that failed at any stage.
+func (s ProbeStageStats) Failures() int {
+	return s.DNS + s.TCP + s.TLS + s.HTTP
 }
 
 // HealthCheckMetrics holds active health probe results.
@@ -532,6 +629,7 @@ type DiagFinding struct {
 	Summary  string
 	Detail   string
 	Advice   string
+	AdviceID string // advice catalog key the Advice was rendered from
 }
 
 // ServiceDiag holds diagnostic results for one service.
@@ -866,6 +964,8 @@ type GlobalMetrics struct {
 	Memory         MemoryMetrics
 	VMStat         VMStatMetrics
 	Disks          []DiskStats
+	BlockQueues    []BlockQueue
+	OOMScores      []OOMScoreService
 	Network        []NetworkStats
 	TCP            TCPMetrics
 	UDP            UDPMetrics
@@ -880,6 +980,7 @@ type GlobalMetrics struct {
 	TopRemoteIPs     []RemoteIPStats
 	CloseWaitLeakers []CloseWaitLeaker
 	CloseWaitTrend   CloseWaitTrend
+	OutboundWaits    []OutboundWait
 	Mounts           []MountStats
 	DeletedOpen    []DeletedOpenFile
 	BigFiles       []BigFile
@@ -895,6 +996,7 @@ type GlobalMetrics struct {
 	Apps           AppMetrics
 	AppIdentities  map[int]AppIdentity // PID → resolved identity
 	Proxmox        *ProxmoxMetrics     // non-nil only on Proxmox hosts
+	GuestVMs       []GuestVM           // QEMU/KVM guests, on hypervisors only
 	Profile        *ServerProfile      // system profiler (optimization audit)
 	GPU            GPUSnapshot         // NVIDIA GPU metrics (empty if no GPU)
 	PHPFPM         PHPFPMMetrics       // per-pool/per-worker PHP-FPM view
@@ -1040,6 +1142,55 @@ type PHPFPMIssue struct {
 	Action   string
 }
 
+// CgroupDetail is an on-demand, single-cgroup read used by the CGroups
+// detail view. It carries the files the per-tick tree walk skips
+// (limits, PSI, per-device IO, full memory.events) so the walk stays cheap.
+type CgroupDetail struct {
+	Path      string
+	Timestamp time.Time
+
+	// CPU limits (cpu.max / cpu.weight). QuotaUsec == 0 means unlimited.
+	CPUQuotaUsec  uint64
+	CPUPeriodUsec uint64
+	CPUWeight     uint64
+
+	// Memory protection/limits. 0 = not set ("max" or absent).
+	MemMin  uint64
+	MemLow  uint64
+	MemHigh uint64
+	MemMax  uint64
+
+	PSI PSIMetrics
+
+	// memory.events counters (cumulative, include descendants)
+	MemEvents CgroupMemEvents
+	// memory.events.local counters: this cgroup's own members only.
+	// nil when the kernel has no memory.events.local (< 5.2).
+	MemEventsLocal *CgroupMemEvents
+
+	IODevices []CgroupIODevice
+	PIDs      []int // members from cgroup.procs
+}
+
+// CgroupMemEvents mirrors memory.events in cgroup v2.
+type CgroupMemEvents struct {
+	Low          uint64
+	High         uint64
+	Max          uint64
+	OOM          uint64
+	OOMKill      uint64
+	OOMGroupKill uint64
+}
+
+// CgroupIODevice is one io.stat line for a cgroup.
+type CgroupIODevice struct {
+	Device string // "sda", "nvme0n1", or "MAJ:MIN" when unresolved
+	RBytes uint64
+	WBytes uint64
+	RIOs   uint64
+	WIOs   uint64
+}
+
 // CgroupMetrics holds metrics for a single cgroup.
 type CgroupMetrics struct {
 	Path string
@@ -1054,12 +1205,14 @@ type CgroupMetrics struct {
 	NrPeriods     uint64
 
 	// Memory
-	MemCurrent uint64
-	MemLimit   uint64 // max or high, whichever is set
-	MemSwap    uint64
-	OOMKills   uint64
-	PgFault    uint64
-	PgMajFault uint64
+	MemCurrent     uint64
+	MemLimit       uint64 // max or high, whichever is set
+	MemSwap        uint64
+	OOMKills       uint64 // memory.events: includes kills in descendant cgroups
+	OOMKillsLocal  uint64 // memory.events.local: kills of this cgroup's own members
+	HasLocalEvents bool   // memory.events.local exists (cgroup v2, kernel 5.2+)
+	PgFault        uint64
+	PgMajFault     uint64
 
 	// IO (aggregated across devices)
 	IORBytes uint64
@@ -1079,6 +1232,17 @@ type CgroupMetrics struct {
 	PodQoS         string // "Guaranteed", "Burstable", "BestEffort"
 }
 
+// OwnOOMKills returns the OOM kills that happened in this cgroup itself
+// rather than somewhere below it. memory.events is hierarchical, so a pod
+// slice reports every kill in its containers; memory.events.local does not.
+// Falls back to the hierarchical count on kernels without the local file.
+func (c CgroupMetrics) OwnOOMKills() uint64 {
+	if c.HasLocalEvents {
+		return c.OOMKillsLocal
+	}
+	return c.OOMKills
+}
+
 // ProcessMetrics holds metrics for a single process.
 type ProcessMetrics struct {
 	PID        int
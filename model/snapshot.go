@@ -58,8 +58,9 @@ type Warning struct {
 
 // Action is a suggested remediation.
 type Action struct {
-	Summary string
-	Command string // optional runnable command
+	Summary  string
+	Command  string // optional runnable command
+	AdviceID string // advice catalog key the Summary was rendered from
 }
 
 // Capacity represents headroom for one resource.
@@ -132,14 +133,16 @@ type NetRate struct {
 
 // CgroupRate holds computed per-cgroup rates.
 type CgroupRate struct {
-	Path         string
-	Name         string
-	CPUPct       float64
-	ThrottlePct  float64
-	MemPct       float64
-	IORateMBs    float64
-	IOWRateMBs   float64
-	OOMKillDelta uint64 // OOM kills since last tick (delta, not cumulative)
+	Path              string
+	Name              string
+	CPUPct            float64
+	ThrottlePct       float64
+	MemPct            float64
+	IORateMBs         float64
+	IOWRateMBs        float64
+	OOMKillDelta      uint64 // OOM kills since last tick (delta, not cumulative), includes descendants
+	OOMKillLocalDelta uint64 // same, from memory.events.local: this cgroup's own members only
+	HasLocalEvents    bool   // OOMKillLocalDelta is meaningful
 }
 
 // ProcessRate holds computed per-process rates.
@@ -231,6 +234,21 @@ type RateSnapshot struct {
 
 	// Processes
 	ProcessRates []ProcessRate
+
+	// Host-wide top-K file paths by write throughput (smoothed)
+	TopWritePaths []PathWriteRate
+}
+
+// PathWriteRate is one heavy hitter among the files being written. Each
+// writer's rate is attributed to the file it has open (ProcessRate.WritePath)
+// and summed across writers, so a log shared by many workers ranks as one.
+type PathWriteRate struct {
+	Path     string
+	Mount    string  // filesystem the path lives on
+	WriteMBs float64 // EWMA-smoothed
+	Writers  int     // processes writing it this tick
+	TopPID   int
+	TopComm  string
 }
 
 // WatchdogState holds auto-trigger state from the watchdog.
@@ -260,7 +278,8 @@ type AnalysisResult struct {
 	PrimaryCulprit    string
 	PrimaryPID        int
 	PrimaryProcess    string
-	PrimaryAppName    string // resolved app name for primary culprit
+	PrimaryAppName    string          // resolved app name for primary culprit
+	CulpritCapture    *CulpritCapture // full, redacted cmdline/env of PrimaryPID; set while unhealthy
 
 	// Sustained pressure tracking
 	Sustained      bool // true if pressure persisted >10 ticks
@@ -342,8 +361,10 @@ type AnalysisResult struct {
 	// Cross-signal correlation
 	CrossCorrelations []CrossCorrelation
 
-	// Blame attribution
-	Blame []BlameEntry
+	// Blame attribution: Blame is the primary bottleneck's offenders,
+	// BlameDomains ranks offenders for every domain under pressure.
+	Blame        []BlameEntry
+	BlameDomains []BlameDomain `json:"blame_domains,omitempty"`
 
 	// Statistical intelligence (v0.31.0)
 	BaselineAnomalies []BaselineAnomaly   // Evidence deviating from learned baseline
@@ -415,6 +436,117 @@ type AnalysisResult struct {
 
 	// Cross-host correlation: related incidents on other hosts
 	CrossHostCorrelation string `json:"cross_host_correlation,omitempty"` // e.g. "Host db-server also reports IO bottleneck (score 78)"
+
+	// SlowDependencies ranks remote endpoints by latency added to local
+	// processes versus their healthy baseline. Populated while degraded.
+	SlowDependencies []DependencyLatency `json:"slow_dependencies,omitempty"`
+
+	// IOBackpressure is a concrete io.weight / io.max plan when a database
+	// cgroup is starved of IO by another cgroup. Nil otherwise.
+	IOBackpressure *IOBackpressurePlan `json:"io_backpressure,omitempty"`
+
+	// DBWriter names the statement behind a database's writes during an
+	// IO stall, from the database's own processlist. Nil otherwise.
+	DBWriter *DBWriterAttribution `json:"db_writer,omitempty"`
+
+	// OOMAvoidActions are the victims the opt-in OOM-avoidance mode
+	// signalled since the previous tick.
+	OOMAvoidActions []OOMAvoidAction `json:"oom_avoid_actions,omitempty"`
+}
+
+// OOMAvoidAction is one process the OOM-avoidance mode signalled (or, in
+// dry-run mode, would have) because memory and swap were both exhausted.
+type OOMAvoidAction struct {
+	Time        time.Time `json:"time"`
+	PID         int       `json:"pid"`
+	Comm        string    `json:"comm"`
+	RSS         uint64    `json:"rss"`
+	OOMScore    int       `json:"oom_score"`
+	Signal      string    `json:"signal"` // "SIGTERM", "SIGKILL"
+	Policy      string    `json:"policy"` // "largest_rss", "oom_score"
+	MemAvailPct float64   `json:"mem_avail_pct"`
+	SwapFreePct float64   `json:"swap_free_pct"`
+	DryRun      bool      `json:"dry_run,omitempty"`
+	Error       string    `json:"error,omitempty"` // signal failed (usually EPERM when not root)
+}
+
+// DBWriterAttribution ties the files a database is writing during an IO
+// stall to the running statement doing the writing.
+type DBWriterAttribution struct {
+	Kind      string         `json:"kind"`                // "mysql", "postgresql"
+	Summary   string         `json:"summary"`             // "ALTER TABLE on orders (240s) is the writer"
+	Statement *DBStatement   `json:"statement,omitempty"` // nil when no statement matched the files
+	File      string         `json:"file,omitempty"`      // written file the match came through
+	Files     []DBWriterFile `json:"files,omitempty"`     // recently written files, newest first
+	Running   []DBStatement  `json:"running,omitempty"`   // longest-running statements
+	CheckedAt time.Time      `json:"checked_at"`
+}
+
+// DBStatement is one row of the processlist / pg_stat_activity. Query is
+// redacted and whitespace-collapsed.
+type DBStatement struct {
+	ID      int64  `json:"id"` // MySQL connection ID or PostgreSQL backend PID
+	User    string `json:"user,omitempty"`
+	DB      string `json:"db,omitempty"`
+	Seconds int    `json:"seconds"`
+	State   string `json:"state,omitempty"`
+	Query   string `json:"query"`
+	Verb    string `json:"verb,omitempty"`  // "ALTER TABLE", "INSERT", "VACUUM"
+	Table   string `json:"table,omitempty"` // target table as written in the query
+}
+
+// DBWriterFile is a file a database process wrote recently, mapped to what
+// it stores.
+type DBWriterFile struct {
+	Path  string `json:"path"`
+	Kind  string `json:"kind"`            // "table", "ddl", "redo", "undo", "temp", "binlog", "wal", "system"
+	Table string `json:"table,omitempty"` // "shop.orders" for table files
+}
+
+// IOBackpressurePlan turns observed IO shares between a starved database
+// cgroup and the cgroup crowding it out into exact cgroup v2 settings.
+// Weights apply to the two sibling cgroups directly under the pair's lowest
+// common ancestor, since io.weight only arbitrates between siblings.
+type IOBackpressurePlan struct {
+	DB           string  `json:"db"`        // "mysqld"
+	DBCgroup     string  `json:"db_cgroup"` // "/system.slice/mysql.service"
+	DBMBs        float64 `json:"db_mbs"`
+	DBPSISome    float64 `json:"db_psi_some"` // cgroup io.pressure some avg10
+	DBDState     int     `json:"db_dstate"`
+	Aggressor    string  `json:"aggressor"` // cgroup path
+	AggressorMBs float64 `json:"aggressor_mbs"`
+	DBShare      float64 `json:"db_share"` // observed share of the pair's throughput, 0-1
+
+	Device    string `json:"device"`  // "sdb"
+	MajMin    string `json:"maj_min"` // "8:16"
+	Scheduler string `json:"scheduler,omitempty"`
+	IOCost    string `json:"io_cost"` // "enabled", "disabled", "unsupported"
+
+	DBWeightCgroup  string  `json:"db_weight_cgroup"`
+	AggWeightCgroup string  `json:"agg_weight_cgroup"`
+	DBWeightNow     int     `json:"db_weight_now"`
+	AggWeightNow    int     `json:"agg_weight_now"`
+	DBWeight        int     `json:"db_weight"`  // recommended io.weight for DBWeightCgroup
+	AggWeight       int     `json:"agg_weight"` // alternative: lower AggWeightCgroup instead
+	TargetShare     float64 `json:"target_share"`
+	AggRBps         uint64  `json:"agg_rbps,omitempty"` // recommended io.max for the aggressor (0 = leave)
+	AggWBps         uint64  `json:"agg_wbps,omitempty"`
+
+	Actions []Action `json:"actions"`
+}
+
+// DependencyLatency is one remote endpoint's latency as seen by one local
+// process, compared against the baseline learned while the host was healthy.
+type DependencyLatency struct {
+	Endpoint   string  `json:"endpoint"`          // "10.0.0.5:443"
+	Service    string  `json:"service,omitempty"` // "https"
+	Comm       string  `json:"comm"`
+	PID        int     `json:"pid"`
+	P95Ms      float64 `json:"p95_ms"`
+	BaselineMs float64 `json:"baseline_ms"` // 0 = no healthy baseline yet
+	AddedMs    float64 `json:"added_ms"`    // P95Ms - BaselineMs
+	Samples    int     `json:"samples"`
+	Source     string  `json:"source"` // "inflight" (oldest outstanding outbound request)
 }
 
 // USECheck represents one USE method check for a resource (Utilization, Saturation, Errors).
@@ -488,6 +620,7 @@ type SysInfo struct {
 	OS             string // OS name from /etc/os-release
 	Arch           string // architecture
 	CPUModel       string // CPU model name
+	MachineUUID    string // SMBIOS product UUID, lower-case; on VMs the hypervisor's -uuid
 }
 
 // RCAEntry holds one bottleneck analysis result.
@@ -635,6 +768,17 @@ type BlameEntry struct {
 	CgroupPath string
 	Metrics    map[string]string // "cpu" → "45.2%", "io" → "12 MB/s"
 	ImpactPct  float64
+	Domain     string  // bottleneck this entry is blamed for, e.g. "CPU Contention"
+	SharePct   float64 // share of the domain's attributed load, 0-100
+	Confidence float64 // 0..1: share, lead over the runner-up, and domain RCA score
+}
+
+// BlameDomain ranks the offenders for one resource domain under pressure.
+type BlameDomain struct {
+	Bottleneck string       `json:"bottleneck"`
+	Score      int          `json:"score"`   // the domain's RCA score
+	Primary    bool         `json:"primary"` // the incident's primary bottleneck
+	Offenders  []BlameEntry `json:"offenders"`
 }
 
 // BaselineAnomaly represents an evidence value that deviates from its learned EWMA baseline.
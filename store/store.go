@@ -35,6 +35,19 @@ type IncidentRecord struct {
 	PeakCPU         float64   `json:"peak_cpu"`
 	PeakMem         float64   `json:"peak_mem"`
 	PeakIOPSI       float64   `json:"peak_io_psi"`
+	CaptureJSON     string    `json:"capture_json,omitempty"` // model.CulpritCapture
+}
+
+// Capture decodes CaptureJSON; nil when the incident has none.
+func (r *IncidentRecord) Capture() *model.CulpritCapture {
+	if r.CaptureJSON == "" {
+		return nil
+	}
+	var c model.CulpritCapture
+	if json.Unmarshal([]byte(r.CaptureJSON), &c) != nil {
+		return nil
+	}
+	return &c
 }
 
 // IncidentOffender is a stored per-incident offender.
@@ -115,7 +128,8 @@ func (s *Store) Migrate() error {
 			evidence_json TEXT,
 			peak_cpu REAL DEFAULT 0,
 			peak_mem REAL DEFAULT 0,
-			peak_io_psi REAL DEFAULT 0
+			peak_io_psi REAL DEFAULT 0,
+			capture_json TEXT
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_incidents_fp ON incidents(fingerprint)`,
 		`CREATE INDEX IF NOT EXISTS idx_incidents_start ON incidents(start_time)`,
@@ -195,9 +209,51 @@ func (s *Store) Migrate() error {
 			return fmt.Errorf("migrate: %w", err)
 		}
 	}
+
+	// Columns added after the first release. SQLite has no ADD COLUMN IF
+	// NOT EXISTS, so check table_info first.
+	if err := s.addColumn("incidents", "capture_json", "TEXT"); err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
 	return nil
 }
 
+// addColumn adds a column to an existing table unless it's already there.
+func (s *Store) addColumn(table, column, typ string) error {
+	rows, err := s.db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, typ))
+	return err
+}
+
+// captureJSON encodes an event's culprit capture for the capture_json
+// column; "" when there is none.
+func captureJSON(e model.Event) string {
+	if e.CulpritCapture == nil {
+		return ""
+	}
+	b, err := json.Marshal(e.CulpritCapture)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
 // InsertIncident inserts a new incident with its offenders.
 func (s *Store) InsertIncident(e model.Event, fp string, offenders []model.ImpactScore) error {
 	evidenceJSON, _ := json.Marshal(e.Evidence)
@@ -205,11 +261,12 @@ func (s *Store) InsertIncident(e model.Event, fp string, offenders []model.Impac
 	_, err := s.db.Exec(`INSERT INTO incidents
 		(id, fingerprint, start_time, peak_health, bottleneck, peak_score,
 		 culprit_process, culprit_pid, culprit_cgroup, causal_chain, evidence_json,
-		 peak_cpu, peak_mem, peak_io_psi)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		 peak_cpu, peak_mem, peak_io_psi, capture_json)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		e.ID, fp, e.StartTime, e.PeakHealth.String(), e.Bottleneck, e.PeakScore,
 		e.CulpritProcess, e.CulpritPID, e.CulpritCgroup, e.CausalChain,
-		string(evidenceJSON), e.PeakCPUBusy, e.PeakMemUsedPct, e.PeakIOPSI)
+		string(evidenceJSON), e.PeakCPUBusy, e.PeakMemUsedPct, e.PeakIOPSI,
+		captureJSON(e))
 	if err != nil {
 		return fmt.Errorf("insert incident: %w", err)
 	}
@@ -230,14 +287,16 @@ func (s *Store) InsertIncident(e model.Event, fp string, offenders []model.Impac
 }
 
 // UpdateIncident updates end time and duration for a closed incident.
+// The culprit capture is only overwritten when the event carries one, so a
+// capture taken at insert time survives the culprit exiting.
 func (s *Store) UpdateIncident(id string, e model.Event) error {
 	_, err := s.db.Exec(`UPDATE incidents SET end_time=?, duration_sec=?,
 		peak_score=?, peak_cpu=?, peak_mem=?, peak_io_psi=?,
-		narrative=?
+		narrative=?, capture_json=COALESCE(NULLIF(?, ''), capture_json)
 		WHERE id=?`,
 		e.EndTime, e.Duration, e.PeakScore,
 		e.PeakCPUBusy, e.PeakMemUsedPct, e.PeakIOPSI,
-		"", id)
+		"", captureJSON(e), id)
 	return err
 }
 
@@ -246,7 +305,7 @@ func (s *Store) GetIncident(id string) (*IncidentRecord, error) {
 	row := s.db.QueryRow(`SELECT id, fingerprint, start_time, end_time, duration_sec,
 		peak_health, bottleneck, peak_score, culprit_process, culprit_pid,
 		culprit_cgroup, causal_chain, narrative, evidence_json,
-		peak_cpu, peak_mem, peak_io_psi
+		peak_cpu, peak_mem, peak_io_psi, COALESCE(capture_json, '')
 		FROM incidents WHERE id=?`, id)
 
 	var r IncidentRecord
@@ -254,7 +313,7 @@ func (s *Store) GetIncident(id string) (*IncidentRecord, error) {
 	err := row.Scan(&r.ID, &r.Fingerprint, &r.StartTime, &endTime, &r.DurationSec,
 		&r.PeakHealth, &r.Bottleneck, &r.PeakScore, &r.CulpritProcess, &r.CulpritPID,
 		&r.CulpritCgroup, &r.CausalChain, &r.Narrative, &r.EvidenceJSON,
-		&r.PeakCPU, &r.PeakMem, &r.PeakIOPSI)
+		&r.PeakCPU, &r.PeakMem, &r.PeakIOPSI, &r.CaptureJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -269,7 +328,7 @@ func (s *Store) ListIncidents(limit, offset int) ([]IncidentRecord, error) {
 	rows, err := s.db.Query(`SELECT id, fingerprint, start_time, end_time, duration_sec,
 		peak_health, bottleneck, peak_score, culprit_process, culprit_pid,
 		culprit_cgroup, causal_chain, narrative, evidence_json,
-		peak_cpu, peak_mem, peak_io_psi
+		peak_cpu, peak_mem, peak_io_psi, COALESCE(capture_json, '')
 		FROM incidents ORDER BY start_time DESC LIMIT ? OFFSET ?`, limit, offset)
 	if err != nil {
 		return nil, err
@@ -284,7 +343,7 @@ func (s *Store) ListByFingerprint(fp string) ([]IncidentRecord, error) {
 	rows, err := s.db.Query(`SELECT id, fingerprint, start_time, end_time, duration_sec,
 		peak_health, bottleneck, peak_score, culprit_process, culprit_pid,
 		culprit_cgroup, causal_chain, narrative, evidence_json,
-		peak_cpu, peak_mem, peak_io_psi
+		peak_cpu, peak_mem, peak_io_psi, COALESCE(capture_json, '')
 		FROM incidents WHERE fingerprint=? ORDER BY start_time DESC`, fp)
 	if err != nil {
 		return nil, err
@@ -383,7 +442,7 @@ func scanIncidents(rows *sql.Rows) ([]IncidentRecord, error) {
 		if err := rows.Scan(&r.ID, &r.Fingerprint, &r.StartTime, &endTime, &r.DurationSec,
 			&r.PeakHealth, &r.Bottleneck, &r.PeakScore, &r.CulpritProcess, &r.CulpritPID,
 			&r.CulpritCgroup, &r.CausalChain, &r.Narrative, &r.EvidenceJSON,
-			&r.PeakCPU, &r.PeakMem, &r.PeakIOPSI); err != nil {
+			&r.PeakCPU, &r.PeakMem, &r.PeakIOPSI, &r.CaptureJSON); err != nil {
 			return nil, err
 		}
 		if endTime.Valid {
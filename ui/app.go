@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"syscall"
@@ -15,6 +16,7 @@ import (
 	"github.com/ftahirops/xtop/collector/phpfpm"
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // Page identifies the current screen.
@@ -83,47 +85,6 @@ type saveConfirmMsg struct {
 	err  error
 }
 
-// frozenProc tracks a process frozen by Contain mode.
-type frozenProc struct {
-	Comm      string
-	WritePath string
-	FrozenAt  time.Time
-	StartTime string // /proc/PID/stat field 22 — unique per PID lifecycle
-}
-
-// readProcStartTime reads field 22 (starttime) from /proc/PID/stat.
-// Returns empty string on error. Used to detect PID reuse.
-func readProcStartTime(pid int) string {
-	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
-	if err != nil {
-		return ""
-	}
-	content := string(data)
-	closeIdx := strings.LastIndex(content, ")")
-	if closeIdx < 0 || closeIdx+2 >= len(content) {
-		return ""
-	}
-	fields := strings.Fields(content[closeIdx+2:])
-	if len(fields) < 20 {
-		return ""
-	}
-	return fields[19] // field 22 = starttime (0-indexed after comm: index 19)
-}
-
-// verifyFrozenPID checks that a frozen PID still belongs to the same process.
-func verifyFrozenPID(pid int, fp frozenProc) bool {
-	st := readProcStartTime(pid)
-	return st != "" && st == fp.StartTime
-}
-
-// freezeDenylist prevents critical system processes from being frozen or killed.
-var freezeDenylist = map[string]bool{
-	"mysqld": true, "mariadbd": true, "postgres": true, "mongod": true,
-	"redis-server": true, "journald": true, "systemd": true,
-	"systemd-journald": true, "sshd": true, "kubelet": true,
-	"containerd": true, "dockerd": true, "crio": true, "xtop": true,
-}
-
 // Model is the bubbletea model.
 type Model struct {
 	ticker   engine.Ticker
@@ -144,6 +105,17 @@ type Model struct {
 	rates  *model.RateSnapshot
 	result *model.AnalysisResult
 
+	// Compare-host mode: a second source (recording or another daemon)
+	// rendered side by side with this one. See WithCompare.
+	compare       engine.Ticker
+	compareLabel  string
+	compareMode   bool
+	compareProbe  *engine.ProbeManager
+	compareSnap   *model.Snapshot
+	compareRates  *model.RateSnapshot
+	compareResult *model.AnalysisResult
+	comparePane   bool // this copy renders host B (see renderCompare)
+
 	// Navigation
 	page        Page
 	showHelp    bool
@@ -157,14 +129,38 @@ type Model struct {
 	saveMsg     string
 	saveMsgTime time.Time
 
+	// Inline image protocol for Timeline charts (imageNone = block charts)
+	imageProto imageProto
+
 	// Cgroup page state
 	cgSortCol  cgSort
 	cgSelected int
 
+	// Cgroup detail view (Enter on the CGroups page). Re-read every tick
+	// while open; cgDetailPrev turns cumulative counters into rates. Only
+	// read when cgDetailLive, otherwise rendered from the snapshot.
+	cgDetailMode bool
+	cgDetailPath string
+	cgDetail     *model.CgroupDetail
+	cgDetailPrev *model.CgroupDetail
+	cgDetailErr  error
+
 	// Events page state
 	eventDetector *engine.EventDetector
 	evtSelected   int
 
+	// Events page filter ('f'): query text as typed and the parsed filter
+	evtFilterInputActive bool
+	evtFilterInput       string
+	evtFilterQuery       string
+	evtFilter            engine.EventFilter
+
+	// Operator note entry ('c'): free-text annotation attached to the
+	// incident timeline and persisted to <datadir>/notes.jsonl
+	dataDir         string
+	noteInputActive bool
+	noteInput       string
+
 	// Overview layout mode
 	layoutMode      LayoutMode
 	overviewCompact bool // true = clean summary (default), false = full detail with sparklines
@@ -175,14 +171,8 @@ type Model struct {
 	// Server identity roles (loaded from config)
 	serverRoles []string
 
-	// DiskGuard mode
-	diskGuardMode       string
-	diskGuardMsg        string             // action feedback message
-	diskGuardMsgT       time.Time          // when message was set
-	frozenPIDs          map[int]frozenProc // PIDs frozen by Contain mode
-	lastActionTime      time.Time          // cooldown: last auto-action time
-	incidentActionCount int                // max actions per incident
-	stableStart         time.Time          // tracks when disk became stable OK
+	// DiskGuard containment policy (mode, frozen PIDs, last action)
+	diskGuard *engine.DiskGuard
 
 	// Beginner mode / onboarding
 	showOnboarding bool // true when ExperienceLevel == "" (first run)
@@ -210,8 +200,8 @@ type Model struct {
 	threshShowAll bool // false=anomalies only; true=show all
 
 	// Probe page collapsible sections
-	probeSectionCursor   int       // 0-12: highlighted section
-	probeSectionExpanded [13]bool  // which sections are expanded
+	probeSectionCursor   int                 // 0-13: highlighted section
+	probeSectionExpanded [probSecCount]bool  // which sections are expanded
 	probeAutoExpanded    bool      // auto-expand done after probe completes
 
 	// Apps page
@@ -282,6 +272,10 @@ func NewModel(ticker engine.Ticker, interval time.Duration, dataDir string) Mode
 		if err == nil && len(events) > 0 {
 			detector.LoadEvents(events)
 		}
+		notes, err := engine.ReadStandaloneNotes(filepath.Join(dataDir, engine.NotesFileName), events)
+		if err == nil && len(notes) > 0 {
+			detector.LoadNotes(notes)
+		}
 	}
 
 	// Load default layout and roles from user config
@@ -297,25 +291,32 @@ func NewModel(ticker engine.Ticker, interval time.Duration, dataDir string) Mode
 	beginnerMode := cfg.ExperienceLevel == "beginner"
 
 	base := ticker.Base()
+	if base != nil {
+		reloadRuntimeConfig(base)
+	}
 	return Model{
 		ticker:         ticker,
 		engine:         base,
 		interval:       interval,
 		eventDetector:  detector,
+		dataDir:        dataDir,
 		layoutMode:     layout,
 		serverRoles:    roles,
 		probeManager:   engine.NewProbeManager(),
-		diskGuardMode:  "Monitor",
-		frozenPIDs:     make(map[int]frozenProc),
+		diskGuard:      engine.NewDiskGuard(),
 		showOnboarding:  showOnboarding,
 		beginnerMode:    beginnerMode,
 		appsViewCompact:   false,
 		overviewCompact:   true,
 		containerResolver: collector.NewContainerResolver(),
+		imageProto:        detectImageProto(os.Getenv, cfg.ChartImages),
 	}
 }
 
 func (m Model) Init() tea.Cmd {
+	if m.compare != nil {
+		return tea.Batch(tick(m.interval), collectOnce(m.ticker), collectCompare(m.compare))
+	}
 	return tea.Batch(tick(m.interval), collectOnce(m.ticker))
 }
 
@@ -340,7 +341,7 @@ func collectSmartAsync(smart *collector.SMARTCollector) tea.Cmd {
 }
 
 // saveRCA saves the current analysis state to a JSON file.
-func saveRCA(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult) tea.Cmd {
+func saveRCA(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult, notes []model.TimelineEntry) tea.Cmd {
 	return func() tea.Msg {
 		ts := time.Now().Format("20060102-150405")
 		path := fmt.Sprintf("xtop-rca-%s.json", ts)
@@ -351,6 +352,9 @@ func saveRCA(snap *model.Snapshot, rates *model.RateSnapshot, result *model.Anal
 			"rates":     rates,
 			"analysis":  result,
 		}
+		if len(notes) > 0 {
+			data["notes"] = notes
+		}
 
 		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
 		if err != nil {
@@ -443,10 +447,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					pid := m.signalTargetPID
 					comm := m.signalTargetComm
 					// Verify PID identity
-					st := readProcStartTime(pid)
+					st := engine.ReadProcStartTime(pid)
 					if st == "" {
 						m.signalMsg = fmt.Sprintf("PID %d no longer exists", pid)
-					} else if freezeDenylist[comm] && sig.Sig == syscall.SIGKILL {
+					} else if engine.FreezeDenylist[comm] && sig.Sig == syscall.SIGKILL {
 						m.signalMsg = fmt.Sprintf("Blocked: %s (PID %d) is in denylist", comm, pid)
 					} else {
 						err := syscall.Kill(pid, sig.Sig)
@@ -475,10 +479,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					sig := signalList[m.signalMenuIdx]
 					pid := m.signalTargetPID
 					comm := m.signalTargetComm
-					st := readProcStartTime(pid)
+					st := engine.ReadProcStartTime(pid)
 					if st == "" {
 						m.signalMsg = fmt.Sprintf("PID %d no longer exists", pid)
-					} else if freezeDenylist[comm] && sig.Sig == syscall.SIGKILL {
+					} else if engine.FreezeDenylist[comm] && sig.Sig == syscall.SIGKILL {
 						m.signalMsg = fmt.Sprintf("Blocked: %s (PID %d) is in denylist", comm, pid)
 					} else {
 						err := syscall.Kill(pid, sig.Sig)
@@ -501,6 +505,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+		// Note entry: intercept all keys
+		if m.noteInputActive {
+			return m.handleNoteInput(msg.String()), nil
+		}
+		// Events filter entry: intercept all keys
+		if m.evtFilterInputActive {
+			return m.handleEventFilterInput(msg.String()), nil
+		}
 		// Page picker: intercept all keys
 		if m.pagePickerActive {
 			return m.handlePagePicker(msg.String()), nil
@@ -546,7 +558,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "n":
 			// Step one frame when paused in replay mode
 			if m.paused {
-				if p, ok := m.ticker.(*engine.Player); ok {
+				if p := m.replayPlayer(); p != nil {
 					snap, rates, result := p.Tick()
 					if snap != nil {
 						m.snap = snap
@@ -556,74 +568,65 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 			}
+		case "left":
+			if p := m.replayPlayer(); p != nil {
+				m.replaySeek(p, p.Position()-1)
+			}
+		case "right":
+			if p := m.replayPlayer(); p != nil {
+				m.replaySeek(p, p.Position()+1)
+			}
 		case "[":
-			if p, ok := m.ticker.(*engine.Player); ok {
-				target := p.Index() - 10
-				snap, rates, result := p.Seek(target)
-				if snap != nil {
-					m.snap = snap
-					m.rates = rates
-					m.result = result
-					m.eventDetector.Process(snap, rates, result)
-				}
+			if p := m.replayPlayer(); p != nil {
+				m.replaySeek(p, p.Position()-10)
 			}
 		case "]":
-			if p, ok := m.ticker.(*engine.Player); ok {
-				target := p.Index() + 10
-				snap, rates, result := p.Seek(target)
-				if snap != nil {
-					m.snap = snap
-					m.rates = rates
-					m.result = result
-					m.eventDetector.Process(snap, rates, result)
-				}
+			if p := m.replayPlayer(); p != nil {
+				m.replaySeek(p, p.Position()+10)
 			}
 		case "{":
-			if p, ok := m.ticker.(*engine.Player); ok {
-				target := p.Index() - 60
-				snap, rates, result := p.Seek(target)
-				if snap != nil {
-					m.snap = snap
-					m.rates = rates
-					m.result = result
-					m.eventDetector.Process(snap, rates, result)
-				}
+			if p := m.replayPlayer(); p != nil {
+				m.replaySeek(p, p.Position()-60)
 			}
 		case "}":
-			if p, ok := m.ticker.(*engine.Player); ok {
-				target := p.Index() + 60
-				snap, rates, result := p.Seek(target)
-				if snap != nil {
-					m.snap = snap
-					m.rates = rates
-					m.result = result
-					m.eventDetector.Process(snap, rates, result)
-				}
+			if p := m.replayPlayer(); p != nil {
+				m.replaySeek(p, p.Position()+60)
+			}
+		case "shift+left":
+			if p := m.replayPlayer(); p != nil {
+				m.replaySeekTime(p, -replaySeekStep)
+			}
+		case "shift+right":
+			if p := m.replayPlayer(); p != nil {
+				m.replaySeekTime(p, replaySeekStep)
+			}
+		case "<":
+			if p := m.replayPlayer(); p != nil {
+				m.replayMarkerSeek(p, -1)
+			}
+		case ">":
+			if p := m.replayPlayer(); p != nil {
+				m.replayMarkerSeek(p, 1)
 			}
 		case "J":
-			if p, ok := m.ticker.(*engine.Player); ok {
-				snap, rates, result := p.Seek(0)
-				if snap != nil {
-					m.snap = snap
-					m.rates = rates
-					m.result = result
-					m.eventDetector.Process(snap, rates, result)
-				}
+			if p := m.replayPlayer(); p != nil {
+				m.replaySeek(p, 0)
 			}
 		case "K":
-			if p, ok := m.ticker.(*engine.Player); ok {
-				snap, rates, result := p.Seek(p.Len() - 1)
-				if snap != nil {
-					m.snap = snap
-					m.rates = rates
-					m.result = result
-					m.eventDetector.Process(snap, rates, result)
-				}
+			if p := m.replayPlayer(); p != nil {
+				m.replaySeek(p, p.Len()-1)
+			}
+		case "=":
+			if m.compare == nil {
+				m.statusMessage = "no comparison host — start xtop with --compare <recording|socket>"
+				m.statusMessageAt = time.Now()
+			} else {
+				m.compareMode = !m.compareMode
 			}
 		case "S":
 			// Save RCA to file (works on any page)
 			if m.snap != nil {
-				return m, saveRCA(m.snap, m.rates, m.result)
+				return m, saveRCA(m.snap, m.rates, m.result, m.allNotes())
 			}
 		case "0":
 			m.page = PageOverview
@@ -684,6 +687,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.dockerStackCursor = 0
 				m.dockerStackExpanded = nil
 				m.dockerContainerIdx = 0
+			} else if m.page == PageCgroups && m.cgDetailMode {
+				m.closeCgroupDetail()
+			} else if m.page == PageEvents && m.evtFilterQuery != "" {
+				m.evtFilter = engine.EventFilter{}
+				m.evtFilterQuery = ""
+				m.evtSelected = 0
 			} else {
 				m.page = PageOverview
 				m.scroll = 0
@@ -704,6 +713,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else if m.snap != nil && len(m.snap.Global.PHPFPM.Apps) > 0 {
 					m.phpfpmSelectedIdx = (m.phpfpmSelectedIdx + 1) % len(m.snap.Global.PHPFPM.Apps)
 				}
+			} else if m.page == PageCgroups && m.cgDetailMode {
+				m.scroll++
 			} else if m.page == PageCgroups {
 				maxIdx := 0
 				if m.snap != nil && len(m.snap.Cgroups) > 0 {
@@ -713,7 +724,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.cgSelected++
 				}
 			} else if m.page == PageEvents {
-				_, completed := m.eventDetector.AllEvents()
+				_, completed, _ := m.filteredEvents()
 				if m.evtSelected < len(completed)-1 {
 					m.evtSelected++
 				}
@@ -742,6 +753,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					n := len(m.snap.Global.PHPFPM.Apps)
 					m.phpfpmSelectedIdx = (m.phpfpmSelectedIdx + n - 1) % n
 				}
+			} else if m.page == PageCgroups && m.cgDetailMode {
+				if m.scroll > 0 {
+					m.scroll--
+				}
 			} else if m.page == PageCgroups {
 				if m.cgSelected > 0 {
 					m.cgSelected--
@@ -754,7 +769,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.scroll--
 			}
 		case "s":
-			if m.page == PageCgroups {
+			if m.page == PageCgroups && !m.cgDetailMode {
 				m.cgSortCol = (m.cgSortCol + 1) % cgSortCount
 			}
 		case "t":
@@ -831,6 +846,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 			}
+			// CGroups page: open the detail view for the selected row
+			if m.page == PageCgroups && !m.cgDetailMode {
+				if m.snap != nil {
+					rows := buildCgroupRows(m.snap, m.rates, m.cgSortCol)
+					if m.cgSelected < len(rows) {
+						m.cgDetailMode = true
+						m.cgDetailPath = rows[m.cgSelected].path
+						m.cgDetail, m.cgDetailPrev, m.cgDetailErr = nil, nil, nil
+						m.scroll = 0
+						if m.cgDetailLive() {
+							return m, readCgroupDetailAsync(m.cgDetailPath)
+						}
+					}
+				}
+				return m, nil
+			}
 			// PHP-FPM page: drill into selected site / collapse back
 			if m.page == PagePHPFPM {
 				if !m.phpfpmDetailMode {
@@ -922,7 +953,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.scroll = 0
 				m.explainScroll = 0
 			} else if m.page == PageEvents {
-				_, completed := m.eventDetector.AllEvents()
+				_, completed, _ := m.filteredEvents()
 				if m.evtSelected < len(completed) {
 					evt := completed[m.evtSelected]
 					m.page = bottleneckToPage(evt.Bottleneck)
@@ -983,7 +1014,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "P":
 			// Export incident report as markdown (was E, moved for explain panel)
 			active, completed := m.eventDetector.AllEvents()
-			return m, exportIncidentMarkdown(m.snap, m.rates, m.result, active, completed)
+			return m, exportIncidentMarkdown(m.snap, m.rates, m.result, active, completed, m.eventDetector.Notes())
 		case "D":
 			// Page-specific: D on the PHP-FPM page triggers a deep
 			// filesystem scan for the focused site (or all if no detail).
@@ -1013,6 +1044,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.page = PageDiag
 			m.scroll = 0
 			m.explainScroll = 0
+		case "ctrl+r":
+			// Re-read config.json (thresholds, probe targets, guard
+			// policy) into the running engine — same path as SIGHUP in
+			// the daemon. History and events are untouched.
+			if m.replayPlayer() != nil || m.engine == nil {
+				m.statusMessage = "● config reload needs a live engine (not available in replay)"
+			} else if changes := reloadRuntimeConfig(m.engine); len(changes) == 0 {
+				m.statusMessage = "● config reloaded — no changes"
+			} else {
+				m.statusMessage = "● config reloaded — " + strings.Join(changes, "; ")
+			}
+			m.statusMessageAt = time.Now()
 		case "ctrl+d":
 			// Set current layout as default
 			if err := saveDefaultLayout(m.layoutMode); err != nil {
@@ -1108,61 +1151,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "m", "M":
 			// Cycle DiskGuard mode (only on DiskGuard page)
 			if m.page == PageDiskGuard {
-				switch m.diskGuardMode {
-				case "Monitor":
-					m.diskGuardMode = "DryRun"
-				case "DryRun":
-					m.diskGuardMode = "Contain"
-				case "Contain":
-					m.diskGuardMode = "Action"
-				default:
-					m.diskGuardMode = "Monitor"
-				}
+				m.diskGuard.CycleMode()
 			}
 		case "x", "X":
 			// Navigate to Intel page (unless on DiskGuard in Action mode)
-			if m.page != PageDiskGuard || m.diskGuardMode != "Action" {
+			if m.page != PageDiskGuard || m.diskGuard.Mode != engine.DiskGuardAction {
 				m.page = PageIntel
 				m.scroll = 0
 				m.explainScroll = 0
-			} else if m.page == PageDiskGuard && m.diskGuardMode == "Action" && m.rates != nil {
-				procs := make([]model.ProcessRate, len(m.rates.ProcessRates))
-				copy(procs, m.rates.ProcessRates)
-				sort.Slice(procs, func(i, j int) bool {
-					return procs[i].WriteMBs > procs[j].WriteMBs
-				})
-				if len(procs) > 0 && procs[0].WriteMBs > 0.1 {
-					pid := procs[0].PID
-					comm := procs[0].Comm
-					wp := procs[0].WritePath
-					if freezeDenylist[comm] {
-						m.diskGuardMsg = fmt.Sprintf("Skipped: PID %d (%s) is in denylist", pid, comm)
-					} else {
-						// #11: Verify PID identity before killing — store and re-check
-						st := readProcStartTime(pid)
-						if st == "" {
-							m.diskGuardMsg = fmt.Sprintf("PID %d no longer exists", pid)
-						} else if st2 := readProcStartTime(pid); st2 != st {
-							m.diskGuardMsg = fmt.Sprintf("PID %d was reused, aborting kill", pid)
-						} else {
-							err := syscall.Kill(pid, syscall.SIGKILL)
-							if err != nil {
-								m.diskGuardMsg = fmt.Sprintf("Failed to kill PID %d (%s): %v", pid, comm, err)
-							} else {
-								if wp != "" {
-									m.diskGuardMsg = fmt.Sprintf("KILLED PID %d (%s) — was writing to %s", pid, comm, wp)
-								} else {
-									m.diskGuardMsg = fmt.Sprintf("KILLED PID %d (%s)", pid, comm)
-								}
-							}
-						}
-						delete(m.frozenPIDs, pid)
-					}
-					m.diskGuardMsgT = time.Now()
-				} else {
-					m.diskGuardMsg = "No active writer to kill"
-					m.diskGuardMsgT = time.Now()
-				}
+			} else if m.rates != nil {
+				m.diskGuard.KillTop(m.rates.ProcessRates)
 			}
 		case "z", "Z":
 			// Navigate to Proxmox page (only if Proxmox host)
@@ -1188,13 +1186,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.explainScroll = 0
 		case "H":
 			// Export HTML incident report
-			path, err := exportHTMLReport(m.snap, m.rates, m.result)
+			path, err := exportHTMLReport(m.snap, m.rates, m.result, m.engine.History)
 			if err != nil {
 				m.saveMsg = fmt.Sprintf("Export failed: %v", err)
 			} else {
 				m.saveMsg = fmt.Sprintf("HTML report: %s", path)
 			}
 			m.saveMsgTime = time.Now()
+		case "c":
+			// Annotate the current moment ("restarted php-fpm")
+			m.noteInputActive = true
+			m.noteInput = ""
 		case "/":
 			// Open page picker
 			m.pagePickerActive = true
@@ -1202,44 +1204,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.pagePickerCursor = 0
 		case "f", "F":
 			// Network page: toggle focus mode
-			if m.page == PageNetwork {
+			if m.page == PageEvents {
+				// Events page: open the filter prompt, pre-filled for editing
+				m.evtFilterInputActive = true
+				m.evtFilterInput = m.evtFilterQuery
+			} else if m.page == PageNetwork {
 				m.netFocusMode = !m.netFocusMode
-			} else if m.page == PageDiskGuard && (m.diskGuardMode == "Contain" || m.diskGuardMode == "Action") && m.rates != nil {
-				procs := make([]model.ProcessRate, len(m.rates.ProcessRates))
-				copy(procs, m.rates.ProcessRates)
-				sort.Slice(procs, func(i, j int) bool {
-					return procs[i].WriteMBs > procs[j].WriteMBs
-				})
-				if len(procs) > 0 && procs[0].WriteMBs > 0.1 {
-					pid := procs[0].PID
-					comm := procs[0].Comm
-					if freezeDenylist[comm] {
-						m.diskGuardMsg = fmt.Sprintf("Skipped: PID %d (%s) is in denylist", pid, comm)
-					} else if _, already := m.frozenPIDs[pid]; already {
-						m.diskGuardMsg = fmt.Sprintf("PID %d (%s) already frozen", pid, comm)
-					} else {
-						// #11: Verify PID still exists before sending SIGSTOP
-						st := readProcStartTime(pid)
-						if st == "" {
-							m.diskGuardMsg = fmt.Sprintf("PID %d no longer exists", pid)
-							m.diskGuardMsgT = time.Now()
-							break
-						}
-						err := syscall.Kill(pid, syscall.SIGSTOP)
-						if err != nil {
-							m.diskGuardMsg = fmt.Sprintf("Failed to freeze PID %d: %v", pid, err)
-						} else {
-							m.frozenPIDs[pid] = frozenProc{
-								Comm:      comm,
-								WritePath: procs[0].WritePath,
-								FrozenAt:  time.Now(),
-								StartTime: st,
-							}
-							m.diskGuardMsg = fmt.Sprintf("FROZEN PID %d (%s) — writing paused", pid, comm)
-						}
-					}
-					m.diskGuardMsgT = time.Now()
-				}
+			} else if m.page == PageDiskGuard && (m.diskGuard.Mode == engine.DiskGuardContain || m.diskGuard.Mode == engine.DiskGuardAction) && m.rates != nil {
+				m.diskGuard.FreezeTop(m.rates.ProcessRates)
 			}
 		case "f9":
 			// Open signal menu (like htop F9) — works on pages with process lists
@@ -1262,28 +1234,45 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			// Resume all frozen processes (verify PID identity first)
-			if m.page == PageDiskGuard && len(m.frozenPIDs) > 0 {
-				resumed := 0
-				for pid, fp := range m.frozenPIDs {
-					if verifyFrozenPID(pid, fp) {
-						if err := syscall.Kill(pid, syscall.SIGCONT); err == nil {
-							resumed++
-						}
-					}
-					delete(m.frozenPIDs, pid)
-				}
-				m.diskGuardMsg = fmt.Sprintf("RESUMED %d frozen process(es)", resumed)
-				m.diskGuardMsgT = time.Now()
+			if m.page == PageDiskGuard && len(m.diskGuard.Frozen) > 0 {
+				m.diskGuard.ResumeAll()
 			}
 		}
+	case tea.MouseMsg:
+		// Mouse reporting is only turned on for replay, where the top row
+		// is the scrubber bar.
+		if !m.showOnboarding && !m.showHelp && !m.pagePickerActive && m.snap != nil {
+			m.replayMouse(msg)
+		}
 	case tea.WindowSizeMsg:
+		// A shrinking terminal reflows the previous frame's rows before we
+		// get to redraw, leaving stale fragments above the new frame that
+		// the renderer doesn't know to erase. Clear on shrink; growth is
+		// handled by the normal repaint.
+		shrunk := msg.Width < m.width || msg.Height < m.height
 		m.width = msg.Width
 		m.height = msg.Height
+		if shrunk {
+			return m, tea.ClearScreen
+		}
 	case tickMsg:
 		if m.paused {
 			return m, nil
 		}
-		return m, tea.Batch(tick(m.interval), collectOnce(m.ticker), collectSmartAsync(m.engine.Smart))
+		cmds := []tea.Cmd{tick(m.interval), collectOnce(m.ticker), collectSmartAsync(m.engine.Smart)}
+		if m.compare != nil {
+			cmds = append(cmds, collectCompare(m.compare))
+		}
+		if m.page == PageCgroups && m.cgDetailMode && m.cgDetailLive() {
+			cmds = append(cmds, readCgroupDetailAsync(m.cgDetailPath))
+		}
+		return m, tea.Batch(cmds...)
+	case compareMsg:
+		if !m.paused && msg.snap != nil {
+			m.compareSnap = msg.snap
+			m.compareRates = msg.rates
+			m.compareResult = msg.result
+		}
 	case collectMsg:
 		if !m.paused {
 			m.snap = msg.snap
@@ -1312,8 +1301,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.probeManager.State() == engine.ProbeDone && !m.probeAutoExpanded {
 				if f := m.probeManager.Findings(); f != nil {
 					m.probeAutoExpanded = true
-					sections := []int{probSecOffCPU, probSecIOLat, probSecLockWait, probSecTCPRetrans, probSecNetThruput, probSecTCPRTT, probSecTCPConnLat, probSecRunQLat, probSecWBStall, probSecPgFault, probSecSwapEvict, probSecSyscall, probSecSockIO}
-					counts := []int{len(f.OffCPUWaiters), len(f.IOLatency), len(f.LockWaiters), len(f.TCPRetrans), len(f.NetThroughput), len(f.TCPRTT), len(f.TCPConnLat), len(f.RunQLat), len(f.WBStall), len(f.PgFault), len(f.SwapEvict), len(f.SyscallDissect), len(f.SockIO)}
+					sections := []int{probSecOffCPU, probSecIOLat, probSecLockWait, probSecTCPRetrans, probSecNetThruput, probSecTCPRTT, probSecTCPConnLat, probSecRunQLat, probSecWBStall, probSecPgFault, probSecSwapEvict, probSecSyscall, probSecSockIO, probSecNetWait}
+					counts := []int{len(f.OffCPUWaiters), len(f.IOLatency), len(f.LockWaiters), len(f.TCPRetrans), len(f.NetThroughput), len(f.TCPRTT), len(f.TCPConnLat), len(f.RunQLat), len(f.WBStall), len(f.PgFault), len(f.SwapEvict), len(f.SyscallDissect), len(f.SockIO), len(f.NetWait)}
 					for i, c := range counts {
 						if c > 0 {
 							m.probeSectionExpanded[sections[i]] = true
@@ -1330,7 +1319,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 			// DiskGuard Contain mode: auto-freeze top writers when CRIT
-			m.diskGuardContain()
+			if m.result != nil && m.rates != nil {
+				m.diskGuard.Tick(m.result.DiskGuardWorst, m.rates.ProcessRates)
+			}
 			// Sticky RCA: pin significant findings so they persist after recovery
 			m.updatePinnedRCA()
 			// Sticky network intelligence summary
@@ -1353,11 +1344,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.saveMsgTime = time.Now()
 	case smartMsg:
 		m.cachedSmart = msg.disks
+	case cgroupDetailMsg:
+		if m.cgDetailMode && msg.detail.Path == m.cgDetailPath {
+			m.cgDetailErr = msg.err
+			if msg.err == nil {
+				m.cgDetailPrev = m.cgDetail
+				d := msg.detail
+				m.cgDetail = &d
+			}
+		}
 	}
 	return m, nil
 }
 
 func (m Model) View() string {
+	if m.width > 0 && termTooSmall(m.width, m.height) {
+		return renderTooSmall(m.width, m.height)
+	}
+	v := fitFrame(m.renderView(), m.width, m.height)
+	// Kitty images live on their own layer and survive text redraws, so
+	// clear them on any frame that doesn't re-place them.
+	if m.imageProto == imageKitty && !strings.Contains(v, "\x1b_Ga=T") {
+		v = kittyDeleteAll + v
+	}
+	return v
+}
+
+func (m Model) renderView() string {
 	if m.showOnboarding {
 		if m.width == 0 {
 			return "Loading..."
@@ -1391,73 +1404,15 @@ func (m Model) View() string {
 		}
 	}
 
-	smartDisks := m.cachedSmart
-
 	// Sticky RCA: use pinned result for RCA-heavy views (overview, beginner, explain)
 	// Live metrics on detail pages always use current m.result
 	rcaResult, resolvedAgo := m.displayResult()
 
 	var content string
-	// Beginner mode: render simplified page on overview
-	if m.beginnerMode && m.page == PageOverview {
-		content = renderBeginnerPage(m.snap, m.rates, rcaResult, resolvedAgo, renderW, m.height)
+	if m.compareMode && m.compare != nil {
+		content = m.renderCompare(renderW, rcaResult, resolvedAgo)
 	} else {
-		switch m.page {
-		case PageOverview:
-			content = renderOverview(m.snap, m.rates, rcaResult, m.engine.History, smartDisks, m.probeManager, m.layoutMode, m.overviewCompact, renderW, m.height, m.intermediateMode)
-		case PageCPU:
-			content = renderCPUPage(m.snap, m.rates, m.result, m.probeManager, renderW, m.height, m.intermediateMode)
-		case PageMemory:
-			content = renderMemPage(m.snap, m.rates, m.result, m.probeManager, renderW, m.height, m.intermediateMode)
-		case PageIO:
-			content = renderIOPage(m.snap, m.rates, m.result, smartDisks, m.probeManager, renderW, m.height, m.intermediateMode)
-		case PageNetwork:
-			netSum, netResAgo := m.displayNetSummary()
-			content = renderNetPage(m.snap, m.rates, m.result, m.probeManager,
-				netSum, netResAgo,
-				m.netSectionCursor, m.netSectionExpanded, m.netFocusMode,
-				renderW, m.height)
-		case PageCgroups:
-			content = renderCgroupPage(m.snap, m.rates, m.result, m.probeManager, m.cgSortCol, m.cgSelected, renderW, m.height)
-		case PageTimeline:
-			content = renderTimelinePage(m.engine.History, renderW, m.height)
-		case PageEvents:
-			active, completed := m.eventDetector.AllEvents()
-			content = renderEventsPage(active, completed, m.evtSelected, renderW, m.height)
-		case PageProbe:
-			content = renderProbePage(m.probeManager, m.snap, renderW, m.height, m.probeSectionCursor, m.probeSectionExpanded, m.intermediateMode)
-		case PageThresholds:
-			content = renderThresholdsPage(m.snap, m.rates, m.result, renderW, m.height, m.threshShowAll)
-		case PageDiskGuard:
-			dgMsg := ""
-			if time.Since(m.diskGuardMsgT) < 10*time.Second {
-				dgMsg = m.diskGuardMsg
-			}
-			content = renderDiskGuardPage(m.snap, m.rates, m.result, smartDisks, m.probeManager, m.diskGuardMode, dgMsg, m.frozenPIDs, renderW, m.height)
-		case PageSecurity:
-			content = renderSecurityPage(m.snap, m.rates, m.result, m.probeManager,
-				m.secSectionCursor, m.secSectionExpanded,
-				renderW, m.height)
-		case PageDiag:
-			content = renderDiagPage(m.snap, m.rates, m.result, m.probeManager, renderW, m.height)
-		case PageIntel:
-			content = renderIntelPage(m.snap, m.rates, m.result, m.engine,
-				m.intelSectionCursor, m.intelSectionExpanded,
-				renderW, m.height)
-		case PageProxmox:
-			content = renderProxmoxPage(m.snap, m.rates, m.result, smartDisks, m.probeManager, renderW, m.height)
-		case PageApps:
-			content = renderAppsPage(m.snap, m.result, m.appsSelectedIdx, m.appsDetailMode,
-				m.appsViewCompact,
-				m.dockerStackCursor, m.dockerStackExpanded, m.dockerContainerIdx,
-				renderW, m.height)
-		case PageProfiler:
-			content = renderProfilerPage(m.snap, m.profSectionCursor, m.profSectionExpanded, renderW, m.height)
-		case PageGPU:
-			content = renderGPUPage(m.snap, renderW, m.height)
-		case PagePHPFPM:
-			content = renderPHPFPMPage(m.snap, m.phpfpmSelectedIdx, m.phpfpmDetailMode, m.phpfpmScrollY, renderW, m.height)
-		}
+		content = m.renderPage(renderW, rcaResult, resolvedAgo)
 	}
 
 	// Old explain verdict panel — uses pinned RCA for persistence
@@ -1509,13 +1464,28 @@ func (m Model) View() string {
 	if m.scroll > 0 && m.scroll < len(lines) {
 		lines = lines[m.scroll:]
 	}
-	// Trim to viewport height (leave room for status bar)
+	// Trim to viewport height (leave room for status bar, and for the
+	// scrubber pinned on top in replay mode)
 	maxLines := m.height - 2
+	player := m.replayPlayer()
+	if player != nil {
+		maxLines--
+	}
+	// Input prompts sit just above the status bar, outside the scrolled
+	// area, so a full page cannot push them off screen.
+	var prompts []string
+	if m.noteInputActive {
+		prompts = append(prompts, renderNotePrompt(m.noteInput))
+	}
+	if m.evtFilterInputActive {
+		prompts = append(prompts, renderEventFilterPrompt(m.evtFilterInput))
+	}
+	maxLines -= len(prompts)
 	truncated := maxLines > 0 && len(lines) > maxLines
 	if truncated {
 		lines = lines[:maxLines]
 	}
-	content = strings.Join(lines, "\n")
+	content = strings.Join(append(lines, prompts...), "\n")
 
 	// Pass scroll info to status bar
 	scrollInfo := ""
@@ -1525,6 +1495,9 @@ func (m Model) View() string {
 	}
 
 	final := content + "\n" + m.renderStatusBar(scrollInfo)
+	if player != nil {
+		final = newReplayScrubber(player, m.paused, m.width).render(player.Markers()) + "\n" + final
+	}
 
 	// Page picker overlay
 	if m.pagePickerActive {
@@ -1534,6 +1507,83 @@ func (m Model) View() string {
 	return final
 }
 
+// renderPage renders the current page body for m's host at width w.
+func (m Model) renderPage(renderW int, rcaResult *model.AnalysisResult, resolvedAgo int) string {
+	smartDisks := m.cachedSmart
+
+	var content string
+	// Beginner mode: render simplified page on overview
+	if m.beginnerMode && m.page == PageOverview {
+		content = renderBeginnerPage(m.snap, m.rates, rcaResult, resolvedAgo, renderW, m.height)
+	} else {
+		switch m.page {
+		case PageOverview:
+			content = renderOverview(m.snap, m.rates, rcaResult, m.engine.History, smartDisks, m.probeManager, m.layoutMode, m.overviewCompact, renderW, m.height, m.intermediateMode)
+		case PageCPU:
+			content = renderCPUPage(m.snap, m.rates, m.result, m.probeManager, renderW, m.height, m.intermediateMode)
+		case PageMemory:
+			content = renderMemPage(m.snap, m.rates, m.result, m.probeManager, renderW, m.height, m.intermediateMode)
+		case PageIO:
+			content = renderIOPage(m.snap, m.rates, m.result, smartDisks, m.probeManager, renderW, m.height, m.intermediateMode)
+		case PageNetwork:
+			netSum, netResAgo := m.displayNetSummary()
+			content = renderNetPage(m.snap, m.rates, m.result, m.probeManager,
+				netSum, netResAgo,
+				m.netSectionCursor, m.netSectionExpanded, m.netFocusMode,
+				renderW, m.height)
+		case PageCgroups:
+			if m.cgDetailMode && !m.cgDetailLive() {
+				content = renderCgroupDetail(m.snap, m.rates, m.engine.History, m.cgDetailPath,
+					recordedCgroupDetail(m.snap, m.cgDetailPath), nil, nil, true, renderW, m.height)
+			} else if m.cgDetailMode {
+				content = renderCgroupDetail(m.snap, m.rates, m.engine.History, m.cgDetailPath,
+					m.cgDetail, m.cgDetailPrev, m.cgDetailErr, false, renderW, m.height)
+			} else {
+				content = renderCgroupPage(m.snap, m.rates, m.result, m.probeManager, m.cgSortCol, m.cgSelected, renderW, m.height)
+			}
+		case PageTimeline:
+			content = renderTimelinePage(m.engine.History, renderW, m.height, m.chartImageProto())
+		case PageEvents:
+			active, completed, total := m.filteredEvents()
+			content = renderEventsPage(active, completed, m.eventDetector.Notes(), m.evtSelected, m.evtFilterQuery, total, renderW, m.height)
+		case PageProbe:
+			content = renderProbePage(m.probeManager, m.snap, renderW, m.height, m.probeSectionCursor, m.probeSectionExpanded, m.intermediateMode)
+		case PageThresholds:
+			content = renderThresholdsPage(m.snap, m.rates, m.result, renderW, m.height, m.threshShowAll)
+		case PageDiskGuard:
+			dgMsg := ""
+			if time.Since(m.diskGuard.MsgAt) < 10*time.Second {
+				dgMsg = m.diskGuard.Msg
+			}
+			content = renderDiskGuardPage(m.snap, m.rates, m.result, smartDisks, m.probeManager, m.diskGuard.Mode, dgMsg, m.diskGuard.Frozen, renderW, m.height)
+		case PageSecurity:
+			content = renderSecurityPage(m.snap, m.rates, m.result, m.probeManager,
+				m.secSectionCursor, m.secSectionExpanded,
+				renderW, m.height)
+		case PageDiag:
+			content = renderDiagPage(m.snap, m.rates, m.result, m.probeManager, renderW, m.height)
+		case PageIntel:
+			content = renderIntelPage(m.snap, m.rates, m.result, m.engine,
+				m.intelSectionCursor, m.intelSectionExpanded,
+				renderW, m.height)
+		case PageProxmox:
+			content = renderProxmoxPage(m.snap, m.rates, m.result, smartDisks, m.probeManager, renderW, m.height)
+		case PageApps:
+			content = renderAppsPage(m.snap, m.result, m.appsSelectedIdx, m.appsDetailMode,
+				m.appsViewCompact,
+				m.dockerStackCursor, m.dockerStackExpanded, m.dockerContainerIdx,
+				renderW, m.height)
+		case PageProfiler:
+			content = renderProfilerPage(m.snap, m.profSectionCursor, m.profSectionExpanded, renderW, m.height)
+		case PageGPU:
+			content = renderGPUPage(m.snap, renderW, m.height)
+		case PagePHPFPM:
+			content = renderPHPFPMPage(m.snap, m.phpfpmSelectedIdx, m.phpfpmDetailMode, m.phpfpmScrollY, renderW, m.height)
+		}
+	}
+	return content
+}
+
 func (m Model) renderStatusBar(scrollInfo string) string {
 	// Page keys
 	pageKey := func(i int) string {
@@ -1808,7 +1858,7 @@ func bottleneckToPage(bneck string) Page {
 
 // exportIncidentMarkdown generates a markdown report for the current state.
 func exportIncidentMarkdown(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult,
-	active *model.Event, completed []model.Event) tea.Cmd {
+	active *model.Event, completed []model.Event, notes []model.TimelineEntry) tea.Cmd {
 	return func() tea.Msg {
 		ts := time.Now().Format("20060102-150405")
 		path := fmt.Sprintf("xtop-incident-%s.md", ts)
@@ -1898,12 +1948,28 @@ func exportIncidentMarkdown(snap *model.Snapshot, rates *model.RateSnapshot, res
 			if active.CulpritProcess != "" {
 				sb.WriteString(fmt.Sprintf("- **Culprit**: %s (PID %d)\n", active.CulpritProcess, active.CulpritPID))
 			}
+			if c := active.CulpritCapture; c != nil {
+				sb.WriteString(fmt.Sprintf("\n### Culprit Command (PID %d)\n\n", c.PID))
+				sb.WriteString("```\n" + c.CommandLine() + "\n```\n")
+				if len(c.Env) > 0 {
+					sb.WriteString("\n<details><summary>Environment</summary>\n\n```\n")
+					sb.WriteString(strings.Join(c.Env, "\n"))
+					sb.WriteString("\n```\n</details>\n")
+				}
+				if c.Redacted > 0 {
+					sb.WriteString(fmt.Sprintf("\n*%d secret-looking value(s) masked*\n", c.Redacted))
+				}
+			}
 			if len(active.Timeline) > 0 {
 				sb.WriteString("\n### Timeline\n\n")
 				sb.WriteString("| Time | Event |\n")
 				sb.WriteString("|------|-------|\n")
 				for _, te := range active.Timeline {
-					sb.WriteString(fmt.Sprintf("| %s | %s |\n", te.Time.Format("15:04:05"), te.Message))
+					msg := te.Message
+					if te.IsNote() {
+						msg = "**Operator:** " + msg
+					}
+					sb.WriteString(fmt.Sprintf("| %s | %s |\n", util.FmtTime(te.Time, "15:04:05"), msg))
 				}
 			}
 			sb.WriteString("\n")
@@ -1924,12 +1990,25 @@ func exportIncidentMarkdown(snap *model.Snapshot, rates *model.RateSnapshot, res
 					dur = fmt.Sprintf("%dm%ds", evt.Duration/60, evt.Duration%60)
 				}
 				sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %d%% | %s |\n",
-					evt.StartTime.Format("15:04:05"), dur, evt.PeakHealth,
+					util.FmtTime(evt.StartTime, "15:04:05"), dur, evt.PeakHealth,
 					evt.Bottleneck, evt.PeakScore, evt.CulpritProcess))
 			}
 			sb.WriteString("\n")
 		}
 
+		// Operator notes taken outside an incident
+		if len(notes) > 0 {
+			sb.WriteString("## Operator Notes\n\n")
+			shown := notes
+			if len(shown) > 20 {
+				shown = shown[len(shown)-20:]
+			}
+			for _, n := range shown {
+				sb.WriteString(fmt.Sprintf("- %s — %s\n", util.FmtTime(n.Time, "2006-01-02 15:04:05"), n.Message))
+			}
+			sb.WriteString("\n")
+		}
+
 		sb.WriteString("---\n*Generated by xtop*\n")
 
 		if err := os.WriteFile(path, []byte(sb.String()), 0600); err != nil {
@@ -1964,14 +2043,20 @@ func (m Model) renderHelp() string {
 	sb.WriteString("\n")
 	sb.WriteString("  v/V       Cycle overview layout (F1-F6 for direct)\n")
 	sb.WriteString("  Ctrl+D    Set current layout as default\n")
+	sb.WriteString("  Ctrl+R    Reload config.json (thresholds, probes, guard)\n")
 	sb.WriteString("  a         Toggle auto-refresh (pause/resume)\n")
 	sb.WriteString("  n         Step one frame (replay mode while paused)\n")
-	sb.WriteString("  [ / ]     Replay seek -10 / +10 frames\n")
-	sb.WriteString("  { / }     Replay seek -60 / +60 frames\n")
+	sb.WriteString("  ← / →     Replay step one frame back / forward\n")
+	sb.WriteString("  ⇧← / ⇧→   Replay seek -1 / +1 minute of recording\n")
+	sb.WriteString("  < / >     Replay jump to previous / next incident (▲)\n")
+	sb.WriteString("  [ ] { }   Replay seek ±10 / ±60 frames\n")
 	sb.WriteString("  J / K     Replay jump to start / end\n")
+	sb.WriteString("  click     Replay seek to a point on the scrubber bar\n")
+	sb.WriteString("  =         Toggle side-by-side compare (with --compare)\n")
 	sb.WriteString("  F9        Send signal to process (kill/stop/term/HUP)\n")
 	sb.WriteString("  I         Start eBPF probe investigation (auto-detect)\n")
 	sb.WriteString("  S         Save RCA snapshot to JSON file\n")
+	sb.WriteString("  c         Add operator note to the incident timeline\n")
 	sb.WriteString("  E         Toggle explain side panel (metric glossary)\n")
 	sb.WriteString("  e         Toggle explain verdict panel (evidence detail)\n")
 	sb.WriteString("  N         Toggle verdict mode (adds ● OK / ▲ HIGH badges + abbreviation expansions)\n")
@@ -2001,8 +2086,8 @@ func (m Model) renderHelp() string {
 	sb.WriteString("\n")
 	sb.WriteString("  Network    Tab:sections  Enter:expand  A:all  C:collapse  F:focus\n")
 	sb.WriteString("  DiskGuard  m:cycle mode  f:freeze  x:kill  r:resume\n")
-	sb.WriteString("  CGroups    s:cycle sort  Enter:drilldown\n")
-	sb.WriteString("  Events     Enter:jump to detail\n")
+	sb.WriteString("  CGroups    s:cycle sort  Enter:detail  b:back\n")
+	sb.WriteString("  Events     Enter:jump to detail  c:add note  f:filter/search  b:clear filter\n")
 	sb.WriteString("  Thresholds t:toggle anomaly filter\n")
 	sb.WriteString("  Probe      Tab:sections  Enter:expand  A:all  C:collapse\n")
 	sb.WriteString("\n")
@@ -2109,7 +2194,7 @@ func (m *Model) renderSignalOverlay(content string, width int) string {
 		sb.WriteString(borderStyle.Render("│") + headerStyle.Render(fmt.Sprintf(" Send %s to PID %d (%s)?",
 			sig.Name, m.signalTargetPID, m.signalTargetComm)) + "\n")
 		sb.WriteString(borderStyle.Render("│") + "\n")
-		if freezeDenylist[m.signalTargetComm] && sig.Sig == syscall.SIGKILL {
+		if engine.FreezeDenylist[m.signalTargetComm] && sig.Sig == syscall.SIGKILL {
 			sb.WriteString(borderStyle.Render("│") + lipgloss.NewStyle().Foreground(lipgloss.Color("#ff5555")).Render(
 				fmt.Sprintf("  ⚠ %s is in denylist — SIGKILL blocked", m.signalTargetComm)) + "\n")
 		}
@@ -2320,119 +2405,14 @@ func (m *Model) autoExpandNetSection() {
 	}
 }
 
-// diskGuardContain handles automatic freeze/resume in Contain/DryRun mode.
-func (m *Model) diskGuardContain() {
-	if m.result == nil || m.rates == nil {
-		return
-	}
-
-	worst := m.result.DiskGuardWorst
-
-	// Track stable period for cooldown reset
-	if worst == "OK" {
-		if m.stableStart.IsZero() {
-			m.stableStart = time.Now()
-		}
-		// Reset incident action count after 30s continuous OK
-		if time.Since(m.stableStart) >= 30*time.Second {
-			m.incidentActionCount = 0
-		}
-	} else {
-		m.stableStart = time.Time{}
-	}
-
-	// DryRun mode: log what WOULD happen but don't send signals
-	if m.diskGuardMode == "DryRun" && worst == "CRIT" {
-		procs := make([]model.ProcessRate, len(m.rates.ProcessRates))
-		copy(procs, m.rates.ProcessRates)
-		sort.Slice(procs, func(i, j int) bool {
-			return procs[i].WriteMBs > procs[j].WriteMBs
-		})
-		for _, p := range procs {
-			if p.WriteMBs < 0.5 {
-				break
-			}
-			target := p.WritePath
-			if target == "" {
-				target = "unknown"
-			}
-			m.diskGuardMsg = fmt.Sprintf("Would freeze: PID %d (%s) writing %.1f MB/s to %s", p.PID, p.Comm, p.WriteMBs, target)
-			m.diskGuardMsgT = time.Now()
-			break // only show top writer
-		}
-	}
-
-	// Contain mode: auto-freeze top writers when CRIT
-	if m.diskGuardMode == "Contain" && worst == "CRIT" {
-		// Cooldown: 60s after last action
-		if !m.lastActionTime.IsZero() && time.Since(m.lastActionTime) < 60*time.Second {
-			return
-		}
-		// Max 1 auto-freeze per incident
-		if m.incidentActionCount >= 1 {
-			return
-		}
-
-		procs := make([]model.ProcessRate, len(m.rates.ProcessRates))
-		copy(procs, m.rates.ProcessRates)
-		sort.Slice(procs, func(i, j int) bool {
-			return procs[i].WriteMBs > procs[j].WriteMBs
-		})
-
-		for _, p := range procs {
-			if p.WriteMBs < 0.5 {
-				break
-			}
-			if _, already := m.frozenPIDs[p.PID]; already {
-				continue
-			}
-			// Denylist check
-			if freezeDenylist[p.Comm] {
-				m.diskGuardMsg = fmt.Sprintf("Skipped: PID %d (%s) is in denylist", p.PID, p.Comm)
-				m.diskGuardMsgT = time.Now()
-				continue
-			}
-			st := readProcStartTime(p.PID)
-			err := syscall.Kill(p.PID, syscall.SIGSTOP)
-			if err == nil {
-				m.frozenPIDs[p.PID] = frozenProc{
-					Comm:      p.Comm,
-					WritePath: p.WritePath,
-					FrozenAt:  time.Now(),
-					StartTime: st,
-				}
-				m.diskGuardMsg = fmt.Sprintf("AUTO-FROZEN PID %d (%s) — disk CRIT, writing paused", p.PID, p.Comm)
-				m.diskGuardMsgT = time.Now()
-				m.lastActionTime = time.Now()
-				m.incidentActionCount++
-			}
-			break // only freeze one per tick
-		}
-	}
-
-	// Auto-resume when disk drops to OK (any mode) — only after 30s continuous OK
-	if worst == "OK" && len(m.frozenPIDs) > 0 && !m.stableStart.IsZero() && time.Since(m.stableStart) >= 30*time.Second {
-		resumed := 0
-		for pid, fp := range m.frozenPIDs {
-			if verifyFrozenPID(pid, fp) {
-				if err := syscall.Kill(pid, syscall.SIGCONT); err == nil {
-					resumed++
-				}
-			}
-			delete(m.frozenPIDs, pid)
-		}
-		if resumed > 0 {
-			m.diskGuardMsg = fmt.Sprintf("AUTO-RESUMED %d process(es) — disk OK for 30s", resumed)
-			m.diskGuardMsgT = time.Now()
-		}
-	}
-
-	// Clean up dead or reused PIDs from frozen map
-	for pid, fp := range m.frozenPIDs {
-		if !verifyFrozenPID(pid, fp) {
-			delete(m.frozenPIDs, pid)
-		}
+// chartImageProto returns the image protocol for this frame. Images are
+// anchored to screen rows, so anything that shifts or covers the page
+// (scrolling, side panel, overlays) falls back to block charts.
+func (m Model) chartImageProto() imageProto {
+	if m.scroll > 0 || m.explainPanelOpen || m.showExplain || m.pagePickerActive || m.signalMode {
+		return imageNone
 	}
+	return m.imageProto
 }
 
 // injectClock overlays "HH:MM:SS  every Ns" on the top-right of the first content line.
@@ -2441,7 +2421,7 @@ func (m Model) injectClock(content string) string {
 		return content
 	}
 
-	now := time.Now().Format("15:04:05")
+	now := util.FmtTime(time.Now(), "15:04:05")
 	intervalStr := fmt.Sprintf("%.0fs", m.interval.Seconds())
 	clock := dimStyle.Render(now+"  every "+intervalStr)
 	clockW := lipgloss.Width(clock)
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CastWriter tees the TUI's terminal output into an asciinema v2 cast so
+// the exact screens an operator saw during an incident can be replayed
+// (`asciinema play`) or attached to a post-mortem next to the -record data
+// file. It stands in for the terminal as the program's output: everything
+// goes to the terminal first, and cast write errors never reach the TUI —
+// the first one is kept and returned by Close.
+type CastWriter struct {
+	mu      sync.Mutex
+	term    *os.File
+	cast    *bufio.Writer
+	start   time.Time
+	now     func() time.Time // test hook
+	partial []byte           // incomplete UTF-8 tail held for the next write
+	err     error
+}
+
+// castHeader is the first line of an asciinema v2 file.
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// NewCastWriter writes the cast header for a width×height terminal to w
+// and returns a writer to hand to tea.WithOutput in place of term.
+func NewCastWriter(term *os.File, w io.Writer, width, height int, title string) (*CastWriter, error) {
+	c := &CastWriter{term: term, cast: bufio.NewWriter(w), now: time.Now}
+	c.start = c.now()
+	hdr := castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: c.start.Unix(),
+		Title:     title,
+		Env:       map[string]string{"TERM": os.Getenv("TERM"), "SHELL": os.Getenv("SHELL")},
+	}
+	data, err := json.Marshal(hdr)
+	if err != nil {
+		return nil, err
+	}
+	c.cast.Write(append(data, '\n'))
+	if err := c.cast.Flush(); err != nil {
+		return nil, fmt.Errorf("write cast header: %w", err)
+	}
+	return c, nil
+}
+
+// Write sends p to the terminal and appends it to the cast as an output
+// event. A multi-byte character split across writes is held back until
+// it is complete, since cast events must be valid UTF-8.
+func (c *CastWriter) Write(p []byte) (int, error) {
+	n, err := c.term.Write(p)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data := append(c.partial, p[:n]...)
+	cut := len(data)
+	for i := 1; i <= utf8.UTFMax && i <= len(data); i++ {
+		if utf8.RuneStart(data[len(data)-i]) {
+			if !utf8.FullRune(data[len(data)-i:]) {
+				cut = len(data) - i
+			}
+			break
+		}
+	}
+	c.partial = append([]byte(nil), data[cut:]...)
+	if cut > 0 {
+		c.event("o", string(data[:cut]))
+	}
+	return n, err
+}
+
+// Resize records a terminal size change as an asciinema "r" event.
+func (c *CastWriter) Resize(width, height int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.event("r", fmt.Sprintf("%dx%d", width, height))
+}
+
+// Filter is a tea.WithFilter hook that records window resizes so the cast
+// replays at the size the operator was looking at.
+func (c *CastWriter) Filter(_ tea.Model, msg tea.Msg) tea.Msg {
+	if ws, ok := msg.(tea.WindowSizeMsg); ok {
+		c.Resize(ws.Width, ws.Height)
+	}
+	return msg
+}
+
+// event appends one [time, code, data] line; callers hold mu.
+func (c *CastWriter) event(code, data string) {
+	if c.err != nil {
+		return
+	}
+	t := float64(c.now().Sub(c.start).Microseconds()) / 1e6
+	line, err := json.Marshal([]any{t, code, data})
+	if err == nil {
+		c.cast.Write(append(line, '\n'))
+		err = c.cast.Flush()
+	}
+	c.err = err
+}
+
+// Close flushes any held-back bytes and reports the first cast write
+// error. The terminal itself is left open.
+func (c *CastWriter) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.partial) > 0 {
+		c.event("o", string(c.partial))
+		c.partial = nil
+	}
+	return c.err
+}
+
+// Read and Fd make CastWriter satisfy bubbletea's term.File, so the
+// program still detects the real terminal and receives resize events.
+func (c *CastWriter) Read(p []byte) (int, error) { return c.term.Read(p) }
+
+// Fd returns the terminal's file descriptor.
+func (c *CastWriter) Fd() uintptr { return c.term.Fd() }
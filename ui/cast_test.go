@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCastWriter(t *testing.T) {
+	term, err := os.Create(filepath.Join(t.TempDir(), "tty"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+	var buf bytes.Buffer
+	c, err := NewCastWriter(term, &buf, 100, 30, "xtop test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := c.start
+	c.now = func() time.Time { return clock }
+
+	clock = clock.Add(1500 * time.Millisecond)
+	c.Write([]byte("\x1b[H CPU ─"))
+	c.Write([]byte("\xe2\x94")) // first two bytes of "┐"
+	c.Filter(nil, tea.WindowSizeMsg{Width: 120, Height: 40})
+	c.Write([]byte("\x90 ok"))
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("cast has %d lines:\n%s", len(lines), buf.String())
+	}
+	var hdr castHeader
+	if err := json.Unmarshal([]byte(lines[0]), &hdr); err != nil || hdr.Version != 2 || hdr.Width != 100 || hdr.Height != 30 {
+		t.Fatalf("header = %+v (%v)", hdr, err)
+	}
+	var ev []any
+	json.Unmarshal([]byte(lines[1]), &ev)
+	if len(ev) != 3 || ev[0] != 1.5 || ev[1] != "o" || ev[2] != "\x1b[H CPU ─" {
+		t.Errorf("output event = %v", ev)
+	}
+	if !strings.Contains(lines[2], `"r","120x40"`) {
+		t.Errorf("resize event = %s", lines[2])
+	}
+	json.Unmarshal([]byte(lines[3]), &ev)
+	if ev[2] != "┐ ok" {
+		t.Errorf("split rune not rejoined: %q", ev[2])
+	}
+
+	got, _ := os.ReadFile(term.Name())
+	if string(got) != "\x1b[H CPU ─\xe2\x94\x90 ok" {
+		t.Errorf("terminal got %q", got)
+	}
+}
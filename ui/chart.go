@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ftahirops/xtop/util"
 )
 
 // areaChart renders a multi-line area chart with Y-axis labels, sub-cell
@@ -109,8 +111,8 @@ func areaChart(data []float64, label string, width, height int, minVal, maxVal f
 
 	// Time labels
 	if !startTime.IsZero() && !endTime.IsZero() {
-		left := startTime.Format("15:04:05")
-		right := endTime.Format("15:04:05")
+		left := util.FmtTime(startTime, "15:04:05")
+		right := util.FmtTime(endTime, "15:04:05")
 		gap := len(resampled) - len(left) - len(right) + axisW
 		if gap < 1 {
 			gap = 1
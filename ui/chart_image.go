@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ftahirops/xtop/util"
+)
+
+// chartLineColors are the series colors a chart image can use; each gets a
+// line shade and a darker fill shade in chartPalette.
+var chartLineColors = []lipgloss.Color{colorGreen, colorOrange, colorYellow, colorRed, colorCyan}
+
+// chartPalette: 0 transparent, 1 grid, 2 baseline, then a (line, fill)
+// pair per chartLineColors entry. A small palette keeps sixel output cheap
+// and PNGs tiny.
+var chartPalette = buildChartPalette()
+
+const (
+	chartIdxGrid     = 1
+	chartIdxBaseline = 2
+	chartIdxSeries   = 3
+)
+
+func buildChartPalette() color.Palette {
+	bg := hexRGBA(string(lipgloss.Color("#282A36")))
+	p := color.Palette{
+		color.RGBA{},
+		hexRGBA(string(colorPanel)),
+		hexRGBA(string(colorGray)),
+	}
+	for _, c := range chartLineColors {
+		line := hexRGBA(string(c))
+		p = append(p, line, blendRGBA(line, bg, 0.35))
+	}
+	return p
+}
+
+func hexRGBA(hex string) color.RGBA {
+	var r, g, b uint8
+	fmt.Sscanf(strings.TrimPrefix(hex, "#"), "%02x%02x%02x", &r, &g, &b)
+	return color.RGBA{r, g, b, 0xff}
+}
+
+func blendRGBA(fg, bg color.RGBA, a float64) color.RGBA {
+	mix := func(f, b uint8) uint8 { return uint8(float64(f)*a + float64(b)*(1-a)) }
+	return color.RGBA{mix(fg.R, bg.R), mix(fg.G, bg.G), mix(fg.B, bg.B), 0xff}
+}
+
+// chartLineIndex maps a chart style (okStyle, critStyle, ...) to the line
+// color's palette index. Styles without a known foreground draw in cyan.
+func chartLineIndex(st lipgloss.Style) uint8 {
+	if fg, ok := st.GetForeground().(lipgloss.Color); ok {
+		for i, c := range chartLineColors {
+			if strings.EqualFold(string(fg), string(c)) {
+				return uint8(chartIdxSeries + 2*i)
+			}
+		}
+	}
+	return uint8(chartIdxSeries + 2*(len(chartLineColors)-1))
+}
+
+// renderChartImage draws data as a filled line chart of pw x ph pixels.
+// Each column is colored by colorFn, like the cells of areaChart.
+func renderChartImage(data []float64, minVal, maxVal float64, pw, ph int,
+	colorFn func(float64, float64) lipgloss.Style) *image.Paletted {
+
+	img := image.NewPaletted(image.Rect(0, 0, pw, ph), chartPalette)
+	if pw < 2 || ph < 2 {
+		return img
+	}
+	if maxVal <= minVal {
+		maxVal = minVal + 1
+	}
+	rangeVal := maxVal - minVal
+
+	// Dashed quarter gridlines and a solid baseline.
+	for q := 1; q < 4; q++ {
+		y := (ph - 1) - q*(ph-1)/4
+		for x := 0; x < pw; x += 6 {
+			img.SetColorIndex(x, y, chartIdxGrid)
+			img.SetColorIndex(x+1, y, chartIdxGrid)
+			img.SetColorIndex(x+2, y, chartIdxGrid)
+		}
+	}
+	for x := 0; x < pw; x++ {
+		img.SetColorIndex(x, ph-1, chartIdxBaseline)
+	}
+	if len(data) == 0 {
+		return img
+	}
+
+	thick := ph / 60
+	if thick < 1 {
+		thick = 1
+	}
+	prevY := -1
+	for x := 0; x < pw; x++ {
+		// Linear interpolation between samples.
+		v := data[0]
+		if len(data) > 1 {
+			pos := float64(x) * float64(len(data)-1) / float64(pw-1)
+			i := int(pos)
+			if i >= len(data)-1 {
+				v = data[len(data)-1]
+			} else {
+				f := pos - float64(i)
+				v = data[i]*(1-f) + data[i+1]*f
+			}
+		}
+		ratio := math.Max(0, math.Min(1, (v-minVal)/rangeVal))
+		y := int(math.Round(float64(ph-1) * (1 - ratio)))
+		line := chartLineIndex(colorFn(v, ratio))
+
+		for yy := y + 1; yy < ph-1; yy++ {
+			img.SetColorIndex(x, yy, line+1)
+		}
+		lo, hi := y, y
+		if prevY >= 0 {
+			lo, hi = min(prevY, y), max(prevY, y)
+		}
+		for yy := lo; yy <= hi+thick-1 && yy < ph; yy++ {
+			img.SetColorIndex(x, yy, line)
+		}
+		prevY = y
+	}
+	return img
+}
+
+// imageChart renders the areaChart layout — title, height rows with a Y
+// axis, time labels — with the plot area drawn as an inline image. The
+// image carries its own baseline, so there is no X axis row. id
+// distinguishes charts on one page for kitty.
+func imageChart(proto imageProto, id int, data []float64, label string, width, height int, minVal, maxVal float64,
+	colorFn func(float64, float64) lipgloss.Style, startTime, endTime time.Time) string {
+
+	if height < 2 {
+		height = 2
+	}
+	if maxVal <= minVal {
+		maxVal = minVal + 1
+	}
+	axisW := 4
+	cols := width - axisW - 1
+	if cols < 10 {
+		cols = 10
+	}
+
+	var sb strings.Builder
+	last := float64(0)
+	if len(data) > 0 {
+		last = data[len(data)-1]
+	}
+	sb.WriteString(titleStyle.Render(label))
+	sb.WriteString(dimStyle.Render(fmt.Sprintf("  now: %.1f", last)))
+	sb.WriteString("\n")
+
+	cw, ch := cellPixelSize()
+	pw, ph := cols*cw, height*ch
+	if proto == imageSixel {
+		ph -= ph % 6 // whole sixel bands, so nothing spills onto the time labels
+	}
+	img := renderChartImage(data, minVal, maxVal, pw, ph, colorFn)
+
+	rangeVal := maxVal - minVal
+	for row := height - 1; row >= 0; row-- {
+		yVal := minVal + (float64(row+1)/float64(height))*rangeVal
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("%3.0f", yVal)))
+		sb.WriteString(dimStyle.Render("│"))
+		if row == 0 {
+			sb.WriteString(encodeTermImage(proto, img, id, cols, height))
+		}
+		sb.WriteString("\n")
+	}
+
+	if !startTime.IsZero() && !endTime.IsZero() {
+		left := util.FmtTime(startTime, "15:04:05")
+		right := util.FmtTime(endTime, "15:04:05")
+		gap := cols - len(left) - len(right) + axisW
+		if gap < 1 {
+			gap = 1
+		}
+		sb.WriteString(dimStyle.Render("   " + left + strings.Repeat(" ", gap) + right))
+	}
+	return sb.String()
+}
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/model"
+)
+
+// compareMsg carries the comparison host's latest frame.
+type compareMsg struct {
+	snap   *model.Snapshot
+	rates  *model.RateSnapshot
+	result *model.AnalysisResult
+}
+
+func collectCompare(ticker engine.Ticker) tea.Cmd {
+	return func() tea.Msg {
+		snap, rates, result := ticker.Tick()
+		return compareMsg{snap: snap, rates: rates, result: result}
+	}
+}
+
+// WithCompare adds a second host to compare against — a Player over a
+// recording, or a ticker polling another xtop daemon — and opens the TUI in
+// split view: every page is rendered once per host, side by side, so "why is
+// node A slow when identical node B is fine" is answered on one screen.
+// label names B until its first frame arrives; = toggles the split.
+func (m Model) WithCompare(t engine.Ticker, label string) Model {
+	m.compare = t
+	m.compareLabel = label
+	m.compareMode = true
+	// B gets its own idle probe manager so A's eBPF results are never
+	// shown under B's name.
+	m.compareProbe = engine.NewProbeManager()
+	return m
+}
+
+// renderCompare renders the current page for both hosts in two columns,
+// under a row of the metrics where they differ most.
+func (m Model) renderCompare(renderW int, rcaResult *model.AnalysisResult, resolvedAgo int) string {
+	leftW := (renderW - 3) / 2 // joinColumns puts " │ " between the panes
+	rightW := renderW - leftW - 3
+
+	b := m
+	b.snap, b.rates, b.result = m.compareSnap, m.compareRates, m.compareResult
+	b.engine = m.compare.Base()
+	b.probeManager = m.compareProbe
+	b.cachedSmart = nil
+	b.comparePane = true
+
+	left := compareLabel("A", compareHost(m.snap, "this host"), m.result) + "\n" +
+		m.renderPage(leftW, rcaResult, resolvedAgo)
+	right := compareLabel("B", compareHost(b.snap, m.compareLabel), b.result) + "\n"
+	if b.snap != nil {
+		right += b.renderPage(rightW, b.result, 0)
+	} else {
+		right += dimStyle.Render(" waiting for the first frame from " + m.compareLabel + "...")
+	}
+
+	return renderCompareDelta(m.snap, m.rates, b.snap, b.rates, renderW) + "\n" +
+		joinColumns(left, right, leftW, dimStyle.Render("│"))
+}
+
+func compareHost(snap *model.Snapshot, fallback string) string {
+	if snap != nil && snap.SysInfo != nil && snap.SysInfo.Hostname != "" {
+		return snap.SysInfo.Hostname
+	}
+	return fallback
+}
+
+func compareLabel(side, host string, result *model.AnalysisResult) string {
+	label := " " + titleStyle.Render(side+" ") + valueStyle.Render(host)
+	if result != nil {
+		label += "  " + healthStyled(result.Health)
+		if result.Health > model.HealthOK && result.PrimaryBottleneck != "" {
+			label += " " + dimStyle.Render(result.PrimaryBottleneck)
+		}
+	}
+	return label
+}
+
+// compareMetric is one headline number for both hosts. gap is the
+// difference worth flagging: below it the hosts count as alike.
+type compareMetric struct {
+	name string
+	a, b float64
+	unit string
+	gap  float64
+}
+
+// spread is how many "gaps" apart the hosts are.
+func (c compareMetric) spread() float64 {
+	return math.Abs(c.a-c.b) / c.gap
+}
+
+func (c compareMetric) format(v float64) string {
+	if c.unit == "%" {
+		return fmt.Sprintf("%.0f%%", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+func compareMetrics(snapA *model.Snapshot, ratesA *model.RateSnapshot, snapB *model.Snapshot, ratesB *model.RateSnapshot) []compareMetric {
+	if snapA == nil || snapB == nil {
+		return nil
+	}
+	ga, gb := snapA.Global, snapB.Global
+	out := []compareMetric{
+		{"PSI cpu", ga.PSI.CPU.Some.Avg10, gb.PSI.CPU.Some.Avg10, "", 5},
+		{"PSI mem", ga.PSI.Memory.Some.Avg10, gb.PSI.Memory.Some.Avg10, "", 5},
+		{"PSI io", ga.PSI.IO.Some.Avg10, gb.PSI.IO.Some.Avg10, "", 5},
+		{"MEM", memUsedPct(ga.Memory), memUsedPct(gb.Memory), "%", 20},
+		{"load/core", loadPerCore(ga.CPU), loadPerCore(gb.CPU), "", 0.5},
+	}
+	if ratesA != nil && ratesB != nil {
+		out = append([]compareMetric{{"CPU", ratesA.CPUBusyPct, ratesB.CPUBusyPct, "%", 20}}, out...)
+	}
+	return out
+}
+
+func memUsedPct(mem model.MemoryMetrics) float64 {
+	if mem.Total == 0 {
+		return 0
+	}
+	return float64(mem.Total-mem.Available) / float64(mem.Total) * 100
+}
+
+func loadPerCore(cpu model.CPUMetrics) float64 {
+	if cpu.NumCPUs == 0 {
+		return cpu.LoadAvg.Load1
+	}
+	return cpu.LoadAvg.Load1 / float64(cpu.NumCPUs)
+}
+
+// renderCompareDelta is the one-line answer above the split: the metric
+// where A and B diverge most, then every headline number as A/B with the
+// divergent ones highlighted.
+func renderCompareDelta(snapA *model.Snapshot, ratesA *model.RateSnapshot, snapB *model.Snapshot, ratesB *model.RateSnapshot, width int) string {
+	metrics := compareMetrics(snapA, ratesA, snapB, ratesB)
+	if len(metrics) == 0 {
+		return dimStyle.Render(" A vs B  waiting for both hosts...")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(" " + headerStyle.Render("A vs B") + "  ")
+	widest := append([]compareMetric(nil), metrics...)
+	sort.SliceStable(widest, func(i, j int) bool { return widest[i].spread() > widest[j].spread() })
+	if w := widest[0]; w.spread() >= 1 {
+		sb.WriteString(orangeStyle.Render(fmt.Sprintf("biggest gap: %s %s vs %s", w.name, w.format(w.a), w.format(w.b))))
+	} else {
+		sb.WriteString(okStyle.Render("no significant gap"))
+	}
+	for _, c := range metrics {
+		pair := fmt.Sprintf("%s %s/%s", c.name, c.format(c.a), c.format(c.b))
+		if c.spread() >= 1 {
+			sb.WriteString("  " + warnStyle.Render(pair))
+		} else {
+			sb.WriteString("  " + dimStyle.Render(pair))
+		}
+	}
+	return truncateToWidth(sb.String(), width)
+}
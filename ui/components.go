@@ -6,6 +6,8 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+
+	"github.com/ftahirops/xtop/util"
 )
 
 // Column widths used across all layouts for consistent alignment.
@@ -181,43 +183,26 @@ func psiBar(pct float64, width int) string {
 }
 
 func fmtBytes(b uint64) string {
-	switch {
-	case b >= 1<<30:
-		return fmt.Sprintf("%.1fG", float64(b)/(1<<30))
-	case b >= 1<<20:
-		return fmt.Sprintf("%.1fM", float64(b)/(1<<20))
-	case b >= 1<<10:
-		return fmt.Sprintf("%.1fK", float64(b)/(1<<10))
-	default:
-		return fmt.Sprintf("%dB", b)
-	}
+	return util.FmtBytesShort(b)
 }
 
 func fmtRate(mbps float64) string {
-	if mbps >= 1024 {
-		return fmt.Sprintf("%.1f GB/s", mbps/1024)
+	v, i := util.ScaleBytes(mbps * (1 << 20))
+	if i == 0 {
+		v, i = mbps*(1<<20)/util.ByteBase(), 1
 	}
-	if mbps >= 1 {
-		return fmt.Sprintf("%.1f MB/s", mbps)
+	if i == 1 {
+		return util.FmtFloat(v, 0) + " " + util.ByteUnit(i, false) + "/s"
 	}
-	return fmt.Sprintf("%.0f KB/s", mbps*1024)
+	return util.FmtFloat(v, 1) + " " + util.ByteUnit(i, false) + "/s"
 }
 
 func fmtPct(v float64) string {
-	return fmt.Sprintf("%.1f%%", v)
+	return util.FmtFloat(v, 1) + "%"
 }
 
 func fmtBytesRate(bps float64) string {
-	switch {
-	case bps >= 1<<30:
-		return fmt.Sprintf("%.1f GB/s", bps/(1<<30))
-	case bps >= 1<<20:
-		return fmt.Sprintf("%.1f MB/s", bps/(1<<20))
-	case bps >= 1<<10:
-		return fmt.Sprintf("%.1f KB/s", bps/(1<<10))
-	default:
-		return fmt.Sprintf("%.0f B/s", bps)
-	}
+	return util.FmtBytesRate(bps)
 }
 
 // #22: Use rune-aware operations for proper UTF-8 handling
@@ -561,6 +546,19 @@ func probeInterpretPgFault(comm string, majorCount int) string {
 	}
 }
 
+func probeInterpretNetWait(comm, dest string, waitPct float64, connecting bool) string {
+	switch {
+	case connecting:
+		return fmt.Sprintf("%s is stuck connecting to %s — endpoint down, firewalled or SYN backlog full", comm, dest)
+	case waitPct >= 100:
+		return fmt.Sprintf("%s has threads parked on %s the whole window — that dependency is the bottleneck", comm, dest)
+	case waitPct >= 30:
+		return fmt.Sprintf("%s spends a large share of its time waiting on %s", comm, dest)
+	default:
+		return fmt.Sprintf("%s waits briefly on %s — not a significant dependency stall", comm, dest)
+	}
+}
+
 func probeInterpretSockIO(comm string, dest string, avgWaitMs float64) string {
 	switch {
 	case avgWaitMs >= 50:
@@ -1,12 +1,16 @@
 package ui
 
-import "github.com/ftahirops/xtop/config"
+import (
+	"github.com/ftahirops/xtop/config"
+	"github.com/ftahirops/xtop/engine"
+)
 
 // userConfig holds user preferences persisted to disk.
 type userConfig struct {
 	DefaultLayout   int      `json:"default_layout"`
 	Roles           []string `json:"roles,omitempty"`
 	ExperienceLevel string   `json:"experience_level,omitempty"`
+	ChartImages     string   `json:"chart_images,omitempty"`
 }
 
 // loadConfig loads user config from disk.
@@ -15,6 +19,7 @@ func loadConfig() userConfig {
 	uc := userConfig{
 		DefaultLayout:   cfg.DefaultLayout,
 		ExperienceLevel: cfg.ExperienceLevel,
+		ChartImages:     cfg.ChartImages,
 	}
 	if cfg.ServerIdentity != nil {
 		for _, r := range cfg.ServerIdentity.Roles {
@@ -24,6 +29,12 @@ func loadConfig() userConfig {
 	return uc
 }
 
+// reloadRuntimeConfig re-reads config.json into a running engine and
+// returns what changed.
+func reloadRuntimeConfig(e *engine.Engine) []string {
+	return e.ApplyRuntimeConfig(engine.RuntimeConfigFrom(config.Load()))
+}
+
 // saveDefaultLayout persists the default layout to disk.
 func saveDefaultLayout(layout LayoutMode) error {
 	cfg := config.Load()
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/model"
+)
+
+// maxFilterLen caps the Events page filter query.
+const maxFilterLen = 120
+
+// handleEventFilterInput processes key events while the Events filter
+// prompt is open. Enter applies the query (empty clears it), Esc cancels.
+func (m *Model) handleEventFilterInput(key string) Model {
+	switch key {
+	case "esc", "ctrl+c":
+		m.evtFilterInputActive = false
+		m.evtFilterInput = ""
+	case "enter":
+		query := strings.TrimSpace(m.evtFilterInput)
+		m.evtFilterInputActive = false
+		m.evtFilterInput = ""
+		f, err := engine.ParseEventFilter(query, time.Now())
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("● filter: %v", err)
+			m.statusMessageAt = time.Now()
+			return *m
+		}
+		m.evtFilter = f
+		m.evtFilterQuery = query
+		m.evtSelected = 0
+	case "backspace":
+		if r := []rune(m.evtFilterInput); len(r) > 0 {
+			m.evtFilterInput = string(r[:len(r)-1])
+		}
+	default:
+		r := []rune(key)
+		if len(r) == 1 && r[0] >= 32 && len(m.evtFilterInput) < maxFilterLen {
+			m.evtFilterInput += key
+		}
+	}
+	return *m
+}
+
+// renderEventFilterPrompt renders the single-line filter entry prompt.
+func renderEventFilterPrompt(input string) string {
+	return "  " + headerStyle.Render("Filter: ") + valueStyle.Render(input) + dimStyle.Render("_") +
+		"  " + dimStyle.Render("bn:io sev:crit since:2d culprit:php <text>  Enter:apply  Esc:cancel")
+}
+
+// filteredEvents returns the completed events (newest first) that pass the
+// Events page filter. evtSelected indexes into this list.
+func (m *Model) filteredEvents() (active *model.Event, completed []model.Event, total int) {
+	active, all := m.eventDetector.AllEvents()
+	return active, engine.FilterEvents(all, m.evtFilter), len(all)
+}
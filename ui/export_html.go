@@ -7,11 +7,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
-// exportHTMLReport generates a self-contained HTML incident report.
-func exportHTMLReport(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult) (string, error) {
+// exportHTMLReport generates a self-contained HTML incident report. When
+// history is available the Timeline charts are embedded as PNG images.
+func exportHTMLReport(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult, history *engine.History) (string, error) {
 	if snap == nil {
 		return "", fmt.Errorf("no snapshot available")
 	}
@@ -77,11 +80,12 @@ td{padding:8px;border-bottom:1px solid #44475a}
 .bar-bg{background:#282a36;flex:1;border-radius:4px;overflow:hidden}
 .bar-fill{height:100%}
 .bf-g{background:#50fa7b} .bf-y{background:#ffb86c} .bf-r{background:#ff5555}
+.chart{margin:10px 0 15px} .chart img{width:100%;height:120px;display:block;border-bottom:1px solid #44475a}
 </style></head><body><div class="container">
 `)
 
 	sb.WriteString(fmt.Sprintf("<h1>xtop Incident Report</h1>\n"))
-	sb.WriteString(fmt.Sprintf("<p class=\"sub\">%s &mdash; %s</p>\n", htmlEsc(sysInfo), time.Now().Format("2006-01-02 15:04:05 MST")))
+	sb.WriteString(fmt.Sprintf("<p class=\"sub\">%s &mdash; %s</p>\n", htmlEsc(sysInfo), util.FmtTime(time.Now(), "2006-01-02 15:04:05 MST")))
 
 	// Health
 	healthClass, healthText := "ok", "HEALTHY"
@@ -186,6 +190,11 @@ td{padding:8px;border-bottom:1px solid #44475a}
 
 	sb.WriteString("</div>\n")
 
+	// Timeline charts
+	if history != nil && history.Len() >= 2 {
+		sb.WriteString(htmlTimeline(history))
+	}
+
 	// Capacity table
 	if result != nil && len(result.Capacities) > 0 {
 		sb.WriteString("<h2>Capacity</h2>\n<table>\n")
@@ -242,7 +251,7 @@ td{padding:8px;border-bottom:1px solid #44475a}
 
 	// Footer
 	sb.WriteString(fmt.Sprintf("<div class=\"footer\"><p>Generated by xtop &mdash; %s</p><p>%s</p></div>\n",
-		time.Now().Format("2006-01-02 15:04:05 MST"), htmlEsc(sysInfo)))
+		util.FmtTime(time.Now(), "2006-01-02 15:04:05 MST"), htmlEsc(sysInfo)))
 	sb.WriteString("</div></body></html>")
 
 	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
@@ -251,6 +260,25 @@ td{padding:8px;border-bottom:1px solid #44475a}
 	return path, nil
 }
 
+// htmlTimeline renders the Timeline page's series as inline PNG charts.
+func htmlTimeline(history *engine.History) string {
+	var sb strings.Builder
+	span := ""
+	if oldest, latest := history.Get(0), history.Latest(); oldest != nil && latest != nil {
+		span = fmt.Sprintf(" <span class=\"dim\">%s &ndash; %s</span>",
+			util.FmtTime(oldest.Timestamp, "15:04:05"), util.FmtTime(latest.Timestamp, "15:04:05"))
+	}
+	sb.WriteString("<h2>Timeline" + span + "</h2>\n")
+	for _, c := range timelineCharts(history) {
+		img := renderChartImage(c.data, 0, c.maxVal, 1200, 240, c.color)
+		last := c.data[len(c.data)-1]
+		sb.WriteString(fmt.Sprintf("<div class=\"chart\"><div class=\"m\"><span class=\"mk\">%s</span><span class=\"mv\">now %.1f / scale %.0f</span></div>\n",
+			htmlEsc(c.label), last, c.maxVal))
+		sb.WriteString(fmt.Sprintf("<img alt=\"%s\" src=\"data:image/png;base64,%s\"></div>\n", htmlEsc(c.label), pngBase64(img)))
+	}
+	return sb.String()
+}
+
 type htmlKV struct {
 	Key string
 	Val string
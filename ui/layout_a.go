@@ -71,6 +71,7 @@ func renderLayoutA(snap *model.Snapshot, rates *model.RateSnapshot, result *mode
 		// "Top Resource Owners" was removed — App Load Distribution shows the
 		// same data in a more human-readable per-app pivot.
 		right.WriteString(renderRCABox(result, rightW))
+		right.WriteString(renderBlameBlock(result, rightW))
 		right.WriteString(renderTopConsumersBlock(snap, rates, result, rightW))
 		right.WriteString(renderCapacityBlock(result, true, 16, rightW, intermediate))
 		right.WriteString(renderOverviewAppsSummary(snap, rightW))
@@ -87,6 +88,7 @@ func renderLayoutA(snap *model.Snapshot, rates *model.RateSnapshot, result *mode
 		// Full detail: everything + sparklines.
 		// "Top Resource Owners" removed; App Load Distribution covers it.
 		right.WriteString(renderRCABox(result, rightW))
+		right.WriteString(renderBlameBlock(result, rightW))
 		right.WriteString(renderChangesBlock(result, rightW))
 		right.WriteString(renderActionsBlock(result, rightW))
 		right.WriteString(renderTopConsumersBlock(snap, rates, result, rightW))
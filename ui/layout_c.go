@@ -62,6 +62,8 @@ func renderLayoutC(snap *model.Snapshot, rates *model.RateSnapshot, result *mode
 	sb.WriteString(renderChangesInline(result))
 	// Owners (top-3 per resource)
 	sb.WriteString(renderOwnersInline(result))
+	// Blame (ranked offenders per pressured domain)
+	sb.WriteString(renderBlameBlock(result, width))
 	// Capacity (always render inline)
 	sb.WriteString(renderCapacityInline(result))
 	// Probe status
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/model"
+)
+
+// maxNoteLen caps a single operator note. Notes are one-liners
+// ("failing over DB"), not a scratchpad.
+const maxNoteLen = 200
+
+// handleNoteInput processes key events while the note prompt is open.
+func (m *Model) handleNoteInput(key string) Model {
+	switch key {
+	case "esc", "ctrl+c":
+		m.noteInputActive = false
+		m.noteInput = ""
+	case "enter":
+		text := strings.TrimSpace(m.noteInput)
+		m.noteInputActive = false
+		m.noteInput = ""
+		if text == "" {
+			return *m
+		}
+		now := time.Now()
+		if m.snap != nil && !m.snap.Timestamp.IsZero() {
+			// Stamp the note with the frame on screen: the latest
+			// sample when live, the moment being viewed in replay
+			now = m.snap.Timestamp
+		}
+		active := m.eventDetector.ActiveEvent()
+		note := m.eventDetector.AddNote(now, text)
+		if m.dataDir != "" {
+			incidentID := ""
+			if active != nil {
+				incidentID = active.ID
+			}
+			if err := engine.AppendNoteLog(filepath.Join(m.dataDir, engine.NotesFileName), note, incidentID); err != nil {
+				m.statusMessage = fmt.Sprintf("● note kept in memory only: %v", err)
+				m.statusMessageAt = time.Now()
+				return *m
+			}
+		}
+		if active != nil {
+			m.statusMessage = fmt.Sprintf("● note added to incident %s", active.ID)
+		} else {
+			m.statusMessage = "● note recorded"
+		}
+		m.statusMessageAt = time.Now()
+	case "backspace":
+		if r := []rune(m.noteInput); len(r) > 0 {
+			m.noteInput = string(r[:len(r)-1])
+		}
+	default:
+		r := []rune(key)
+		if len(r) == 1 && r[0] >= 32 && len(m.noteInput) < maxNoteLen {
+			m.noteInput += key
+		}
+	}
+	return *m
+}
+
+// renderNotePrompt renders the single-line note entry prompt.
+func renderNotePrompt(input string) string {
+	return "  " + headerStyle.Render("✎ Note: ") + valueStyle.Render(input) + dimStyle.Render("_") +
+		"  " + dimStyle.Render("Enter:save  Esc:cancel")
+}
+
+// allNotes returns every operator note known to the TUI — standalone
+// notes plus those on the active incident's timeline — oldest first.
+func (m *Model) allNotes() []model.TimelineEntry {
+	notes := m.eventDetector.Notes()
+	if active := m.eventDetector.ActiveEvent(); active != nil {
+		for _, te := range active.Timeline {
+			if te.IsNote() {
+				notes = append(notes, te)
+			}
+		}
+	}
+	sort.SliceStable(notes, func(i, j int) bool { return notes[i].Time.Before(notes[j].Time) })
+	return notes
+}
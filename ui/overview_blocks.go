@@ -784,6 +784,72 @@ func capacityBar(pct float64, width int) string {
 	return style.Render(filledStr) + dimStyle.Render(emptyStr)
 }
 
+// ─── SHARED: BLAME ──────────────────────────────────────────────────────────
+
+// renderBlameBlock lists the ranked offenders per pressured domain with
+// their share of the domain's load and the confidence in each. Empty when
+// blame attribution has nothing to say (healthy, or the feature is off).
+func renderBlameBlock(result *model.AnalysisResult, width int) string {
+	if result == nil || len(result.BlameDomains) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+
+	innerW := width - 7
+	if innerW < 40 {
+		innerW = 40
+	}
+	if innerW > 200 {
+		innerW = 200
+	}
+
+	title := fmt.Sprintf(" %s ", titleStyle.Render("Blame"))
+	sb.WriteString(boxTopTitle(title, innerW) + "\n")
+	for _, d := range result.BlameDomains {
+		head := headerStyle.Render(d.Bottleneck) + dimStyle.Render(fmt.Sprintf("  score %d", d.Score))
+		if d.Primary {
+			head += "  " + orangeStyle.Render("primary")
+		}
+		sb.WriteString(boxRow(head, innerW) + "\n")
+		for i, b := range d.Offenders {
+			if i >= 3 {
+				break
+			}
+			name := b.Comm
+			if b.AppName != "" {
+				name = b.AppName
+			}
+			if b.PID > 0 {
+				name += fmt.Sprintf(" (PID %d)", b.PID)
+			}
+			keys := make([]string, 0, len(b.Metrics))
+			for k := range b.Metrics {
+				if k != "reason" {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			metrics := make([]string, 0, len(keys))
+			for _, k := range keys {
+				metrics = append(metrics, k+":"+b.Metrics[k])
+			}
+			confStyle := dimStyle
+			if b.Confidence >= 0.7 {
+				confStyle = valueStyle
+			}
+			content := fmt.Sprintf(" %s %s  %s  %s  %s",
+				orangeStyle.Render(fmt.Sprintf("%d.", i+1)),
+				valueStyle.Render(truncate(name, 30)),
+				dimStyle.Render(fmt.Sprintf("%3.0f%% of load", b.SharePct)),
+				confStyle.Render(fmt.Sprintf("conf %d%%", int(b.Confidence*100))),
+				dimStyle.Render(truncate(strings.Join(metrics, " "), innerW/3)))
+			sb.WriteString(boxRow(content, innerW) + "\n")
+		}
+	}
+	sb.WriteString(boxBot(innerW) + "\n")
+	return sb.String()
+}
+
 // ─── SHARED: EXHAUSTION WARNINGS ────────────────────────────────────────────
 
 func renderExhaustionBlock(result *model.AnalysisResult, width int) string {
@@ -1444,10 +1510,12 @@ func renderExplainPanel(result *model.AnalysisResult, width int) string {
 			if b.AppName != "" {
 				displayName = b.AppName
 			}
-			line := fmt.Sprintf(" %d. %s (PID %d) — %s",
+			line := fmt.Sprintf(" %d. %s (PID %d) — %.0f%% share, conf %d%% — %s",
 				i+1,
 				truncate(displayName, 22),
 				b.PID,
+				b.SharePct,
+				int(b.Confidence*100),
 				strings.Join(metricParts, ", "))
 			sb.WriteString(boxRow(dimStyle.Render(line), innerW) + "\n")
 		}
@@ -1627,9 +1695,31 @@ func perCoreBusy(prev, curr *model.Snapshot) []float64 {
 		return nil
 	}
 
+	// Pair cores by CPU number when known: after a core goes offline or
+	// comes back, slice positions no longer line up between snapshots.
+	prevIdx := func(i int) int { return i }
+	prevIDs, currIDs := prev.Global.CPU.CPUIDs, curr.Global.CPU.CPUIDs
+	if len(prevIDs) == len(prevCPU) && len(currIDs) == len(currCPU) {
+		byID := make(map[int]int, len(prevIDs))
+		for j, id := range prevIDs {
+			byID[id] = j
+		}
+		prevIdx = func(i int) int {
+			if j, ok := byID[currIDs[i]]; ok {
+				return j
+			}
+			return -1 // onlined since the previous tick
+		}
+		n = len(currCPU)
+	}
+
 	pcts := make([]float64, n)
 	for i := 0; i < n; i++ {
-		prevTotal := prevCPU[i].Total()
+		j := prevIdx(i)
+		if j < 0 {
+			continue
+		}
+		prevTotal := prevCPU[j].Total()
 		currTotal := currCPU[i].Total()
 		// #10: Guard against uint64 underflow on counter reset
 		if currTotal < prevTotal {
@@ -1639,10 +1729,10 @@ func perCoreBusy(prev, curr *model.Snapshot) []float64 {
 		if delta == 0 {
 			continue
 		}
-		if currCPU[i].Idle < prevCPU[i].Idle {
+		if currCPU[i].Idle < prevCPU[j].Idle {
 			continue
 		}
-		idle := currCPU[i].Idle - prevCPU[i].Idle
+		idle := currCPU[i].Idle - prevCPU[j].Idle
 		if idle > delta {
 			continue
 		}
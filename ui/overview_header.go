@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // ─── SHARED: HEADER ─────────────────────────────────────────────────────────
@@ -177,7 +178,7 @@ func renderOverviewAppsSummary(snap *model.Snapshot, width int) string {
 		if f, err := os.OpenFile("/tmp/xtop_tui_apps.log",
 			os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err == nil {
 			fmt.Fprintf(f, "[%s] render: snap=%p ident=%d apps=%d\n",
-				time.Now().Format("15:04:05"), snap,
+				util.FmtTime(time.Now(), "15:04:05"), snap,
 				len(snap.Global.AppIdentities),
 				len(snap.Global.Apps.Instances))
 			if len(snap.Global.Apps.Instances) > 0 {
@@ -21,34 +21,23 @@ const (
 
 var cgSortNames = []string{"CPU%", "Throttle%", "Mem", "OOM", "IO"}
 
-func renderCgroupPage(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult, pm probeQuerier, sortCol cgSort, selected int, width, height int) string {
-	var sb strings.Builder
-	iw := pageInnerW(width)
-
-	sortName := "CPU%"
-	if int(sortCol) < len(cgSortNames) {
-		sortName = cgSortNames[sortCol]
-	}
-	sb.WriteString(titleStyle.Render(fmt.Sprintf("CGROUPS  (sort: %s, %d total)", sortName, len(snap.Cgroups))))
-	sb.WriteString("\n")
-	sb.WriteString(renderRCAInline(result))
-	sb.WriteString(renderProbeStatusLine(pm, snap, false))
-	sb.WriteString("\n")
-
-	// Build merged view: cgroup metrics + rates
-	type cgRow struct {
-		name     string
-		path     string
-		cpuPct   float64
-		thrPct   float64
-		memBytes uint64
-		memPct   float64
-		oomKills uint64
-		ioRMBs   float64
-		ioWMBs   float64
-		pids     uint64
-	}
+// cgRow is one line of the CGroups table: cgroup metrics merged with rates.
+type cgRow struct {
+	name     string
+	path     string
+	cpuPct   float64
+	thrPct   float64
+	memBytes uint64
+	memPct   float64
+	oomKills uint64
+	ioRMBs   float64
+	ioWMBs   float64
+	pids     uint64
+}
 
+// buildCgroupRows merges cgroup metrics with rates and sorts them. The
+// cursor (cgSelected) indexes into this order, so Enter must use it too.
+func buildCgroupRows(snap *model.Snapshot, rates *model.RateSnapshot, sortCol cgSort) []cgRow {
 	rateMap := make(map[string]model.CgroupRate)
 	if rates != nil {
 		for _, cr := range rates.CgroupRates {
@@ -67,7 +56,7 @@ func renderCgroupPage(snap *model.Snapshot, rates *model.RateSnapshot, result *m
 			path:     cg.Path,
 			memBytes: cg.MemCurrent,
 			memPct:   float64(cg.MemCurrent) / float64(totalMem) * 100,
-			oomKills: cg.OOMKills,
+			oomKills: cg.OwnOOMKills(),
 			pids:     cg.PIDCount,
 		}
 		if cr, ok := rateMap[cg.Path]; ok {
@@ -79,8 +68,7 @@ func renderCgroupPage(snap *model.Snapshot, rates *model.RateSnapshot, result *m
 		rows = append(rows, r)
 	}
 
-	// Sort
-	sort.Slice(rows, func(i, j int) bool {
+	sort.SliceStable(rows, func(i, j int) bool {
 		switch sortCol {
 		case cgSortCPU:
 			return rows[i].cpuPct > rows[j].cpuPct
@@ -96,6 +84,24 @@ func renderCgroupPage(snap *model.Snapshot, rates *model.RateSnapshot, result *m
 			return rows[i].cpuPct > rows[j].cpuPct
 		}
 	})
+	return rows
+}
+
+func renderCgroupPage(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult, pm probeQuerier, sortCol cgSort, selected int, width, height int) string {
+	var sb strings.Builder
+	iw := pageInnerW(width)
+
+	sortName := "CPU%"
+	if int(sortCol) < len(cgSortNames) {
+		sortName = cgSortNames[sortCol]
+	}
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("CGROUPS  (sort: %s, %d total)", sortName, len(snap.Cgroups))))
+	sb.WriteString("\n")
+	sb.WriteString(renderRCAInline(result))
+	sb.WriteString(renderProbeStatusLine(pm, snap, false))
+	sb.WriteString("\n")
+
+	rows := buildCgroupRows(snap, rates, sortCol)
 
 	// Build table lines
 	var tblLines []string
@@ -106,8 +112,16 @@ func renderCgroupPage(snap *model.Snapshot, rates *model.RateSnapshot, result *m
 	if maxRows < 5 {
 		maxRows = 30
 	}
+	// Keep the cursor on screen when it moves past the visible rows
+	offset := 0
+	if selected >= maxRows {
+		offset = selected - maxRows + 1
+	}
 	for i, r := range rows {
-		if i >= maxRows {
+		if i < offset {
+			continue
+		}
+		if i-offset >= maxRows {
 			break
 		}
 		name := r.name
@@ -126,7 +140,7 @@ func renderCgroupPage(snap *model.Snapshot, rates *model.RateSnapshot, result *m
 	}
 	sb.WriteString(boxSection("ALL CGROUPS", tblLines, iw))
 
-	sb.WriteString(pageFooter("s:sort j/k:scroll"))
+	sb.WriteString(pageFooter("s:sort j/k:select Enter:detail"))
 
 	return sb.String()
 }
@@ -0,0 +1,415 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	cgcollector "github.com/ftahirops/xtop/collector/cgroup"
+	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/model"
+)
+
+// cgroupDetailMsg carries an on-demand read of one cgroup's detail files.
+type cgroupDetailMsg struct {
+	detail model.CgroupDetail
+	err    error
+}
+
+// readCgroupDetailAsync reads the selected cgroup off the UI goroutine.
+func readCgroupDetailAsync(path string) tea.Cmd {
+	return func() tea.Msg {
+		d, err := cgcollector.ReadDetail(path)
+		return cgroupDetailMsg{detail: d, err: err}
+	}
+}
+
+// cgNotRecorded labels detail fields that snapshots do not carry.
+const cgNotRecorded = "not recorded in snapshots"
+
+// recordedCgroupDetail builds the detail from the snapshot alone, for
+// replay and the compare pane where this host's /sys/fs/cgroup describes
+// a different machine or moment. Only OOM kills and membership survive;
+// renderCgroupDetail labels the rest.
+func recordedCgroupDetail(snap *model.Snapshot, path string) *model.CgroupDetail {
+	d := &model.CgroupDetail{Path: path}
+	if snap == nil {
+		return d
+	}
+	d.Timestamp = snap.Timestamp
+	for _, cg := range snap.Cgroups {
+		if cg.Path == path {
+			d.MemEvents.OOMKill = cg.OOMKills
+			if cg.HasLocalEvents {
+				d.MemEventsLocal = &model.CgroupMemEvents{OOMKill: cg.OOMKillsLocal}
+			}
+			break
+		}
+	}
+	for _, p := range snap.Processes {
+		if p.CgroupPath == path {
+			d.PIDs = append(d.PIDs, p.PID)
+		}
+	}
+	return d
+}
+
+// renderCgroupDetail renders the drill-down for one cgroup: limits vs usage,
+// PSI, throttling history, member processes, IO by device and memory.events.
+// prev is the previous detail read (nil on the first one) and is used to turn
+// the cumulative io.stat / memory.events counters into per-second rates.
+// recorded means detail came from recordedCgroupDetail rather than a live read.
+func renderCgroupDetail(snap *model.Snapshot, rates *model.RateSnapshot, history *engine.History,
+	path string, detail, prev *model.CgroupDetail, detailErr error, recorded bool, width, height int) string {
+	var sb strings.Builder
+	iw := pageInnerW(width)
+
+	var cg *model.CgroupMetrics
+	for i := range snap.Cgroups {
+		if snap.Cgroups[i].Path == path {
+			cg = &snap.Cgroups[i]
+			break
+		}
+	}
+	var cr *model.CgroupRate
+	if rates != nil {
+		for i := range rates.CgroupRates {
+			if rates.CgroupRates[i].Path == path {
+				cr = &rates.CgroupRates[i]
+				break
+			}
+		}
+	}
+
+	sb.WriteString(titleStyle.Render("CGROUP  " + path))
+	sb.WriteString("\n")
+	if detailErr != nil {
+		sb.WriteString(critStyle.Render(fmt.Sprintf("  Cannot read cgroup: %v", detailErr)))
+		sb.WriteString("\n")
+		sb.WriteString(pageFooter("b/esc:back"))
+		return sb.String()
+	}
+	if detail == nil {
+		sb.WriteString(dimStyle.Render("  Reading cgroup..."))
+		sb.WriteString("\n")
+		sb.WriteString(pageFooter("b/esc:back"))
+		return sb.String()
+	}
+	if cg == nil {
+		sb.WriteString(warnStyle.Render("  cgroup no longer present in the last sample"))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	// ── Limits vs usage ──
+	var lim []string
+	cpuPct, thrPct := 0.0, 0.0
+	if cr != nil {
+		cpuPct, thrPct = cr.CPUPct, cr.ThrottlePct
+	}
+	cpuLimit := "unlimited"
+	if recorded {
+		cpuLimit = dimStyle.Render(cgNotRecorded)
+	} else if detail.CPUQuotaUsec > 0 && detail.CPUPeriodUsec > 0 {
+		cores := float64(detail.CPUQuotaUsec) / float64(detail.CPUPeriodUsec)
+		cpuLimit = fmt.Sprintf("%.2f cores (%d/%d µs)", cores, detail.CPUQuotaUsec, detail.CPUPeriodUsec)
+	}
+	lim = append(lim, fmt.Sprintf("%-10s %s  limit: %s", "CPU",
+		valueStyle.Render(fmt.Sprintf("%5.1f%%", cpuPct)), cpuLimit))
+	if detail.CPUWeight > 0 {
+		lim = append(lim, fmt.Sprintf("%-10s %d", "CPU weight", detail.CPUWeight))
+	}
+	if cg != nil {
+		memLine := fmt.Sprintf("%-10s %s", "Memory", valueStyle.Render(fmtBytes(cg.MemCurrent)))
+		memMax, maxName := detail.MemMax, "max"
+		if recorded {
+			memMax, maxName = cg.MemLimit, "limit" // memory.max, or memory.high if that is all there is
+		}
+		if memMax > 0 {
+			pct := float64(cg.MemCurrent) / float64(memMax) * 100
+			memLine += fmt.Sprintf(" / %s %s  %s", fmtBytes(memMax), maxName, cgLimitStyle(pct).Render(fmt.Sprintf("%.0f%%", pct)))
+		} else if recorded {
+			memLine += dimStyle.Render("  (no limit)")
+		} else {
+			memLine += dimStyle.Render("  (no memory.max)")
+		}
+		lim = append(lim, memLine)
+		if cg.MemSwap > 0 {
+			lim = append(lim, fmt.Sprintf("%-10s %s", "Swap", fmtBytes(cg.MemSwap)))
+		}
+	}
+	var prot []string
+	if detail.MemMin > 0 {
+		prot = append(prot, "min "+fmtBytes(detail.MemMin))
+	}
+	if detail.MemLow > 0 {
+		prot = append(prot, "low "+fmtBytes(detail.MemLow))
+	}
+	if detail.MemHigh > 0 {
+		prot = append(prot, "high "+fmtBytes(detail.MemHigh))
+	}
+	if recorded {
+		lim = append(lim, fmt.Sprintf("%-10s %s", "Mem knobs", dimStyle.Render(cgNotRecorded)))
+	} else if len(prot) > 0 {
+		lim = append(lim, fmt.Sprintf("%-10s %s", "Mem knobs", strings.Join(prot, "  ")))
+	}
+	if cg != nil {
+		pids := fmt.Sprintf("%d", cg.PIDCount)
+		if cg.PIDLimit > 0 {
+			pids += fmt.Sprintf(" / %d max", cg.PIDLimit)
+		}
+		lim = append(lim, fmt.Sprintf("%-10s %s", "Tasks", pids))
+	}
+	sb.WriteString(boxSection("LIMITS vs USAGE", lim, iw))
+
+	// ── PSI ──
+	psi := []string{
+		dimStyle.Render(fmt.Sprintf("%-8s %18s %18s", "", "some avg10/60", "full avg10/60")),
+		cgPSIRow("CPU", detail.PSI.CPU),
+		cgPSIRow("Memory", detail.PSI.Memory),
+		cgPSIRow("IO", detail.PSI.IO),
+	}
+	if recorded {
+		psi = []string{dimStyle.Render(cgNotRecorded)}
+	}
+	sb.WriteString(boxSection("PRESSURE (PSI)", psi, iw))
+
+	// ── Throttling history ──
+	thr := cgThrottleHistory(history, path)
+	sparkW := iw - 24
+	if sparkW < 10 {
+		sparkW = 10
+	}
+	maxThr := 10.0
+	for _, v := range thr {
+		if v > maxThr {
+			maxThr = v
+		}
+	}
+	thrLines := []string{
+		fmt.Sprintf("%-10s %s %s", "Throttle", sparkline(thr, sparkW, 0, maxThr),
+			cgLimitStyle(thrPct*5).Render(fmt.Sprintf("%5.1f%%", thrPct))),
+	}
+	if cg != nil && cg.NrPeriods > 0 {
+		thrLines = append(thrLines, dimStyle.Render(fmt.Sprintf("%-10s %d of %d periods throttled (%s total)", "",
+			cg.NrThrottled, cg.NrPeriods, formatDuration(time.Duration(cg.ThrottledUsec)*time.Microsecond))))
+	}
+	sb.WriteString(boxSection("THROTTLING HISTORY", thrLines, iw))
+
+	// ── Member processes ──
+	sb.WriteString(boxSection(fmt.Sprintf("PROCESSES (%d)", len(detail.PIDs)),
+		cgMemberLines(snap, rates, detail.PIDs, height), iw))
+
+	// ── IO by device ──
+	var ioLines []string
+	if recorded {
+		ioLines = append(ioLines, dimStyle.Render(fmt.Sprintf("%-14s %10s %10s %10s %10s", "DEVICE", "READ/s", "WRITE/s", "READ", "WRITTEN")))
+		rRate, wRate, rTotal, wTotal := "-", "-", "-", "-"
+		if cr != nil {
+			rRate = fmtBytesRate(cr.IORateMBs * 1024 * 1024)
+			wRate = fmtBytesRate(cr.IOWRateMBs * 1024 * 1024)
+		}
+		if cg != nil {
+			rTotal, wTotal = fmtBytes(cg.IORBytes), fmtBytes(cg.IOWBytes)
+		}
+		ioLines = append(ioLines,
+			fmt.Sprintf("%-14s %10s %10s %10s %10s", "all devices", rRate, wRate, rTotal, wTotal),
+			dimStyle.Render("per-device split "+cgNotRecorded))
+	} else if len(detail.IODevices) == 0 {
+		ioLines = append(ioLines, dimStyle.Render("no IO accounted to this cgroup"))
+	} else {
+		ioLines = append(ioLines, dimStyle.Render(fmt.Sprintf("%-14s %10s %10s %10s %10s", "DEVICE", "READ/s", "WRITE/s", "READ", "WRITTEN")))
+		prevDev := make(map[string]model.CgroupIODevice)
+		var dt float64
+		if prev != nil {
+			for _, d := range prev.IODevices {
+				prevDev[d.Device] = d
+			}
+			dt = detail.Timestamp.Sub(prev.Timestamp).Seconds()
+		}
+		for _, d := range detail.IODevices {
+			rRate, wRate := "-", "-"
+			if p, ok := prevDev[d.Device]; ok && dt > 0 {
+				rRate = fmtBytesRate(float64(subU64(d.RBytes, p.RBytes)) / dt)
+				wRate = fmtBytesRate(float64(subU64(d.WBytes, p.WBytes)) / dt)
+			}
+			ioLines = append(ioLines, fmt.Sprintf("%-14s %10s %10s %10s %10s",
+				d.Device, rRate, wRate, fmtBytes(d.RBytes), fmtBytes(d.WBytes)))
+		}
+	}
+	sb.WriteString(boxSection("IO BY DEVICE", ioLines, iw))
+
+	// ── memory.events ──
+	ev := detail.MemEvents
+	var pev model.CgroupMemEvents
+	if prev != nil {
+		pev = prev.MemEvents
+	}
+	evRow := func(name string, cur, was uint64, hint string) string {
+		line := fmt.Sprintf("%-15s %8d", name, cur)
+		if prev != nil && cur > was {
+			return warnStyle.Render(fmt.Sprintf("%s  +%d since last sample  %s", line, cur-was, hint))
+		}
+		return line + "  " + dimStyle.Render(hint)
+	}
+	evLines := []string{
+		evRow("low", ev.Low, pev.Low, "reclaimed below memory.low"),
+		evRow("high", ev.High, pev.High, "throttled at memory.high"),
+		evRow("max", ev.Max, pev.Max, "hit memory.max"),
+		evRow("oom", ev.OOM, pev.OOM, "allocation failed at limit"),
+		evRow("oom_kill", ev.OOMKill, pev.OOMKill, "processes OOM-killed"),
+		evRow("oom_group_kill", ev.OOMGroupKill, pev.OOMGroupKill, "whole-cgroup OOM kills"),
+	}
+	if recorded {
+		evLines = []string{
+			evRow("oom_kill", ev.OOMKill, pev.OOMKill, "processes OOM-killed"),
+			dimStyle.Render("low, high, max, oom, oom_group_kill " + cgNotRecorded),
+		}
+	}
+	if local := detail.MemEventsLocal; local != nil {
+		// Counters above include child cgroups; the local file says whether
+		// the kills happened here or in a nested container.
+		var was uint64
+		if prev != nil && prev.MemEventsLocal != nil {
+			was = prev.MemEventsLocal.OOMKill
+		}
+		evLines = append(evLines,
+			evRow("oom_kill local", local.OOMKill, was, "OOM-killed in this cgroup, not a child"))
+	}
+	sb.WriteString(boxSection("MEMORY EVENTS", evLines, iw))
+
+	sb.WriteString(pageFooter("b/esc:back"))
+	return sb.String()
+}
+
+// cgPSIRow formats one PSI resource line for the detail view.
+func cgPSIRow(name string, r model.PSIResource) string {
+	some := fmt.Sprintf("%18s", fmt.Sprintf("%6.2f / %6.2f", r.Some.Avg10, r.Some.Avg60))
+	full := fmt.Sprintf("%18s", fmt.Sprintf("%6.2f / %6.2f", r.Full.Avg10, r.Full.Avg60))
+	return fmt.Sprintf("%-8s %s %s", name,
+		cgLimitStyle(r.Some.Avg10*4).Render(some), cgLimitStyle(r.Full.Avg10*8).Render(full))
+}
+
+// cgLimitStyle colours a 0-100 utilisation value.
+func cgLimitStyle(pct float64) lipgloss.Style {
+	switch {
+	case pct >= 90:
+		return critStyle
+	case pct >= 70:
+		return warnStyle
+	default:
+		return valueStyle
+	}
+}
+
+// cgThrottleHistory extracts the throttle% series for one cgroup from history.
+func cgThrottleHistory(history *engine.History, path string) []float64 {
+	if history == nil {
+		return nil
+	}
+	var out []float64
+	for i := 0; i < history.Len(); i++ {
+		r := history.GetRate(i)
+		if r == nil {
+			continue
+		}
+		v := 0.0
+		for _, cr := range r.CgroupRates {
+			if cr.Path == path {
+				v = cr.ThrottlePct
+				break
+			}
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// cgMemberLines lists the cgroup's member processes, busiest first.
+func cgMemberLines(snap *model.Snapshot, rates *model.RateSnapshot, pids []int, height int) []string {
+	if len(pids) == 0 {
+		return []string{dimStyle.Render("no processes (cgroup may only contain child cgroups)")}
+	}
+	procs := make(map[int]model.ProcessMetrics, len(snap.Processes))
+	for _, p := range snap.Processes {
+		procs[p.PID] = p
+	}
+	prs := make(map[int]model.ProcessRate)
+	if rates != nil {
+		for _, pr := range rates.ProcessRates {
+			prs[pr.PID] = pr
+		}
+	}
+
+	type member struct {
+		pid      int
+		comm     string
+		state    string
+		cpu      float64
+		rss      uint64
+		ioR, ioW float64
+	}
+	var members []member
+	for _, pid := range pids {
+		m := member{pid: pid, comm: "?"}
+		if p, ok := procs[pid]; ok {
+			m.comm, m.state, m.rss = p.Comm, p.State, p.RSS
+		}
+		if pr, ok := prs[pid]; ok {
+			m.comm, m.state = pr.Comm, pr.State
+			m.cpu, m.ioR, m.ioW = pr.CPUPct, pr.ReadMBs, pr.WriteMBs
+		}
+		members = append(members, m)
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].cpu != members[j].cpu {
+			return members[i].cpu > members[j].cpu
+		}
+		return members[i].rss > members[j].rss
+	})
+
+	lines := []string{dimStyle.Render(fmt.Sprintf("%7s %-16s %2s %6s %9s %9s %9s", "PID", "COMM", "S", "CPU%", "RSS", "IO_R MB/s", "IO_W MB/s"))}
+	maxRows := height / 4
+	if maxRows < 5 {
+		maxRows = 5
+	}
+	for i, m := range members {
+		if i >= maxRows {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("... %d more", len(members)-maxRows)))
+			break
+		}
+		row := fmt.Sprintf("%7d %-16s %2s %5.1f%% %9s %9.2f %9.2f",
+			m.pid, truncate(m.comm, 16), m.state, m.cpu, fmtBytes(m.rss), m.ioR, m.ioW)
+		if m.state == "D" {
+			row = warnStyle.Render(row)
+		}
+		lines = append(lines, row)
+	}
+	return lines
+}
+
+// subU64 returns a-b, or 0 if the counter went backwards.
+func subU64(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// closeCgroupDetail leaves the detail view and drops its cached reads.
+func (m *Model) closeCgroupDetail() {
+	m.cgDetailMode = false
+	m.cgDetailPath = ""
+	m.cgDetail, m.cgDetailPrev, m.cgDetailErr = nil, nil, nil
+	m.scroll = 0
+}
+
+// cgDetailLive reports whether the detail view may read /sys/fs/cgroup:
+// only when this pane shows the live engine, not a replay or the compare
+// host.
+func (m Model) cgDetailLive() bool {
+	return m.replayPlayer() == nil && !m.comparePane
+}
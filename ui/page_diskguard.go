@@ -6,11 +6,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 func renderDiskGuardPage(snap *model.Snapshot, rates *model.RateSnapshot, result *model.AnalysisResult,
-	smartDisks []model.SMARTDisk, pm probeQuerier, diskGuardMode string, actionMsg string, frozen map[int]frozenProc, width, height int) string {
+	smartDisks []model.SMARTDisk, pm probeQuerier, diskGuardMode string, actionMsg string, frozen map[int]engine.FrozenProc, width, height int) string {
 
 	var sb strings.Builder
 	iw := pageInnerW(width)
@@ -63,7 +65,7 @@ func renderDiskGuardPage(snap *model.Snapshot, rates *model.RateSnapshot, result
 				if !mr.GrowthStarted.IsZero() {
 					dur := time.Since(mr.GrowthStarted)
 					sinceStr = fmt.Sprintf(" since %s (%s ago)",
-						mr.GrowthStarted.Format("15:04:05"), fmtDuration(int(dur.Seconds())))
+						util.FmtTime(mr.GrowthStarted, "15:04:05"), fmtDuration(int(dur.Seconds())))
 				}
 
 				alertStyle := warnStyle
@@ -74,7 +76,7 @@ func renderDiskGuardPage(snap *model.Snapshot, rates *model.RateSnapshot, result
 				sb.WriteString("\n")
 				sb.WriteString(alertStyle.Render(fmt.Sprintf(
 					"  >> DISK %s FULL AT %s — %.1f MB/s growth (%s)%s",
-					mr.MountPoint, fullAt.Format("15:04"), growthMBs, trend, sinceStr)))
+					mr.MountPoint, util.FmtTime(fullAt, "15:04"), growthMBs, trend, sinceStr)))
 				sb.WriteString("\n")
 			}
 		}
@@ -145,11 +147,11 @@ func renderDiskGuardPage(snap *model.Snapshot, rates *model.RateSnapshot, result
 			if mr.ETASeconds > 0 && mr.GrowthBytesPerSec > 1024 {
 				fullAt := time.Now().Add(time.Duration(mr.ETASeconds) * time.Second)
 				if mr.ETASeconds < 1800 {
-					fullAtStr = critStyle.Render(fullAt.Format("15:04!"))
+					fullAtStr = critStyle.Render(util.FmtTime(fullAt, "15:04!"))
 				} else if mr.ETASeconds < 7200 {
-					fullAtStr = warnStyle.Render(fullAt.Format("15:04"))
+					fullAtStr = warnStyle.Render(util.FmtTime(fullAt, "15:04"))
 				} else if mr.ETASeconds < 86400 {
-					fullAtStr = dimStyle.Render(fullAt.Format("15:04"))
+					fullAtStr = dimStyle.Render(util.FmtTime(fullAt, "15:04"))
 				} else {
 					fullAtStr = dimStyle.Render(fullAt.Format("Jan 2"))
 				}
@@ -267,6 +269,8 @@ func renderDiskGuardPage(snap *model.Snapshot, rates *model.RateSnapshot, result
 
 	sb.WriteString(boxSection("TOP WRITERS", writerLines, iw))
 
+	sb.WriteString(renderTopWritePaths(rates, iw))
+
 	// Section 3: BIGGEST FILES
 	var bigLines []string
 	bigHdr := fmt.Sprintf("%s %s %s",
@@ -287,7 +291,7 @@ func renderDiskGuardPage(snap *model.Snapshot, rates *model.RateSnapshot, result
 			} else if bf.SizeBytes > 100*1024*1024 {
 				sizeStr = warnStyle.Render(sizeStr)
 			}
-			modStr := dimStyle.Render(time.Unix(bf.ModTime, 0).Format("Jan 02 15:04"))
+			modStr := dimStyle.Render(util.FmtTime(time.Unix(bf.ModTime, 0), "Jan 02 15:04"))
 			line := fmt.Sprintf("%s %s %s",
 				styledPad(sizeStr, 10),
 				styledPad(modStr, 20),
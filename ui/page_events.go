@@ -5,16 +5,24 @@ import (
 	"strings"
 
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
-func renderEventsPage(active *model.Event, completed []model.Event, selected int, width, height int) string {
+// renderEventsPage renders the incident list. completed is already filtered;
+// filter is the query that produced it ("" = none) and unfiltered the number
+// of completed events before filtering.
+func renderEventsPage(active *model.Event, completed []model.Event, notes []model.TimelineEntry, selected int, filter string, unfiltered int, width, height int) string {
 	var sb strings.Builder
 
-	total := len(completed)
+	total := unfiltered
 	if active != nil {
 		total++
 	}
 	sb.WriteString(titleStyle.Render(fmt.Sprintf("EVENTS  (%d events)", total)))
+	if filter != "" {
+		sb.WriteString("  " + headerStyle.Render("filter: ") + valueStyle.Render(filter) +
+			dimStyle.Render(fmt.Sprintf("  %d of %d match", len(completed), unfiltered)))
+	}
 	sb.WriteString("\n\n")
 
 	// Active incident banner
@@ -22,7 +30,7 @@ func renderEventsPage(active *model.Event, completed []model.Event, selected int
 		sb.WriteString(critStyle.Render("  ACTIVE INCIDENT"))
 		sb.WriteString("\n")
 		sb.WriteString(fmt.Sprintf("  Started: %s  Bottleneck: %s  Score: %s  ",
-			valueStyle.Render(active.StartTime.Format("15:04:05")),
+			valueStyle.Render(util.FmtTime(active.StartTime, "15:04:05")),
 			warnStyle.Render(active.Bottleneck),
 			scoreColor(active.PeakScore).Render(fmt.Sprintf("%d", active.PeakScore)),
 		))
@@ -35,9 +43,33 @@ func renderEventsPage(active *model.Event, completed []model.Event, selected int
 			sb.WriteString(fmt.Sprintf("  Chain: %s", orangeStyle.Render(active.CausalChain)))
 			sb.WriteString("\n")
 		}
+		for _, te := range active.Timeline {
+			if te.IsNote() {
+				sb.WriteString(fmt.Sprintf("  %s  %s\n", dimStyle.Render(util.FmtTime(te.Time, "15:04:05")), renderNoteEntry(te)))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	// Standalone operator notes (taken while no incident was open)
+	if len(notes) > 0 {
+		sb.WriteString(headerStyle.Render("  OPERATOR NOTES"))
+		sb.WriteString("\n")
+		shown := notes
+		if len(shown) > 5 {
+			shown = shown[len(shown)-5:]
+		}
+		for _, n := range shown {
+			sb.WriteString(fmt.Sprintf("  %s  %s\n", dimStyle.Render(util.FmtTime(n.Time, "01-02 15:04:05")), renderNoteEntry(n)))
+		}
 		sb.WriteString("\n")
 	}
 
+	if len(completed) == 0 && filter != "" {
+		sb.WriteString(dimStyle.Render("  No events match the filter  (f:edit  b:clear)"))
+		sb.WriteString("\n")
+		return sb.String()
+	}
 	if len(completed) == 0 {
 		if active == nil {
 			sb.WriteString(okStyle.Render("  No events detected yet — system is healthy"))
@@ -56,9 +88,9 @@ func renderEventsPage(active *model.Event, completed []model.Event, selected int
 	sb.WriteString("\n")
 
 	for i, evt := range completed {
-		timeRange := evt.StartTime.Format("15:04:05")
+		timeRange := util.FmtTime(evt.StartTime, "15:04:05")
 		if !evt.EndTime.IsZero() {
-			timeRange += "-" + evt.EndTime.Format("15:04:05")
+			timeRange += "-" + util.FmtTime(evt.EndTime, "15:04:05")
 		}
 
 		dur := fmt.Sprintf("%ds", evt.Duration)
@@ -103,20 +135,29 @@ func renderEventsPage(active *model.Event, completed []model.Event, selected int
 			if len(evt.Timeline) > 0 {
 				sb.WriteString(dimStyle.Render("    Timeline:") + "\n")
 				for _, te := range evt.Timeline {
-					ts := te.Time.Format("15:04:05")
-					sb.WriteString(fmt.Sprintf("      %s  %s\n",
-						dimStyle.Render(ts), valueStyle.Render(te.Message)))
+					ts := util.FmtTime(te.Time, "15:04:05")
+					msg := valueStyle.Render(te.Message)
+					if te.IsNote() {
+						msg = renderNoteEntry(te)
+					}
+					sb.WriteString(fmt.Sprintf("      %s  %s\n", dimStyle.Render(ts), msg))
 				}
 			}
 		}
 	}
 
 	sb.WriteString("\n")
-	sb.WriteString(pageFooter("j/k:navigate Enter:jump E:export"))
+	sb.WriteString(pageFooter("j/k:navigate Enter:jump f:filter c:note P:export"))
 
 	return sb.String()
 }
 
+// renderNoteEntry styles an operator note so it stands apart from
+// machine-observed milestones.
+func renderNoteEntry(te model.TimelineEntry) string {
+	return orangeStyle.Render("✎ ") + valueStyle.Render(te.Message)
+}
+
 func healthStyled(h model.HealthLevel) string {
 	return renderHealthBadge(h.String())
 }
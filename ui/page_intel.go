@@ -10,6 +10,7 @@ import (
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
 	"github.com/ftahirops/xtop/store"
+	"github.com/ftahirops/xtop/util"
 )
 
 // ── Intel page collapsible section constants ────────────────────────────────
@@ -621,7 +622,7 @@ func renderIntelAutopilotContent(ap *engine.Autopilot, iw int) string {
 			styledPad(warnStyle.Render(target), 16),
 			styledPad(dimStyle.Render(oldVal), 16),
 			styledPad(valueStyle.Render(newVal), 16),
-			dimStyle.Render(a.Timestamp.Format("15:04:05")))
+			dimStyle.Render(util.FmtTime(a.Timestamp, "15:04:05")))
 		sb.WriteString(boxRow(row, iw) + "\n")
 	}
 
@@ -679,7 +680,7 @@ func renderIntelIncidentsContent(incidents []store.IncidentRecord, st *store.Sto
 		}
 
 		row := fmt.Sprintf("  %s %s %s %s %s %s",
-			styledPad(dimStyle.Render(inc.StartTime.Format("Jan 02 15:04:05")), 20),
+			styledPad(dimStyle.Render(util.FmtTime(inc.StartTime, "Jan 02 15:04:05")), 20),
 			styledPad(dimStyle.Render(durStr), 10),
 			styledPad(warnStyle.Render(bn), 14),
 			styledPad(scoreStyle.Render(fmt.Sprintf("%d", inc.PeakScore)), 8),
@@ -695,16 +696,11 @@ func renderIntelIncidentsContent(incidents []store.IncidentRecord, st *store.Sto
 // ── Helpers ─────────────────────────────────────────────────────────────────
 
 func formatBytes(b uint64) string {
-	switch {
-	case b >= 1<<30:
-		return fmt.Sprintf("%.1f GB", float64(b)/float64(1<<30))
-	case b >= 1<<20:
-		return fmt.Sprintf("%.1f MB", float64(b)/float64(1<<20))
-	case b >= 1<<10:
-		return fmt.Sprintf("%.1f KB", float64(b)/float64(1<<10))
-	default:
+	v, i := util.ScaleBytes(float64(b))
+	if i == 0 {
 		return fmt.Sprintf("%d B", b)
 	}
+	return util.FmtFloat(v, 1) + " " + util.ByteUnit(i, false)
 }
 
 func formatDurationSec(secs int) string {
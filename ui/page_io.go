@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -93,6 +94,11 @@ func renderIOPage(snap *model.Snapshot, rates *model.RateSnapshot, result *model
 	}
 	sb.WriteString(boxSection("PER-DEVICE BREAKDOWN", devLines, iw))
 
+	// === Queue settings (scheduler / nr_requests / read-ahead) ===
+	if len(snap.Global.BlockQueues) > 0 {
+		sb.WriteString(renderQueueSettings(snap.Global.BlockQueues, iw))
+	}
+
 	// === IO type analysis ===
 	var ioLines []string
 	if rates != nil && len(rates.DiskRates) > 0 {
@@ -163,6 +169,8 @@ func renderIOPage(snap *model.Snapshot, rates *model.RateSnapshot, result *model
 	}
 	sb.WriteString(boxSection("TOP CGROUPS BY IO", cgLines, iw))
 
+	sb.WriteString(renderTopWritePaths(rates, iw))
+
 	// === Top PIDs by IO ===
 	var procLines []string
 	procLines = append(procLines, dimStyle.Render(fmt.Sprintf("%7s %-16s %5s %10s %10s", "PID", "COMMAND", "STATE", "READ MB/s", "WRITE MB/s")))
@@ -203,6 +211,86 @@ func renderIOPage(snap *model.Snapshot, rates *model.RateSnapshot, result *model
 	return sb.String()
 }
 
+// renderTopWritePaths lists the host-wide heaviest written file paths
+// (engine.WritePathTracker). Shared by the IO and DiskGuard pages.
+func renderTopWritePaths(rates *model.RateSnapshot, iw int) string {
+	var lines []string
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("%10s %7s  %-22s %-12s %s", "WRITE MB/s", "WRITERS", "TOP PROCESS", "MOUNT", "PATH")))
+	if rates == nil {
+		lines = append(lines, dimStyle.Render("(collecting...)"))
+		return boxSection("TOP WRITTEN PATHS", lines, iw)
+	}
+	if len(rates.TopWritePaths) == 0 {
+		lines = append(lines, dimStyle.Render("  no file writes attributed"))
+		return boxSection("TOP WRITTEN PATHS", lines, iw)
+	}
+	pathW := iw - 59
+	if pathW < 20 {
+		pathW = 20
+	}
+	for _, wp := range rates.TopWritePaths {
+		rate := fmt.Sprintf("%10.2f", wp.WriteMBs)
+		switch {
+		case wp.WriteMBs >= 50:
+			rate = critStyle.Render(rate)
+		case wp.WriteMBs >= 10:
+			rate = warnStyle.Render(rate)
+		}
+		writers := fmt.Sprintf("%7d", wp.Writers)
+		if wp.Writers == 0 {
+			writers = dimStyle.Render(fmt.Sprintf("%7s", "idle"))
+		}
+		top := ""
+		if wp.TopPID > 0 {
+			top = fmt.Sprintf("%s[%d]", truncate(wp.TopComm, 14), wp.TopPID)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s  %-22s %-12s %s",
+			rate, writers, top, truncate(wp.Mount, 12), valueStyle.Render(truncate(wp.Path, pathW))))
+	}
+	return boxSection("TOP WRITTEN PATHS", lines, iw)
+}
+
+// renderQueueSettings lists each disk's block-layer queue settings and,
+// under any device whose settings don't suit its hardware, the suggested
+// value with the command to apply it now and the udev rule to keep it.
+func renderQueueSettings(queues []model.BlockQueue, iw int) string {
+	var lines []string
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("%-10s %-7s %-12s %7s %7s %-5s %s",
+		"DEVICE", "TYPE", "SCHEDULER", "NR_REQ", "RA KB", "ROT", "RAID")))
+	for _, q := range queues {
+		sched := q.Scheduler
+		if sched == "" {
+			sched = "-"
+		}
+		rot := "no"
+		if q.Rotational {
+			rot = "yes"
+		}
+		raid := q.RAID
+		if raid == "" {
+			raid = "-"
+		}
+		row := fmt.Sprintf("%-10s %-7s %-12s %7d %7d %-5s %s",
+			q.Device, q.Transport, sched, q.NrRequests, q.ReadAheadKB, rot, raid)
+		switch {
+		case len(q.Advice) == 0:
+			lines = append(lines, row)
+		case slices.ContainsFunc(q.Advice, func(a model.BlockQueueAdvice) bool { return a.Severity == "warn" }):
+			lines = append(lines, warnStyle.Render(row))
+		default:
+			lines = append(lines, orangeStyle.Render(row))
+		}
+		for _, a := range q.Advice {
+			lines = append(lines, fmt.Sprintf("  %s %s %s → %s",
+				orangeStyle.Render("▸"), a.Setting, a.Current, okStyle.Render(a.Suggested)))
+			lines = append(lines, dimStyle.Render("    "+a.Reason))
+			lines = append(lines, dimStyle.Render("    now:     ")+a.Fix)
+			lines = append(lines, dimStyle.Render("    persist: ")+a.Udev)
+		}
+	}
+	return boxSection("QUEUE SETTINGS", lines, iw)
+}
+
 // renderDiskHealth renders the DISK HEALTH section with life gauge and failure prediction.
 func renderDiskHealth(disks []model.SMARTDisk, iw int) string {
 	var lines []string
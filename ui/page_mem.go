@@ -220,10 +220,15 @@ func renderMemPage(snap *model.Snapshot, rates *model.RateSnapshot, result *mode
 			limitStr = fmtBytes(cg.MemLimit)
 		}
 		cgLines = append(cgLines, fmt.Sprintf("%-28s %10s %8s %6d %8d",
-			name, fmtBytes(cg.MemCurrent), limitStr, cg.OOMKills, cg.PgMajFault))
+			name, fmtBytes(cg.MemCurrent), limitStr, cg.OwnOOMKills(), cg.PgMajFault))
 	}
 	sb.WriteString(boxSection("TOP CGROUPS BY MEMORY", cgLines, iw))
 
+	// === OOM-killer standing of critical services ===
+	if len(snap.Global.OOMScores) > 0 {
+		sb.WriteString(renderOOMScores(snap.Global.OOMScores, iw))
+	}
+
 	// === Top PIDs by RSS ===
 	var procLines []string
 	procLines = append(procLines, dimStyle.Render(fmt.Sprintf("%7s %-16s %10s %10s %8s %10s",
@@ -349,3 +354,41 @@ func renderMemPage(snap *model.Snapshot, rates *model.RateSnapshot, result *mode
 
 	return sb.String()
 }
+
+// renderOOMScores lists sshd, databases and monitoring agents with their
+// oom_score_adj and victim rank and, under any the kernel is set up to
+// kill, the suggested OOMScoreAdjust with the command to apply it now and
+// the systemd drop-in to keep it.
+func renderOOMScores(svcs []model.OOMScoreService, iw int) string {
+	var lines []string
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("%7s %-24s %-10s %7s %6s %6s",
+		"PID", "SERVICE", "ROLE", "ADJ", "SCORE", "RANK")))
+	for _, s := range svcs {
+		name := s.Comm
+		if s.Unit != "" {
+			name = s.Unit
+		}
+		if len(name) > 24 {
+			name = name[:21] + "..."
+		}
+		row := fmt.Sprintf("%7d %-24s %-10s %7d %6d %6s",
+			s.PID, name, s.Role, s.ScoreAdj, s.Score, fmt.Sprintf("#%d", s.Rank))
+		switch s.Severity {
+		case "":
+			lines = append(lines, row)
+			continue
+		case "warn":
+			lines = append(lines, warnStyle.Render(row))
+		default:
+			lines = append(lines, orangeStyle.Render(row))
+		}
+		lines = append(lines, fmt.Sprintf("  %s oom_score_adj %d → %s",
+			orangeStyle.Render("▸"), s.ScoreAdj, okStyle.Render(fmt.Sprintf("%d", s.Suggested))))
+		lines = append(lines, dimStyle.Render("    "+s.Reason))
+		lines = append(lines, dimStyle.Render("    now:     ")+s.Fix)
+		if s.Persist != "" {
+			lines = append(lines, dimStyle.Render("    persist: ")+s.Persist)
+		}
+	}
+	return boxSection("OOM PROTECTION", lines, iw)
+}
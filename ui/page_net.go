@@ -59,6 +59,11 @@ func renderNetPage(snap *model.Snapshot, rates *model.RateSnapshot,
 		sb.WriteString(buildNetIntelligenceSummary(snap, rates, result, iw))
 	}
 
+	// Slow dependencies: which remote endpoints are adding latency (degraded only)
+	if result != nil && result.Health >= model.HealthDegraded && len(result.SlowDependencies) > 0 {
+		sb.WriteString(renderSlowDependencies(result.SlowDependencies, iw))
+	}
+
 	// Focus mode: only show RCA summary + intelligence + key hint
 	if focusMode {
 		sb.WriteString(dimStyle.Render("  F:exit focus  C:collapse all  j/k:navigate  Enter:expand") + "\n")
@@ -100,6 +105,39 @@ func renderNetPage(snap *model.Snapshot, rates *model.RateSnapshot,
 	return sb.String()
 }
 
+// renderSlowDependencies lists remote endpoints ranked by latency added over
+// their healthy baseline: "10.0.0.5:443 (https)  +180ms p95 → php-fpm".
+func renderSlowDependencies(deps []model.DependencyLatency, iw int) string {
+	var lines []string
+	for _, d := range deps {
+		dst := resolveIP(d.Endpoint)
+		if d.Service != "" {
+			dst += " (" + d.Service + ")"
+		}
+		var added string
+		if d.BaselineMs > 0 {
+			added = fmt.Sprintf("+%.0fms p95", d.AddedMs)
+		} else {
+			added = fmt.Sprintf("%.0fms p95", d.P95Ms)
+		}
+		style := warnStyle
+		if d.AddedMs >= 200 {
+			style = critStyle
+		}
+		line := fmt.Sprintf("%s  %s %s", style.Render(fmt.Sprintf("%-12s", added)),
+			truncate(dst, 40), dimStyle.Render("\u2192")+" "+valueStyle.Render(d.Comm))
+		var ctx string
+		if d.BaselineMs > 0 {
+			ctx = fmt.Sprintf("baseline %.0fms", d.BaselineMs)
+		} else {
+			ctx = "no baseline"
+		}
+		ctx += fmt.Sprintf(", %d samples, %s", d.Samples, d.Source)
+		lines = append(lines, line+"  "+dimStyle.Render("("+ctx+")"))
+	}
+	return boxSection("SLOW DEPENDENCIES", lines, iw)
+}
+
 // renderNetSectionHeader renders a collapsible section header line.
 func renderNetSectionHeader(title, summary string, selected, expanded bool, iw int) string {
 	arrow := "\u25b6" // ▶
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ftahirops/xtop/collector/phpfpm"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // renderPHPFPMPage shows two modes:
@@ -382,7 +383,7 @@ func renderPHPFPMDetail(sb *strings.Builder, a *model.PHPFPMApp, scrollY, iw, he
 				break
 			}
 			raw.WriteString(fmt.Sprintf("    %s %8d  %-16s  %s\n",
-				critStyle.Render("[shell] "), f.Size, f.ModTime.Format("2006-01-02 15:04"), f.Path))
+				critStyle.Render("[shell] "), f.Size, util.FmtTime(f.ModTime, "2006-01-02 15:04"), f.Path))
 			raw.WriteString(fmt.Sprintf("                                                %s\n", dimStyle.Render("→ "+f.Signal)))
 		}
 		for i, f := range a.FSBinaries {
@@ -391,7 +392,7 @@ func renderPHPFPMDetail(sb *strings.Builder, a *model.PHPFPMApp, scrollY, iw, he
 				break
 			}
 			raw.WriteString(fmt.Sprintf("    %s %8d  %-16s  %s\n",
-				critStyle.Render("[binary]"), f.Size, f.ModTime.Format("2006-01-02 15:04"), f.Path))
+				critStyle.Render("[binary]"), f.Size, util.FmtTime(f.ModTime, "2006-01-02 15:04"), f.Path))
 			raw.WriteString(fmt.Sprintf("                                                %s\n", dimStyle.Render("→ "+f.Signal)))
 		}
 	}
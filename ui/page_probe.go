@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // Probe page section constants
@@ -24,11 +25,12 @@ const (
 	probSecSwapEvict  = 10
 	probSecSyscall    = 11
 	probSecSockIO     = 12
-	probSecCount      = 13
+	probSecNetWait    = 13
+	probSecCount      = 14
 )
 
 // renderProbePage renders the full probe investigation page (page 8).
-func renderProbePage(pm *engine.ProbeManager, snap *model.Snapshot, width, height int, cursor int, expanded [13]bool, intermediate bool) string {
+func renderProbePage(pm *engine.ProbeManager, snap *model.Snapshot, width, height int, cursor int, expanded [probSecCount]bool, intermediate bool) string {
 	var sb strings.Builder
 
 	if pm == nil {
@@ -284,7 +286,7 @@ func renderProbeSectionHeader(title string, hasData bool, count int, selected, e
 	return titleStyle.Render(line) + "\n"
 }
 
-func renderProbeDone(pm *engine.ProbeManager, width int, cursor int, expanded [13]bool, intermediate bool) string {
+func renderProbeDone(pm *engine.ProbeManager, width int, cursor int, expanded [probSecCount]bool, intermediate bool) string {
 	var sb strings.Builder
 	f := pm.Findings()
 	if f == nil {
@@ -639,19 +641,53 @@ func renderProbeDone(pm *engine.ProbeManager, width int, cursor int, expanded [1
 		}
 	}
 
+	// Network wait: which dependency threads are idle-waiting on
+	if hdr := renderProbeSectionHeader("Network Wait (dependencies)", len(f.NetWait) > 0, len(f.NetWait), cursor == probSecNetWait, expanded[probSecNetWait]); hdr != "" {
+		sb.WriteString(hdr)
+		if expanded[probSecNetWait] {
+			if intermediate && len(f.NetWait) > 0 {
+				top := f.NetWait[0]
+				sb.WriteString("  " + dimStyle.Render(probeInterpretNetWait(top.Comm, top.DstAddr, top.WaitPct, top.Connecting)) + "\n")
+			}
+			sb.WriteString(boxTop(innerW) + "\n")
+			hdrLine := fmt.Sprintf("  %s %s %s %s %s %s", styledPad(dimStyle.Render("PID"), 8), styledPad(dimStyle.Render("CMD"), 12), styledPad(dimStyle.Render("WAITING IN"), 13), styledPad(dimStyle.Render("DEST"), 28), styledPad(dimStyle.Render("CONNS"), 6), dimStyle.Render("WAIT"))
+			sb.WriteString(boxRow(hdrLine, innerW) + "\n")
+			sb.WriteString(boxMid(innerW) + "\n")
+			for _, e := range f.NetWait {
+				dest := e.DstAddr
+				if e.Service != "" {
+					dest = fmt.Sprintf("%s (%s)", e.DstAddr, e.Service)
+				}
+				sys := e.Syscall
+				if e.Connecting {
+					sys += "*"
+				}
+				ws := dimStyle
+				if e.Connecting || e.WaitPct >= 100 {
+					ws = critStyle
+				} else if e.WaitPct >= 30 {
+					ws = warnStyle
+				}
+				wait := fmt.Sprintf("%.0f%%", e.WaitPct)
+				if e.MaxThreads > 1 {
+					wait += fmt.Sprintf(" (%d thr)", e.MaxThreads)
+				}
+				row := fmt.Sprintf("  %s %s %s %s %s %s", styledPad(dimStyle.Render(fmt.Sprintf("%d", e.PID)), 8), styledPad(valueStyle.Render(truncate(e.Comm, 10)), 12), styledPad(dimStyle.Render(truncate(sys, 12)), 13), styledPad(dimStyle.Render(truncate(dest, 26)), 28), styledPad(dimStyle.Render(fmt.Sprintf("%d", e.Conns)), 6), ws.Render(wait))
+				sb.WriteString(boxRow(row, innerW) + "\n")
+			}
+			sb.WriteString(boxBot(innerW) + "\n")
+			sb.WriteString("  " + dimStyle.Render("WAIT = thread-time blocked / probe window; * = still in TCP handshake") + "\n")
+		}
+	}
+
 	return sb.String()
 }
 
 // formatProbeBytes formats bytes into a human-readable string (KB/MB/GB).
 func formatProbeBytes(b uint64) string {
-	switch {
-	case b >= 1<<30:
-		return fmt.Sprintf("%.1fGB", float64(b)/(1<<30))
-	case b >= 1<<20:
-		return fmt.Sprintf("%.1fMB", float64(b)/(1<<20))
-	case b >= 1<<10:
-		return fmt.Sprintf("%.1fKB", float64(b)/(1<<10))
-	default:
+	v, i := util.ScaleBytes(float64(b))
+	if i == 0 {
 		return fmt.Sprintf("%dB", b)
 	}
+	return util.FmtFloat(v, 1) + util.ByteUnit(i, false)
 }
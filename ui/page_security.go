@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
 )
 
 // ──────────────────────────────────────────────────────────────────────────────
@@ -590,7 +591,7 @@ func renderSecSUIDContent(sec model.SecurityMetrics, iw int) string {
 		for _, s := range sec.SUIDAnomalies {
 			sb.WriteString(fmt.Sprintf("  %s %s\n",
 				critStyle.Render(padRight(s.Path, 48)),
-				dimStyle.Render(s.ModTime.Format("2006-01-02 15:04"))))
+				dimStyle.Render(util.FmtTime(s.ModTime, "2006-01-02 15:04"))))
 		}
 		sb.WriteString(secContext(
 			"SUID binaries run with root privileges regardless of who executes them. New ones could be privilege escalation backdoors.",
@@ -112,6 +112,46 @@ func renderServicesPage(snap *model.Snapshot, rates *model.RateSnapshot, result
 		sb.WriteString(boxSection("TCP PROBES", tcpLines, iw))
 	}
 
+	// === PROBE DIAGNOSTICS ===
+	// Per-stage failure counts over the recent probe window, for probes
+	// that have failed at least once or saw an intercepting middlebox.
+	var diagLines []string
+	for _, p := range append(httpProbes, tcpProbes...) {
+		st := p.Stats
+		if st.Failures() == 0 && !p.Intercepted {
+			continue
+		}
+		if len(diagLines) == 0 {
+			diagLines = append(diagLines, fmt.Sprintf("  %s %s %s %s %s %s %s",
+				styledPad(dimStyle.Render("TARGET"), 30),
+				styledPad(dimStyle.Render("DNS"), 6),
+				styledPad(dimStyle.Render("TCP"), 6),
+				styledPad(dimStyle.Render("TLS"), 6),
+				styledPad(dimStyle.Render("HTTP"), 6),
+				styledPad(dimStyle.Render("OF"), 5),
+				dimStyle.Render("LAST FAILURE")))
+			diagLines = append(diagLines, dimStyle.Render("  "+strings.Repeat("─", iw-4)))
+		}
+		last := dimStyle.Render("—")
+		if p.FailStage != "" {
+			last = critStyle.Render(strings.ToUpper(p.FailStage)) + " " + dimStyle.Render(truncate(p.Detail, 40))
+		}
+		diagLines = append(diagLines, fmt.Sprintf("  %s %s %s %s %s %s %s",
+			styledPad(valueStyle.Render(truncate(p.Target, 28)), 30),
+			styledPad(stageCount(st.DNS), 6),
+			styledPad(stageCount(st.TCP), 6),
+			styledPad(stageCount(st.TLS), 6),
+			styledPad(stageCount(st.HTTP), 6),
+			styledPad(dimStyle.Render(fmt.Sprintf("%d", st.Probes)), 5),
+			last))
+		if p.Intercepted {
+			diagLines = append(diagLines, "    "+warnStyle.Render("TLS intercepted: ")+dimStyle.Render(truncate(p.InterceptReason, iw-24)))
+		}
+	}
+	if len(diagLines) > 0 {
+		sb.WriteString(boxSection("PROBE DIAGNOSTICS", diagLines, iw))
+	}
+
 	// === CERTIFICATE EXPIRY ===
 	if len(certProbes) > 0 {
 		var certLines []string
@@ -156,6 +196,7 @@ func renderServicesPage(snap *model.Snapshot, rates *model.RateSnapshot, result
 		hintLines = append(hintLines, dimStyle.Render("  Probes auto-discover from listening ports (5432, 3306, 6379, etc.)"))
 		hintLines = append(hintLines, dimStyle.Render("  and web ports (80, 443, 8080, 8443)."))
 		hintLines = append(hintLines, dimStyle.Render("  Cert files are scanned from /etc/letsencrypt/live/*/cert.pem"))
+		hintLines = append(hintLines, dimStyle.Render("  Add remote targets with XTOP_PROBE_TARGETS=https://api.example.com/,db:5432"))
 		sb.WriteString(boxSection("PROBE DISCOVERY", hintLines, iw))
 	}
 	sb.WriteString(pageFooter(""))
@@ -167,6 +208,13 @@ func statusBadge(status string) string {
 	return renderHealthBadge(status)
 }
 
+func stageCount(n int) string {
+	if n == 0 {
+		return dimStyle.Render("0")
+	}
+	return warnStyle.Render(fmt.Sprintf("%d", n))
+}
+
 func certDaysStr(days int) string {
 	if days < 0 {
 		return dimStyle.Render("—")
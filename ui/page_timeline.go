@@ -9,44 +9,26 @@ import (
 	"github.com/ftahirops/xtop/engine"
 )
 
-func renderTimelinePage(history *engine.History, width, height int) string {
-	var sb strings.Builder
+// timelineChart is one series shown on the Timeline page.
+type timelineChart struct {
+	label  string
+	data   []float64
+	maxVal float64
+	height int // rows in block-character mode
+	color  func(float64, float64) lipgloss.Style
+}
 
+// timelineCharts gathers the Timeline series from history, oldest first.
+func timelineCharts(history *engine.History) []timelineChart {
 	n := history.Len()
-	if n < 2 {
-		sb.WriteString(titleStyle.Render("TIMELINE"))
-		sb.WriteString("\n")
-		sb.WriteString(dimStyle.Render("  Need more data (collecting...)"))
-		return sb.String()
-	}
-
-	// Use all available samples
-	maxSamples := n
-
-	// Time range info
-	oldest := history.Get(0)
-	latest := history.Latest()
-	timeRange := ""
-	var startTime, endTime time.Time
-	if oldest != nil && latest != nil {
-		startTime = oldest.Timestamp
-		endTime = latest.Timestamp
-		dur := endTime.Sub(startTime)
-		timeRange = fmt.Sprintf(" (%s, %d samples)",
-			formatDuration(dur), maxSamples)
-	}
-	sb.WriteString(titleStyle.Render("TIMELINE") + dimStyle.Render(timeRange))
-	sb.WriteString("\n\n")
-
-	// Gather all data series
-	cpuBusy := make([]float64, maxSamples)
-	memUsedPct := make([]float64, maxSamples)
-	cpuPSI := make([]float64, maxSamples)
-	memPSI := make([]float64, maxSamples)
-	ioPSI := make([]float64, maxSamples)
-	dStates := make([]float64, maxSamples)
-
-	for i := 0; i < maxSamples; i++ {
+	cpuBusy := make([]float64, n)
+	memUsedPct := make([]float64, n)
+	cpuPSI := make([]float64, n)
+	memPSI := make([]float64, n)
+	ioPSI := make([]float64, n)
+	dStates := make([]float64, n)
+
+	for i := 0; i < n; i++ {
 		s := history.Get(i)
 		if s == nil {
 			continue
@@ -85,39 +67,96 @@ func renderTimelinePage(history *engine.History, width, height int) string {
 		dStates[i] = float64(ds)
 	}
 
-	// Chart dimensions
-	chartH := 6
-	chartW := width - 2
-	if chartW < 30 {
-		chartW = 30
+	return []timelineChart{
+		{"CPU Load %", cpuBusy, autoScale(cpuBusy, 100), 6, pctChartColor},
+		{"Memory Used %", memUsedPct, autoScale(memUsedPct, 100), 6, pctChartColor},
+		{"CPU PSI (some avg10)", cpuPSI, autoScale(cpuPSI, 50), 6, psiChartColor},
+		{"IO PSI (full avg10)", ioPSI, autoScale(ioPSI, 50), 6, psiChartColor},
+		{"MEM PSI (full avg10)", memPSI, autoScale(memPSI, 50), 6, psiChartColor},
+		{"D-State Tasks", dStates, autoScale(dStates, 20), 4, dStateChartColor},
 	}
+}
 
-	// Render multi-line area charts with auto-scaled Y-axis
-	sb.WriteString(areaChart(cpuBusy, "CPU Load %", chartW, chartH, 0, autoScale(cpuBusy, 100), pctChartColor, startTime, endTime))
-	sb.WriteString("\n\n")
+func dStateChartColor(val, ratio float64) lipgloss.Style {
+	if val >= 5 {
+		return critStyle
+	}
+	if val >= 1 {
+		return warnStyle
+	}
+	return okStyle
+}
 
-	sb.WriteString(areaChart(memUsedPct, "Memory Used %", chartW, chartH, 0, autoScale(memUsedPct, 100), pctChartColor, startTime, endTime))
-	sb.WriteString("\n\n")
+// timelineImageRows returns the chart height (in rows) that fits every
+// Timeline chart on screen, or 0 when images would not fit. Inline images
+// must never run past the bottom of the screen: the terminal would scroll
+// and tear the frame.
+func timelineImageRows(height, charts int) int {
+	// Title, blank, footer, OOM notice and status bar; each chart adds a
+	// title, time labels and a blank separator around its rows.
+	avail := height - 9 - 3*charts
+	rows := avail / charts
+	if rows > 10 {
+		rows = 10
+	}
+	if rows < 3 {
+		return 0
+	}
+	return rows
+}
 
-	sb.WriteString(areaChart(cpuPSI, "CPU PSI (some avg10)", chartW, chartH, 0, autoScale(cpuPSI, 50), psiChartColor, startTime, endTime))
-	sb.WriteString("\n\n")
+// renderTimelinePage draws the rolling history charts. With an image
+// protocol the plots are real line charts; otherwise, or when the terminal
+// is too short to fit them all, block-character area charts.
+func renderTimelinePage(history *engine.History, width, height int, proto imageProto) string {
+	var sb strings.Builder
 
-	sb.WriteString(areaChart(ioPSI, "IO PSI (full avg10)", chartW, chartH, 0, autoScale(ioPSI, 50), psiChartColor, startTime, endTime))
-	sb.WriteString("\n\n")
+	n := history.Len()
+	if n < 2 {
+		sb.WriteString(titleStyle.Render("TIMELINE"))
+		sb.WriteString("\n")
+		sb.WriteString(dimStyle.Render("  Need more data (collecting...)"))
+		return sb.String()
+	}
 
-	sb.WriteString(areaChart(memPSI, "MEM PSI (full avg10)", chartW, chartH, 0, autoScale(memPSI, 50), psiChartColor, startTime, endTime))
+	// Time range info
+	oldest := history.Get(0)
+	latest := history.Latest()
+	timeRange := ""
+	var startTime, endTime time.Time
+	if oldest != nil && latest != nil {
+		startTime = oldest.Timestamp
+		endTime = latest.Timestamp
+		dur := endTime.Sub(startTime)
+		timeRange = fmt.Sprintf(" (%s, %d samples)",
+			formatDuration(dur), n)
+	}
+	sb.WriteString(titleStyle.Render("TIMELINE") + dimStyle.Render(timeRange))
 	sb.WriteString("\n\n")
 
-	sb.WriteString(areaChart(dStates, "D-State Tasks", chartW, 4, 0, autoScale(dStates, 20),
-		func(val, ratio float64) lipgloss.Style {
-			if val >= 5 {
-				return critStyle
-			}
-			if val >= 1 {
-				return warnStyle
-			}
-			return okStyle
-		}, startTime, endTime))
+	charts := timelineCharts(history)
+
+	// Chart dimensions
+	chartW := width - 2
+	if chartW < 30 {
+		chartW = 30
+	}
+	imgRows := 0
+	if proto != imageNone {
+		imgRows = timelineImageRows(height, len(charts))
+	}
+
+	// Render multi-line charts with auto-scaled Y-axis
+	for i, c := range charts {
+		if i > 0 {
+			sb.WriteString("\n\n")
+		}
+		if imgRows > 0 {
+			sb.WriteString(imageChart(proto, kittyImageID+i, c.data, c.label, chartW, imgRows, 0, c.maxVal, c.color, startTime, endTime))
+		} else {
+			sb.WriteString(areaChart(c.data, c.label, chartW, c.height, 0, c.maxVal, c.color, startTime, endTime))
+		}
+	}
 	sb.WriteString("\n")
 
 	// OOM event notice — only show if BPF sentinel detected OOM kills this tick
@@ -1,9 +1,13 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/ftahirops/xtop/engine"
 	"github.com/ftahirops/xtop/model"
 )
@@ -141,6 +145,40 @@ func TestRenderIOPage_NoPanic(t *testing.T) {
 	}
 }
 
+func TestRenderCgroupDetail_NoPanic(t *testing.T) {
+	snap := testSnapshot()
+	snap.Cgroups = []model.CgroupMetrics{{Path: "/system.slice/mysql.service", Name: "mysql.service", MemCurrent: 512 << 20}}
+	detail := &model.CgroupDetail{
+		Path:      "/system.slice/mysql.service",
+		MemMax:    1 << 30,
+		MemEvents: model.CgroupMemEvents{High: 4},
+		IODevices: []model.CgroupIODevice{{Device: "sda", RBytes: 4096}},
+		PIDs:      []int{1234, 999},
+	}
+	got := renderCgroupDetail(snap, testRates(), nil, detail.Path, detail, nil, nil, false, 120, 40)
+	for _, want := range []string{"LIMITS vs USAGE", "MEMORY EVENTS", "mysqld", "sda"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderCgroupDetail missing %q", want)
+		}
+	}
+	if got := renderCgroupDetail(snap, nil, nil, "/gone", nil, nil, nil, false, 120, 40); got == "" {
+		t.Error("renderCgroupDetail returned empty string while loading")
+	}
+
+	// Replay: built from the snapshot, unrecorded fields labelled.
+	snap.Processes[0].CgroupPath = detail.Path
+	rec := recordedCgroupDetail(snap, detail.Path)
+	if len(rec.PIDs) != 1 || rec.PIDs[0] != snap.Processes[0].PID {
+		t.Errorf("recorded PIDs = %v, want [%d]", rec.PIDs, snap.Processes[0].PID)
+	}
+	got = renderCgroupDetail(snap, testRates(), nil, detail.Path, rec, nil, nil, true, 120, 40)
+	for _, want := range []string{"LIMITS vs USAGE", cgNotRecorded, "all devices"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("recorded renderCgroupDetail missing %q", want)
+		}
+	}
+}
+
 func TestRenderGPUPage_NoGPU(t *testing.T) {
 	snap := testSnapshot()
 	got := renderGPUPage(snap, 120, 40)
@@ -176,7 +214,7 @@ func TestRenderGPUPage_WithGPU(t *testing.T) {
 func TestRenderProbePage_NoPanic(t *testing.T) {
 	pm := engine.NewProbeManager()
 	snap := testSnapshot()
-	var expanded [13]bool
+	var expanded [probSecCount]bool
 	got := renderProbePage(pm, snap, 120, 40, 0, expanded, false)
 	if got == "" {
 		t.Error("renderProbePage returned empty string")
@@ -197,7 +235,7 @@ func TestExportHTML_NoPanic(t *testing.T) {
 	snap := testSnapshot()
 	rates := testRates()
 	result := testResult()
-	path, err := exportHTMLReport(snap, rates, result)
+	path, err := exportHTMLReport(snap, rates, result, nil)
 	if err != nil {
 		t.Fatalf("exportHTMLReport failed: %v", err)
 	}
@@ -214,7 +252,7 @@ func TestExportHTML_ContainsKeyElements(t *testing.T) {
 	snap := testSnapshot()
 	rates := testRates()
 	result := testResult()
-	path, err := exportHTMLReport(snap, rates, result)
+	path, err := exportHTMLReport(snap, rates, result, nil)
 	if err != nil {
 		t.Fatalf("exportHTMLReport failed: %v", err)
 	}
@@ -278,6 +316,55 @@ func TestMetricVerdict(t *testing.T) {
 	}
 }
 
+func TestDetectImageProto(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		pref string
+		want imageProto
+	}{
+		{map[string]string{"TERM": "xterm-256color"}, "", imageNone},
+		{map[string]string{"TERM": "xterm-kitty"}, "", imageKitty},
+		{map[string]string{"TERM_PROGRAM": "iTerm.app"}, "", imageITerm2},
+		{map[string]string{"TERM": "foot"}, "", imageSixel},
+		{map[string]string{"TERM": "xterm-kitty", "TMUX": "/tmp/tmux"}, "", imageNone},
+		{map[string]string{"TERM": "xterm-kitty"}, "off", imageNone},
+		{map[string]string{"TMUX": "/tmp/tmux"}, "sixel", imageSixel},
+		{map[string]string{"TERM": "xterm-kitty", "XTOP_IMAGES": "iterm2"}, "off", imageITerm2},
+	}
+	for _, tt := range tests {
+		got := detectImageProto(func(k string) string { return tt.env[k] }, tt.pref)
+		if got != tt.want {
+			t.Errorf("detectImageProto(%v, %q) = %v, want %v", tt.env, tt.pref, got, tt.want)
+		}
+	}
+}
+
+func TestTimelineImageCharts(t *testing.T) {
+	h := engine.NewHistory(60, 1)
+	for i := 0; i < 30; i++ {
+		snap := *testSnapshot()
+		snap.Global.PSI.IO.Full.Avg10 = float64(i)
+		h.Push(snap)
+	}
+
+	for _, proto := range []imageProto{imageKitty, imageITerm2, imageSixel} {
+		out := renderTimelinePage(h, 120, 60, proto)
+		marker := map[imageProto]string{imageKitty: "\x1b_Ga=T", imageITerm2: "\x1b]1337;File=", imageSixel: "\x1bP0;1;0q"}[proto]
+		if got := strings.Count(out, marker); got != 6 {
+			t.Errorf("%v: %d images, want 6", proto, got)
+		}
+		if lines := strings.Count(out, "\n") + 1; lines > 60-2 {
+			t.Errorf("%v: %d lines do not fit a 60-row terminal", proto, lines)
+		}
+	}
+
+	// Too short for every chart: fall back to block characters.
+	out := renderTimelinePage(h, 120, 30, imageKitty)
+	if strings.Contains(out, "\x1b_G") || !strings.Contains(out, "█") {
+		t.Error("short terminal should fall back to block charts")
+	}
+}
+
 // helpers for test cleanup
 func removeFile(path string) error {
 	return removeFileOS(path)
@@ -286,3 +373,194 @@ func removeFile(path string) error {
 func readFileBytes(path string) ([]byte, error) {
 	return readFileBytesOS(path)
 }
+
+func TestFitFrameAndTooSmall(t *testing.T) {
+	frame := strings.Repeat("─", 120) + "\n" + titleStyle.Render(strings.Repeat("x", 90)) + "\nshort\nextra"
+	got := strings.Split(fitFrame(frame, 80, 3), "\n")
+	if len(got) != 3 {
+		t.Fatalf("rows = %d, want 3", len(got))
+	}
+	for i, l := range got {
+		if w := lipgloss.Width(l); w > 80 {
+			t.Errorf("row %d is %d columns wide", i, w)
+		}
+	}
+	if got[2] != "short" {
+		t.Errorf("narrow row changed: %q", got[2])
+	}
+
+	if !termTooSmall(79, 40) || !termTooSmall(120, 23) || termTooSmall(80, 24) {
+		t.Error("termTooSmall boundaries wrong")
+	}
+	m := Model{width: 40, height: 10}
+	v := m.View()
+	if !strings.Contains(v, "terminal too small") || !strings.Contains(v, "have 40x10") {
+		t.Errorf("too-small view = %q", v)
+	}
+	for _, l := range strings.Split(v, "\n") {
+		if lipgloss.Width(l) > 40 {
+			t.Errorf("notice overflows: %q", l)
+		}
+	}
+}
+
+func TestPerCoreBusy_MatchesByCPUNumber(t *testing.T) {
+	core := func(busy, idle uint64) model.CPUTimes { return model.CPUTimes{User: busy, Idle: idle} }
+	prev := &model.Snapshot{}
+	prev.Global.CPU.PerCPU = []model.CPUTimes{core(100, 100), core(100, 100), core(100, 100)}
+	prev.Global.CPU.CPUIDs = []int{0, 1, 2}
+	// cpu1 went offline: cpu2 now sits at index 1.
+	curr := &model.Snapshot{}
+	curr.Global.CPU.PerCPU = []model.CPUTimes{core(150, 150), core(200, 100)}
+	curr.Global.CPU.CPUIDs = []int{0, 2}
+
+	got := perCoreBusy(prev, curr)
+	if len(got) != 2 || got[0] != 50 || got[1] != 100 {
+		t.Errorf("perCoreBusy = %v, want [50 100]", got)
+	}
+}
+
+func TestReplayScrubber(t *testing.T) {
+	var frames strings.Builder
+	base := time.Date(2026, 3, 1, 14, 0, 0, 0, time.Local)
+	for i := 0; i < 100; i++ {
+		health := model.HealthOK
+		if i >= 40 && i < 50 {
+			health = model.HealthCritical
+		}
+		fmt.Fprintf(&frames, `{"snapshot":{"Timestamp":%q},"result":{"Health":%d}}`+"\n",
+			base.Add(time.Duration(i)*3*time.Second).Format(time.RFC3339), health)
+	}
+	p, err := engine.NewPlayer(strings.NewReader(frames.String()), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Seek(25)
+
+	s := newReplayScrubber(p, true, 120)
+	out := s.render(p.Markers())
+	if w := lipgloss.Width(out); w > 120 {
+		t.Errorf("scrubber %d cols wide, want <= 120", w)
+	}
+	for _, want := range []string{"REPLAY", "14:00:00", "14:04:57", "@14:01:15", " 26/100", "●", "▲"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("scrubber missing %q: %s", want, out)
+		}
+	}
+
+	// Clicking the ends of the bar seeks to the first and last frame,
+	// and the marker column lands on the incident.
+	if i, ok := s.frameAt(s.barX); !ok || i != 0 {
+		t.Errorf("left edge = %d %v", i, ok)
+	}
+	if i, ok := s.frameAt(s.barX + s.barW - 1); !ok || i != 99 {
+		t.Errorf("right edge = %d %v", i, ok)
+	}
+	if i, ok := s.frameAt(s.barX + s.cell(40)); !ok || i < 39 || i > 41 {
+		t.Errorf("marker column = %d %v", i, ok)
+	}
+	if _, ok := s.frameAt(s.barX - 1); ok {
+		t.Error("click left of the bar seeked")
+	}
+	if s := newReplayScrubber(p, false, 30); s.barW != 0 {
+		t.Errorf("narrow terminal bar width = %d, want hidden", s.barW)
+	}
+}
+
+func TestConfigReloadRefusedInReplay(t *testing.T) {
+	p, err := engine.NewPlayer(strings.NewReader(`{"snapshot":{"Timestamp":"2026-03-01T14:00:00Z"},"result":{}}`+"\n"), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Model{ticker: p, engine: p.Base()}
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if got := next.(Model).statusMessage; !strings.Contains(got, "not available in replay") {
+		t.Errorf("Ctrl+R in replay: status = %q", got)
+	}
+}
+
+// staticTicker replays one fixed frame, standing in for a compare source.
+type staticTicker struct {
+	eng    *engine.Engine
+	snap   *model.Snapshot
+	rates  *model.RateSnapshot
+	result *model.AnalysisResult
+}
+
+func (s staticTicker) Tick() (*model.Snapshot, *model.RateSnapshot, *model.AnalysisResult) {
+	return s.snap, s.rates, s.result
+}
+
+func (s staticTicker) Base() *engine.Engine { return s.eng }
+
+func TestRenderCompare(t *testing.T) {
+	b := testSnapshot()
+	b.SysInfo = &model.SysInfo{Hostname: "node-b"}
+	b.Global.PSI.IO.Some.Avg10 = 28
+	bRates := testRates()
+	bRates.CPUBusyPct = 31
+
+	m := Model{
+		width:        200,
+		height:       60,
+		page:         PageCPU,
+		snap:         testSnapshot(),
+		rates:        testRates(),
+		result:       testResult(),
+		engine:       &engine.Engine{History: engine.NewHistory(60, 1)},
+		probeManager: engine.NewProbeManager(),
+	}
+	m = m.WithCompare(staticTicker{eng: &engine.Engine{History: engine.NewHistory(60, 1)}}, "b.wlog")
+
+	out := m.renderCompare(200, m.result, 0)
+	if !strings.Contains(out, "waiting for the first frame from b.wlog") {
+		t.Errorf("no placeholder before B's first frame:\n%s", out)
+	}
+
+	m.compareSnap, m.compareRates, m.compareResult = b, bRates, testResult()
+	out = m.renderCompare(200, m.result, 0)
+	for _, want := range []string{"test-host", "node-b", "biggest gap: PSI io 3.1 vs 28.0", "CPU 25%/31%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("compare view missing %q", want)
+		}
+	}
+	for i, line := range strings.Split(out, "\n") {
+		if w := lipgloss.Width(line); w > 200 {
+			t.Errorf("line %d is %d cols wide", i, w)
+		}
+	}
+
+	if d := renderCompareDelta(m.snap, m.rates, m.snap, m.rates, 200); !strings.Contains(d, "no significant gap") {
+		t.Errorf("identical hosts: %s", d)
+	}
+}
+
+func TestInputPromptsSurviveFullPage(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		set  func(*Model)
+		want string
+	}{
+		{"note", func(m *Model) { m.noteInputActive, m.noteInput = true, "rolled back deploy" }, "rolled back deploy"},
+		{"event filter", func(m *Model) { m.evtFilterInputActive, m.evtFilterInput = true, "sev:crit" }, "sev:crit"},
+	} {
+		m := Model{
+			width:        120,
+			height:       30,
+			page:         PageCPU,
+			snap:         testSnapshot(),
+			rates:        testRates(),
+			result:       testResult(),
+			engine:       &engine.Engine{History: engine.NewHistory(60, 1)},
+			probeManager: engine.NewProbeManager(),
+		}
+		tc.set(&m)
+		out := m.renderView()
+		if !strings.Contains(out, tc.want) {
+			t.Errorf("%s prompt trimmed off a full page:\n%s", tc.name, out)
+		}
+		if n := strings.Count(out, "\n") + 1; n > m.height {
+			t.Errorf("%s: view is %d rows, want <= %d", tc.name, n, m.height)
+		}
+	}
+}
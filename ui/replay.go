@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ftahirops/xtop/engine"
+	"github.com/ftahirops/xtop/model"
+	"github.com/ftahirops/xtop/util"
+)
+
+// replaySeekStep is how far shift+←/→ move in recording time.
+const replaySeekStep = time.Minute
+
+// replayScrubber lays out the seek bar pinned above the page in replay mode:
+//
+//	▶ REPLAY 14:02:10 ━━━━━━━●───▲──────▲── 14:32:10  @14:08:10  120/600
+//
+// ▲ marks frames where health worsened. The same layout maps mouse clicks
+// back to frames, so rendering and hit-testing cannot drift apart.
+type replayScrubber struct {
+	glyph, start   string
+	prefix, suffix string
+	barX, barW     int
+	frames, pos    int
+}
+
+func newReplayScrubber(p *engine.Player, paused bool, width int) replayScrubber {
+	n := p.Len()
+	pos := p.Position()
+	if pos < 0 {
+		pos = 0
+	}
+	glyph := "▶"
+	if paused {
+		glyph = "‖"
+	}
+	s := replayScrubber{glyph: glyph, start: fmtReplayTime(p.FrameTime(0)), frames: n, pos: pos}
+	s.prefix = " " + s.glyph + " REPLAY " + s.start + " "
+	digits := len(fmt.Sprint(n))
+	s.suffix = fmt.Sprintf(" %s  @%s  %*d/%d", fmtReplayTime(p.FrameTime(n-1)),
+		fmtReplayTime(p.FrameTime(pos)), digits, pos+1, n)
+	s.barX = lipgloss.Width(s.prefix)
+	s.barW = width - s.barX - lipgloss.Width(s.suffix) - 1
+	if s.barW < 10 {
+		s.barW = 0
+	}
+	return s
+}
+
+func fmtReplayTime(t time.Time) string {
+	if t.IsZero() {
+		return "--:--:--"
+	}
+	return util.FmtTime(t, "15:04:05")
+}
+
+// cell returns the bar column that frame i falls on.
+func (s replayScrubber) cell(i int) int {
+	if s.frames < 2 || s.barW < 2 {
+		return 0
+	}
+	return i * (s.barW - 1) / (s.frames - 1)
+}
+
+// frameAt maps a screen column to the nearest frame. ok is false when x is
+// outside the bar.
+func (s replayScrubber) frameAt(x int) (int, bool) {
+	if s.barW == 0 || s.frames == 0 || x < s.barX || x >= s.barX+s.barW {
+		return 0, false
+	}
+	if s.barW < 2 || s.frames < 2 {
+		return 0, true
+	}
+	return ((x-s.barX)*(s.frames-1) + (s.barW-1)/2) / (s.barW - 1), true
+}
+
+func (s replayScrubber) render(markers []engine.ReplayMarker) string {
+	var sb strings.Builder
+	sb.WriteString(dimStyle.Render(" " + s.glyph + " "))
+	sb.WriteString(titleStyle.Render("REPLAY"))
+	sb.WriteString(dimStyle.Render(" " + s.start + " "))
+	if s.barW > 0 {
+		marks := make(map[int]model.HealthLevel, len(markers))
+		for _, mk := range markers {
+			if c := s.cell(mk.Index); mk.Health > marks[c] {
+				marks[c] = mk.Health
+			}
+		}
+		cursor := s.cell(s.pos)
+		for c := 0; c < s.barW; c++ {
+			switch h, marked := marks[c]; {
+			case c == cursor:
+				sb.WriteString(valueStyle.Render("●"))
+			case marked && h >= model.HealthCritical:
+				sb.WriteString(critStyle.Render("▲"))
+			case marked:
+				sb.WriteString(warnStyle.Render("▲"))
+			case c < cursor:
+				sb.WriteString(headerStyle.Render("━"))
+			default:
+				sb.WriteString(dimStyle.Render("─"))
+			}
+		}
+	}
+	sb.WriteString(dimStyle.Render(s.suffix))
+	return sb.String()
+}
+
+// replayPlayer returns the replay source behind m.ticker, or nil when
+// running live. Wrappers such as the Prometheus instrumentation are
+// looked through.
+func (m Model) replayPlayer() *engine.Player {
+	t := m.ticker
+	for {
+		switch v := t.(type) {
+		case *engine.Player:
+			return v
+		case interface{ Unwrap() engine.Ticker }:
+			t = v.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+// replaySeek shows frame i of the recording.
+func (m *Model) replaySeek(p *engine.Player, i int) {
+	snap, rates, result := p.Seek(i)
+	if snap != nil {
+		m.snap = snap
+		m.rates = rates
+		m.result = result
+		m.eventDetector.Process(snap, rates, result)
+	}
+}
+
+// replaySeekTime moves d of recording time from the current frame.
+func (m *Model) replaySeekTime(p *engine.Player, d time.Duration) {
+	pos := p.Position()
+	at := p.FrameTime(pos)
+	if at.IsZero() {
+		return
+	}
+	i := p.IndexAt(at.Add(d))
+	// Sparse recordings can leave a gap wider than d; always move at
+	// least one frame.
+	if d < 0 && i >= pos {
+		i = pos - 1
+	} else if d > 0 && i <= pos {
+		i = pos + 1
+	}
+	m.replaySeek(p, i)
+}
+
+// replayMarkerSeek jumps to the previous (dir < 0) or next incident marker.
+func (m *Model) replayMarkerSeek(p *engine.Player, dir int) {
+	mk, ok := p.NextMarker(p.Position(), dir)
+	if !ok {
+		m.statusMessage = "no more incidents in this recording"
+		m.statusMessageAt = time.Now()
+		return
+	}
+	m.replaySeek(p, mk.Index)
+	what := mk.Health.String()
+	if mk.Bottleneck != "" {
+		what += " " + mk.Bottleneck
+	}
+	m.statusMessage = what + " at " + fmtReplayTime(p.FrameTime(mk.Index))
+	m.statusMessageAt = time.Now()
+}
+
+// replayMouse seeks when the scrubber row is clicked or dragged.
+func (m *Model) replayMouse(msg tea.MouseMsg) {
+	p := m.replayPlayer()
+	if p == nil || msg.Y != 0 || msg.Button != tea.MouseButtonLeft {
+		return
+	}
+	if msg.Action != tea.MouseActionPress && msg.Action != tea.MouseActionMotion {
+		return
+	}
+	if i, ok := newReplayScrubber(p, m.paused, m.width).frameAt(msg.X); ok {
+		m.replaySeek(p, i)
+	}
+}
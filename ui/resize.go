@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Smallest terminal the pages are laid out for. Below this the box
+// renderers run out of room and emit fragments, so we show a notice instead.
+const (
+	minTermWidth  = 80
+	minTermHeight = 24
+)
+
+// termTooSmall reports whether a w×h terminal is below the supported size.
+func termTooSmall(w, h int) bool {
+	return w < minTermWidth || h < minTermHeight
+}
+
+// renderTooSmall is the whole frame while the terminal is under
+// minTermWidth×minTermHeight. Every line is clipped so the notice itself
+// stays readable in a window of any size.
+func renderTooSmall(w, h int) string {
+	lines := []string{
+		warnStyle.Render("terminal too small"),
+		fmt.Sprintf("need %dx%d, have %dx%d", minTermWidth, minTermHeight, w, h),
+		dimStyle.Render("enlarge the window, or press q to quit"),
+	}
+	if h > 0 && len(lines) > h {
+		lines = lines[:h]
+	}
+	top := max((h-len(lines))/2, 0)
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat("\n", top))
+	for i, l := range lines {
+		if pad := (w - lipgloss.Width(l)) / 2; pad > 0 {
+			l = strings.Repeat(" ", pad) + l
+		}
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(ansi.Truncate(l, w, ""))
+	}
+	return sb.String()
+}
+
+// fitFrame clips a rendered frame to the terminal: lines wider than w are
+// truncated (ANSI-aware) and rows past h are dropped. A line that is even
+// one column too wide makes the terminal wrap it, which shifts every row
+// below and leaves box fragments behind after a live resize.
+func fitFrame(frame string, w, h int) string {
+	if w <= 0 || h <= 0 {
+		return frame
+	}
+	lines := strings.Split(frame, "\n")
+	if len(lines) > h {
+		lines = lines[:h]
+	}
+	for i, l := range lines {
+		if lipgloss.Width(l) > w {
+			lines[i] = ansi.Truncate(l, w, "")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,244 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// ─── Terminal image protocols ───────────────────────────────────────────────
+//
+// Terminals that can display inline images get real line charts on the
+// Timeline page instead of block-character area charts. Three protocols are
+// supported: kitty graphics (kitty, Ghostty), iTerm2 inline images (iTerm2,
+// WezTerm) and sixel (foot, mlterm, xterm -ti vt340, ...).
+//
+// Detection is environment based — querying the terminal from inside the
+// Bubbletea event loop would race with its input reader. XTOP_IMAGES
+// (or "chart_images" in the config) overrides it: auto, off, kitty, iterm2
+// or sixel. Multiplexers get no images unless forced, since tmux and screen
+// swallow the escapes without passthrough.
+
+type imageProto int
+
+const (
+	imageNone imageProto = iota
+	imageKitty
+	imageITerm2
+	imageSixel
+)
+
+func (p imageProto) String() string {
+	switch p {
+	case imageKitty:
+		return "kitty"
+	case imageITerm2:
+		return "iterm2"
+	case imageSixel:
+		return "sixel"
+	}
+	return "off"
+}
+
+// detectImageProto picks the image protocol for this terminal. pref is the
+// configured preference; the XTOP_IMAGES environment variable wins over it.
+func detectImageProto(getenv func(string) string, pref string) imageProto {
+	if v := getenv("XTOP_IMAGES"); v != "" {
+		pref = v
+	}
+	switch strings.ToLower(strings.TrimSpace(pref)) {
+	case "off", "none", "no", "0", "false":
+		return imageNone
+	case "kitty":
+		return imageKitty
+	case "iterm2", "iterm":
+		return imageITerm2
+	case "sixel":
+		return imageSixel
+	}
+
+	if getenv("TMUX") != "" || getenv("STY") != "" {
+		return imageNone
+	}
+	term := getenv("TERM")
+	prog := getenv("TERM_PROGRAM")
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "", term == "xterm-kitty",
+		prog == "ghostty", term == "xterm-ghostty":
+		return imageKitty
+	case prog == "iTerm.app", getenv("LC_TERMINAL") == "iTerm2", prog == "WezTerm":
+		return imageITerm2
+	case strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "mlterm"),
+		prog == "contour", term == "yaft-256color":
+		return imageSixel
+	}
+	return imageNone
+}
+
+// cellPixelSize returns the terminal's character cell size in pixels, or a
+// typical 8x16 when the terminal doesn't report its pixel dimensions.
+func cellPixelSize() (w, h int) {
+	ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err == nil && ws.Col > 0 && ws.Row > 0 && ws.Xpixel > 0 && ws.Ypixel > 0 {
+		w, h = int(ws.Xpixel)/int(ws.Col), int(ws.Ypixel)/int(ws.Row)
+	}
+	if w < 4 || h < 8 {
+		return 8, 16
+	}
+	return w, h
+}
+
+// kittyImageID is the base image ID for Timeline charts. Re-sending an ID
+// replaces the old image and its placement, so redraws don't leak images.
+const kittyImageID = 7870
+
+// kittyDeleteAll removes every image xtop placed, for frames without charts.
+const kittyDeleteAll = "\x1b_Ga=d,d=A,q=2\x1b\\"
+
+// encodeTermImage returns the escape sequence that draws img over a block of
+// cols x rows cells whose bottom-left corner is at the cursor. Drawing
+// upwards from the block's last line means the blank lines above are
+// already painted when the image arrives, so the renderer's line erases
+// don't clip it. The cursor is restored and parked at the right margin so
+// the erase-to-end-of-line that follows touches nothing.
+func encodeTermImage(proto imageProto, img *image.Paletted, id, cols, rows int) string {
+	var body string
+	switch proto {
+	case imageKitty:
+		body = kittyImage(img, id, cols, rows)
+	case imageITerm2:
+		body = iterm2Image(img, cols, rows)
+	case imageSixel:
+		body = sixelImage(img)
+	default:
+		return ""
+	}
+	if body == "" {
+		return ""
+	}
+	up := ""
+	if rows > 1 {
+		up = fmt.Sprintf("\x1b[%dA", rows-1)
+	}
+	return "\x1b7" + up + body + "\x1b8\x1b[999C"
+}
+
+func pngBase64(img image.Image) string {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// kittyImage transmits a PNG in 4096-byte chunks and places it scaled to
+// cols x rows cells without moving the cursor (C=1).
+func kittyImage(img image.Image, id, cols, rows int) string {
+	data := pngBase64(img)
+	if data == "" {
+		return ""
+	}
+	const chunk = 4096
+	var sb strings.Builder
+	for off := 0; off < len(data); off += chunk {
+		end := off + chunk
+		more := 1
+		if end >= len(data) {
+			end = len(data)
+			more = 0
+		}
+		if off == 0 {
+			fmt.Fprintf(&sb, "\x1b_Ga=T,f=100,i=%d,c=%d,r=%d,C=1,q=2,m=%d;%s\x1b\\", id, cols, rows, more, data[off:end])
+		} else {
+			fmt.Fprintf(&sb, "\x1b_Gm=%d;%s\x1b\\", more, data[off:end])
+		}
+	}
+	return sb.String()
+}
+
+// iterm2Image sends a PNG as an OSC 1337 inline file sized in cells.
+func iterm2Image(img image.Image, cols, rows int) string {
+	data := pngBase64(img)
+	if data == "" {
+		return ""
+	}
+	return fmt.Sprintf("\x1b]1337;File=inline=1;width=%d;height=%d;preserveAspectRatio=0:%s\x07", cols, rows, data)
+}
+
+// sixelImage encodes a paletted image as sixel. Palette index 0 is treated
+// as transparent (P2=1 leaves those pixels untouched).
+func sixelImage(img *image.Paletted) string {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w == 0 || h == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\x1bP0;1;0q\"1;1;%d;%d", w, h)
+	for i, c := range img.Palette {
+		if i == 0 {
+			continue
+		}
+		r, g, bl, _ := c.RGBA()
+		fmt.Fprintf(&sb, "#%d;2;%d;%d;%d", i, r*100/0xffff, g*100/0xffff, bl*100/0xffff)
+	}
+
+	row := make([]byte, w)
+	for y0 := 0; y0 < h; y0 += 6 {
+		// Which colors appear in this 6-pixel band.
+		used := make([]bool, len(img.Palette))
+		for y := y0; y < y0+6 && y < h; y++ {
+			for x := 0; x < w; x++ {
+				used[img.ColorIndexAt(b.Min.X+x, b.Min.Y+y)] = true
+			}
+		}
+		first := true
+		for ci := 1; ci < len(img.Palette); ci++ {
+			if !used[ci] {
+				continue
+			}
+			for x := 0; x < w; x++ {
+				var bits byte
+				for k := 0; k < 6 && y0+k < h; k++ {
+					if int(img.ColorIndexAt(b.Min.X+x, b.Min.Y+y0+k)) == ci {
+						bits |= 1 << k
+					}
+				}
+				row[x] = 63 + bits
+			}
+			if !first {
+				sb.WriteByte('$')
+			}
+			first = false
+			fmt.Fprintf(&sb, "#%d", ci)
+			sixelRLE(&sb, row)
+		}
+		sb.WriteByte('-')
+	}
+	sb.WriteString("\x1b\\")
+	return sb.String()
+}
+
+// sixelRLE writes one sixel row with run-length compression ("!<n><ch>").
+func sixelRLE(sb *strings.Builder, row []byte) {
+	for i := 0; i < len(row); {
+		j := i + 1
+		for j < len(row) && row[j] == row[i] {
+			j++
+		}
+		if n := j - i; n > 3 {
+			fmt.Fprintf(sb, "!%d%c", n, row[i])
+		} else {
+			for k := 0; k < n; k++ {
+				sb.WriteByte(row[i])
+			}
+		}
+		i = j
+	}
+}
@@ -2,22 +2,17 @@ package util
 
 import "fmt"
 
-// FmtBytes formats bytes to human-readable string (1024-based).
+// FmtBytes formats bytes to a human-readable string in the configured
+// units (1024-based "3.5 GB" by default).
 func FmtBytes(b uint64) string {
-	const (
-		kb = 1024
-		mb = 1024 * kb
-		gb = 1024 * mb
-	)
-	switch {
-	case b >= gb:
-		return fmt.Sprintf("%.1f GB", float64(b)/float64(gb))
-	case b >= mb:
-		return fmt.Sprintf("%.1f MB", float64(b)/float64(mb))
-	case b >= kb:
-		return fmt.Sprintf("%.0f KB", float64(b)/float64(kb))
-	default:
+	v, i := ScaleBytes(float64(b))
+	switch i {
+	case 0:
 		return fmt.Sprintf("%d B", b)
+	case 1:
+		return FmtFloat(v, 0) + " " + ByteUnit(i, false)
+	default:
+		return FmtFloat(v, 1) + " " + ByteUnit(i, false)
 	}
 }
 
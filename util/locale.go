@@ -0,0 +1,202 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Byte unit systems for Format.Units.
+const (
+	UnitsDefault = ""    // 1024-based, K/M/G and KB/MB/GB labels (historical output)
+	UnitsIEC     = "iec" // 1024-based, Ki/Mi/Gi and KiB/MiB/GiB labels
+	UnitsSI      = "si"  // 1000-based, k/M/G and kB/MB/GB labels
+)
+
+// Format holds the display preferences for numbers and timestamps: byte
+// units, decimal separator, 12/24h clock and the time zone every human
+// readable timestamp is shown in (TUI, exports, reports, alerts).
+// Machine-readable output (JSON) is unaffected except for the zone offset.
+type Format struct {
+	Units    string `json:"units,omitempty"`    // "", "iec" or "si"
+	Decimal  string `json:"decimal,omitempty"`  // "." (default) or ","
+	Clock    string `json:"clock,omitempty"`    // "24h" (default) or "12h"
+	TimeZone string `json:"timezone,omitempty"` // "" = local, "UTC", or an IANA name such as "Europe/Berlin"
+}
+
+type formatState struct {
+	Format
+	loc *time.Location
+}
+
+var activeFormat atomic.Pointer[formatState]
+
+// SetFormat validates f and makes it the active format. On error the
+// previous format stays active.
+func SetFormat(f Format) error {
+	f.Units = strings.ToLower(strings.TrimSpace(f.Units))
+	switch f.Units {
+	case UnitsDefault, UnitsIEC, UnitsSI:
+	case "binary", "legacy":
+		f.Units = UnitsDefault
+	default:
+		return fmt.Errorf("units %q: want iec or si", f.Units)
+	}
+	switch f.Decimal {
+	case "", ".":
+		f.Decimal = ""
+	case ",":
+	default:
+		return fmt.Errorf("decimal %q: want . or ,", f.Decimal)
+	}
+	switch strings.ToLower(f.Clock) {
+	case "", "24h", "24":
+		f.Clock = ""
+	case "12h", "12":
+		f.Clock = "12h"
+	default:
+		return fmt.Errorf("clock %q: want 12h or 24h", f.Clock)
+	}
+	st := &formatState{Format: f, loc: time.Local}
+	if tz := strings.TrimSpace(f.TimeZone); tz != "" && !strings.EqualFold(tz, "local") {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return fmt.Errorf("timezone %q: %w", tz, err)
+		}
+		st.loc = loc
+	}
+	activeFormat.Store(st)
+	return nil
+}
+
+// FormatFromEnv overlays XTOP_UNITS, XTOP_DECIMAL, XTOP_CLOCK and XTOP_TZ
+// on f; environment variables win over config.json.
+func FormatFromEnv(f Format) Format {
+	if v := os.Getenv("XTOP_UNITS"); v != "" {
+		f.Units = v
+	}
+	if v := os.Getenv("XTOP_DECIMAL"); v != "" {
+		f.Decimal = v
+	}
+	if v := os.Getenv("XTOP_CLOCK"); v != "" {
+		f.Clock = v
+	}
+	if v := os.Getenv("XTOP_TZ"); v != "" {
+		f.TimeZone = v
+	}
+	return f
+}
+
+func currentFormat() *formatState {
+	if st := activeFormat.Load(); st != nil {
+		return st
+	}
+	return &formatState{loc: time.Local}
+}
+
+// ActiveFormat returns the format in effect.
+func ActiveFormat() Format {
+	return currentFormat().Format
+}
+
+// FmtFloat formats v with prec decimals and the configured separator.
+func FmtFloat(v float64, prec int) string {
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+	if currentFormat().Decimal == "," {
+		s = strings.Replace(s, ".", ",", 1)
+	}
+	return s
+}
+
+var byteUnitLabels = map[string][2][]string{
+	// [long, compact]
+	UnitsDefault: {{"B", "KB", "MB", "GB", "TB"}, {"B", "K", "M", "G", "T"}},
+	UnitsIEC:     {{"B", "KiB", "MiB", "GiB", "TiB"}, {"B", "Ki", "Mi", "Gi", "Ti"}},
+	UnitsSI:      {{"B", "kB", "MB", "GB", "TB"}, {"B", "k", "M", "G", "T"}},
+}
+
+// ByteBase is 1000 for SI units, 1024 otherwise.
+func ByteBase() float64 {
+	if currentFormat().Units == UnitsSI {
+		return 1000
+	}
+	return 1024
+}
+
+// ScaleBytes divides b down to the largest unit it reaches (0 = bytes,
+// 1 = K, 2 = M, 3 = G, 4 = T) in the configured unit system. The default
+// units stop at G ("1536.0G"), as xtop always printed them.
+func ScaleBytes(b float64) (float64, int) {
+	base := ByteBase()
+	top := 4
+	if currentFormat().Units == UnitsDefault {
+		top = 3
+	}
+	i := 0
+	for i < top && (b >= base || b <= -base) {
+		b /= base
+		i++
+	}
+	return b, i
+}
+
+// ByteUnit returns the label of unit i from ScaleBytes: "GB", "GiB" or
+// "GB" when long, "G", "Gi" or "G" when compact.
+func ByteUnit(i int, compact bool) string {
+	labels := byteUnitLabels[currentFormat().Units]
+	if compact {
+		return labels[1][i]
+	}
+	return labels[0][i]
+}
+
+// FmtBytesShort formats bytes compactly for tables: "3.5G", "3.5Gi".
+func FmtBytesShort(b uint64) string {
+	v, i := ScaleBytes(float64(b))
+	if i == 0 {
+		return fmt.Sprintf("%dB", b)
+	}
+	return FmtFloat(v, 1) + ByteUnit(i, true)
+}
+
+// FmtBytesRate formats a bytes-per-second rate: "3.5 MB/s", "3.5 MiB/s".
+func FmtBytesRate(bps float64) string {
+	v, i := ScaleBytes(bps)
+	if i == 0 {
+		return FmtFloat(bps, 0) + " B/s"
+	}
+	return FmtFloat(v, 1) + " " + ByteUnit(i, false) + "/s"
+}
+
+// LocalTime converts t to the configured time zone.
+func LocalTime(t time.Time) time.Time {
+	return t.In(currentFormat().loc)
+}
+
+// FmtTime formats t in the configured time zone with a Go layout written
+// for the 24h clock ("15:04:05", "2006-01-02 15:04 MST"). With the 12h
+// clock, the hour becomes "3" and " PM" follows the time of day.
+func FmtTime(t time.Time, layout string) string {
+	st := currentFormat()
+	if st.Clock == "12h" {
+		layout = twelveHour(layout)
+	}
+	return t.In(st.loc).Format(layout)
+}
+
+// twelveHour rewrites the first "15:04[:05]" of a 24h layout as
+// "3:04[:05] PM".
+func twelveHour(layout string) string {
+	i := strings.Index(layout, "15:04")
+	if i < 0 {
+		return layout
+	}
+	end := i + len("15:04")
+	if strings.HasPrefix(layout[end:], ":05") {
+		end += len(":05")
+	}
+	return layout[:i] + "3" + layout[i+2:end] + " PM" + layout[end:]
+}
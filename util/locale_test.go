@@ -0,0 +1,87 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatUnits(t *testing.T) {
+	defer SetFormat(Format{})
+
+	cases := []struct {
+		f                  Format
+		bytes, short, rate string
+	}{
+		{Format{}, "3.5 GB", "3.5G", "1.5 MB/s"},
+		{Format{Units: "iec"}, "3.5 GiB", "3.5Gi", "1.5 MiB/s"},
+		{Format{Units: "SI"}, "3.8 GB", "3.8G", "1.6 MB/s"},
+		{Format{Units: "iec", Decimal: ","}, "3,5 GiB", "3,5Gi", "1,5 MiB/s"},
+	}
+	for _, c := range cases {
+		if err := SetFormat(c.f); err != nil {
+			t.Fatal(err)
+		}
+		b := uint64(3.5 * (1 << 30))
+		if got := FmtBytes(b); got != c.bytes {
+			t.Errorf("%+v: FmtBytes = %q, want %q", c.f, got, c.bytes)
+		}
+		if got := FmtBytesShort(b); got != c.short {
+			t.Errorf("%+v: FmtBytesShort = %q, want %q", c.f, got, c.short)
+		}
+		if got := FmtBytesRate(1.5 * (1 << 20)); got != c.rate {
+			t.Errorf("%+v: FmtBytesRate = %q, want %q", c.f, got, c.rate)
+		}
+	}
+
+	// The default units never go past G; the others reach T.
+	tb := uint64(1.5 * (1 << 40))
+	for units, want := range map[string]string{"": "1536.0G", "iec": "1.5Ti"} {
+		if err := SetFormat(Format{Units: units}); err != nil {
+			t.Fatal(err)
+		}
+		if got := FmtBytesShort(tb); got != want {
+			t.Errorf("units %q: FmtBytesShort(1.5 TiB) = %q, want %q", units, got, want)
+		}
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	defer SetFormat(Format{})
+	at := time.Date(2026, 3, 1, 15, 4, 5, 0, time.UTC)
+
+	if err := SetFormat(Format{Clock: "12h", TimeZone: "UTC"}); err != nil {
+		t.Fatal(err)
+	}
+	for layout, want := range map[string]string{
+		"15:04:05":                "3:04:05 PM",
+		"15:04!":                  "3:04 PM!",
+		"2006-01-02 15:04:05 MST": "2026-03-01 3:04:05 PM UTC",
+		"Jan 02":                  "Mar 01",
+	} {
+		if got := FmtTime(at, layout); got != want {
+			t.Errorf("FmtTime(%q) = %q, want %q", layout, got, want)
+		}
+	}
+
+	if err := SetFormat(Format{TimeZone: "Asia/Tokyo"}); err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+	if got := FmtTime(at, "15:04 MST"); got != "00:04 JST" {
+		t.Errorf("Tokyo = %q", got)
+	}
+}
+
+func TestSetFormatRejects(t *testing.T) {
+	defer SetFormat(Format{})
+	if err := SetFormat(Format{Units: "iec"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []Format{{Units: "metric"}, {Decimal: ";"}, {Clock: "36h"}, {TimeZone: "Mars/Olympus"}} {
+		if err := SetFormat(f); err == nil {
+			t.Errorf("SetFormat(%+v) accepted", f)
+		}
+	}
+	if ActiveFormat().Units != UnitsIEC {
+		t.Error("a rejected format replaced the active one")
+	}
+}